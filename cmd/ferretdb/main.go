@@ -18,6 +18,7 @@
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"expvar"
 	"fmt"
 	"log"
@@ -25,6 +26,8 @@
 	"os"
 	"runtime"
 	runtimedebug "runtime/debug"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -36,8 +39,12 @@
 	_ "golang.org/x/crypto/x509roots/fallback" // register root TLS certificates for production Docker image
 
 	"github.com/FerretDB/FerretDB/v2/build/version"
+	"github.com/FerretDB/FerretDB/v2/internal/audit"
 	"github.com/FerretDB/FerretDB/v2/internal/clientconn"
+	"github.com/FerretDB/FerretDB/v2/internal/dataapi"
+	"github.com/FerretDB/FerretDB/v2/internal/handler/authlimit"
 	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/util/bsondiff"
 	"github.com/FerretDB/FerretDB/v2/internal/util/ctxutil"
 	"github.com/FerretDB/FerretDB/v2/internal/util/debug"
 	"github.com/FerretDB/FerretDB/v2/internal/util/devbuild"
@@ -61,6 +68,20 @@
 	Run  struct{} `cmd:"" default:"1"                             hidden:""`
 	Ping struct{} `cmd:"" help:"Ping existing FerretDB instance."`
 
+	Replay struct {
+		Dir            string   `arg:""                                    help:"Directory with traffic recorded by --dev-records-dir."`
+		Target         string   `default:"mongodb://127.0.0.1:27017/"      help:"Target MongoDB URI."                                              env:"FERRETDB_REPLAY_TARGET"`
+		PreserveTiming bool     `default:"false"                           help:"Start recorded connections at the recorded time offsets."         env:"FERRETDB_REPLAY_PRESERVE_TIMING" negatable:""`
+		Report         string   `default:""                                help:"Path to a file for structured reports about differing responses." env:"FERRETDB_REPLAY_REPORT"`
+		IgnoreFields   []string `default:"${default_ignore_fields}"        help:"Response fields ignored when comparing responses."                env:"FERRETDB_REPLAY_IGNORE_FIELDS"`
+	} `cmd:"" help:"Replay recorded traffic and compare responses."`
+
+	MCPStdio struct {
+		Username string `default:"" help:"Username for MCP tool calls if authentication is enabled."           env:"FERRETDB_MCP_USERNAME"`
+		Password string `default:"" help:"Password for MCP tool calls if authentication is enabled."           env:"FERRETDB_MCP_PASSWORD"`
+		APIKey   string `default:"" help:"API key for MCP tool calls; overrides --username and --password." env:"FERRETDB_MCP_API_KEY" name:"api-key"`
+	} `cmd:"" help:"Run MCP server on stdin and stdout." name:"mcp"`
+
 	Version bool `default:"false" help:"Print version to stdout and exit." env:"-"`
 
 	PostgreSQLURL     string `name:"postgresql-url"      default:"postgres://127.0.0.1:5432/postgres"                                                                   help:"PostgreSQL URL." group:"PostgreSQL"`
@@ -74,9 +95,20 @@
 		TLSKeyFile  string `default:""                help:"TLS key file path."`
 		TLSCaFile   string `default:""                help:"TLS CA file path."`
 		DataAPIAddr string `default:""                help:"Listen TCP address for HTTP Data API."`
+		DataAPIUnix string `default:""                help:"Listen Unix domain socket path for HTTP Data API."`
+		DataAPITls  string `default:""                help:"Listen TLS address for HTTPS Data API."`
 		MCPAddr     string `default:""                help:"Listen TCP address for HTTP MCP server."`
 	} `embed:"" prefix:"listen-" group:"Interfaces"`
 
+	DataAPI struct {
+		MaxBodySize int64    `default:"${default_data_api_max_body_size}" help:"Maximum size of Data API request body in bytes."`
+		CORSOrigins []string `help:"Origins allowed to make cross-origin requests to Data API; '*' allows all."`
+	} `embed:"" prefix:"data-api-" group:"Interfaces"`
+
+	MCP struct {
+		AllowWrites bool `default:"false" help:"Enable MCP tools that modify data." negatable:""`
+	} `embed:"" prefix:"mcp-" group:"Interfaces"`
+
 	Proxy struct {
 		Addr        string `default:"" help:"Proxy address."`
 		TLSCertFile string `default:"" help:"Proxy TLS cert file path."`
@@ -86,9 +118,42 @@
 
 	DebugAddr string `default:"127.0.0.1:8088" help:"Listen address for HTTP handlers for metrics, pprof, etc." group:"Interfaces"`
 
-	Mode     string `default:"${default_mode}" help:"${help_mode}"                           enum:"${enum_mode}"   group:"Miscellaneous"`
-	StateDir string `default:"."               help:"Process state directory."               group:"Miscellaneous"`
-	Auth     bool   `default:"true"            help:"Enable authentication (on by default)." group:"Miscellaneous" negatable:""`
+	Mode     string `default:"${default_mode}" help:"${help_mode}"                                      enum:"${enum_mode}"   group:"Miscellaneous"`
+	StateDir string `default:"."               help:"Process state directory."                          group:"Miscellaneous"`
+	Auth     bool   `default:"true"            help:"Enable authentication (on by default)."            group:"Miscellaneous" negatable:""`
+	ReadOnly bool   `default:"false"           help:"Start in read-only mode, rejecting write commands." group:"Miscellaneous" negatable:""`
+
+	AuthLimit struct {
+		MaxFailures int           `default:"${default_auth_max_failures}" help:"Failed authentication attempts per username or peer address before lockout; 0 disables lockouts."`
+		Lockout     time.Duration `default:"${default_auth_lockout}"      help:"Duration of the first authentication lockout; doubled for each subsequent one."`
+		MaxLockout  time.Duration `default:"${default_auth_max_lockout}"  help:"Maximum duration of authentication lockout."`
+	} `embed:"" prefix:"auth-" group:"Miscellaneous"`
+
+	Diff struct {
+		ReportFile   string   `default:""                         help:"Path to a file for structured reports about differing responses in diff modes."`
+		IgnoreFields []string `default:"${default_ignore_fields}" help:"Response fields ignored when comparing responses in diff modes."`
+	} `embed:"" prefix:"diff-" group:"Miscellaneous"`
+
+	Shadow struct {
+		QueueSize  int      `default:"${default_shadow_queue_size}"  help:"Maximum number of requests waiting to be sent to the secondary handler in shadow modes."`
+		SampleRate float64  `default:"${default_shadow_sample_rate}" help:"Fraction of requests sent to the secondary handler in shadow modes."`
+		Commands   []string `help:"Commands sent to the secondary handler in shadow modes; all if empty."`
+		Namespaces []string `help:"Databases and collections (db.coll) sent to the secondary handler in shadow modes; all if empty."`
+	} `embed:"" prefix:"shadow-" group:"Miscellaneous"`
+
+	RouteRulesFile string `default:"" help:"Path to a JSON file with routing rules for route mode." group:"Miscellaneous"`
+
+	DualWriteReconciliationFile string `default:"" help:"Path to a file for logging diverged write results in dual-write modes." group:"Miscellaneous"`
+
+	Audit struct {
+		Destination string   `default:""                          help:"${help_audit_destination}"`
+		Path        string   `default:""                          help:"Path to the audit log file or Unix socket; defaults to ${default_audit_syslog_path} for syslog."`
+		Format      string   `default:"json"                      help:"${help_audit_format}"                                                                            enum:"${enum_audit_format}"`
+		MaxSize     int64    `default:"${default_audit_max_size}" help:"Maximum audit log file size in bytes before rotation; 0 disables rotation."`
+		MaxBackups  int      `default:"10"                        help:"Maximum number of rotated audit log files to keep."`
+		Filter      string   `default:""                          help:"Audit log filter (JSON query document on event fields)."`
+		Namespaces  []string `help:"Databases and collections (db.coll) for auditing CRUD commands; none if empty."`
+	} `embed:"" prefix:"audit-" group:"Miscellaneous"`
 
 	Log struct {
 		Level  string `default:"${default_log_level}" help:"${help_log_level}"`
@@ -134,16 +199,30 @@
 
 	kongOptions = []kong.Option{
 		kong.Vars{
-			"default_log_level": defaultLogLevel().String(),
-			"default_mode":      middleware.AllModes[0],
-
-			"enum_log_format": strings.Join(logFormats, ","),
-			"enum_mode":       strings.Join(middleware.AllModes, ","),
-
-			"help_log_format": fmt.Sprintf("Log format: '%s'.", strings.Join(logFormats, "', '")),
-			"help_log_level":  fmt.Sprintf("Log level: '%s'.", strings.Join(logLevels, "', '")),
-			"help_mode":       fmt.Sprintf("Operation mode: '%s'.", strings.Join(middleware.AllModes, "', '")),
-			"help_telemetry":  "Enable or disable basic telemetry reporting. See https://beacon.ferretdb.com.",
+			"default_log_level":              defaultLogLevel().String(),
+			"default_mode":                   middleware.AllModes[0],
+			"default_ignore_fields":          strings.Join(bsondiff.DefaultIgnoredFields, ","),
+			"default_shadow_queue_size":      strconv.Itoa(middleware.DefaultShadowQueueSize),
+			"default_shadow_sample_rate":     strconv.FormatFloat(middleware.DefaultShadowSampleRate, 'g', -1, 64),
+			"default_data_api_max_body_size": strconv.Itoa(dataapi.DefaultMaxBodySize),
+			"default_audit_max_size":         strconv.Itoa(100 << 20),
+			"default_audit_syslog_path":      audit.DefaultSyslogPath,
+			"default_auth_max_failures":      strconv.Itoa(authlimit.DefaultMaxFailures),
+			"default_auth_lockout":           authlimit.DefaultLockout.String(),
+			"default_auth_max_lockout":       authlimit.DefaultMaxLockout.String(),
+
+			"enum_audit_format": strings.Join(audit.AllFormats, ","),
+			"enum_log_format":   strings.Join(logFormats, ","),
+			"enum_mode":         strings.Join(middleware.AllModes, ","),
+
+			"help_audit_destination": fmt.Sprintf(
+				"Audit log destination: '%s'; disabled if empty.", strings.Join(audit.AllDestinations, "', '"),
+			),
+			"help_audit_format": fmt.Sprintf("Audit log format: '%s'.", strings.Join(audit.AllFormats, "', '")),
+			"help_log_format":   fmt.Sprintf("Log format: '%s'.", strings.Join(logFormats, "', '")),
+			"help_log_level":    fmt.Sprintf("Log level: '%s'.", strings.Join(logLevels, "', '")),
+			"help_mode":         fmt.Sprintf("Operation mode: '%s'.", strings.Join(middleware.AllModes, "', '")),
+			"help_telemetry":    "Enable or disable basic telemetry reporting. See https://beacon.ferretdb.com.",
 		},
 		kong.DefaultEnvars("FERRETDB"),
 	}
@@ -171,6 +250,27 @@ func main() {
 			os.Exit(1)
 		}
 
+	case "replay <dir>":
+		logger := setupDefaultLogger(cli.Log.Format, "")
+
+		ctx, stop := ctxutil.SigTerm(context.Background())
+		defer stop()
+
+		if !replayRecords(ctx, logger) {
+			os.Exit(1)
+		}
+
+	case "mcp":
+		logger := setupDefaultLogger(cli.Log.Format, "")
+		checkFlags(logger)
+
+		ctx, stop := ctxutil.SigTerm(context.Background())
+		defer stop()
+
+		if !runMCP(ctx, logger) {
+			os.Exit(1)
+		}
+
 	default:
 		panic("unknown sub-command")
 	}
@@ -254,6 +354,8 @@ func checkFlags(logger *slog.Logger) {
 		&cli.Listen.Unix,
 		&cli.Listen.TLS,
 		&cli.Listen.DataAPIAddr,
+		&cli.Listen.DataAPIUnix,
+		&cli.Listen.DataAPITls,
 		&cli.Listen.MCPAddr,
 		&cli.DebugAddr,
 		&cli.OTel.Traces.URL,
@@ -277,11 +379,36 @@ func checkFlags(logger *slog.Logger) {
 		logger.WarnContext(ctx, "Authentication is disabled; the server will accept any connection")
 	}
 
-	if cli.Auth && cli.Listen.MCPAddr != "" {
-		logger.Log(
-			ctx, logging.LevelFatal,
-			"MCP server does not support authentication; please disable authentication or MCP server",
-		)
+	if cli.DataAPI.MaxBodySize <= 0 {
+		logger.Log(ctx, logging.LevelFatal, "--data-api-max-body-size must be positive")
+	}
+
+	if cli.Shadow.QueueSize <= 0 {
+		logger.Log(ctx, logging.LevelFatal, "--shadow-queue-size must be positive")
+	}
+
+	if cli.Shadow.SampleRate <= 0 || cli.Shadow.SampleRate > 1 {
+		logger.Log(ctx, logging.LevelFatal, "--shadow-sample-rate must be in the (0, 1] range")
+	}
+
+	if cli.AuthLimit.MaxFailures < 0 {
+		logger.Log(ctx, logging.LevelFatal, "--auth-max-failures must not be negative")
+	}
+
+	if cli.AuthLimit.Lockout <= 0 || cli.AuthLimit.MaxLockout < cli.AuthLimit.Lockout {
+		logger.Log(ctx, logging.LevelFatal, "--auth-lockout must be positive and not greater than --auth-max-lockout")
+	}
+
+	if cli.Audit.Destination != "" && !slices.Contains(audit.AllDestinations, cli.Audit.Destination) {
+		logger.Log(ctx, logging.LevelFatal, fmt.Sprintf("--audit-destination must be one of '%s'", strings.Join(audit.AllDestinations, "', '")))
+	}
+
+	if cli.Audit.MaxSize < 0 {
+		logger.Log(ctx, logging.LevelFatal, "--audit-max-size must not be negative")
+	}
+
+	if cli.Audit.MaxSize > 0 && cli.Audit.MaxBackups < 1 {
+		logger.Log(ctx, logging.LevelFatal, "--audit-max-backups must be positive if --audit-max-size is set")
 	}
 }
 
@@ -386,6 +513,9 @@ func run() {
 	// used to start debug handler with probes as soon as possible, even before listener is created
 	var listener atomic.Pointer[clientconn.Listener]
 
+	// used by debug handler to change read-only mode at runtime
+	var result atomic.Pointer[setup.SetupResult]
+
 	var wg sync.WaitGroup
 
 	if cli.DebugAddr != "" {
@@ -408,10 +538,53 @@ func run() {
 						return false
 					}
 
-					return listener.Load().Listening()
+					// draining listener does not accept new connections, but it is still alive
+					return listener.Load().Listening() || listener.Load().Draining()
+				},
+
+				Readyz: func(ctx context.Context) bool {
+					if lis := listener.Load(); lis != nil && lis.Draining() {
+						l.DebugContext(ctx, "Listener is draining")
+						return false
+					}
+
+					return ready.Probe(ctx)
+				},
+
+				ReadOnly: &debug.Switch{
+					Get: func() bool {
+						if r := result.Load(); r != nil {
+							return r.ReadOnly()
+						}
+
+						return cli.ReadOnly
+					},
+					Set: func(readOnly bool) {
+						if r := result.Load(); r != nil {
+							r.SetReadOnly(readOnly)
+							return
+						}
+
+						l.WarnContext(ctx, "Read-only mode can't be changed before FerretDB is set up")
+					},
+				},
+
+				Drain: func() {
+					if r := result.Load(); r != nil {
+						r.Drain()
+						return
+					}
+
+					l.WarnContext(ctx, "Listeners are not set up yet; nothing to drain")
 				},
 
-				Readyz: ready.Probe,
+				ReloadRoutes: func() error {
+					if r := result.Load(); r != nil {
+						return r.ReloadRoutes()
+					}
+
+					return errors.New("FerretDB is not set up yet")
+				},
 			})
 			if e != nil {
 				l.LogAttrs(ctx, logging.LevelFatal, "Failed to create debug handler", logging.Error(e))
@@ -481,13 +654,41 @@ func run() {
 		PostgreSQLURL:          cli.PostgreSQLURL,
 		Auth:                   cli.Auth,
 		ReplSetName:            cli.Dev.ReplSetName,
+		ReadOnly:               cli.ReadOnly,
 		SessionCleanupInterval: 0,
+		CustomCommands:         nil,
+		AuthMaxFailures:        cli.AuthLimit.MaxFailures,
+		AuthLockout:            cli.AuthLimit.Lockout,
+		AuthMaxLockout:         cli.AuthLimit.MaxLockout,
 
 		ProxyAddr:        cli.Proxy.Addr,
 		ProxyTLSCertFile: cli.Proxy.TLSCertFile,
 		ProxyTLSKeyFile:  cli.Proxy.TLSKeyFile,
 		ProxyTLSCAFile:   cli.Proxy.TLSCaFile,
 
+		DiffReportFile:    cli.Diff.ReportFile,
+		DiffIgnoredFields: cli.Diff.IgnoreFields,
+
+		ShadowQueueSize:  cli.Shadow.QueueSize,
+		ShadowSampleRate: cli.Shadow.SampleRate,
+		ShadowCommands:   cli.Shadow.Commands,
+		ShadowNamespaces: cli.Shadow.Namespaces,
+
+		RouteRulesFile: cli.RouteRulesFile,
+
+		DualWriteReconciliationFile: cli.DualWriteReconciliationFile,
+
+		Interceptors:   nil,
+		TracerProvider: nil,
+
+		AuditDestination: cli.Audit.Destination,
+		AuditPath:        cli.Audit.Path,
+		AuditFormat:      cli.Audit.Format,
+		AuditMaxSize:     cli.Audit.MaxSize,
+		AuditMaxBackups:  cli.Audit.MaxBackups,
+		AuditFilter:      cli.Audit.Filter,
+		AuditNamespaces:  cli.Audit.Namespaces,
+
 		TCPAddr:        cli.Listen.Addr,
 		UnixAddr:       cli.Listen.Unix,
 		TLSAddr:        cli.Listen.TLS,
@@ -497,15 +698,25 @@ func run() {
 		Mode:           middleware.Mode(cli.Mode),
 		TestRecordsDir: cli.Dev.RecordsDir,
 
-		DataAPIAddr: cli.Listen.DataAPIAddr,
-
-		MCPAddr: cli.Listen.MCPAddr,
+		DataAPIAddr:        cli.Listen.DataAPIAddr,
+		DataAPIUnixAddr:    cli.Listen.DataAPIUnix,
+		DataAPITLSAddr:     cli.Listen.DataAPITls,
+		DataAPIMaxBodySize: cli.DataAPI.MaxBodySize,
+		DataAPICORSOrigins: cli.DataAPI.CORSOrigins,
+
+		MCPAddr:        cli.Listen.MCPAddr,
+		MCPAllowWrites: cli.MCP.AllowWrites,
+		MCPStdio:       false,
+		MCPUsername:    "",
+		MCPPassword:    "",
+		MCPAPIKey:      "",
 	})
 	if res == nil {
 		os.Exit(1)
 	}
 
 	listener.Store(res.WireListener)
+	result.Store(res)
 
 	metricsRegisterer.MustRegister(res)
 
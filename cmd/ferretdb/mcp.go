@@ -0,0 +1,119 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
+	"github.com/FerretDB/FerretDB/v2/internal/util/setup"
+	"github.com/FerretDB/FerretDB/v2/internal/util/state"
+)
+
+// runMCP implements the `mcp` command.
+//
+// It sets up the handler directly against PostgreSQL and serves MCP over stdin and stdout
+// until the client closes stdin or ctx is canceled.
+// Other listeners are not started; logs are written to stderr.
+//
+// It returns false if FerretDB could not be set up.
+func runMCP(ctx context.Context, l *slog.Logger) bool {
+	if len(cli.PostgreSQLURLFile) > 0 {
+		cli.PostgreSQLURL = strings.TrimSpace(string(cli.PostgreSQLURLFile))
+	}
+
+	stateProvider, err := state.NewProviderDir(cli.StateDir)
+	if err != nil {
+		l.ErrorContext(ctx, "Failed to set up state provider", logging.Error(err))
+		return false
+	}
+
+	//exhaustruct:enforce
+	res := setup.Setup(ctx, &setup.SetupOpts{
+		Logger:        l,
+		StateProvider: stateProvider,
+		Metrics:       middleware.NewMetrics(),
+
+		PostgreSQLURL:          cli.PostgreSQLURL,
+		Auth:                   cli.Auth,
+		ReplSetName:            cli.Dev.ReplSetName,
+		ReadOnly:               cli.ReadOnly,
+		SessionCleanupInterval: 0,
+		CustomCommands:         nil,
+		AuthMaxFailures:        cli.AuthLimit.MaxFailures,
+		AuthLockout:            cli.AuthLimit.Lockout,
+		AuthMaxLockout:         cli.AuthLimit.MaxLockout,
+
+		ProxyAddr:        cli.Proxy.Addr,
+		ProxyTLSCertFile: cli.Proxy.TLSCertFile,
+		ProxyTLSKeyFile:  cli.Proxy.TLSKeyFile,
+		ProxyTLSCAFile:   cli.Proxy.TLSCaFile,
+
+		DiffReportFile:    cli.Diff.ReportFile,
+		DiffIgnoredFields: cli.Diff.IgnoreFields,
+
+		ShadowQueueSize:  cli.Shadow.QueueSize,
+		ShadowSampleRate: cli.Shadow.SampleRate,
+		ShadowCommands:   cli.Shadow.Commands,
+		ShadowNamespaces: cli.Shadow.Namespaces,
+
+		RouteRulesFile: cli.RouteRulesFile,
+
+		DualWriteReconciliationFile: cli.DualWriteReconciliationFile,
+
+		Interceptors:   nil,
+		TracerProvider: nil,
+
+		AuditDestination: cli.Audit.Destination,
+		AuditPath:        cli.Audit.Path,
+		AuditFormat:      cli.Audit.Format,
+		AuditMaxSize:     cli.Audit.MaxSize,
+		AuditMaxBackups:  cli.Audit.MaxBackups,
+		AuditFilter:      cli.Audit.Filter,
+		AuditNamespaces:  cli.Audit.Namespaces,
+
+		TCPAddr:        "",
+		UnixAddr:       "",
+		TLSAddr:        "",
+		TLSCertFile:    "",
+		TLSKeyFile:     "",
+		TLSCAFile:      "",
+		Mode:           middleware.Mode(cli.Mode),
+		TestRecordsDir: cli.Dev.RecordsDir,
+
+		DataAPIAddr:        "",
+		DataAPIUnixAddr:    "",
+		DataAPITLSAddr:     "",
+		DataAPIMaxBodySize: 0,
+		DataAPICORSOrigins: nil,
+
+		MCPAddr:        "",
+		MCPAllowWrites: cli.MCP.AllowWrites,
+		MCPStdio:       true,
+		MCPUsername:    cli.MCPStdio.Username,
+		MCPPassword:    cli.MCPStdio.Password,
+		MCPAPIKey:      cli.MCPStdio.APIKey,
+	})
+	if res == nil {
+		return false
+	}
+
+	res.Run(ctx)
+
+	return true
+}
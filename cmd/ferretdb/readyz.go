@@ -21,15 +21,13 @@
 	"net"
 	"net/url"
 
-	"github.com/FerretDB/wire"
-	"github.com/FerretDB/wire/wireclient"
-
 	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
+	"github.com/FerretDB/FerretDB/v2/internal/util/readyz"
 )
 
 // ReadyZ represents the Readiness probe.
 //
-// It connects to FerretDB with Go driver and sends `ping` command.
+// It connects to all configured listeners and sends `ping` command with [readyz.PingURI].
 type ReadyZ struct {
 	l *slog.Logger
 }
@@ -75,52 +73,12 @@ func (r *ReadyZ) Probe(ctx context.Context) bool {
 	for _, u := range urls {
 		r.l.DebugContext(ctx, fmt.Sprintf("Pinging %s", u))
 
-		cleanUri, _, _, _, err := wireclient.Credentials(u)
-		if err != nil {
-			r.l.ErrorContext(ctx, "Getting credentials failed", logging.Error(err))
-			return false
-		}
-
-		conn, err := wireclient.Connect(ctx, cleanUri, r.l)
-		if err != nil {
-			r.l.ErrorContext(ctx, "Connection failed", logging.Error(err))
+		if err := readyz.PingURI(ctx, u, r.l); err != nil {
+			r.l.ErrorContext(ctx, "Ping failed", slog.String("url", u), logging.Error(err))
 			return false
 		}
 
-		defer func() {
-			if err = conn.Close(); err != nil {
-				r.l.ErrorContext(ctx, "Closing connection failed", logging.Error(err))
-			}
-		}()
-
-		_, resBody, err := conn.Request(ctx, wire.MustOpMsg(
-			"ping", int32(1),
-			"$db", "admin",
-		))
-		if err != nil {
-			r.l.ErrorContext(ctx, "Ping request failed", logging.Error(err))
-			return false
-		}
-
-		res, err := resBody.(*wire.OpMsg).Document()
-		if err != nil {
-			r.l.ErrorContext(ctx, "Decoding ping response failed", logging.Error(err))
-			return false
-		}
-
-		if res.Get("ok").(float64) == 1 {
-			r.l.InfoContext(ctx, "Ping successful", slog.String("url", u))
-			continue
-		}
-
-		attrs := []any{
-			slog.Int("code", int(res.Get("code").(int32))),
-			slog.String("code_name", res.Get("codeName").(string)),
-			slog.String("errmsg", res.Get("errmsg").(string)),
-		}
-		r.l.ErrorContext(ctx, "Ping failed", attrs...)
-
-		return false
+		r.l.InfoContext(ctx, "Ping successful", slog.String("url", u))
 	}
 
 	return true
@@ -0,0 +1,89 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/FerretDB/FerretDB/v2/internal/replay"
+	"github.com/FerretDB/FerretDB/v2/internal/util/bsondiff"
+	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
+)
+
+// replayRecords implements the `replay` command.
+//
+// It returns false if replaying failed or found regressions.
+func replayRecords(ctx context.Context, l *slog.Logger) bool {
+	sessions, err := replay.Load(cli.Replay.Dir)
+	if err != nil {
+		l.ErrorContext(ctx, "Failed to load records", logging.Error(err))
+		return false
+	}
+
+	if len(sessions) == 0 {
+		l.WarnContext(ctx, "No records found", slog.String("dir", cli.Replay.Dir))
+		return true
+	}
+
+	opts := &replay.Opts{
+		URI:            cli.Replay.Target,
+		PreserveTiming: cli.Replay.PreserveTiming,
+		Rules: &bsondiff.Rules{
+			IgnoredFields: cli.Replay.IgnoreFields,
+		},
+		L: l,
+	}
+
+	if cli.Replay.Report != "" {
+		var f *os.File
+		if f, err = os.Create(cli.Replay.Report); err != nil {
+			l.ErrorContext(ctx, "Failed to create report file", logging.Error(err))
+			return false
+		}
+
+		defer func() {
+			if err = f.Close(); err != nil {
+				l.ErrorContext(ctx, "Failed to close report file", logging.Error(err))
+			}
+		}()
+
+		opts.Report = f
+	}
+
+	summary, err := replay.Replay(ctx, sessions, opts)
+	if err != nil {
+		l.ErrorContext(ctx, "Failed to replay records", logging.Error(err))
+		return false
+	}
+
+	if summary.NoResponses > 0 {
+		l.WarnContext(
+			ctx, "Some sessions have no recorded responses (no .resp files) and were not replayed; "+
+				"record them again with the current version",
+			slog.Int("sessions", summary.NoResponses),
+		)
+	}
+
+	if summary.Regressed() {
+		l.ErrorContext(ctx, "Replay found regressions: "+summary.String())
+		return false
+	}
+
+	l.InfoContext(ctx, "Replay completed: "+summary.String())
+
+	return true
+}
@@ -0,0 +1,151 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ferretdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/FerretDB/wire/wirebson"
+
+	"github.com/FerretDB/FerretDB/v2/internal/handler"
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+)
+
+// Command represents a custom OP_MSG command registered with [Config]'s Commands field.
+//
+// Custom commands are handled like built-in commands:
+// they are listed by `listCommands` (if Help is set),
+// require authentication (unless Anonymous is set),
+// respect read-only mode and API key scope (if Write is set),
+// and are included in metrics and traces.
+type Command struct {
+	// Command name (the first field of the request document). Required.
+	// It must not conflict with built-in commands.
+	Name string
+
+	// Shown in the `listCommands` command output.
+	// If empty, the command is hidden, but still can be used.
+	Help string
+
+	// Allow the command to be used without authentication.
+	Anonymous bool
+
+	// The command modifies data.
+	Write bool
+
+	// Processes the command. Required.
+	//
+	// The passed context is canceled when the client disconnects.
+	// The returned document is sent to the client; `ok` field is added if it is not present.
+	// Return [*CommandError] to send an error with the specific code.
+	Handler func(ctx context.Context, req *CommandRequest) (*wirebson.Document, error)
+}
+
+// CommandRequest represents a custom command request.
+type CommandRequest struct {
+	// Decoded request document, including the command name and `$db` field.
+	Document *wirebson.Document
+
+	// Name of the authenticated user.
+	// Empty if authentication is disabled or the client is not authenticated.
+	Username string
+
+	req *handler.CustomRequest
+}
+
+// WithConn calls the given function with a PostgreSQL connection from the FerretDB connection pool.
+// DocumentDB functions could be called on that connection.
+//
+// The connection is returned to the pool after the function returns;
+// calling its methods after that returns an error.
+func (req *CommandRequest) WithConn(f func(*Conn) error) error {
+	return req.req.WithConn(func(c *handler.CustomConn) error {
+		return f(&Conn{c: c})
+	})
+}
+
+// Conn represents a PostgreSQL connection passed to [CommandRequest.WithConn] function.
+type Conn struct {
+	c *handler.CustomConn
+}
+
+// Exec executes the given SQL statement with arguments and returns the number of affected rows.
+func (c *Conn) Exec(ctx context.Context, sql string, args ...any) (int64, error) {
+	return c.c.Exec(ctx, sql, args...)
+}
+
+// Query executes the given SQL query with arguments and returns all rows.
+// Each row contains values of all columns in order.
+func (c *Conn) Query(ctx context.Context, sql string, args ...any) ([][]any, error) {
+	return c.c.Query(ctx, sql, args...)
+}
+
+// CommandError represents a custom command error sent to the client with the given code and message.
+type CommandError struct {
+	Code    int32
+	Message string
+}
+
+// Error implements error interface.
+func (e *CommandError) Error() string {
+	return fmt.Sprintf("%s (%d)", e.Message, e.Code)
+}
+
+// customCommands converts public commands to handler's custom commands.
+func customCommands(cmds []*Command) []*handler.CustomCommand {
+	res := make([]*handler.CustomCommand, len(cmds))
+
+	for i, c := range cmds {
+		cc := &handler.CustomCommand{
+			Name:      c.Name,
+			Help:      c.Help,
+			Anonymous: c.Anonymous,
+			Write:     c.Write,
+		}
+
+		if c.Handler != nil {
+			cc.Handler = func(ctx context.Context, req *handler.CustomRequest) (*wirebson.Document, error) {
+				res, err := c.Handler(ctx, &CommandRequest{
+					Document: req.Document,
+					Username: req.Username,
+					req:      req,
+				})
+
+				if err != nil {
+					return nil, mongoError(err)
+				}
+
+				return res, nil
+			}
+		}
+
+		res[i] = cc
+	}
+
+	return res
+}
+
+// mongoError converts [*CommandError] to the error with the specific code.
+// Other errors are returned as is.
+func mongoError(err error) error {
+	var ce *CommandError
+	if errors.As(err, &ce) && ce.Code > 0 {
+		return mongoerrors.New(mongoerrors.Code(ce.Code), ce.Message)
+	}
+
+	return err
+}
@@ -29,14 +29,24 @@
 	"fmt"
 	"io"
 	"log/slog"
+	"net"
 	"net/url"
 	"os"
+	"slices"
 	"sync"
 	"time"
 
+	"github.com/FerretDB/wire/wireclient"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/FerretDB/FerretDB/v2/build/version"
+	"github.com/FerretDB/FerretDB/v2/internal/audit"
+	"github.com/FerretDB/FerretDB/v2/internal/handler"
 	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/util/ctxutil"
 	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
+	"github.com/FerretDB/FerretDB/v2/internal/util/readyz"
 	"github.com/FerretDB/FerretDB/v2/internal/util/setup"
 	"github.com/FerretDB/FerretDB/v2/internal/util/state"
 	"github.com/FerretDB/FerretDB/v2/internal/util/telemetry"
@@ -46,6 +56,9 @@
 // Avoid breaking changes.
 
 // Config represents FerretDB configuration.
+//
+// The zero value of each optional field disables the corresponding feature or uses the default value.
+// Note that unlike the FerretDB binary, authentication is disabled by default.
 type Config struct {
 	// PostgreSQL URL. Required.
 	PostgreSQLURL string
@@ -54,31 +67,229 @@ type Config struct {
 	// If empty, TCP listener is disabled.
 	ListenAddr string
 
+	// Listen Unix domain socket path for MongoDB protocol.
+	// If empty, Unix listener is disabled.
+	ListenUnix string
+
+	// Listen TLS address for MongoDB protocol.
+	// If empty, TLS listener is disabled.
+	ListenTLS string
+
+	// TLS cert, key, and CA file paths.
+	// They are used by both MongoDB protocol and Data API TLS listeners.
+	ListenTLSCertFile string
+	ListenTLSKeyFile  string
+	ListenTLSCAFile   string
+
+	// Listen TCP address, Unix domain socket path, and TLS address for HTTP Data API.
+	// If all are empty, Data API is disabled.
+	ListenDataAPIAddr string
+	ListenDataAPIUnix string
+	ListenDataAPITLS  string
+
+	// Maximum size of Data API request body in bytes.
+	// Defaults to 16 MiB.
+	DataAPIMaxBodySize int64
+
+	// Origins allowed to make cross-origin requests to Data API; "*" allows all.
+	// If empty, CORS is disabled.
+	DataAPICORSOrigins []string
+
+	// Listen TCP address for HTTP MCP server.
+	// If empty, MCP server is disabled.
+	ListenMCPAddr string
+
+	// Enable MCP tools that modify data.
+	MCPAllowWrites bool
+
+	// Proxy address and TLS file paths for proxy and diff modes.
+	ProxyAddr        string
+	ProxyTLSCertFile string
+	ProxyTLSKeyFile  string
+	ProxyTLSCAFile   string
+
+	// Operation mode: "normal", "proxy", "diff-normal", "diff-proxy", etc.
+	// Defaults to "normal".
+	Mode string
+
 	// State directory. Required.
 	StateDir string
 
+	// Enable authentication.
+	Auth bool
+
+	// Reject write commands.
+	ReadOnly bool
+
+	// Path to a file for structured reports about differing responses in diff modes.
+	// If empty, reports are disabled.
+	DiffReportFile string
+
+	// Response fields ignored when comparing responses in diff modes.
+	// If nil, the default set of fields is used.
+	DiffIgnoredFields []string
+
+	// Maximum number of requests waiting to be sent to the secondary handler in shadow modes.
+	// Defaults to 1000.
+	ShadowQueueSize int
+
+	// Fraction of requests sent to the secondary handler in shadow modes, in the (0, 1] range.
+	// Defaults to 1.
+	ShadowSampleRate float64
+
+	// Commands sent to the secondary handler in shadow modes.
+	// If empty, all commands are sent.
+	ShadowCommands []string
+
+	// Databases and collections (db.coll) sent to the secondary handler in shadow modes.
+	// If empty, all namespaces are sent.
+	ShadowNamespaces []string
+
+	// Path to a JSON file with routing rules. Required for route mode.
+	RouteRulesFile string
+
+	// Path to a file for logging diverged write results in dual-write modes.
+	// If empty, the log is disabled.
+	DualWriteReconciliationFile string
+
+	// Interval of cleaning up expired sessions.
+	// Defaults to 1 minute.
+	SessionCleanupInterval time.Duration
+
+	// Failed authentication attempts per username or peer address before lockout.
+	// If zero, lockouts are disabled.
+	AuthMaxFailures int
+
+	// Duration of the first authentication lockout; doubled for each subsequent one.
+	// Defaults to 30 seconds.
+	AuthLockout time.Duration
+
+	// Maximum duration of authentication lockout.
+	// Defaults to 1 hour.
+	AuthMaxLockout time.Duration
+
+	// Custom commands handled in addition to built-in commands.
+	Commands []*Command
+
+	// Interceptors called in order for every request.
+	Interceptors []*Interceptor
+
+	// Audit log destination: "file", "syslog", or "socket".
+	// If empty, audit log is disabled.
+	AuditDestination string
+
+	// Path to the audit log file or Unix socket.
+	// Defaults to "/dev/log" for "syslog" destination.
+	AuditPath string
+
+	// Audit log format: "json" or "bson" ("json" only for "syslog" destination).
+	// Defaults to "json".
+	AuditFormat string
+
+	// Maximum audit log file size in bytes before rotation.
+	// If zero, rotation is disabled.
+	AuditMaxSize int64
+
+	// Maximum number of rotated audit log files to keep.
+	// Must be positive if AuditMaxSize is set.
+	AuditMaxBackups int
+
+	// Audit log filter: JSON query document on event fields,
+	// similar to MongoDB's `auditLog.filter`.
+	// If empty, all events are written.
+	AuditFilter string
+
+	// Databases and collections (db.coll) for auditing CRUD commands.
+	// If empty, CRUD commands are not audited.
+	AuditNamespaces []string
+
 	// Defaults to [slog.LevelError].
+	// Ignored if LogHandler is set.
 	LogLevel slog.Leveler
 
 	// Defaults to [io.Discard], effectively disabling logging.
+	// Ignored if LogHandler is set.
 	LogOutput io.Writer
 
+	// Custom log handler.
+	// If set, it controls log level, format, and output.
+	LogHandler slog.Handler
+
+	// Prometheus registerer for FerretDB metrics.
+	// Metrics are registered by [New] and unregistered when [*FerretDB.Run] returns.
+	// If nil, metrics are not registered.
+	MetricsRegisterer prometheus.Registerer
+
+	// OpenTelemetry tracer provider.
+	// If set, it is used for request spans instead of the global tracer provider;
+	// the global one is not changed.
+	TracerProvider trace.TracerProvider
+
 	// Defaults to undecided.
 	// Set to `true` to enable telemetry, `false` to disable it.
 	// See https://docs.ferretdb.io/telemetry/.
 	Telemetry *bool
 }
 
+// inProcessHost is a placeholder host used in MongoDB URI when TCP listener is disabled.
+const inProcessHost = "ferretdb.invalid"
+
 // FerretDB represents an instance of embedded FerretDB implementation.
 type FerretDB struct {
-	tr  *telemetry.Reporter
-	res *setup.SetupResult
+	l          *slog.Logger
+	tr         *telemetry.Reporter
+	res        *setup.SetupResult
+	registerer prometheus.Registerer
+	collectors []prometheus.Collector
+
+	runM      sync.Mutex
+	runCancel context.CancelFunc // nil if Run was not called yet
+	done      chan struct{}      // closed when Run returns
+}
+
+// Stats represents runtime statistics of FerretDB instance.
+type Stats struct {
+	Connections int64 // open MongoDB protocol client connections, including in-process ones
+	Requests    int64 // total number of handled requests
+	Cursors     int   // open cursors
+	Sessions    int   // active sessions
 }
 
+// readyInterval is the interval between readiness checks in [*FerretDB.WaitReady].
+const readyInterval = 100 * time.Millisecond
+
 // New creates a new instance of embedded FerretDB implementation.
 func New(config *Config) (*FerretDB, error) {
 	version.Get().Package = "embedded"
 
+	interceptors := middlewareInterceptors(config.Interceptors)
+	if err := middleware.ValidateInterceptors(interceptors); err != nil {
+		return nil, fmt.Errorf("invalid interceptors: %w", err)
+	}
+
+	auditFormat := config.AuditFormat
+	if auditFormat == "" {
+		auditFormat = audit.FormatJSON
+	}
+
+	cmds := customCommands(config.Commands)
+	if err := handler.ValidateCustomCommands(cmds); err != nil {
+		return nil, fmt.Errorf("invalid custom commands: %w", err)
+	}
+
+	mode := middleware.NormalMode
+	if config.Mode != "" {
+		if !slices.Contains(middleware.AllModes, config.Mode) {
+			return nil, fmt.Errorf("invalid mode %q", config.Mode)
+		}
+
+		mode = middleware.Mode(config.Mode)
+	}
+
+	if err := validateMode(mode, config); err != nil {
+		return nil, err
+	}
+
 	stateProvider, err := state.NewProviderDir(config.StateDir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to set up state provider: %w", err)
@@ -97,12 +308,20 @@ func New(config *Config) (*FerretDB, error) {
 		logOutput = io.Discard
 	}
 
-	lOpts := &logging.NewHandlerOpts{
-		Base:       "console",
-		Level:      logLevel,
-		SkipChecks: true,
+	var logger *slog.Logger
+
+	if config.LogHandler != nil {
+		logger = slog.New(logging.WrapHandler(config.LogHandler))
+	} else {
+		lOpts := &logging.NewHandlerOpts{
+			Base:       "console",
+			Level:      logLevel,
+			SkipChecks: true,
+		}
+		logger = logging.Logger(logOutput, lOpts, "")
 	}
-	logger := logging.WithName(logging.Logger(logOutput, lOpts, ""), "ferretdb")
+
+	logger = logging.WithName(logger, "ferretdb")
 
 	mm := middleware.NewMetrics()
 
@@ -129,45 +348,143 @@ func New(config *Config) (*FerretDB, error) {
 		Metrics:       mm,
 
 		PostgreSQLURL:          config.PostgreSQLURL,
-		Auth:                   false,
+		Auth:                   config.Auth,
 		ReplSetName:            "",
-		SessionCleanupInterval: 0,
+		ReadOnly:               config.ReadOnly,
+		SessionCleanupInterval: config.SessionCleanupInterval,
+		CustomCommands:         cmds,
+		AuthMaxFailures:        config.AuthMaxFailures,
+		AuthLockout:            config.AuthLockout,
+		AuthMaxLockout:         config.AuthMaxLockout,
 
-		ProxyAddr:        "",
-		ProxyTLSCertFile: "",
-		ProxyTLSKeyFile:  "",
-		ProxyTLSCAFile:   "",
+		ProxyAddr:        config.ProxyAddr,
+		ProxyTLSCertFile: config.ProxyTLSCertFile,
+		ProxyTLSKeyFile:  config.ProxyTLSKeyFile,
+		ProxyTLSCAFile:   config.ProxyTLSCAFile,
+
+		DiffReportFile:    config.DiffReportFile,
+		DiffIgnoredFields: config.DiffIgnoredFields,
+
+		ShadowQueueSize:  config.ShadowQueueSize,
+		ShadowSampleRate: config.ShadowSampleRate,
+		ShadowCommands:   config.ShadowCommands,
+		ShadowNamespaces: config.ShadowNamespaces,
+
+		RouteRulesFile: config.RouteRulesFile,
+
+		DualWriteReconciliationFile: config.DualWriteReconciliationFile,
+
+		Interceptors:   interceptors,
+		TracerProvider: config.TracerProvider,
+
+		AuditDestination: config.AuditDestination,
+		AuditPath:        config.AuditPath,
+		AuditFormat:      auditFormat,
+		AuditMaxSize:     config.AuditMaxSize,
+		AuditMaxBackups:  config.AuditMaxBackups,
+		AuditFilter:      config.AuditFilter,
+		AuditNamespaces:  config.AuditNamespaces,
 
 		TCPAddr:        config.ListenAddr,
-		UnixAddr:       "",
-		TLSAddr:        "",
-		TLSCertFile:    "",
-		TLSKeyFile:     "",
-		TLSCAFile:      "",
-		Mode:           middleware.NormalMode,
+		UnixAddr:       config.ListenUnix,
+		TLSAddr:        config.ListenTLS,
+		TLSCertFile:    config.ListenTLSCertFile,
+		TLSKeyFile:     config.ListenTLSKeyFile,
+		TLSCAFile:      config.ListenTLSCAFile,
+		Mode:           mode,
 		TestRecordsDir: "",
 
-		DataAPIAddr: "",
-
-		MCPAddr: "",
+		DataAPIAddr:        config.ListenDataAPIAddr,
+		DataAPIUnixAddr:    config.ListenDataAPIUnix,
+		DataAPITLSAddr:     config.ListenDataAPITLS,
+		DataAPIMaxBodySize: config.DataAPIMaxBodySize,
+		DataAPICORSOrigins: config.DataAPICORSOrigins,
+
+		MCPAddr:        config.ListenMCPAddr,
+		MCPAllowWrites: config.MCPAllowWrites,
+		MCPStdio:       false,
+		MCPUsername:    "",
+		MCPPassword:    "",
+		MCPAPIKey:      "",
 	})
 	if res == nil {
 		return nil, fmt.Errorf("failed to create FerretDB")
 	}
 
-	return &FerretDB{
-		tr:  tr,
-		res: res,
-	}, nil
+	f := &FerretDB{
+		l:          logger,
+		tr:         tr,
+		res:        res,
+		registerer: config.MetricsRegisterer,
+		done:       make(chan struct{}),
+	}
+
+	if f.registerer != nil {
+		for _, c := range []prometheus.Collector{stateProvider.MetricsCollector(true), res} {
+			if err = f.registerer.Register(c); err != nil {
+				f.unregister()
+
+				// run with canceled context to release created resources
+				ctx, cancel := context.WithCancel(context.TODO())
+				cancel()
+				res.Run(ctx)
+
+				return nil, fmt.Errorf("failed to register metrics: %w", err)
+			}
+
+			f.collectors = append(f.collectors, c)
+		}
+	}
+
+	return f, nil
+}
+
+// validateMode checks that the configuration contains options required by the given operation mode.
+func validateMode(mode middleware.Mode, config *Config) error {
+	if mode != middleware.NormalMode && config.ProxyAddr == "" {
+		return fmt.Errorf("proxy address is required for %q mode", mode)
+	}
+
+	if mode == middleware.RouteMode && config.RouteRulesFile == "" {
+		return fmt.Errorf("routing rules file is required for %q mode", mode)
+	}
+
+	if config.ShadowQueueSize < 0 {
+		return fmt.Errorf("shadow queue size must not be negative")
+	}
+
+	if config.ShadowSampleRate < 0 || config.ShadowSampleRate > 1 {
+		return fmt.Errorf("shadow sample rate must be in the (0, 1] range")
+	}
+
+	return nil
+}
+
+// unregister unregisters all registered metrics collectors.
+func (f *FerretDB) unregister() {
+	for _, c := range f.collectors {
+		f.registerer.Unregister(c)
+	}
+
+	f.collectors = nil
 }
 
-// Run runs FerretDB until ctx is canceled.
+// Run runs FerretDB until ctx is canceled or [*FerretDB.Shutdown] is called.
+// It should be called only once.
 //
 // When this method returns, all listeners, all client connections, and all PostgreSQL connections are closed.
 //
 // It is required to run this method in order to initialize listeners with their respective IP addresses and ports.
 // Calling [*FerretDB.MongoDBURI] before calling this method will result in a deadlock.
 func (f *FerretDB) Run(ctx context.Context) {
+	defer close(f.done)
+
+	f.runM.Lock()
+	ctx, f.runCancel = context.WithCancel(ctx)
+	f.runM.Unlock()
+
+	defer f.runCancel()
+
 	var wg sync.WaitGroup
 
 	wg.Add(1)
@@ -185,15 +502,179 @@ func (f *FerretDB) Run(ctx context.Context) {
 	}()
 
 	wg.Wait()
+
+	if f.registerer != nil {
+		f.unregister()
+	}
+}
+
+// Done returns a channel that is closed when [*FerretDB.Run] returns.
+func (f *FerretDB) Done() <-chan struct{} {
+	return f.done
+}
+
+// WaitReady waits until FerretDB is ready to handle requests:
+// [*FerretDB.Run] is running, and `ping` command sent over in-process connection succeeds
+// (which requires PostgreSQL to be available).
+//
+// It returns an error if ctx is canceled or Run returns before that.
+func (f *FerretDB) WaitReady(ctx context.Context) error {
+	for {
+		err := f.ping(ctx)
+		if err == nil {
+			return nil
+		}
+
+		f.l.DebugContext(ctx, "FerretDB is not ready yet", logging.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("FerretDB is not ready: %w", context.Cause(ctx))
+		case <-f.done:
+			return fmt.Errorf("FerretDB stopped before becoming ready")
+		case <-time.After(readyInterval):
+		}
+	}
+}
+
+// ping sends `ping` command over the new in-process connection.
+func (f *FerretDB) ping(ctx context.Context) error {
+	netConn, err := f.res.WireListener.Dial(ctx)
+	if err != nil {
+		return err
+	}
+
+	conn := wireclient.New(netConn, logging.WithName(f.l, "readyz"))
+	defer conn.Close() //nolint:errcheck // we are only reading
+
+	return readyz.Ping(ctx, conn)
+}
+
+// Shutdown gracefully stops FerretDB started by [*FerretDB.Run] and waits for Run to return.
+//
+// It stops accepting new MongoDB protocol, Data API, and MCP connections,
+// and waits for open MongoDB protocol connections to be closed after their in-flight requests are handled.
+// The ctx is used as a drain timeout: when it is canceled, remaining connections are closed,
+// and the context's error is returned.
+// Shutdown does nothing if Run was not called.
+func (f *FerretDB) Shutdown(ctx context.Context) error {
+	f.runM.Lock()
+	runCancel := f.runCancel
+	f.runM.Unlock()
+
+	if runCancel == nil {
+		return nil
+	}
+
+	f.res.Drain()
+
+	var err error
+
+	for f.res.WireListener.Connections() > 0 {
+		if ctx.Err() != nil {
+			err = fmt.Errorf("failed to drain connections: %w", context.Cause(ctx))
+			break
+		}
+
+		ctxutil.Sleep(ctx, readyInterval)
+	}
+
+	runCancel()
+	<-f.done
+
+	return err
+}
+
+// Stats returns runtime statistics of this FerretDB instance.
+func (f *FerretDB) Stats() *Stats {
+	s := f.res.Stats()
+
+	return &Stats{
+		Connections: s.Connections,
+		Requests:    s.Requests,
+		Cursors:     s.Cursors,
+		Sessions:    s.Sessions,
+	}
 }
 
 // MongoDBURI returns MongoDB URI for this FerretDB instance.
+//
+// If TCP listener is disabled, the returned URI contains a placeholder host
+// and could be used only with [*FerretDB.DialContext].
 func (f *FerretDB) MongoDBURI() string {
+	host := inProcessHost
+	if addr := f.res.WireListener.TCPAddr(); addr != nil {
+		host = addr.String()
+	}
+
 	u := &url.URL{
 		Scheme: "mongodb",
-		Host:   f.res.WireListener.TCPAddr().String(),
+		Host:   host,
 		Path:   "/",
 	}
 
 	return u.String()
 }
+
+// DialContext creates a new in-process client connection without opening any socket.
+// Network and address are ignored.
+//
+// It implements the dialer interface of the MongoDB Go driver, so FerretDB instance could be passed
+// to the driver's `SetDialer` client option together with [*FerretDB.MongoDBURI].
+// [*FerretDB.Run] must be running for connections to be accepted.
+func (f *FerretDB) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	return f.res.WireListener.Dial(ctx)
+}
+
+// TCPAddr returns the address of the MongoDB protocol TCP listener, or nil, if it is disabled.
+// It can be used to determine an actually used port, if it was zero.
+func (f *FerretDB) TCPAddr() net.Addr {
+	return f.res.WireListener.TCPAddr()
+}
+
+// UnixAddr returns the address of the MongoDB protocol Unix domain socket listener, or nil, if it is disabled.
+func (f *FerretDB) UnixAddr() net.Addr {
+	return f.res.WireListener.UnixAddr()
+}
+
+// TLSAddr returns the address of the MongoDB protocol TLS listener, or nil, if it is disabled.
+// It can be used to determine an actually used port, if it was zero.
+func (f *FerretDB) TLSAddr() net.Addr {
+	return f.res.WireListener.TLSAddr()
+}
+
+// DataAPIAddr returns the address of the Data API TCP listener, or nil, if it is disabled.
+func (f *FerretDB) DataAPIAddr() net.Addr {
+	if f.res.DataAPIListener == nil {
+		return nil
+	}
+
+	return f.res.DataAPIListener.Addr()
+}
+
+// DataAPIUnixAddr returns the address of the Data API Unix domain socket listener, or nil, if it is disabled.
+func (f *FerretDB) DataAPIUnixAddr() net.Addr {
+	if f.res.DataAPIListener == nil {
+		return nil
+	}
+
+	return f.res.DataAPIListener.UnixAddr()
+}
+
+// DataAPITLSAddr returns the address of the Data API TLS listener, or nil, if it is disabled.
+func (f *FerretDB) DataAPITLSAddr() net.Addr {
+	if f.res.DataAPIListener == nil {
+		return nil
+	}
+
+	return f.res.DataAPIListener.TLSAddr()
+}
+
+// MCPAddr returns the address of the MCP server listener, or nil, if it is disabled.
+func (f *FerretDB) MCPAddr() net.Addr {
+	if f.res.MCPListener == nil {
+		return nil
+	}
+
+	return f.res.MCPListener.Addr()
+}
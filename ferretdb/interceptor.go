@@ -0,0 +1,189 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ferretdb
+
+import (
+	"context"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+
+	"github.com/FerretDB/FerretDB/v2/internal/clientconn/conninfo"
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+)
+
+// Interceptor represents a pair of hooks registered with [Config]'s Interceptors field.
+//
+// Interceptors are called in order before the request is handled,
+// and in reverse order after the response is produced.
+// They could be used for access checks, request rewriting, auditing, caching, quotas, etc.
+// Each hook call has its own OpenTelemetry span.
+//
+// Hooks are called concurrently for different requests.
+type Interceptor struct {
+	// Interceptor name used in traces. Required.
+	// It must be unique.
+	Name string
+
+	// Called before the request is handled.
+	//
+	// It may return a request document to replace the request (nil keeps it as is),
+	// a response document to skip handling and the rest of the chain (for example, for caching),
+	// or an error to reject the request.
+	// In the last two cases, After hooks of only the previous interceptors are called.
+	//
+	// The `ok` field is added to the response document if it is not present.
+	// Return [*CommandError] to send an error with the specific code.
+	Before func(ctx context.Context, req *InterceptorRequest) (replace, respond *wirebson.Document, err error)
+
+	// Called after the response is produced.
+	// It is not called if the response can't be produced, for example, if the client disconnected.
+	//
+	// It may return a response document to replace the response (nil keeps it as is),
+	// or an error to replace it with an error response.
+	//
+	// The `ok` field is added to the response document if it is not present.
+	// Return [*CommandError] to send an error with the specific code.
+	After func(ctx context.Context, req *InterceptorRequest, resp *wirebson.Document) (*wirebson.Document, error)
+}
+
+// InterceptorRequest represents a request passed to [Interceptor] hooks.
+type InterceptorRequest struct {
+	// Deeply decoded request document, including the command name, `$db` field,
+	// and documents sent by drivers separately (like `documents` for `insert`).
+	// It may be modified and returned by Before hook to replace the request.
+	Document *wirebson.Document
+
+	// Name of the authenticated user.
+	// Empty if authentication is disabled or the client is not authenticated.
+	Username string
+
+	// Client's address.
+	// Empty for Unix domain sockets and in-process connections.
+	PeerAddr string
+}
+
+// middlewareInterceptors converts public interceptors to middleware's interceptors.
+func middlewareInterceptors(interceptors []*Interceptor) []*middleware.Interceptor {
+	res := make([]*middleware.Interceptor, len(interceptors))
+
+	for i, ic := range interceptors {
+		if ic == nil {
+			continue
+		}
+
+		mi := &middleware.Interceptor{
+			Name: ic.Name,
+		}
+
+		if ic.Before != nil {
+			mi.Before = func(ctx context.Context, req *middleware.Request) (*middleware.Request, *middleware.Response, error) {
+				ir, err := interceptorRequest(ctx, req)
+				if err != nil {
+					return nil, nil, lazyerrors.Error(err)
+				}
+
+				replace, respond, err := ic.Before(ctx, ir)
+
+				switch {
+				case err != nil:
+					return nil, nil, mongoError(err)
+
+				case respond != nil:
+					resp, err := responseDoc(req, respond)
+					if err != nil {
+						return nil, nil, lazyerrors.Error(err)
+					}
+
+					return nil, resp, nil
+
+				case replace != nil:
+					r, err := req.WithDocument(replace)
+					if err != nil {
+						return nil, nil, lazyerrors.Error(err)
+					}
+
+					return r, nil, nil
+
+				default:
+					return nil, nil, nil
+				}
+			}
+		}
+
+		if ic.After != nil {
+			mi.After = func(ctx context.Context, req *middleware.Request, resp *middleware.Response) (*middleware.Response, error) {
+				ir, err := interceptorRequest(ctx, req)
+				if err != nil {
+					return nil, lazyerrors.Error(err)
+				}
+
+				doc, err := resp.DocumentDeep()
+				if err != nil {
+					return nil, lazyerrors.Error(err)
+				}
+
+				if doc, err = ic.After(ctx, ir, doc); err != nil {
+					return nil, mongoError(err)
+				}
+
+				if doc == nil {
+					return nil, nil
+				}
+
+				return responseDoc(req, doc)
+			}
+		}
+
+		res[i] = mi
+	}
+
+	return res
+}
+
+// interceptorRequest returns a request for interceptor hooks.
+func interceptorRequest(ctx context.Context, req *middleware.Request) (*InterceptorRequest, error) {
+	doc, err := req.DocumentMerged()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	res := &InterceptorRequest{
+		Document: doc,
+	}
+
+	ci := conninfo.Get(ctx)
+
+	if ci.Authenticated() {
+		res.Username = ci.Username()
+	}
+
+	if ci.Peer.IsValid() {
+		res.PeerAddr = ci.Peer.String()
+	}
+
+	return res, nil
+}
+
+// responseDoc returns a response for the given document, adding the `ok` field if it is not present.
+func responseDoc(req *middleware.Request, doc *wirebson.Document) (*middleware.Response, error) {
+	if doc.Get("ok") == nil {
+		if err := doc.Add("ok", float64(1)); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+	}
+
+	return middleware.ResponseDoc(req, doc)
+}
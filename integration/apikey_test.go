@@ -0,0 +1,173 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/FerretDB/FerretDB/v2/integration/setup"
+)
+
+// createUserDatabase creates a user with the given roles (none means `readWriteAnyDatabase`, not admin)
+// and returns the given database of a client authenticated as that user.
+func createUserDatabase(t testing.TB, s *setup.SetupResult, username string, roles ...bson.D) *mongo.Database {
+	t.Helper()
+
+	ctx, db := s.Ctx, s.Collection.Database()
+	password := "password"
+
+	if len(roles) == 0 {
+		roles = []bson.D{{{"role", "readWriteAnyDatabase"}, {"db", "admin"}}}
+	}
+
+	// TODO https://github.com/FerretDB/FerretDB-DocumentDB/issues/864
+	_ = db.RunCommand(ctx, bson.D{{"dropUser", username}})
+
+	err := db.RunCommand(ctx, bson.D{
+		{"createUser", username},
+		{"roles", roles},
+		{"pwd", password},
+		{"mechanisms", bson.A{"SCRAM-SHA-256"}},
+	}).Err()
+	require.NoError(t, err, "cannot create user")
+
+	t.Cleanup(func() {
+		_ = db.RunCommand(ctx, bson.D{{"dropUser", username}})
+	})
+
+	credential := options.Credential{
+		AuthMechanism: "SCRAM-SHA-256",
+		AuthSource:    db.Name(),
+		Username:      username,
+		Password:      password,
+	}
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(s.MongoDBURI).SetAuth(credential))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, client.Disconnect(ctx))
+	})
+
+	require.NoError(t, client.Ping(ctx, nil))
+
+	return client.Database(db.Name())
+}
+
+func TestAPIKeys(t *testing.T) {
+	t.Parallel()
+
+	s := setup.SetupWithOpts(t, nil)
+	setup.SkipForMongoDB(t, "FerretDB-specific command")
+
+	ctx, adminDB := s.Ctx, s.Collection.Database()
+
+	userDB := createUserDatabase(t, s, "apikeyuser")
+	_ = createUserDatabase(t, s, "apikeyother")
+
+	errUnauthorized := func(command string) mongo.CommandError {
+		return mongo.CommandError{
+			Code:    13,
+			Name:    "Unauthorized",
+			Message: "Command " + command + " requires the clusterAdmin role to manage API keys of other users",
+		}
+	}
+
+	createKey := func(t *testing.T, db *mongo.Database, user string) string {
+		t.Helper()
+
+		var res struct {
+			KeyID string `bson:"keyId"`
+		}
+		err := db.RunCommand(ctx, bson.D{{"createAPIKey", user}, {"readOnly", true}}).Decode(&res)
+		require.NoError(t, err)
+		require.NotEmpty(t, res.KeyID)
+
+		return res.KeyID
+	}
+
+	listKeys := func(t *testing.T, db *mongo.Database, cmd bson.D) []string {
+		t.Helper()
+
+		var res struct {
+			Keys []struct {
+				KeyID string `bson:"keyId"`
+				User  string `bson:"user"`
+			} `bson:"keys"`
+		}
+		require.NoError(t, db.RunCommand(ctx, cmd).Decode(&res))
+
+		var users []string
+		for _, k := range res.Keys {
+			users = append(users, k.User)
+		}
+
+		return users
+	}
+
+	t.Run("Admin", func(t *testing.T) {
+		id := createKey(t, adminDB, "apikeyother")
+
+		users := listKeys(t, adminDB, bson.D{{"listAPIKeys", 1}, {"user", "apikeyother"}})
+		assert.Contains(t, users, "apikeyother")
+
+		err := adminDB.RunCommand(ctx, bson.D{{"revokeAPIKey", id}}).Err()
+		require.NoError(t, err)
+
+		err = adminDB.RunCommand(ctx, bson.D{{"revokeAPIKey", id}}).Err()
+		AssertEqualCommandError(t, mongo.CommandError{
+			Code:    2,
+			Name:    "BadValue",
+			Message: `Could not find API key "` + id + `"`,
+		}, err)
+	})
+
+	t.Run("Own", func(t *testing.T) {
+		id := createKey(t, userDB, "apikeyuser")
+
+		users := listKeys(t, userDB, bson.D{{"listAPIKeys", 1}})
+		require.NotEmpty(t, users)
+
+		for _, u := range users {
+			assert.Equal(t, "apikeyuser", u)
+		}
+
+		err := userDB.RunCommand(ctx, bson.D{{"revokeAPIKey", id}}).Err()
+		require.NoError(t, err)
+	})
+
+	t.Run("Other", func(t *testing.T) {
+		err := userDB.RunCommand(ctx, bson.D{{"createAPIKey", "apikeyother"}}).Err()
+		AssertEqualCommandError(t, errUnauthorized("createAPIKey"), err)
+
+		err = userDB.RunCommand(ctx, bson.D{{"listAPIKeys", 1}, {"user", "apikeyother"}}).Err()
+		AssertEqualCommandError(t, errUnauthorized("listAPIKeys"), err)
+
+		id := createKey(t, adminDB, "apikeyother")
+
+		t.Cleanup(func() {
+			_ = adminDB.RunCommand(ctx, bson.D{{"revokeAPIKey", id}})
+		})
+
+		err = userDB.RunCommand(ctx, bson.D{{"revokeAPIKey", id}}).Err()
+		AssertEqualCommandError(t, errUnauthorized("revokeAPIKey"), err)
+	})
+}
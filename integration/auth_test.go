@@ -1308,3 +1308,90 @@ func TestSASLContinueOpQueryErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthLockout(t *testing.T) {
+	setup.SkipForMongoDB(t, "FerretDB-specific brute-force protection")
+
+	t.Parallel()
+
+	s := setup.SetupWithOpts(t, &setup.SetupOpts{
+		ListenerOpts: &setup.ListenerOpts{AuthMaxFailures: 2, AuthLockout: time.Hour},
+	})
+	ctx, db := s.Ctx, s.Collection.Database()
+	adminDB := db.Client().Database("admin")
+
+	username := "lockoutuser"
+	userAdminDB := createUserDatabase(t, s, username).Client().Database("admin")
+
+	connect := func(t *testing.T, password string) error {
+		t.Helper()
+
+		credential := options.Credential{
+			AuthMechanism: "SCRAM-SHA-256",
+			AuthSource:    db.Name(),
+			Username:      username,
+			Password:      password,
+		}
+
+		client, err := mongo.Connect(ctx, options.Client().ApplyURI(s.MongoDBURI).SetAuth(credential))
+		require.NoError(t, err)
+
+		t.Cleanup(func() {
+			require.NoError(t, client.Disconnect(ctx))
+		})
+
+		return client.Ping(ctx, nil)
+	}
+
+	for range 2 {
+		require.ErrorContains(t, connect(t, "wrongpassword"), "Authentication failed.")
+	}
+
+	err := connect(t, "password")
+	require.ErrorContains(t, err, "Authentication failed.", "correct password should be rejected during lockout")
+
+	t.Run("NotAdmin", func(t *testing.T) {
+		for _, command := range []string{"listAuthLockouts", "clearAuthLockouts"} {
+			err := userAdminDB.RunCommand(ctx, bson.D{{command, 1}}).Err()
+			AssertEqualCommandError(t, mongo.CommandError{
+				Code:    13,
+				Name:    "Unauthorized",
+				Message: "Command " + command + " requires the clusterAdmin role",
+			}, err)
+		}
+	})
+
+	t.Run("NotAdminDatabase", func(t *testing.T) {
+		err := db.RunCommand(ctx, bson.D{{"listAuthLockouts", 1}}).Err()
+		AssertEqualCommandError(t, mongo.CommandError{
+			Code:    13,
+			Name:    "Unauthorized",
+			Message: "listAuthLockouts may only be run against the admin database.",
+		}, err)
+	})
+
+	var lockouts struct {
+		Lockouts []struct {
+			Kind  string `bson:"kind"`
+			Value string `bson:"value"`
+		} `bson:"lockouts"`
+	}
+	require.NoError(t, adminDB.RunCommand(ctx, bson.D{{"listAuthLockouts", 1}}).Decode(&lockouts))
+
+	var found bool
+	for _, l := range lockouts.Lockouts {
+		if l.Kind == "username" && l.Value == username {
+			found = true
+		}
+	}
+
+	assert.True(t, found, "%+v", lockouts)
+
+	var cleared struct {
+		Cleared int32 `bson:"cleared"`
+	}
+	require.NoError(t, adminDB.RunCommand(ctx, bson.D{{"clearAuthLockouts", 1}}).Decode(&cleared))
+	assert.Positive(t, cleared.Cleared)
+
+	require.NoError(t, connect(t, "password"))
+}
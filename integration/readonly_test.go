@@ -0,0 +1,67 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/FerretDB/FerretDB/v2/integration/setup"
+	"github.com/FerretDB/FerretDB/v2/integration/shareddata"
+)
+
+func TestReadOnly(t *testing.T) {
+	setup.SkipForMongoDB(t, "FerretDB-specific read-only mode")
+
+	t.Parallel()
+
+	s := setup.SetupWithOpts(t, &setup.SetupOpts{
+		ListenerOpts: &setup.ListenerOpts{ReadOnly: true},
+		Providers:    []shareddata.Provider{shareddata.Int32s},
+	})
+	ctx, collection := s.Ctx, s.Collection
+	db := collection.Database()
+
+	t.Run("Read", func(t *testing.T) {
+		n, err := collection.CountDocuments(ctx, bson.D{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(shareddata.Docs(shareddata.Int32s))), n)
+	})
+
+	t.Run("Write", func(t *testing.T) {
+		for command, doc := range map[string]bson.D{
+			"insert":     {{"insert", collection.Name()}, {"documents", bson.A{bson.D{{"_id", "new"}}}}},
+			"update":     {{"update", collection.Name()}, {"updates", bson.A{bson.D{{"q", bson.D{}}, {"u", bson.D{{"$set", bson.D{{"v", 1}}}}}}}}},
+			"delete":     {{"delete", collection.Name()}, {"deletes", bson.A{bson.D{{"q", bson.D{}}, {"limit", 0}}}}},
+			"drop":       {{"drop", collection.Name()}},
+			"createUser": {{"createUser", "readonlyuser"}, {"roles", bson.A{}}, {"pwd", "password"}},
+		} {
+			err := db.RunCommand(ctx, doc).Err()
+			AssertEqualCommandError(t, mongo.CommandError{
+				Code:    20,
+				Name:    "IllegalOperation",
+				Message: "Command " + command + " is not allowed in read-only mode",
+			}, err)
+		}
+
+		n, err := collection.CountDocuments(ctx, bson.D{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(shareddata.Docs(shareddata.Int32s))), n, "documents should not be changed")
+	})
+}
@@ -35,6 +35,17 @@
 type ListenerOpts struct {
 	// SessionCleanupInterval is a duration between expired session deletion runs.
 	SessionCleanupInterval time.Duration
+
+	// AuthMaxFailures is a number of failed authentication attempts before lockout.
+	// Zero value disables lockouts.
+	AuthMaxFailures int
+
+	// AuthLockout is a duration of the first authentication lockout.
+	AuthLockout time.Duration
+
+	// ReadOnly enables read-only mode after the test collection is set up.
+	// It is disabled before the collection is dropped.
+	ReadOnly bool
 }
 
 // unixSocketPath returns temporary Unix domain socket path for that test.
@@ -79,8 +90,8 @@ func listenerMongoDBURI(tb testing.TB, hostPort, unixSocketPath string) string {
 }
 
 // setupListener starts in-process FerretDB server that runs until ctx is canceled.
-// It returns basic MongoDB URI for that listener.
-func setupListener(tb testing.TB, ctx context.Context, opts *ListenerOpts, logger *slog.Logger) string {
+// It returns basic MongoDB URI for that listener and the setup result.
+func setupListener(tb testing.TB, ctx context.Context, opts *ListenerOpts, logger *slog.Logger) (string, *setup.SetupResult) {
 	tb.Helper()
 
 	ctx, span := otel.Tracer("").Start(ctx, "setup.setupListener")
@@ -115,14 +126,42 @@ func setupListener(tb testing.TB, ctx context.Context, opts *ListenerOpts, logge
 
 		PostgreSQLURL:          *postgreSQLURLF,
 		Auth:                   true,
-		ReplSetName:            "", // TODO https://github.com/FerretDB/FerretDB-DocumentDB/issues/566
+		ReplSetName:            "",    // TODO https://github.com/FerretDB/FerretDB-DocumentDB/issues/566
+		ReadOnly:               false, // see ListenerOpts.ReadOnly
 		SessionCleanupInterval: opts.SessionCleanupInterval,
+		CustomCommands:         nil,
+		AuthMaxFailures:        opts.AuthMaxFailures,
+		AuthLockout:            opts.AuthLockout,
+		AuthMaxLockout:         0,
 
 		ProxyAddr:        "",
 		ProxyTLSCertFile: "",
 		ProxyTLSKeyFile:  "",
 		ProxyTLSCAFile:   "",
 
+		DiffReportFile:    "",
+		DiffIgnoredFields: nil,
+
+		ShadowQueueSize:  0,
+		ShadowSampleRate: 0,
+		ShadowCommands:   nil,
+		ShadowNamespaces: nil,
+
+		RouteRulesFile: "",
+
+		DualWriteReconciliationFile: "",
+
+		Interceptors:   nil,
+		TracerProvider: nil,
+
+		AuditDestination: "",
+		AuditPath:        "",
+		AuditFormat:      "",
+		AuditMaxSize:     0,
+		AuditMaxBackups:  0,
+		AuditFilter:      "",
+		AuditNamespaces:  nil,
+
 		TCPAddr:        "",
 		UnixAddr:       "",
 		TLSAddr:        "",
@@ -132,7 +171,18 @@ func setupListener(tb testing.TB, ctx context.Context, opts *ListenerOpts, logge
 		Mode:           middleware.NormalMode,
 		TestRecordsDir: testutil.TmpRecordsDir,
 
-		DataAPIAddr: "",
+		DataAPIAddr:        "",
+		DataAPIUnixAddr:    "",
+		DataAPITLSAddr:     "",
+		DataAPIMaxBodySize: 0,
+		DataAPICORSOrigins: nil,
+
+		MCPAddr:        "",
+		MCPAllowWrites: false,
+		MCPStdio:       false,
+		MCPUsername:    "",
+		MCPPassword:    "",
+		MCPAPIKey:      "",
 	}
 
 	switch {
@@ -179,5 +229,5 @@ func setupListener(tb testing.TB, ctx context.Context, opts *ListenerOpts, logge
 
 	logger.InfoContext(ctx, "Listener started", slog.String("uri", uri))
 
-	return uri
+	return uri, res
 }
@@ -32,6 +32,7 @@
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.opentelemetry.io/otel"
 
+	"github.com/FerretDB/FerretDB/v2/internal/util/setup"
 	"github.com/FerretDB/FerretDB/v2/internal/util/testutil"
 	"github.com/FerretDB/FerretDB/v2/internal/util/xiter"
 
@@ -172,9 +173,11 @@ func SetupWithOpts(tb testing.TB, opts *SetupOpts) *SetupResult {
 
 	logger := testutil.LevelLogger(tb, &levelVar)
 
+	var listener *setup.SetupResult
+
 	uri := *targetURLF
 	if uri == "" {
-		uri = setupListener(tb, setupCtx, opts.ListenerOpts, logger)
+		uri, listener = setupListener(tb, setupCtx, opts.ListenerOpts, logger)
 	}
 
 	if len(opts.extraOptions) > 0 {
@@ -201,6 +204,17 @@ func SetupWithOpts(tb testing.TB, opts *SetupOpts) *SetupResult {
 
 	collection := setupCollection(tb, setupCtx, client, opts)
 
+	if opts.ListenerOpts != nil && opts.ListenerOpts.ReadOnly {
+		require.NotNil(tb, listener, "read-only mode requires in-process FerretDB")
+
+		listener.SetReadOnly(true)
+
+		// cleanup functions are called in reverse order, so that one is called before dropping the collection
+		tb.Cleanup(func() {
+			listener.SetReadOnly(false)
+		})
+	}
+
 	var conn *wireclient.Conn
 
 	if opts.WireConn != WireConnNoConn {
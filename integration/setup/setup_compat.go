@@ -92,7 +92,7 @@ func SetupCompatWithOpts(tb testing.TB, opts *SetupCompatOpts) *SetupCompatResul
 
 	uri := *targetURLF
 	if uri == "" {
-		uri = setupListener(tb, setupCtx, nil, logger)
+		uri, _ = setupListener(tb, setupCtx, nil, logger)
 	}
 
 	targetClient = setupClient(tb, setupCtx, uri, false)
@@ -0,0 +1,444 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package audit provides audit logging of security-relevant events.
+//
+// Audit log is separate from the operational log.
+// Events are written as Canonical Extended JSON v2 lines or as BSON documents.
+// Each event contains the SHA-256 hash of the previous event as written,
+// so removed or modified events could be detected.
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+
+	"github.com/FerretDB/FerretDB/v2/internal/clientconn/conninfo"
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+	"github.com/FerretDB/FerretDB/v2/internal/util/scram"
+)
+
+// Audit log destinations.
+const (
+	DestinationFile   = "file"
+	DestinationSyslog = "syslog"
+	DestinationSocket = "socket"
+)
+
+// AllDestinations contains all audit log destinations.
+var AllDestinations = []string{DestinationFile, DestinationSyslog, DestinationSocket}
+
+// Audit log formats.
+const (
+	FormatJSON = "json"
+	FormatBSON = "bson"
+)
+
+// AllFormats contains all audit log formats.
+var AllFormats = []string{FormatJSON, FormatBSON}
+
+// DefaultSyslogPath is the default path of the syslog Unix socket.
+const DefaultSyslogPath = "/dev/log"
+
+// Event categories.
+const (
+	categoryAuthentication = "authentication"
+	categoryUserManagement = "userManagement"
+	categoryRoleManagement = "roleManagement"
+	categoryDDL            = "ddl"
+	categorySession        = "session"
+	categoryCRUD           = "crud"
+	categoryAudit          = "audit"
+)
+
+// atypeStart is the type of the event that starts a new hash chain.
+const atypeStart = "auditStart"
+
+// categories contains categories of audited commands.
+var categories = map[string]string{
+	"authenticate":       categoryAuthentication,
+	"authenticateAPIKey": categoryAuthentication,
+	"logout":             categoryAuthentication,
+	"saslContinue":       categoryAuthentication,
+	"saslStart":          categoryAuthentication,
+
+	"createUser":               categoryUserManagement,
+	"dropAllUsersFromDatabase": categoryUserManagement,
+	"dropUser":                 categoryUserManagement,
+	"grantRolesToUser":         categoryUserManagement,
+	"revokeRolesFromUser":      categoryUserManagement,
+	"updateUser":               categoryUserManagement,
+
+	"createRole":               categoryRoleManagement,
+	"dropAllRolesFromDatabase": categoryRoleManagement,
+	"dropRole":                 categoryRoleManagement,
+	"grantPrivilegesToRole":    categoryRoleManagement,
+	"grantRolesToRole":         categoryRoleManagement,
+	"revokePrivilegesFromRole": categoryRoleManagement,
+	"revokeRolesFromRole":      categoryRoleManagement,
+	"updateRole":               categoryRoleManagement,
+
+	"collMod":          categoryDDL,
+	"create":           categoryDDL,
+	"createIndexes":    categoryDDL,
+	"drop":             categoryDDL,
+	"dropDatabase":     categoryDDL,
+	"dropIndexes":      categoryDDL,
+	"renameCollection": categoryDDL,
+
+	"killAllSessions":          categorySession,
+	"killAllSessionsByPattern": categorySession,
+	"killOp":                   categorySession,
+	"killSessions":             categorySession,
+
+	"aggregate":     categoryCRUD,
+	"count":         categoryCRUD,
+	"delete":        categoryCRUD,
+	"distinct":      categoryCRUD,
+	"find":          categoryCRUD,
+	"findAndModify": categoryCRUD,
+	"getMore":       categoryCRUD,
+	"insert":        categoryCRUD,
+	"update":        categoryCRUD,
+}
+
+// Opts represents audit logger options.
+//
+//nolint:vet // for readability
+type Opts struct {
+	Destination string // one of AllDestinations
+	Path        string // file or Unix socket path; empty value means DefaultSyslogPath for syslog
+	Format      string // one of AllFormats; only FormatJSON is supported for syslog
+	MaxSize     int64  // maximum file size in bytes before rotation; zero value disables rotation
+	MaxBackups  int    // maximum number of rotated files to keep; must be positive if MaxSize is
+	Filter      string // JSON query document; empty value matches all events
+	Namespaces  []string
+	L           *slog.Logger
+}
+
+// Logger writes audit events.
+//
+//nolint:vet // for readability
+type Logger struct {
+	opts   *Opts
+	filter *filter
+	w      writer
+
+	m    sync.Mutex
+	seq  int64
+	prev string // hex-encoded hash of the previous event
+}
+
+// New creates a new audit logger.
+// [Logger.Close] must be called on the returned value.
+//
+// Authentication, user and role management, DDL, and session killing commands are always audited
+// (unless excluded by the filter).
+// CRUD commands are audited only for the given Namespaces (databases or db.coll collections).
+func New(opts *Opts) (*Logger, error) {
+	must.NotBeZero(opts)
+
+	if !slices.Contains(AllFormats, opts.Format) {
+		return nil, fmt.Errorf("invalid audit log format %q", opts.Format)
+	}
+
+	f, err := parseFilter(opts.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid audit log filter: %w", err)
+	}
+
+	var w writer
+
+	switch opts.Destination {
+	case DestinationFile:
+		if opts.Path == "" {
+			return nil, fmt.Errorf("audit log path is required for %q destination", opts.Destination)
+		}
+
+		if opts.MaxSize > 0 && opts.MaxBackups < 1 {
+			return nil, errors.New("audit log max backups must be positive if max size is set")
+		}
+
+		w, err = newFileWriter(opts.Path, opts.Format, opts.MaxSize, opts.MaxBackups)
+
+	case DestinationSyslog:
+		if opts.Format != FormatJSON {
+			return nil, fmt.Errorf("audit log format %q is not supported for %q destination", opts.Format, opts.Destination)
+		}
+
+		path := opts.Path
+		if path == "" {
+			path = DefaultSyslogPath
+		}
+
+		w, err = newSocketWriter("unixgram", path, opts.Format, true)
+
+	case DestinationSocket:
+		if opts.Path == "" {
+			return nil, fmt.Errorf("audit log path is required for %q destination", opts.Destination)
+		}
+
+		w, err = newSocketWriter("unix", opts.Path, opts.Format, false)
+
+	default:
+		return nil, fmt.Errorf("invalid audit log destination %q", opts.Destination)
+	}
+
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	l := &Logger{
+		opts:   opts,
+		filter: f,
+		w:      w,
+	}
+
+	// Sequence number and hash chain are not persisted between starts.
+	// This event is always written (regardless of the filter),
+	// so a legitimate start of a new chain could be told apart from removed events.
+	l.append(context.Background(), &event{
+		ts:       time.Now(),
+		atype:    atypeStart,
+		category: categoryAudit,
+	})
+
+	return l, nil
+}
+
+// Interceptor returns a middleware interceptor that writes events for audited commands.
+//
+// It should be the first interceptor to see responses produced by all other interceptors.
+func (l *Logger) Interceptor() *middleware.Interceptor {
+	return &middleware.Interceptor{
+		Name:  "audit",
+		After: l.after,
+	}
+}
+
+// after implements [middleware.Interceptor]'s After hook.
+func (l *Logger) after(ctx context.Context, req *middleware.Request, resp *middleware.Response) (*middleware.Response, error) {
+	if ev := l.event(ctx, req, resp); ev != nil {
+		l.write(ctx, ev)
+	}
+
+	return nil, nil
+}
+
+// event returns an event for the given request and response, or nil if the command is not audited.
+func (l *Logger) event(ctx context.Context, req *middleware.Request, resp *middleware.Response) *event {
+	doc := req.Document()
+	command := doc.Command()
+
+	category := categories[command]
+	if category == "" {
+		return nil
+	}
+
+	db, _ := doc.Get("$db").(string)
+
+	var target string
+	switch command {
+	case "getMore":
+		target, _ = doc.Get("collection").(string)
+	default:
+		target, _ = doc.Get(command).(string)
+	}
+
+	if category == categoryCRUD && !l.selected(db, target) {
+		return nil
+	}
+
+	ev := &event{
+		ts:       time.Now(),
+		atype:    command,
+		category: category,
+		db:       db,
+		target:   target,
+	}
+
+	ci := conninfo.Get(ctx)
+
+	if ci.Peer.IsValid() {
+		ev.remote = ci.Peer.String()
+	}
+
+	// For authentication commands, include the username the client tries to authenticate with.
+	// The handler might have already reset the SCRAM conversation (or kept a stale one),
+	// so it is taken from the saslStart payload and remembered for the following saslContinue.
+	switch command {
+	case "saslStart":
+		ev.user = l.scramUsername(doc)
+		ci.SetAuthUsername(ev.user)
+	case "saslContinue":
+		ev.user = ci.AuthUsername()
+	default:
+		if ci.Authenticated() || category == categoryAuthentication {
+			ev.user = ci.Username()
+		}
+	}
+
+	if !resp.OK() {
+		ev.result = int32(resp.ErrorCode())
+		ev.error = resp.ErrorName()
+	}
+
+	return ev
+}
+
+// scramUsername returns the username from the saslStart request's client-first message,
+// or an empty string if it could not be parsed.
+func (l *Logger) scramUsername(doc *wirebson.Document) string {
+	payload, ok := doc.Get("payload").(wirebson.Binary)
+	if !ok {
+		return ""
+	}
+
+	username, err := scram.NewConv(l.opts.L).ClientFirst(string(payload.B))
+	if err != nil {
+		return ""
+	}
+
+	return username
+}
+
+// selected returns true if CRUD commands for the given database and collection are audited.
+func (l *Logger) selected(db, collection string) bool {
+	return slices.Contains(l.opts.Namespaces, db) || slices.Contains(l.opts.Namespaces, db+"."+collection)
+}
+
+// write writes the given event if it matches the filter.
+// Errors are logged.
+func (l *Logger) write(ctx context.Context, ev *event) {
+	if l.filter != nil && !l.filter.match(ev.fields()) {
+		return
+	}
+
+	l.append(ctx, ev)
+}
+
+// append writes the given event, continuing the hash chain.
+// Errors are logged.
+func (l *Logger) append(ctx context.Context, ev *event) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	l.seq++
+
+	b, err := ev.encode(l.opts.Format, l.seq, l.prev)
+	if err != nil {
+		l.opts.L.ErrorContext(ctx, "Failed to encode audit event", logging.Error(err))
+		return
+	}
+
+	// sequence number and hash are updated even if write fails,
+	// so the gap could be detected
+	h := sha256.Sum256(b)
+	l.prev = hex.EncodeToString(h[:])
+
+	if err = l.w.write(b); err != nil {
+		l.opts.L.ErrorContext(ctx, "Failed to write audit event", slog.String("atype", ev.atype), logging.Error(err))
+	}
+}
+
+// Close closes the underlying file or socket.
+func (l *Logger) Close() error {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	if err := l.w.Close(); err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	return nil
+}
+
+// event represents a single audit event.
+type event struct {
+	ts       time.Time
+	atype    string // command name
+	category string
+	db       string
+	target   string // collection, user, or role name, if any
+	remote   string // empty for Unix domain sockets and in-process connections
+	user     string
+	result   int32  // zero for success
+	error    string // error name
+}
+
+// fields returns event fields for filtering.
+// Empty strings are omitted; numbers are represented as float64, like JSON numbers.
+func (ev *event) fields() map[string]any {
+	res := map[string]any{
+		"result": float64(ev.result),
+	}
+
+	for k, v := range map[string]string{
+		"atype":    ev.atype,
+		"category": ev.category,
+		"db":       ev.db,
+		"target":   ev.target,
+		"remote":   ev.remote,
+		"user":     ev.user,
+		"error":    ev.error,
+	} {
+		if v != "" {
+			res[k] = v
+		}
+	}
+
+	return res
+}
+
+// encode returns the event encoded in the given format with the given sequence number
+// and hash of the previous event.
+func (ev *event) encode(format string, seq int64, prev string) ([]byte, error) {
+	doc, err := wirebson.NewDocument(
+		"ts", ev.ts,
+		"seq", seq,
+		"atype", ev.atype,
+		"category", ev.category,
+		"db", ev.db,
+		"target", ev.target,
+		"remote", ev.remote,
+		"user", ev.user,
+		"result", ev.result,
+		"error", ev.error,
+		"prev", prev,
+	)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	switch format {
+	case FormatJSON:
+		return doc.MarshalJSON()
+	case FormatBSON:
+		return doc.Encode()
+	default:
+		panic("not reached")
+	}
+}
@@ -0,0 +1,275 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FerretDB/FerretDB/v2/internal/clientconn/conninfo"
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+	"github.com/FerretDB/FerretDB/v2/internal/util/testutil"
+)
+
+// handle passes the given request and response to the audit interceptor
+// in the context of a new connection.
+func handle(t *testing.T, l *Logger, req *wirebson.Document, mErr *mongoerrors.Error) {
+	t.Helper()
+
+	ci := conninfo.New()
+	t.Cleanup(ci.Close)
+
+	handleConn(t, l, ci, req, mErr)
+}
+
+// handleConn passes the given request and response to the audit interceptor
+// in the context of the given connection.
+func handleConn(t *testing.T, l *Logger, ci *conninfo.ConnInfo, req *wirebson.Document, mErr *mongoerrors.Error) {
+	t.Helper()
+
+	ctx := conninfo.Ctx(testutil.Ctx(t), ci)
+
+	r := must.NotFail(middleware.RequestDoc(req))
+
+	resp := must.NotFail(middleware.ResponseDoc(r, wirebson.MustDocument("ok", float64(1))))
+	if mErr != nil {
+		resp = middleware.ResponseErr(r, mErr)
+	}
+
+	res, err := l.Interceptor().After(ctx, r, resp)
+	require.NoError(t, err)
+	require.Nil(t, res)
+}
+
+func TestLoggerFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit.json")
+
+	l, err := New(&Opts{
+		Destination: DestinationFile,
+		Path:        path,
+		Format:      FormatJSON,
+		Namespaces:  []string{"app.users"},
+		L:           testutil.Logger(t),
+	})
+	require.NoError(t, err)
+
+	authErr := mongoerrors.New(mongoerrors.ErrAuthenticationFailed, "Authentication failed")
+
+	ci := conninfo.New()
+	t.Cleanup(ci.Close)
+
+	clientFirst := wirebson.Binary{B: []byte("n,,n=alice,r=rOprNGfwEbeRWgbNEkqO")}
+	clientFinal := wirebson.Binary{B: []byte("c=biws,r=rOprNGfwEbeRWgbNEkqO,p=dHzbZapWIk4jUhN+Ute9ytag9zjfMHgsqmmiz7AndVQ=")}
+
+	handleConn(t, l, ci, wirebson.MustDocument("saslStart", int32(1), "payload", clientFirst, "$db", "admin"), nil)
+	handleConn(t, l, ci, wirebson.MustDocument("saslContinue", int32(1), "payload", clientFinal, "$db", "admin"), authErr)
+	handleConn(t, l, ci, wirebson.MustDocument("saslStart", int32(1), "payload", wirebson.Binary{}, "$db", "admin"), authErr)
+	handle(t, l, wirebson.MustDocument("hello", int32(1), "$db", "admin"), nil)
+	handle(t, l, wirebson.MustDocument("create", "users", "$db", "app"), nil)
+	handle(t, l, wirebson.MustDocument("find", "users", "$db", "app"), nil)
+	handle(t, l, wirebson.MustDocument("find", "other", "$db", "app"), nil)
+	handle(t, l, wirebson.MustDocument("getMore", int64(1), "collection", "users", "$db", "app"), nil)
+
+	require.NoError(t, l.Close())
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+
+	defer f.Close() //nolint:errcheck // we are only reading
+
+	var events []*wirebson.Document
+	var prev string
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		var ev wirebson.Document
+		require.NoError(t, ev.UnmarshalJSON(s.Bytes()))
+
+		assert.Equal(t, prev, ev.Get("prev"), "hash chain is broken")
+
+		h := sha256.Sum256(s.Bytes())
+		prev = hex.EncodeToString(h[:])
+
+		events = append(events, &ev)
+	}
+
+	require.NoError(t, s.Err())
+	require.Len(t, events, 7)
+
+	for i, expected := range []struct {
+		atype    string
+		category string
+		target   string
+		user     string
+		result   int32
+	}{
+		{"auditStart", "audit", "", "", 0},
+		{"saslStart", "authentication", "", "alice", 0},
+		{"saslContinue", "authentication", "", "alice", int32(mongoerrors.ErrAuthenticationFailed)},
+		{"saslStart", "authentication", "", "", int32(mongoerrors.ErrAuthenticationFailed)},
+		{"create", "ddl", "users", "", 0},
+		{"find", "crud", "users", "", 0},
+		{"getMore", "crud", "users", "", 0},
+	} {
+		ev := events[i]
+		assert.Equal(t, int64(i+1), ev.Get("seq"))
+		assert.Equal(t, expected.atype, ev.Get("atype"))
+		assert.Equal(t, expected.category, ev.Get("category"))
+		assert.Equal(t, expected.target, ev.Get("target"))
+		assert.Equal(t, expected.user, ev.Get("user"))
+		assert.Equal(t, expected.result, ev.Get("result"))
+	}
+
+	assert.Equal(t, "", events[0].Get("prev"))
+	assert.Equal(t, "AuthenticationFailed", events[2].Get("error"))
+}
+
+func TestLoggerRotation(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit.bson")
+
+	l, err := New(&Opts{
+		Destination: DestinationFile,
+		Path:        path,
+		Format:      FormatBSON,
+		MaxSize:     1,
+		MaxBackups:  2,
+		L:           testutil.Logger(t),
+	})
+	require.NoError(t, err)
+
+	for range 4 {
+		handle(t, l, wirebson.MustDocument("drop", "users", "$db", "app"), nil)
+	}
+
+	require.NoError(t, l.Close())
+
+	for i, name := range []string{path, path + ".1", path + ".2"} {
+		b, err := os.ReadFile(name)
+		require.NoError(t, err)
+
+		ev, err := wirebson.RawDocument(b).Decode()
+		require.NoError(t, err)
+		assert.Equal(t, int64(5-i), ev.Get("seq"))
+	}
+
+	_, err = os.Stat(path + ".3")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestLoggerSocket(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit.sock")
+
+	ln, err := net.Listen("unix", path)
+	require.NoError(t, err)
+
+	defer ln.Close() //nolint:errcheck // we are only reading
+
+	l, err := New(&Opts{
+		Destination: DestinationSocket,
+		Path:        path,
+		Format:      FormatJSON,
+		Filter:      `{"category": {"$in": ["userManagement", "roleManagement"]}}`,
+		L:           testutil.Logger(t),
+	})
+	require.NoError(t, err)
+
+	conn, err := ln.Accept()
+	require.NoError(t, err)
+
+	defer conn.Close() //nolint:errcheck // we are only reading
+
+	handle(t, l, wirebson.MustDocument("drop", "users", "$db", "app"), nil)
+	handle(t, l, wirebson.MustDocument("createUser", "alice", "$db", "admin"), nil)
+
+	require.NoError(t, l.Close())
+
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(conn)
+	require.NoError(t, err)
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte{'\n'})
+	require.Len(t, lines, 2, "%s", buf.String())
+
+	// the start event is not filtered out
+	var start, ev wirebson.Document
+	require.NoError(t, start.UnmarshalJSON(lines[0]))
+	assert.Equal(t, "auditStart", start.Get("atype"))
+
+	require.NoError(t, ev.UnmarshalJSON(lines[1]))
+	assert.Equal(t, "createUser", ev.Get("atype"))
+	assert.Equal(t, "alice", ev.Get("target"))
+	assert.Equal(t, int64(2), ev.Get("seq"))
+}
+
+func TestNewInvalid(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		opts *Opts
+		err  string
+	}{
+		"Destination": {
+			opts: &Opts{Destination: "stdout", Format: FormatJSON},
+			err:  `invalid audit log destination "stdout"`,
+		},
+		"Format": {
+			opts: &Opts{Destination: DestinationFile, Format: "xml"},
+			err:  `invalid audit log format "xml"`,
+		},
+		"SyslogFormat": {
+			opts: &Opts{Destination: DestinationSyslog, Format: FormatBSON},
+			err:  `audit log format "bson" is not supported for "syslog" destination`,
+		},
+		"Path": {
+			opts: &Opts{Destination: DestinationFile, Format: FormatJSON},
+			err:  `audit log path is required for "file" destination`,
+		},
+		"MaxBackups": {
+			opts: &Opts{Destination: DestinationFile, Path: "audit.json", Format: FormatJSON, MaxSize: 1},
+			err:  "audit log max backups must be positive if max size is set",
+		},
+		"Filter": {
+			opts: &Opts{Destination: DestinationFile, Format: FormatJSON, Filter: `{"$where": "true"}`},
+			err:  "invalid audit log filter: unknown top-level operator $where",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(tc.opts)
+			require.Error(t, err)
+			assert.Equal(t, tc.err, err.Error(), fmt.Sprintf("%+v", tc.opts))
+		})
+	}
+}
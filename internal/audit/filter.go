@@ -0,0 +1,210 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// filter represents an audit log filter similar to MongoDB's `auditLog.filter`.
+//
+// It is a JSON query document with conditions on event fields.
+// Conditions are either values (for equality),
+// or documents with `$eq`, `$ne`, `$in`, `$nin`, and `$exists` operators.
+// Top-level `$and`, `$or`, and `$nor` operators with arrays of query documents are also supported.
+// For example:
+//
+//	{"category": {"$in": ["authentication", "ddl"]}, "result": {"$ne": 0}}
+type filter struct {
+	query map[string]any
+}
+
+// parseFilter parses and validates the given filter.
+// It returns nil for an empty string.
+func parseFilter(s string) (*filter, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	var query map[string]any
+	if err := json.Unmarshal([]byte(s), &query); err != nil {
+		return nil, err
+	}
+
+	if err := validateQuery(query); err != nil {
+		return nil, err
+	}
+
+	return &filter{
+		query: query,
+	}, nil
+}
+
+// validateQuery checks that the given query document is valid.
+func validateQuery(query map[string]any) error {
+	for k, v := range query {
+		switch k {
+		case "$and", "$or", "$nor":
+			arr, ok := v.([]any)
+			if !ok || len(arr) == 0 {
+				return fmt.Errorf("%s must be a non-empty array", k)
+			}
+
+			for _, e := range arr {
+				q, ok := e.(map[string]any)
+				if !ok {
+					return fmt.Errorf("%s elements must be documents", k)
+				}
+
+				if err := validateQuery(q); err != nil {
+					return err
+				}
+			}
+
+		default:
+			if strings.HasPrefix(k, "$") {
+				return fmt.Errorf("unknown top-level operator %s", k)
+			}
+
+			if err := validateCondition(k, v); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateCondition checks that the given field condition is valid.
+func validateCondition(field string, cond any) error {
+	ops, ok := cond.(map[string]any)
+	if !ok {
+		return validateValue(field, cond)
+	}
+
+	for op, arg := range ops {
+		switch op {
+		case "$eq", "$ne":
+			if err := validateValue(field, arg); err != nil {
+				return err
+			}
+
+		case "$in", "$nin":
+			arr, ok := arg.([]any)
+			if !ok {
+				return fmt.Errorf("%s: %s must be an array", field, op)
+			}
+
+			for _, v := range arr {
+				if err := validateValue(field, v); err != nil {
+					return err
+				}
+			}
+
+		case "$exists":
+			if _, ok := arg.(bool); !ok {
+				return fmt.Errorf("%s: %s must be a boolean", field, op)
+			}
+
+		default:
+			return fmt.Errorf("%s: unknown operator %s", field, op)
+		}
+	}
+
+	return nil
+}
+
+// validateValue checks that the given value could be compared with event fields.
+func validateValue(field string, v any) error {
+	switch v.(type) {
+	case string, float64, bool, nil:
+		return nil
+	default:
+		return fmt.Errorf("%s: unexpected value %v", field, v)
+	}
+}
+
+// match returns true if the given event fields match the filter.
+func (f *filter) match(fields map[string]any) bool {
+	return matchQuery(f.query, fields)
+}
+
+// matchQuery returns true if the given event fields match the validated query document.
+func matchQuery(query, fields map[string]any) bool {
+	for k, v := range query {
+		switch k {
+		case "$and":
+			for _, q := range v.([]any) {
+				if !matchQuery(q.(map[string]any), fields) {
+					return false
+				}
+			}
+
+		case "$or":
+			if !slices.ContainsFunc(v.([]any), func(q any) bool { return matchQuery(q.(map[string]any), fields) }) {
+				return false
+			}
+
+		case "$nor":
+			if slices.ContainsFunc(v.([]any), func(q any) bool { return matchQuery(q.(map[string]any), fields) }) {
+				return false
+			}
+
+		default:
+			fv, exists := fields[k]
+			if !matchCondition(v, fv, exists) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// matchCondition returns true if the given event field value matches the validated condition.
+func matchCondition(cond, v any, exists bool) bool {
+	ops, ok := cond.(map[string]any)
+	if !ok {
+		return exists && cond == v
+	}
+
+	for op, arg := range ops {
+		var res bool
+
+		switch op {
+		case "$eq":
+			res = exists && arg == v
+		case "$ne":
+			res = !exists || arg != v
+		case "$in":
+			res = exists && slices.Contains(arg.([]any), v)
+		case "$nin":
+			res = !exists || !slices.Contains(arg.([]any), v)
+		case "$exists":
+			res = exists == arg.(bool)
+		default:
+			panic("not reached")
+		}
+
+		if !res {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,131 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+
+	fields := (&event{
+		atype:    "saslStart",
+		category: categoryAuthentication,
+		db:       "admin",
+		user:     "alice",
+		result:   18,
+		error:    "AuthenticationFailed",
+	}).fields()
+
+	for name, tc := range map[string]struct {
+		filter   string
+		expected bool
+	}{
+		"Empty": {
+			filter:   `{}`,
+			expected: true,
+		},
+		"Equal": {
+			filter:   `{"atype": "saslStart", "db": "admin"}`,
+			expected: true,
+		},
+		"NotEqual": {
+			filter:   `{"atype": "saslContinue"}`,
+			expected: false,
+		},
+		"Number": {
+			filter:   `{"result": 18}`,
+			expected: true,
+		},
+		"Ne": {
+			filter:   `{"result": {"$ne": 0}}`,
+			expected: true,
+		},
+		"In": {
+			filter:   `{"category": {"$in": ["authentication", "ddl"]}}`,
+			expected: true,
+		},
+		"Nin": {
+			filter:   `{"category": {"$nin": ["authentication", "ddl"]}}`,
+			expected: false,
+		},
+		"Exists": {
+			filter:   `{"remote": {"$exists": false}, "user": {"$exists": true}}`,
+			expected: true,
+		},
+		"Or": {
+			filter:   `{"$or": [{"category": "ddl"}, {"result": {"$ne": 0}}]}`,
+			expected: true,
+		},
+		"And": {
+			filter:   `{"$and": [{"category": "authentication"}, {"result": 0}]}`,
+			expected: false,
+		},
+		"Nor": {
+			filter:   `{"$nor": [{"category": "ddl"}, {"user": "bob"}]}`,
+			expected: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := parseFilter(tc.filter)
+			require.NoError(t, err)
+			require.NotNil(t, f)
+			assert.Equal(t, tc.expected, f.match(fields))
+		})
+	}
+}
+
+func TestFilterInvalid(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		filter string
+		err    string
+	}{
+		"Operator": {
+			filter: `{"atype": {"$regex": "^sasl"}}`,
+			err:    "atype: unknown operator $regex",
+		},
+		"InArray": {
+			filter: `{"atype": {"$in": "saslStart"}}`,
+			err:    "atype: $in must be an array",
+		},
+		"Value": {
+			filter: `{"atype": ["saslStart"]}`,
+			err:    "atype: unexpected value [saslStart]",
+		},
+		"Or": {
+			filter: `{"$or": {}}`,
+			err:    "$or must be a non-empty array",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseFilter(tc.filter)
+			require.EqualError(t, err, tc.err)
+		})
+	}
+
+	f, err := parseFilter(" ")
+	require.NoError(t, err)
+	assert.Nil(t, f)
+}
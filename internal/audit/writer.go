@@ -0,0 +1,249 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"time"
+
+	"github.com/AlekSi/lazyerrors"
+)
+
+// writer writes encoded events.
+// It is not safe for concurrent use.
+type writer interface {
+	write(b []byte) error
+	Close() error
+}
+
+// fileWriter writes events to a file, rotating it when it becomes too large.
+//
+//nolint:vet // for readability
+type fileWriter struct {
+	path       string
+	newline    bool
+	maxSize    int64
+	maxBackups int
+
+	f    *os.File
+	size int64
+}
+
+// newFileWriter creates a new file writer that appends events to the file with the given path.
+// If maxSize is positive, maxBackups must be positive too.
+func newFileWriter(path, format string, maxSize int64, maxBackups int) (*fileWriter, error) {
+	w := &fileWriter{
+		path:       path,
+		newline:    format == FormatJSON,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+
+	if err := w.open(); err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return w, nil
+}
+
+// open opens the file for appending.
+func (w *fileWriter) open() error {
+	f, err := os.OpenFile(w.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return lazyerrors.Error(err)
+	}
+
+	w.f = f
+	w.size = fi.Size()
+
+	return nil
+}
+
+// rotate closes the file, renames it and previous backups (path.1 being the newest one),
+// removes the oldest backup, and opens a new file.
+func (w *fileWriter) rotate() error {
+	if err := w.f.Close(); err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	w.f = nil
+
+	for i := w.maxBackups; i > 0; i-- {
+		src := w.path
+		if i > 1 {
+			src = fmt.Sprintf("%s.%d", w.path, i-1)
+		}
+
+		dst := fmt.Sprintf("%s.%d", w.path, i)
+
+		// rename does not replace existing files on all platforms
+		if err := os.Remove(dst); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return lazyerrors.Error(err)
+		}
+
+		if err := os.Rename(src, dst); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return lazyerrors.Error(err)
+		}
+	}
+
+	return w.open()
+}
+
+// write implements [writer].
+func (w *fileWriter) write(b []byte) error {
+	if w.newline {
+		b = append(b, '\n')
+	}
+
+	if w.f == nil {
+		// previous rotation failed; try again
+		if err := w.open(); err != nil {
+			return lazyerrors.Error(err)
+		}
+	}
+
+	if w.maxSize > 0 && w.size > 0 && w.size+int64(len(b)) > w.maxSize {
+		if err := w.rotate(); err != nil {
+			return lazyerrors.Error(err)
+		}
+	}
+
+	n, err := w.f.Write(b)
+	w.size += int64(n)
+
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	return nil
+}
+
+// Close implements [writer].
+func (w *fileWriter) Close() error {
+	if w.f == nil {
+		return nil
+	}
+
+	if err := w.f.Close(); err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	return nil
+}
+
+// socketWriter writes events to a Unix domain socket, reconnecting if needed.
+//
+//nolint:vet // for readability
+type socketWriter struct {
+	network string
+	path    string
+	newline bool
+	syslog  bool
+
+	conn net.Conn
+}
+
+// syslogPriority is the syslog priority of audit events: facility authpriv (10), severity info (6).
+const syslogPriority = 10*8 + 6
+
+// newSocketWriter creates a new writer for the Unix domain socket with the given network and path.
+// If syslog is true, events are formatted as syslog messages.
+func newSocketWriter(network, path, format string, syslog bool) (*socketWriter, error) {
+	w := &socketWriter{
+		network: network,
+		path:    path,
+		newline: format == FormatJSON && !syslog,
+		syslog:  syslog,
+	}
+
+	if err := w.dial(); err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return w, nil
+}
+
+// dial connects to the socket.
+func (w *socketWriter) dial() error {
+	conn, err := net.Dial(w.network, w.path)
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	w.conn = conn
+
+	return nil
+}
+
+// write implements [writer].
+func (w *socketWriter) write(b []byte) error {
+	if w.syslog {
+		header := fmt.Sprintf("<%d>%s ferretdb[%d]: ", syslogPriority, time.Now().Format(time.Stamp), os.Getpid())
+		b = append([]byte(header), b...)
+	}
+
+	if w.newline {
+		b = append(b, '\n')
+	}
+
+	if w.conn != nil {
+		if _, err := w.conn.Write(b); err == nil {
+			return nil
+		}
+
+		_ = w.conn.Close()
+		w.conn = nil
+	}
+
+	// the other side might have been restarted; try to reconnect once
+	if err := w.dial(); err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	if _, err := w.conn.Write(b); err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	return nil
+}
+
+// Close implements [writer].
+func (w *socketWriter) Close() error {
+	if w.conn == nil {
+		return nil
+	}
+
+	if err := w.conn.Close(); err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	return nil
+}
+
+// check interfaces
+var (
+	_ writer = (*fileWriter)(nil)
+	_ writer = (*socketWriter)(nil)
+)
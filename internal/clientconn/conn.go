@@ -39,12 +39,16 @@
 	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
 )
 
+// errDrained is returned by [conn.run] when the connection was closed by the draining listener.
+var errDrained = errors.New("listener is draining")
+
 // conn represents client connection.
 type conn struct {
 	netConn        net.Conn
 	l              *slog.Logger
 	m              *middleware.Middleware
-	testRecordsDir string // if empty, no records are created
+	drain          <-chan struct{} // closed when listener is draining; may be nil
+	testRecordsDir string          // if empty, no records are created
 }
 
 // run runs the client connection until ctx is canceled, client disconnects,
@@ -67,7 +71,7 @@ func (c *conn) run(ctx context.Context) (err error) {
 		ci.Close()
 	}()
 
-	if c.netConn.RemoteAddr().Network() != "unix" {
+	if n := c.netConn.RemoteAddr().Network(); n != "unix" && n != pipeNetwork {
 		ci.Peer, err = netip.ParseAddrPort(c.netConn.RemoteAddr().String())
 		if err != nil {
 			err = lazyerrors.Error(err)
@@ -76,42 +80,71 @@ func (c *conn) run(ctx context.Context) (err error) {
 	}
 
 	go func() {
-		<-ctx.Done()
+		select {
+		case <-ctx.Done():
+		case <-c.drain:
+			// unblocks ReadMessage for the idle connection, or the next ReadMessage call
+			// after the in-flight request is handled; writing the response is not affected
+			_ = c.netConn.SetReadDeadline(time.Unix(0, 0))
+
+			<-ctx.Done()
+		}
 
 		// unblocks ReadMessage in the processRequest below; any non-zero past value will do
 		_ = c.netConn.SetDeadline(time.Unix(0, 0))
 	}()
 
 	bufr := bufio.NewReader(c.netConn)
+	bufw := bufio.NewWriter(c.netConn)
 
-	// if test record path is set, split netConn reader to write to file and bufr
+	// if test record path is set, split netConn reader to write to file and bufr,
+	// and netConn writer to write to another file
 	if c.testRecordsDir != "" {
 		if err = os.MkdirAll(c.testRecordsDir, 0o777); err != nil {
 			return
 		}
 
-		// write to temporary file first, then rename to avoid partial files
+		// write to temporary files first, then rename to avoid partial files
+
+		start := time.Now()
 
 		// use local directory so os.Rename below always works
-		var f *os.File
+		var f, respF *os.File
 		if f, err = os.CreateTemp(c.testRecordsDir, "_*.partial"); err != nil {
 			return
 		}
 
+		if respF, err = os.CreateTemp(c.testRecordsDir, "_*.partial"); err != nil {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+
+			return
+		}
+
 		h := sha256.New()
 
+		// recording is best-effort and never affects serving the connection
+		rec := &recorder{ctx: ctx, l: c.l}
+
 		defer func() {
-			c.renamePartialFile(ctx, f, h, err)
+			c.renamePartialFiles(ctx, f, respF, h, start, err, rec.stopped)
 		}()
 
-		r := io.TeeReader(c.netConn, io.MultiWriter(f, h))
+		r := io.TeeReader(c.netConn, io.MultiWriter(rec.writer(f), h))
 		bufr = bufio.NewReader(r)
+		bufw = bufio.NewWriter(io.MultiWriter(c.netConn, rec.writer(respF)))
 	}
 
-	bufw := bufio.NewWriter(c.netConn)
-
 	for {
 		if err = c.processRequest(ctx, bufr, bufw); err != nil {
+			select {
+			case <-c.drain:
+				if errors.Is(err, os.ErrDeadlineExceeded) {
+					err = lazyerrors.Error(errDrained)
+				}
+			default:
+			}
+
 			return
 		}
 	}
@@ -147,19 +180,38 @@ func (c *conn) processRequest(ctx context.Context, bufr *bufio.Reader, bufw *buf
 	return nil
 }
 
-// renamePartialFile takes over an open file `f` and closes it.
+// renamePartialFiles takes over open files `f` (with requests) and `respF` (with responses) and closes them.
 // It uses the given error to check if the connection was closed by the client,
-// if so the given file is renamed to a name generated by hash,
-// otherwise, it deletes the given file.
-func (c *conn) renamePartialFile(ctx context.Context, f *os.File, h hash.Hash, err error) {
+// if so the given files are renamed to names generated by hash
+// (with .bin and .resp extensions respectively),
+// and their modification time is set to the connection start time;
+// otherwise, or if recording was stopped due to write error, it deletes the given files.
+func (c *conn) renamePartialFiles(ctx context.Context, f, respF *os.File, h hash.Hash, start time.Time, err error, stopped bool) {
 	// do not store partial files
-	if !errors.Is(err, wire.ErrZeroRead) {
+	if stopped || !errors.Is(err, wire.ErrZeroRead) {
 		_ = f.Close()
 		_ = os.Remove(f.Name())
+		_ = respF.Close()
+		_ = os.Remove(respF.Name())
 
 		return
 	}
 
+	fileName := hex.EncodeToString(h.Sum(nil))
+
+	hashPath := filepath.Join(c.testRecordsDir, fileName[:2])
+	if e := os.MkdirAll(hashPath, 0o777); e != nil {
+		c.l.WarnContext(ctx, "Failed to make directory", logging.Error(e))
+	}
+
+	// rename responses first, so a file with requests always has them
+	c.renamePartialFile(ctx, respF, filepath.Join(hashPath, fileName+".resp"), start)
+	c.renamePartialFile(ctx, f, filepath.Join(hashPath, fileName+".bin"), start)
+}
+
+// renamePartialFile syncs and closes the given file, renames it to the given path,
+// and sets its modification time.
+func (c *conn) renamePartialFile(ctx context.Context, f *os.File, path string, mtime time.Time) {
 	// surprisingly, Sync is required before Rename on many OS/FS combinations
 	if e := f.Sync(); e != nil {
 		c.l.WarnContext(ctx, "Failed to sync file", logging.Error(e))
@@ -169,15 +221,52 @@ func (c *conn) renamePartialFile(ctx context.Context, f *os.File, h hash.Hash, e
 		c.l.WarnContext(ctx, "Failed to close file", logging.Error(e))
 	}
 
-	fileName := hex.EncodeToString(h.Sum(nil))
+	if e := os.Rename(f.Name(), path); e != nil {
+		c.l.WarnContext(ctx, "Failed to rename file", logging.Error(e))
+		return
+	}
 
-	hashPath := filepath.Join(c.testRecordsDir, fileName[:2])
-	if e := os.MkdirAll(hashPath, 0o777); e != nil {
-		c.l.WarnContext(ctx, "Failed to make directory", logging.Error(e))
+	if e := os.Chtimes(path, time.Time{}, mtime); e != nil {
+		c.l.WarnContext(ctx, "Failed to change file times", logging.Error(e))
 	}
+}
 
-	path := filepath.Join(hashPath, fileName+".bin")
-	if e := os.Rename(f.Name(), path); e != nil {
-		c.l.WarnContext(ctx, "Failed to rename file", logging.Error(e))
+// recorder writes test record files on a best-effort basis.
+//
+// The first write error is logged and stops recording of both requests and responses.
+// Errors are never returned to the caller, so the connection is served as usual.
+type recorder struct {
+	ctx     context.Context
+	l       *slog.Logger
+	stopped bool
+}
+
+// writer returns an [io.Writer] that writes to the given file until recording is stopped.
+func (rec *recorder) writer(f *os.File) io.Writer {
+	return recordWriter{rec: rec, f: f}
+}
+
+// recordWriter is an [io.Writer] returned by [recorder.writer].
+type recordWriter struct {
+	rec *recorder
+	f   *os.File
+}
+
+// Write implements [io.Writer].
+func (w recordWriter) Write(p []byte) (int, error) {
+	if w.rec.stopped {
+		return len(p), nil
 	}
+
+	if _, err := w.f.Write(p); err != nil {
+		w.rec.stopped = true
+		w.rec.l.WarnContext(w.rec.ctx, "Failed to write test record, recording stopped", logging.Error(err))
+	}
+
+	return len(p), nil
 }
+
+// check interfaces
+var (
+	_ io.Writer = recordWriter{}
+)
@@ -20,8 +20,10 @@
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/FerretDB/wire"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/FerretDB/FerretDB/v2/internal/util/testutil"
@@ -38,14 +40,38 @@ func TestRenameFile(t *testing.T) {
 		l:              testutil.Logger(t),
 	}
 
+	start := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
 	t.Run("PartialFileDeleted", func(t *testing.T) {
 		f, err := os.CreateTemp(dir, "_*.partial")
 		require.NoError(t, err)
+		respF, err := os.CreateTemp(dir, "_*.partial")
+		require.NoError(t, err)
 
-		c.renamePartialFile(ctx, f, h, errors.New("some error"))
+		c.renamePartialFiles(ctx, f, respF, h, start, errors.New("some error"), false)
 
 		_, err = os.Stat(f.Name())
 		require.True(t, os.IsNotExist(err))
+		_, err = os.Stat(respF.Name())
+		require.True(t, os.IsNotExist(err))
+
+		files, err := filepath.Glob(filepath.Join(dir, "*", "*.bin"))
+		require.NoError(t, err)
+		require.Empty(t, files)
+	})
+
+	t.Run("StoppedFileDeleted", func(t *testing.T) {
+		f, err := os.CreateTemp(dir, "_*.partial")
+		require.NoError(t, err)
+		respF, err := os.CreateTemp(dir, "_*.partial")
+		require.NoError(t, err)
+
+		c.renamePartialFiles(ctx, f, respF, h, start, wire.ErrZeroRead, true)
+
+		_, err = os.Stat(f.Name())
+		require.True(t, os.IsNotExist(err))
+		_, err = os.Stat(respF.Name())
+		require.True(t, os.IsNotExist(err))
 
 		files, err := filepath.Glob(filepath.Join(dir, "*", "*.bin"))
 		require.NoError(t, err)
@@ -55,14 +81,63 @@ func TestRenameFile(t *testing.T) {
 	t.Run("FileRenamed", func(t *testing.T) {
 		f, err := os.CreateTemp(dir, "_*.partial")
 		require.NoError(t, err)
+		respF, err := os.CreateTemp(dir, "_*.partial")
+		require.NoError(t, err)
 
-		c.renamePartialFile(ctx, f, h, wire.ErrZeroRead)
+		c.renamePartialFiles(ctx, f, respF, h, start, wire.ErrZeroRead, false)
 
 		_, err = os.Stat(f.Name())
 		require.True(t, os.IsNotExist(err))
+		_, err = os.Stat(respF.Name())
+		require.True(t, os.IsNotExist(err))
 
 		files, err := filepath.Glob(filepath.Join(dir, "*", "*.bin"))
 		require.NoError(t, err)
 		require.Len(t, files, 1)
+
+		fi, err := os.Stat(files[0])
+		require.NoError(t, err)
+		assert.True(t, start.Equal(fi.ModTime()))
+
+		files, err = filepath.Glob(filepath.Join(dir, "*", "*.resp"))
+		require.NoError(t, err)
+		require.Len(t, files, 1)
 	})
 }
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	f, err := os.Create(filepath.Join(dir, "req"))
+	require.NoError(t, err)
+
+	respF, err := os.Create(filepath.Join(dir, "resp"))
+	require.NoError(t, err)
+
+	defer respF.Close()
+
+	rec := &recorder{ctx: testutil.Ctx(t), l: testutil.Logger(t)}
+	w, respW := rec.writer(f), rec.writer(respF)
+
+	n, err := respW.Write([]byte("resp"))
+	require.NoError(t, err)
+	assert.Equal(t, 4, n)
+
+	// simulate write error
+	require.NoError(t, f.Close())
+
+	n, err = w.Write([]byte("req"))
+	require.NoError(t, err, "recording errors should not be returned")
+	assert.Equal(t, 3, n)
+	assert.True(t, rec.stopped)
+
+	n, err = respW.Write([]byte("more"))
+	require.NoError(t, err)
+	assert.Equal(t, 4, n)
+
+	b, err := os.ReadFile(respF.Name())
+	require.NoError(t, err)
+	assert.Equal(t, "resp", string(b), "recording should be stopped for all files")
+}
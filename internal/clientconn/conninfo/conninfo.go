@@ -29,15 +29,26 @@ type ConnInfo struct {
 	// the order of fields is weird to make the struct smaller due to alignment
 
 	conv         *scram.Conv    // protected by rw
-	Peer         netip.AddrPort // invalid for Unix domain sockets
+	apiKey       *APIKey        // protected by rw
+	authUsername string         // protected by rw
+	Peer         netip.AddrPort // invalid for Unix domain sockets and in-process connections
 	rw           sync.RWMutex   // rw
 	metadataRecv bool           // protected by rw
+	desynced     bool           // protected by rw
 	steps        int            // protected by rw
 
 	token   *resource.Token
 	onClose func(*ConnInfo)
 }
 
+// APIKey represents an API key used to authenticate the connection.
+type APIKey struct {
+	ID       string
+	Username string
+	DB       string // empty value allows access to all databases
+	ReadOnly bool
+}
+
 // New creates a new ConnInfo.
 func New() *ConnInfo {
 	res := &ConnInfo{
@@ -83,6 +94,64 @@ func (ci *ConnInfo) SetConv(conv *scram.Conv) bool {
 	return was
 }
 
+// APIKey returns API key used for authentication, or nil.
+func (ci *ConnInfo) APIKey() *APIKey {
+	ci.rw.RLock()
+	defer ci.rw.RUnlock()
+
+	return ci.apiKey
+}
+
+// SetAPIKey sets API key used for authentication.
+// It returns true if existing API key was replaced.
+func (ci *ConnInfo) SetAPIKey(key *APIKey) bool {
+	ci.rw.Lock()
+	defer ci.rw.Unlock()
+
+	was := ci.apiKey != nil
+	ci.apiKey = key
+
+	return was
+}
+
+// Username returns the name of the user authenticated with API key, if any,
+// or the client's identification from SCRAM conversation otherwise.
+// In the latter case, it might not be authenticated.
+func (ci *ConnInfo) Username() string {
+	ci.rw.RLock()
+	defer ci.rw.RUnlock()
+
+	if ci.apiKey != nil {
+		return ci.apiKey.Username
+	}
+
+	return ci.conv.Username()
+}
+
+// AuthUsername returns the username of the latest authentication attempt, even if it failed.
+func (ci *ConnInfo) AuthUsername() string {
+	ci.rw.RLock()
+	defer ci.rw.RUnlock()
+
+	return ci.authUsername
+}
+
+// SetAuthUsername sets the username of the latest authentication attempt.
+func (ci *ConnInfo) SetAuthUsername(username string) {
+	ci.rw.Lock()
+	defer ci.rw.Unlock()
+
+	ci.authUsername = username
+}
+
+// Authenticated returns true if SCRAM conversation succeeded or API key was used.
+func (ci *ConnInfo) Authenticated() bool {
+	ci.rw.RLock()
+	defer ci.rw.RUnlock()
+
+	return ci.apiKey != nil || ci.conv.Succeed()
+}
+
 // MetadataRecv returns whatever client metadata was received already.
 func (ci *ConnInfo) MetadataRecv() bool {
 	ci.rw.RLock()
@@ -99,6 +168,23 @@ func (ci *ConnInfo) SetMetadataRecv() {
 	ci.metadataRecv = true
 }
 
+// ShadowDesynced returns true if the connection state of the secondary handler in shadow modes
+// might differ from the primary one.
+func (ci *ConnInfo) ShadowDesynced() bool {
+	ci.rw.RLock()
+	defer ci.rw.RUnlock()
+
+	return ci.desynced
+}
+
+// SetShadowDesynced marks the connection state of the secondary handler in shadow modes as desynchronized.
+func (ci *ConnInfo) SetShadowDesynced() {
+	ci.rw.Lock()
+	defer ci.rw.Unlock()
+
+	ci.desynced = true
+}
+
 // DecrementSteps decreases the steps counter and returns the number of steps left
 // to complete the handshake.
 // The final step returns `0`, a completed handshake returns a negative value.
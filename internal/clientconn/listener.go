@@ -25,6 +25,7 @@
 	"net"
 	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/AlekSi/lazyerrors"
@@ -38,6 +39,7 @@
 
 // Listener listens on one or multiple interfaces (TCP, Unix, TLS sockets)
 // and accepts incoming client connections.
+// It also always accepts in-process connections created by [Listener.Dial].
 type Listener struct {
 	*ListenerOpts
 
@@ -47,8 +49,15 @@ type Listener struct {
 	tcpListener  net.Listener
 	unixListener net.Listener
 	tlsListener  net.Listener
+	pipeListener *pipeListener
 
+	closeOnce       sync.Once
 	listenersClosed chan struct{}
+
+	drainOnce sync.Once
+	drain     chan struct{} // closed when draining starts
+
+	connections atomic.Int64 // number of open client connections
 }
 
 // ListenerOpts represents listener configuration.
@@ -73,9 +82,9 @@ type ListenerOpts struct {
 	TestRecordsDir string // if empty, no records are created
 }
 
-// tlsConfig provides server TLS configuration for the given certificate and key files.
+// TLSConfig provides server TLS configuration for the given certificate and key files.
 // Passing caFile enables client certificate verification.
-func tlsConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+func TLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
 	if _, err := os.Stat(certFile); err != nil {
 		return nil, fmt.Errorf("TLS certificate file: %w", err)
 	}
@@ -124,7 +133,9 @@ func Listen(opts *ListenerOpts) (l *Listener, err error) {
 		ListenerOpts:    opts,
 		ll:              ll,
 		lm:              NewListenerMetrics(),
+		pipeListener:    newPipeListener(),
 		listenersClosed: make(chan struct{}),
+		drain:           make(chan struct{}),
 	}
 
 	defer func() {
@@ -157,7 +168,7 @@ func Listen(opts *ListenerOpts) (l *Listener, err error) {
 	if l.TLS != "" {
 		var config *tls.Config
 
-		if config, err = tlsConfig(l.TLSCertFile, l.TLSKeyFile, l.TLSCAFile); err != nil {
+		if config, err = TLSConfig(l.TLSCertFile, l.TLSKeyFile, l.TLSCAFile); err != nil {
 			err = lazyerrors.Error(err)
 			return
 		}
@@ -174,20 +185,49 @@ func Listen(opts *ListenerOpts) (l *Listener, err error) {
 }
 
 // close closes all listeners.
+// It is safe to call it multiple times.
 func (l *Listener) close() {
-	if l.tcpListener != nil {
-		_ = l.tcpListener.Close()
-	}
+	l.closeOnce.Do(func() {
+		if l.tcpListener != nil {
+			_ = l.tcpListener.Close()
+		}
 
-	if l.unixListener != nil {
-		_ = l.unixListener.Close()
-	}
+		if l.unixListener != nil {
+			_ = l.unixListener.Close()
+		}
 
-	if l.tlsListener != nil {
-		_ = l.tlsListener.Close()
-	}
+		if l.tlsListener != nil {
+			_ = l.tlsListener.Close()
+		}
+
+		_ = l.pipeListener.Close()
+
+		close(l.listenersClosed)
+	})
+}
+
+// Drain stops accepting new connections and closes established connections
+// after their in-flight requests are handled and responses are sent.
+//
+// Draining can't be stopped; the listener stays in that state until [Listener.Run] returns.
+// It is safe to call this method multiple times and concurrently with Run.
+func (l *Listener) Drain() {
+	l.drainOnce.Do(func() {
+		l.ll.Info("Draining: not accepting new connections, waiting for in-flight requests")
+
+		close(l.drain)
+		l.close()
+	})
+}
 
-	close(l.listenersClosed)
+// Draining returns true if [Listener.Drain] was called.
+func (l *Listener) Draining() bool {
+	select {
+	case <-l.drain:
+		return true
+	default:
+		return false
+	}
 }
 
 // Listening returns true if the listener is currently listening and accepting new connection.
@@ -248,6 +288,13 @@ func (l *Listener) Run(ctx context.Context) {
 		}()
 	}
 
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+		acceptLoop(ctx, l.pipeListener, &wg, l)
+	}()
+
 	<-ctx.Done()
 	l.close()
 	l.ll.InfoContext(ctx, "Waiting for all connections to close")
@@ -260,8 +307,8 @@ func acceptLoop(ctx context.Context, listener net.Listener, wg *sync.WaitGroup,
 	for {
 		netConn, err := listener.Accept()
 		if err != nil {
-			// Run closed listener on context cancellation
-			if context.Cause(ctx) != nil {
+			// Run closed listener on context cancellation, or Drain closed it
+			if context.Cause(ctx) != nil || l.Draining() {
 				return
 			}
 
@@ -277,6 +324,7 @@ func acceptLoop(ctx context.Context, listener net.Listener, wg *sync.WaitGroup,
 
 		wg.Add(1)
 		l.lm.accepts.WithLabelValues("0").Inc()
+		l.connections.Add(1)
 
 		go func() {
 			var connErr error
@@ -290,6 +338,7 @@ func acceptLoop(ctx context.Context, listener net.Listener, wg *sync.WaitGroup,
 
 				l.lm.durations.WithLabelValues(lv).Observe(time.Since(start).Seconds())
 				netConn.Close()
+				l.connections.Add(-1)
 				wg.Done()
 			}()
 
@@ -298,9 +347,10 @@ func acceptLoop(ctx context.Context, listener net.Listener, wg *sync.WaitGroup,
 			defer connCancel(nil)
 
 			remoteAddr := netConn.RemoteAddr().String()
-			if netConn.RemoteAddr().Network() == "unix" {
-				// otherwise, all of them would be "" or "@"
-				remoteAddr = fmt.Sprintf("unix:%d", rand.Int())
+			switch network := netConn.RemoteAddr().Network(); network {
+			case "unix", pipeNetwork:
+				// otherwise, all of them would be "", "@", or "pipe"
+				remoteAddr = fmt.Sprintf("%s:%d", network, rand.Int())
 			}
 
 			connID := fmt.Sprintf("%s -> %s", remoteAddr, netConn.LocalAddr())
@@ -310,13 +360,14 @@ func acceptLoop(ctx context.Context, listener net.Listener, wg *sync.WaitGroup,
 				netConn:        netConn,
 				l:              logging.WithName(l.ll, "// "+connID+" "),
 				m:              l.M,
+				drain:          l.drain,
 				testRecordsDir: l.TestRecordsDir,
 			}
 
 			l.ll.InfoContext(ctx, "Connection started", slog.String("conn", connID))
 
 			connErr = conn.run(connCtx)
-			if errors.Is(connErr, wire.ErrZeroRead) {
+			if errors.Is(connErr, wire.ErrZeroRead) || errors.Is(connErr, errDrained) {
 				connErr = nil
 
 				l.ll.InfoContext(ctx, "Connection stopped", slog.String("conn", connID))
@@ -356,6 +407,19 @@ func (l *Listener) TLSAddr() net.Addr {
 	return l.tlsListener.Addr()
 }
 
+// Connections returns the number of open client connections.
+func (l *Listener) Connections() int64 {
+	return l.connections.Load()
+}
+
+// Dial creates a new in-process client connection without opening any socket.
+// The connection is handled the same way as connections accepted by other listeners.
+//
+// It blocks until the connection is accepted by [Listener.Run], ctx is canceled, or the listener is closed.
+func (l *Listener) Dial(ctx context.Context) (net.Conn, error) {
+	return l.pipeListener.dial(ctx)
+}
+
 // Describe implements [prometheus.Collector].
 func (l *Listener) Describe(ch chan<- *prometheus.Desc) {
 	l.lm.Describe(ch)
@@ -15,8 +15,11 @@
 package clientconn
 
 import (
+	"context"
+	"io"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -38,3 +41,90 @@ func TestListener(t *testing.T) {
 
 	assert.Nil(t, l.UnixAddr())
 }
+
+func TestListenerDrain(t *testing.T) {
+	t.Parallel()
+
+	l, err := Listen(&ListenerOpts{
+		Logger: testutil.Logger(t),
+		TCP:    "127.0.0.1:0",
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(testutil.Ctx(t))
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		l.Run(ctx)
+	}()
+
+	addr := l.TCPAddr().String()
+
+	// idle connection without in-flight requests
+	c, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, c.Close())
+	})
+
+	assert.True(t, l.Listening())
+	assert.False(t, l.Draining())
+
+	l.Drain()
+	l.Drain() // should be safe to call again
+
+	assert.False(t, l.Listening())
+	assert.True(t, l.Draining())
+
+	require.NoError(t, c.SetReadDeadline(time.Now().Add(10*time.Second)))
+
+	// idle connection is closed by the server
+	_, err = c.Read(make([]byte, 1))
+	require.ErrorIs(t, err, io.EOF)
+
+	_, err = net.Dial("tcp", addr)
+	require.Error(t, err)
+
+	cancel()
+	<-done
+}
+
+func TestListenerDial(t *testing.T) {
+	t.Parallel()
+
+	l, err := Listen(&ListenerOpts{
+		Logger: testutil.Logger(t),
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(testutil.Ctx(t))
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		l.Run(ctx)
+	}()
+
+	c, err := l.Dial(ctx)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, c.Close())
+	})
+
+	l.Drain()
+
+	require.NoError(t, c.SetReadDeadline(time.Now().Add(10*time.Second)))
+
+	// idle connection is closed by the server
+	_, err = c.Read(make([]byte, 1))
+	require.ErrorIs(t, err, io.EOF)
+
+	_, err = l.Dial(ctx)
+	require.ErrorIs(t, err, net.ErrClosed)
+
+	cancel()
+	<-done
+}
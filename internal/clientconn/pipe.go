@@ -0,0 +1,101 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientconn
+
+import (
+	"context"
+	"net"
+	"sync"
+)
+
+// pipeNetwork is the network name of in-process connections created by [net.Pipe].
+const pipeNetwork = "pipe"
+
+// pipeAddr is a [net.Addr] of [pipeListener].
+type pipeAddr struct{}
+
+// Network implements [net.Addr].
+func (pipeAddr) Network() string { return pipeNetwork }
+
+// String implements [net.Addr].
+func (pipeAddr) String() string { return pipeNetwork }
+
+// pipeListener is a [net.Listener] that accepts in-process connections created by [pipeListener.dial].
+type pipeListener struct {
+	conns     chan net.Conn
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+// newPipeListener creates a new pipeListener.
+func newPipeListener() *pipeListener {
+	return &pipeListener{
+		conns:  make(chan net.Conn),
+		closed: make(chan struct{}),
+	}
+}
+
+// dial creates a new in-process connection and returns its client side.
+// It blocks until the server side is accepted, ctx is canceled, or the listener is closed.
+func (pl *pipeListener) dial(ctx context.Context) (net.Conn, error) {
+	client, server := net.Pipe()
+
+	select {
+	case pl.conns <- server:
+		return client, nil
+
+	case <-pl.closed:
+		_ = client.Close()
+		_ = server.Close()
+
+		return nil, &net.OpError{Op: "dial", Net: pipeNetwork, Addr: pipeAddr{}, Err: net.ErrClosed}
+
+	case <-ctx.Done():
+		_ = client.Close()
+		_ = server.Close()
+
+		return nil, context.Cause(ctx)
+	}
+}
+
+// Accept implements [net.Listener].
+func (pl *pipeListener) Accept() (net.Conn, error) {
+	select {
+	case c := <-pl.conns:
+		return c, nil
+	case <-pl.closed:
+		return nil, &net.OpError{Op: "accept", Net: pipeNetwork, Addr: pipeAddr{}, Err: net.ErrClosed}
+	}
+}
+
+// Close implements [net.Listener].
+func (pl *pipeListener) Close() error {
+	pl.closeOnce.Do(func() {
+		close(pl.closed)
+	})
+
+	return nil
+}
+
+// Addr implements [net.Listener].
+func (pl *pipeListener) Addr() net.Addr {
+	return pipeAddr{}
+}
+
+// check interfaces
+var (
+	_ net.Listener = (*pipeListener)(nil)
+	_ net.Addr     = pipeAddr{}
+)
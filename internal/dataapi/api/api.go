@@ -30,22 +30,38 @@ type Response interface {
 	sealed()
 }
 
-func (r *AggregateResponseBody) sealed()  {}
-func (r *DeleteResponseBody) sealed()     {}
-func (r *Error) sealed()                  {}
-func (r *FindOneResponseBody) sealed()    {}
-func (r *FindManyResponseBody) sealed()   {}
-func (r *InsertOneResponseBody) sealed()  {}
-func (r *InsertManyResponseBody) sealed() {}
-func (r *UpdateResponseBody) sealed()     {}
+func (r *AggregateResponseBody) sealed()       {}
+func (r *CountResponseBody) sealed()           {}
+func (r *CreateIndexesResponseBody) sealed()   {}
+func (r *DeleteResponseBody) sealed()          {}
+func (r *DistinctResponseBody) sealed()        {}
+func (r *DropIndexesResponseBody) sealed()     {}
+func (r *Error) sealed()                       {}
+func (r *FindOneResponseBody) sealed()         {}
+func (r *FindManyResponseBody) sealed()        {}
+func (r *GetMoreResponseBody) sealed()         {}
+func (r *InsertOneResponseBody) sealed()       {}
+func (r *InsertManyResponseBody) sealed()      {}
+func (r *ListCollectionsResponseBody) sealed() {}
+func (r *ListDatabasesResponseBody) sealed()   {}
+func (r *ListIndexesResponseBody) sealed()     {}
+func (r *UpdateResponseBody) sealed()          {}
 
 var (
 	_ Response = (*AggregateResponseBody)(nil)
+	_ Response = (*CountResponseBody)(nil)
+	_ Response = (*CreateIndexesResponseBody)(nil)
 	_ Response = (*DeleteResponseBody)(nil)
+	_ Response = (*DistinctResponseBody)(nil)
+	_ Response = (*DropIndexesResponseBody)(nil)
 	_ Response = (*Error)(nil)
 	_ Response = (*FindOneResponseBody)(nil)
 	_ Response = (*FindManyResponseBody)(nil)
+	_ Response = (*GetMoreResponseBody)(nil)
 	_ Response = (*InsertManyResponseBody)(nil)
 	_ Response = (*InsertOneResponseBody)(nil)
+	_ Response = (*ListCollectionsResponseBody)(nil)
+	_ Response = (*ListDatabasesResponseBody)(nil)
+	_ Response = (*ListIndexesResponseBody)(nil)
 	_ Response = (*UpdateResponseBody)(nil)
 )
@@ -15,11 +15,16 @@
 )
 
 const (
-	HttpAuthScopes = "HttpAuth.Scopes"
+	AccessTokenScopes = "AccessToken.Scopes"
+	ApiKeyScopes      = "ApiKey.Scopes"
+	HttpAuthScopes    = "HttpAuth.Scopes"
 )
 
 // AggregateRequestBody defines model for AggregateRequestBody.
 type AggregateRequestBody struct {
+	// BatchSize The maximum number of documents to include in a single page of results. If there are more results, the response contains a cursor token for the getMore action.
+	BatchSize *int `json:"batchSize,omitempty"`
+
 	// Collection The name of a collection in the specified database.
 	Collection string `json:"collection"`
 
@@ -38,10 +43,76 @@ type AggregateRequestBody struct {
 
 // AggregateResponseBody defines model for AggregateResponseBody.
 type AggregateResponseBody struct {
+	// Cursor An opaque token for fetching the next page of results with the getMore action. It is absent if there are no more results.
+	Cursor *string `json:"cursor,omitempty"`
+
 	// Documents An array that contains the result set of the aggregation.
 	Documents json.RawMessage `json:"documents"`
 }
 
+// BatchSize defines model for BatchSize.
+type BatchSize struct {
+	// BatchSize The maximum number of documents to include in a single page of results. If there are more results, the response contains a cursor token for the getMore action.
+	BatchSize *int `json:"batchSize,omitempty"`
+}
+
+// CountRequestBody defines model for CountRequestBody.
+type CountRequestBody struct {
+	// Collection The name of a collection in the specified database.
+	Collection string `json:"collection"`
+
+	// DataSource The name of a linked MongoDB Atlas data source. This is
+	// commonly `"mongodb-atlas"` though it may be different in
+	// your App if you chose a different name when you created the
+	// data source.
+	DataSource string `json:"dataSource"`
+
+	// Database The name of a database in the specified data source.
+	Database string `json:"database"`
+
+	// Filter A MongoDB query filter that matches documents. For a list of all query operators that the Data API supports, see [Query Operators](https://www.mongodb.com/docs/atlas/app-services/mongodb/crud-and-aggregation-apis/#query-operators).
+	Filter *json.RawMessage `json:"filter,omitempty"`
+
+	// Limit The maximum number of matching documents to include the in the response.
+	Limit *float32 `json:"limit,omitempty"`
+
+	// Skip The number of matching documents to omit from the response.
+	Skip *float32 `json:"skip,omitempty"`
+}
+
+// CountResponseBody defines model for CountResponseBody.
+type CountResponseBody struct {
+	// Count The number of documents.
+	Count interface{} `json:"count"`
+}
+
+// CreateIndexesRequestBody defines model for CreateIndexesRequestBody.
+type CreateIndexesRequestBody struct {
+	// Collection The name of a collection in the specified database.
+	Collection string `json:"collection"`
+
+	// DataSource The name of a linked MongoDB Atlas data source. This is
+	// commonly `"mongodb-atlas"` though it may be different in
+	// your App if you chose a different name when you created the
+	// data source.
+	DataSource string `json:"dataSource"`
+
+	// Database The name of a database in the specified data source.
+	Database string `json:"database"`
+
+	// Indexes A list of [index specifications](https://www.mongodb.com/docs/manual/reference/command/createIndexes/) with `key`, `name`, and other index options.
+	Indexes json.RawMessage `json:"indexes"`
+}
+
+// CreateIndexesResponseBody defines model for CreateIndexesResponseBody.
+type CreateIndexesResponseBody struct {
+	// NumIndexesAfter The number of indexes after the operation.
+	NumIndexesAfter interface{} `json:"numIndexesAfter"`
+
+	// NumIndexesBefore The number of indexes before the operation.
+	NumIndexesBefore interface{} `json:"numIndexesBefore"`
+}
+
 // DeleteRequestBody defines model for DeleteRequestBody.
 type DeleteRequestBody struct {
 	// Collection The name of a collection in the specified database.
@@ -66,8 +137,68 @@ type DeleteResponseBody struct {
 	DeletedCount interface{} `json:"deletedCount"`
 }
 
+// DistinctRequestBody defines model for DistinctRequestBody.
+type DistinctRequestBody struct {
+	// Collection The name of a collection in the specified database.
+	Collection string `json:"collection"`
+
+	// DataSource The name of a linked MongoDB Atlas data source. This is
+	// commonly `"mongodb-atlas"` though it may be different in
+	// your App if you chose a different name when you created the
+	// data source.
+	DataSource string `json:"dataSource"`
+
+	// Database The name of a database in the specified data source.
+	Database string `json:"database"`
+
+	// Filter A MongoDB query filter that matches documents. For a list of all query operators that the Data API supports, see [Query Operators](https://www.mongodb.com/docs/atlas/app-services/mongodb/crud-and-aggregation-apis/#query-operators).
+	Filter *json.RawMessage `json:"filter,omitempty"`
+
+	// Key The field for which to return distinct values.
+	Key string `json:"key"`
+}
+
+// DistinctResponseBody defines model for DistinctResponseBody.
+type DistinctResponseBody struct {
+	// Values Distinct values of the field.
+	Values json.RawMessage `json:"values"`
+}
+
+// DropIndexesRequestBody defines model for DropIndexesRequestBody.
+type DropIndexesRequestBody struct {
+	// Collection The name of a collection in the specified database.
+	Collection string `json:"collection"`
+
+	// DataSource The name of a linked MongoDB Atlas data source. This is
+	// commonly `"mongodb-atlas"` though it may be different in
+	// your App if you chose a different name when you created the
+	// data source.
+	DataSource string `json:"dataSource"`
+
+	// Database The name of a database in the specified data source.
+	Database string `json:"database"`
+
+	// Index An index name, an array of index names, an index key pattern, or `"*"` to drop all indexes except `_id`.
+	Index json.RawMessage `json:"index"`
+}
+
+// DropIndexesResponseBody defines model for DropIndexesResponseBody.
+type DropIndexesResponseBody struct {
+	// NIndexesWas The number of indexes before the operation.
+	NIndexesWas interface{} `json:"nIndexesWas"`
+}
+
 // Error defines model for Error.
 type Error struct {
+	// Code A numeric error code, the same as returned by the MongoDB wire protocol.
+	Code int `json:"code,omitempty"`
+
+	// CodeName A name of the error code.
+	CodeName string `json:"codeName,omitempty"`
+
+	// Errmsg A message that describes the error, the same as `error`.
+	Errmsg string `json:"errmsg,omitempty"`
+
 	// Error A message that describes the error.
 	Error string `json:"error,omitempty"`
 
@@ -76,35 +207,74 @@ type Error struct {
 
 	// Link A link to a [log entry](https://www.mongodb.com/docs/atlas/app-services/logs/endpoint/) for the failed operation.
 	Link string `json:"link,omitempty"`
+
+	// WriteErrors Errors of individual write operations, if any.
+	WriteErrors *json.RawMessage `json:"writeErrors,omitempty"`
 }
 
 // ErrorMissingAuthenticationParameter defines model for ErrorMissingAuthenticationParameter.
 type ErrorMissingAuthenticationParameter struct {
+	// Code A numeric error code, the same as returned by the MongoDB wire protocol.
+	Code int `json:"code,omitempty"`
+
+	// CodeName A name of the error code.
+	CodeName string `json:"codeName,omitempty"`
+
+	// Errmsg A message that describes the error, the same as `error`.
+	Errmsg    string      `json:"errmsg,omitempty"`
 	Error     interface{} `json:"error,omitempty"`
 	ErrorCode interface{} `json:"error_code,omitempty"`
 
 	// Link A link to a [log entry](https://www.mongodb.com/docs/atlas/app-services/logs/endpoint/) for the failed operation.
 	Link string `json:"link,omitempty"`
+
+	// WriteErrors Errors of individual write operations, if any.
+	WriteErrors *json.RawMessage `json:"writeErrors,omitempty"`
 }
 
 // ErrorNoAuthenticationSpecified defines model for ErrorNoAuthenticationSpecified.
 type ErrorNoAuthenticationSpecified struct {
+	// Code A numeric error code, the same as returned by the MongoDB wire protocol.
+	Code int `json:"code,omitempty"`
+
+	// CodeName A name of the error code.
+	CodeName string `json:"codeName,omitempty"`
+
+	// Errmsg A message that describes the error, the same as `error`.
+	Errmsg    string      `json:"errmsg,omitempty"`
 	Error     interface{} `json:"error,omitempty"`
 	ErrorCode interface{} `json:"error_code,omitempty"`
 
 	// Link A link to a [log entry](https://www.mongodb.com/docs/atlas/app-services/logs/endpoint/) for the failed operation.
 	Link string `json:"link,omitempty"`
+
+	// WriteErrors Errors of individual write operations, if any.
+	WriteErrors *json.RawMessage `json:"writeErrors,omitempty"`
 }
 
 // ErrorUserNotFound defines model for ErrorUserNotFound.
 type ErrorUserNotFound struct {
+	// Code A numeric error code, the same as returned by the MongoDB wire protocol.
+	Code int `json:"code,omitempty"`
+
+	// CodeName A name of the error code.
+	CodeName string `json:"codeName,omitempty"`
+
+	// Errmsg A message that describes the error, the same as `error`.
+	Errmsg    string      `json:"errmsg,omitempty"`
 	Error     interface{} `json:"error,omitempty"`
 	ErrorCode interface{} `json:"error_code,omitempty"`
 
 	// Link A link to a [log entry](https://www.mongodb.com/docs/atlas/app-services/logs/endpoint/) for the failed operation.
 	Link string `json:"link,omitempty"`
+
+	// WriteErrors Errors of individual write operations, if any.
+	WriteErrors *json.RawMessage `json:"writeErrors,omitempty"`
 }
 
+// EstimatedDocumentCountRequestBody defines model for EstimatedDocumentCountRequestBody.
+type EstimatedDocumentCountRequestBody = Namespace
+
 // Filter defines model for Filter.
 type Filter struct {
 	// Filter A MongoDB query filter that matches documents. For a list of all query operators that the Data API supports, see [Query Operators](https://www.mongodb.com/docs/atlas/app-services/mongodb/crud-and-aggregation-apis/#query-operators).
@@ -113,6 +283,9 @@ type Filter struct {
 
 // FindManyRequestBody defines model for FindManyRequestBody.
 type FindManyRequestBody struct {
+	// BatchSize The maximum number of documents to include in a single page of results. If there are more results, the response contains a cursor token for the getMore action.
+	BatchSize *int `json:"batchSize,omitempty"`
+
 	// Collection The name of a collection in the specified database.
 	Collection string `json:"collection"`
 
@@ -143,10 +316,48 @@ type FindManyRequestBody struct {
 
 // FindManyResponseBody The result of a find operation.
 type FindManyResponseBody struct {
+	// Cursor An opaque token for fetching the next page of results with the getMore action. It is absent if there are no more results.
+	Cursor *string `json:"cursor,omitempty"`
+
 	// Documents A list of documents that match the specified filter.
 	Documents *json.RawMessage `json:"documents,omitempty"`
 }
 
+// FindOneAndUpdateRequestBody defines model for FindOneAndUpdateRequestBody.
+type FindOneAndUpdateRequestBody struct {
+	// Collection The name of a collection in the specified database.
+	Collection string `json:"collection"`
+
+	// DataSource The name of a linked MongoDB Atlas data source. This is
+	// commonly `"mongodb-atlas"` though it may be different in
+	// your App if you chose a different name when you created the
+	// data source.
+	DataSource string `json:"dataSource"`
+
+	// Database The name of a database in the specified data source.
+	Database string `json:"database"`
+
+	// Filter A MongoDB query filter that matches documents. For a list of all query operators that the Data API supports, see [Query Operators](https://www.mongodb.com/docs/atlas/app-services/mongodb/crud-and-aggregation-apis/#query-operators).
+	Filter json.RawMessage `json:"filter"`
+
+	// Projection A [MongoDB projection](https://www.mongodb.com/docs/manual/tutorial/project-fields-from-query-results/) for matched documents returned by the operation.
+	Projection *json.RawMessage `json:"projection,omitempty"`
+
+	// ReturnNewDocument When `true`, the updated document is returned instead of the original one.
+	ReturnNewDocument *bool `json:"returnNewDocument,omitempty"`
+
+	// Sort A [MongoDB sort expression](https://www.mongodb.com/docs/manual/reference/method/cursor.sort/) that indicates sorted field names and directions.
+	Sort *json.RawMessage `json:"sort,omitempty"`
+
+	// Update A MongoDB update expression to apply to matching documents. For a list of all update operators that the Data API supports, see [Update Operators](https://www.mongodb.com/docs/atlas/app-services/mongodb/crud-and-aggregation-apis/#update-operators).
+	Update json.RawMessage `json:"update"`
+
+	// Upsert When `true`, if the update filter does not match any
+	// existing documents, then insert a new document based on
+	// the filter and the specified update operation.
+	Upsert *bool `json:"upsert,omitempty"`
+}
+
 // FindOneRequestBody defines model for FindOneRequestBody.
 type FindOneRequestBody struct {
 	// Collection The name of a collection in the specified database.
@@ -174,6 +385,24 @@ type FindOneResponseBody struct {
 	Document *json.RawMessage `json:"document"`
 }
 
+// GetMoreRequestBody defines model for GetMoreRequestBody.
+type GetMoreRequestBody struct {
+	// BatchSize The maximum number of documents to include in a single page of results. If there are more results, the response contains a cursor token for the getMore action.
+	BatchSize *int `json:"batchSize,omitempty"`
+
+	// Cursor A cursor token returned by the find, aggregate, or previous getMore action.
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// GetMoreResponseBody defines model for GetMoreResponseBody.
+type GetMoreResponseBody struct {
+	// Cursor An opaque token for fetching the next page of results with the getMore action. It is absent if there are no more results.
+	Cursor *string `json:"cursor,omitempty"`
+
+	// Documents An array that contains the next page of results.
+	Documents json.RawMessage `json:"documents"`
+}
+
 // InsertManyRequestBody defines model for InsertManyRequestBody.
 type InsertManyRequestBody struct {
 	// Collection The name of a collection in the specified database.
@@ -228,6 +457,66 @@ type Limit struct {
 	Limit *float32 `json:"limit,omitempty"`
 }
 
+// ListCollectionsRequestBody defines model for ListCollectionsRequestBody.
+type ListCollectionsRequestBody struct {
+	// DataSource The name of a linked MongoDB Atlas data source. This is
+	// commonly `"mongodb-atlas"` though it may be different in
+	// your App if you chose a different name when you created the
+	// data source.
+	DataSource string `json:"dataSource"`
+
+	// Database The name of a database in the specified data source.
+	Database string `json:"database"`
+
+	// Filter A MongoDB query filter that matches documents. For a list of all query operators that the Data API supports, see [Query Operators](https://www.mongodb.com/docs/atlas/app-services/mongodb/crud-and-aggregation-apis/#query-operators).
+	Filter *json.RawMessage `json:"filter,omitempty"`
+
+	// NameOnly When `true`, only names are returned.
+	NameOnly *bool `json:"nameOnly,omitempty"`
+}
+
+// ListCollectionsResponseBody defines model for ListCollectionsResponseBody.
+type ListCollectionsResponseBody struct {
+	// Collections A list of collections and views.
+	Collections json.RawMessage `json:"collections"`
+}
+
+// ListDatabasesRequestBody defines model for ListDatabasesRequestBody.
+type ListDatabasesRequestBody struct {
+	// DataSource The name of a linked MongoDB Atlas data source. This is
+	// commonly `"mongodb-atlas"` though it may be different in
+	// your App if you chose a different name when you created the
+	// data source.
+	DataSource string `json:"dataSource"`
+
+	// Filter A MongoDB query filter that matches documents. For a list of all query operators that the Data API supports, see [Query Operators](https://www.mongodb.com/docs/atlas/app-services/mongodb/crud-and-aggregation-apis/#query-operators).
+	Filter *json.RawMessage `json:"filter,omitempty"`
+
+	// NameOnly When `true`, only names are returned.
+	NameOnly *bool `json:"nameOnly,omitempty"`
+}
+
+// ListDatabasesResponseBody defines model for ListDatabasesResponseBody.
+type ListDatabasesResponseBody struct {
+	// Databases A list of databases.
+	Databases json.RawMessage `json:"databases"`
+}
+
+// ListIndexesRequestBody defines model for ListIndexesRequestBody.
+type ListIndexesRequestBody = Namespace
+
+// ListIndexesResponseBody defines model for ListIndexesResponseBody.
+type ListIndexesResponseBody struct {
+	// Indexes A list of index specifications.
+	Indexes json.RawMessage `json:"indexes"`
+}
+
+// NameOnly defines model for NameOnly.
+type NameOnly struct {
+	// NameOnly When `true`, only names are returned.
+	NameOnly *bool `json:"nameOnly,omitempty"`
+}
+
 // Namespace defines model for Namespace.
 type Namespace struct {
 	// Collection The name of a collection in the specified database.
@@ -249,6 +538,30 @@ type Projection struct {
 	Projection *json.RawMessage `json:"projection,omitempty"`
 }
 
+// ReplaceRequestBody defines model for ReplaceRequestBody.
+type ReplaceRequestBody struct {
+	// Collection The name of a collection in the specified database.
+	Collection string `json:"collection"`
+
+	// DataSource The name of a linked MongoDB Atlas data source. This is
+	// commonly `"mongodb-atlas"` though it may be different in
+	// your App if you chose a different name when you created the
+	// data source.
+	DataSource string `json:"dataSource"`
+
+	// Database The name of a database in the specified data source.
+	Database string `json:"database"`
+
+	// Filter A MongoDB query filter that matches documents. For a list of all query operators that the Data API supports, see [Query Operators](https://www.mongodb.com/docs/atlas/app-services/mongodb/crud-and-aggregation-apis/#query-operators).
+	Filter json.RawMessage `json:"filter"`
+
+	// Replacement A document that replaces the matched document. It must not contain update operators.
+	Replacement json.RawMessage `json:"replacement"`
+
+	// Upsert When `true`, if the filter does not match any existing documents, then insert the replacement document.
+	Upsert *bool `json:"upsert,omitempty"`
+}
+
 // Skip defines model for Skip.
 type Skip struct {
 	// Skip The number of matching documents to omit from the response.
@@ -299,36 +612,100 @@ type UpdateResponseBody struct {
 	UpsertedId *string `json:"upsertedId,omitempty"`
 }
 
+// WriteError An error of the individual write operation.
+type WriteError struct {
+	// Code A numeric error code.
+	Code *int `json:"code,omitempty"`
+
+	// Errmsg A message that describes the error.
+	Errmsg *string `json:"errmsg,omitempty"`
+
+	// Index The index of the document or statement in the request.
+	Index *int `json:"index,omitempty"`
+}
+
 // BadRequestError defines model for BadRequestError.
 type BadRequestError struct {
 	union json.RawMessage
 }
 
-// UnauthorizedRequestError Indicates that no user matched the provided authentication
-// credentials.
-type UnauthorizedRequestError = ErrorUserNotFound
+// ConflictError defines model for ConflictError.
+type ConflictError = Error
+
+// ForbiddenError defines model for ForbiddenError.
+type ForbiddenError = Error
+
+// InternalServerError defines model for InternalServerError.
+type InternalServerError = Error
+
+// NotFoundError defines model for NotFoundError.
+type NotFoundError = Error
+
+// PayloadTooLargeError defines model for PayloadTooLargeError.
+type PayloadTooLargeError = Error
+
+// ServiceUnavailableError defines model for ServiceUnavailableError.
+type ServiceUnavailableError = Error
+
+// UnauthorizedRequestError defines model for UnauthorizedRequestError.
+type UnauthorizedRequestError struct {
+	union json.RawMessage
+}
 
 // AggregateJSONBody defines parameters for Aggregate.
 type AggregateJSONBody = AggregateRequestBody
 
+// CountDocumentsJSONBody defines parameters for CountDocuments.
+type CountDocumentsJSONBody = CountRequestBody
+
+// CreateIndexesJSONBody defines parameters for CreateIndexes.
+type CreateIndexesJSONBody = CreateIndexesRequestBody
+
 // DeleteManyJSONBody defines parameters for DeleteMany.
 type DeleteManyJSONBody = DeleteRequestBody
 
 // DeleteOneJSONBody defines parameters for DeleteOne.
 type DeleteOneJSONBody = DeleteRequestBody
 
+// DistinctJSONBody defines parameters for Distinct.
+type DistinctJSONBody = DistinctRequestBody
+
+// DropIndexesJSONBody defines parameters for DropIndexes.
+type DropIndexesJSONBody = DropIndexesRequestBody
+
+// EstimatedDocumentCountJSONBody defines parameters for EstimatedDocumentCount.
+type EstimatedDocumentCountJSONBody = EstimatedDocumentCountRequestBody
+
 // FindJSONBody defines parameters for Find.
 type FindJSONBody = FindManyRequestBody
 
 // FindOneJSONBody defines parameters for FindOne.
 type FindOneJSONBody = FindOneRequestBody
 
+// FindOneAndUpdateJSONBody defines parameters for FindOneAndUpdate.
+type FindOneAndUpdateJSONBody = FindOneAndUpdateRequestBody
+
+// GetMoreJSONBody defines parameters for GetMore.
+type GetMoreJSONBody = GetMoreRequestBody
+
 // InsertManyJSONBody defines parameters for InsertMany.
 type InsertManyJSONBody = InsertManyRequestBody
 
 // InsertOneJSONBody defines parameters for InsertOne.
 type InsertOneJSONBody = InsertOneRequestBody
 
+// ListCollectionsJSONBody defines parameters for ListCollections.
+type ListCollectionsJSONBody = ListCollectionsRequestBody
+
+// ListDatabasesJSONBody defines parameters for ListDatabases.
+type ListDatabasesJSONBody = ListDatabasesRequestBody
+
+// ListIndexesJSONBody defines parameters for ListIndexes.
+type ListIndexesJSONBody = ListIndexesRequestBody
+
+// ReplaceOneJSONBody defines parameters for ReplaceOne.
+type ReplaceOneJSONBody = ReplaceRequestBody
+
 // UpdateManyJSONBody defines parameters for UpdateMany.
 type UpdateManyJSONBody = UpdateRequestBody
 
@@ -338,24 +715,57 @@ type BadRequestError struct {
 // AggregateJSONRequestBody defines body for Aggregate for application/json ContentType.
 type AggregateJSONRequestBody = AggregateJSONBody
 
+// CountDocumentsJSONRequestBody defines body for CountDocuments for application/json ContentType.
+type CountDocumentsJSONRequestBody = CountDocumentsJSONBody
+
+// CreateIndexesJSONRequestBody defines body for CreateIndexes for application/json ContentType.
+type CreateIndexesJSONRequestBody = CreateIndexesJSONBody
+
 // DeleteManyJSONRequestBody defines body for DeleteMany for application/json ContentType.
 type DeleteManyJSONRequestBody = DeleteManyJSONBody
 
 // DeleteOneJSONRequestBody defines body for DeleteOne for application/json ContentType.
 type DeleteOneJSONRequestBody = DeleteOneJSONBody
 
+// DistinctJSONRequestBody defines body for Distinct for application/json ContentType.
+type DistinctJSONRequestBody = DistinctJSONBody
+
+// DropIndexesJSONRequestBody defines body for DropIndexes for application/json ContentType.
+type DropIndexesJSONRequestBody = DropIndexesJSONBody
+
+// EstimatedDocumentCountJSONRequestBody defines body for EstimatedDocumentCount for application/json ContentType.
+type EstimatedDocumentCountJSONRequestBody = EstimatedDocumentCountJSONBody
+
 // FindJSONRequestBody defines body for Find for application/json ContentType.
 type FindJSONRequestBody = FindJSONBody
 
 // FindOneJSONRequestBody defines body for FindOne for application/json ContentType.
 type FindOneJSONRequestBody = FindOneJSONBody
 
+// FindOneAndUpdateJSONRequestBody defines body for FindOneAndUpdate for application/json ContentType.
+type FindOneAndUpdateJSONRequestBody = FindOneAndUpdateJSONBody
+
+// GetMoreJSONRequestBody defines body for GetMore for application/json ContentType.
+type GetMoreJSONRequestBody = GetMoreJSONBody
+
 // InsertManyJSONRequestBody defines body for InsertMany for application/json ContentType.
 type InsertManyJSONRequestBody = InsertManyJSONBody
 
 // InsertOneJSONRequestBody defines body for InsertOne for application/json ContentType.
 type InsertOneJSONRequestBody = InsertOneJSONBody
 
+// ListCollectionsJSONRequestBody defines body for ListCollections for application/json ContentType.
+type ListCollectionsJSONRequestBody = ListCollectionsJSONBody
+
+// ListDatabasesJSONRequestBody defines body for ListDatabases for application/json ContentType.
+type ListDatabasesJSONRequestBody = ListDatabasesJSONBody
+
+// ListIndexesJSONRequestBody defines body for ListIndexes for application/json ContentType.
+type ListIndexesJSONRequestBody = ListIndexesJSONBody
+
+// ReplaceOneJSONRequestBody defines body for ReplaceOne for application/json ContentType.
+type ReplaceOneJSONRequestBody = ReplaceOneJSONBody
+
 // UpdateManyJSONRequestBody defines body for UpdateMany for application/json ContentType.
 type UpdateManyJSONRequestBody = UpdateManyJSONBody
 
@@ -414,6 +824,32 @@ func (t *BadRequestError) MergeErrorMissingAuthenticationParameter(v ErrorMissin
 	return err
 }
 
+// AsError returns the union data inside the BadRequestError as a Error
+func (t BadRequestError) AsError() (Error, error) {
+	var body Error
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromError overwrites any union data inside the BadRequestError as the provided Error
+func (t *BadRequestError) FromError(v Error) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeError performs a merge with any union data inside the BadRequestError, using the provided Error
+func (t *BadRequestError) MergeError(v Error) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
 func (t BadRequestError) MarshalJSON() ([]byte, error) {
 	b, err := t.union.MarshalJSON()
 	return b, err
@@ -424,29 +860,124 @@ func (t *BadRequestError) UnmarshalJSON(b []byte) error {
 	return err
 }
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Aggregate Documents
-	// (POST /action/aggregate)
-	Aggregate(w http.ResponseWriter, r *http.Request)
-	// Delete Documents
-	// (POST /action/deleteMany)
-	DeleteMany(w http.ResponseWriter, r *http.Request)
-	// Delete One Document
-	// (POST /action/deleteOne)
-	DeleteOne(w http.ResponseWriter, r *http.Request)
-	// Find Documents
-	// (POST /action/find)
-	Find(w http.ResponseWriter, r *http.Request)
-	// Find One Document
-	// (POST /action/findOne)
+// AsErrorUserNotFound returns the union data inside the UnauthorizedRequestError as a ErrorUserNotFound
+func (t UnauthorizedRequestError) AsErrorUserNotFound() (ErrorUserNotFound, error) {
+	var body ErrorUserNotFound
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromErrorUserNotFound overwrites any union data inside the UnauthorizedRequestError as the provided ErrorUserNotFound
+func (t *UnauthorizedRequestError) FromErrorUserNotFound(v ErrorUserNotFound) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeErrorUserNotFound performs a merge with any union data inside the UnauthorizedRequestError, using the provided ErrorUserNotFound
+func (t *UnauthorizedRequestError) MergeErrorUserNotFound(v ErrorUserNotFound) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsError returns the union data inside the UnauthorizedRequestError as a Error
+func (t UnauthorizedRequestError) AsError() (Error, error) {
+	var body Error
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromError overwrites any union data inside the UnauthorizedRequestError as the provided Error
+func (t *UnauthorizedRequestError) FromError(v Error) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeError performs a merge with any union data inside the UnauthorizedRequestError, using the provided Error
+func (t *UnauthorizedRequestError) MergeError(v Error) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+func (t UnauthorizedRequestError) MarshalJSON() ([]byte, error) {
+	b, err := t.union.MarshalJSON()
+	return b, err
+}
+
+func (t *UnauthorizedRequestError) UnmarshalJSON(b []byte) error {
+	err := t.union.UnmarshalJSON(b)
+	return err
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Aggregate Documents
+	// (POST /action/aggregate)
+	Aggregate(w http.ResponseWriter, r *http.Request)
+	// Count Documents
+	// (POST /action/countDocuments)
+	CountDocuments(w http.ResponseWriter, r *http.Request)
+	// Create Indexes
+	// (POST /action/createIndexes)
+	CreateIndexes(w http.ResponseWriter, r *http.Request)
+	// Delete Documents
+	// (POST /action/deleteMany)
+	DeleteMany(w http.ResponseWriter, r *http.Request)
+	// Delete One Document
+	// (POST /action/deleteOne)
+	DeleteOne(w http.ResponseWriter, r *http.Request)
+	// Find Distinct Values
+	// (POST /action/distinct)
+	Distinct(w http.ResponseWriter, r *http.Request)
+	// Drop Indexes
+	// (POST /action/dropIndexes)
+	DropIndexes(w http.ResponseWriter, r *http.Request)
+	// Estimate Document Count
+	// (POST /action/estimatedDocumentCount)
+	EstimatedDocumentCount(w http.ResponseWriter, r *http.Request)
+	// Find Documents
+	// (POST /action/find)
+	Find(w http.ResponseWriter, r *http.Request)
+	// Find One Document
+	// (POST /action/findOne)
 	FindOne(w http.ResponseWriter, r *http.Request)
+	// Find One Document and Update It
+	// (POST /action/findOneAndUpdate)
+	FindOneAndUpdate(w http.ResponseWriter, r *http.Request)
+	// Get More Documents
+	// (POST /action/getMore)
+	GetMore(w http.ResponseWriter, r *http.Request)
 	// Insert Documents
 	// (POST /action/insertMany)
 	InsertMany(w http.ResponseWriter, r *http.Request)
 	// Insert One Document
 	// (POST /action/insertOne)
 	InsertOne(w http.ResponseWriter, r *http.Request)
+	// List Collections
+	// (POST /action/listCollections)
+	ListCollections(w http.ResponseWriter, r *http.Request)
+	// List Databases
+	// (POST /action/listDatabases)
+	ListDatabases(w http.ResponseWriter, r *http.Request)
+	// List Indexes
+	// (POST /action/listIndexes)
+	ListIndexes(w http.ResponseWriter, r *http.Request)
+	// Replace One Document
+	// (POST /action/replaceOne)
+	ReplaceOne(w http.ResponseWriter, r *http.Request)
 	// Update Documents
 	// (POST /action/updateMany)
 	UpdateMany(w http.ResponseWriter, r *http.Request)
@@ -471,6 +1002,10 @@ func (siw *ServerInterfaceWrapper) Aggregate(w http.ResponseWriter, r *http.Requ
 
 	ctx = context.WithValue(ctx, HttpAuthScopes, []string{})
 
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{})
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{})
+
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -484,6 +1019,54 @@ func (siw *ServerInterfaceWrapper) Aggregate(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
+// CountDocuments operation middleware
+func (siw *ServerInterfaceWrapper) CountDocuments(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, HttpAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{})
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CountDocuments(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateIndexes operation middleware
+func (siw *ServerInterfaceWrapper) CreateIndexes(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, HttpAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{})
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateIndexes(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // DeleteMany operation middleware
 func (siw *ServerInterfaceWrapper) DeleteMany(w http.ResponseWriter, r *http.Request) {
 
@@ -491,6 +1074,10 @@ func (siw *ServerInterfaceWrapper) DeleteMany(w http.ResponseWriter, r *http.Req
 
 	ctx = context.WithValue(ctx, HttpAuthScopes, []string{})
 
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{})
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{})
+
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -511,6 +1098,10 @@ func (siw *ServerInterfaceWrapper) DeleteOne(w http.ResponseWriter, r *http.Requ
 
 	ctx = context.WithValue(ctx, HttpAuthScopes, []string{})
 
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{})
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{})
+
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -524,6 +1115,78 @@ func (siw *ServerInterfaceWrapper) DeleteOne(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
+// Distinct operation middleware
+func (siw *ServerInterfaceWrapper) Distinct(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, HttpAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{})
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Distinct(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DropIndexes operation middleware
+func (siw *ServerInterfaceWrapper) DropIndexes(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, HttpAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{})
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DropIndexes(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// EstimatedDocumentCount operation middleware
+func (siw *ServerInterfaceWrapper) EstimatedDocumentCount(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, HttpAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{})
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.EstimatedDocumentCount(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // Find operation middleware
 func (siw *ServerInterfaceWrapper) Find(w http.ResponseWriter, r *http.Request) {
 
@@ -531,6 +1194,10 @@ func (siw *ServerInterfaceWrapper) Find(w http.ResponseWriter, r *http.Request)
 
 	ctx = context.WithValue(ctx, HttpAuthScopes, []string{})
 
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{})
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{})
+
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -551,6 +1218,10 @@ func (siw *ServerInterfaceWrapper) FindOne(w http.ResponseWriter, r *http.Reques
 
 	ctx = context.WithValue(ctx, HttpAuthScopes, []string{})
 
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{})
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{})
+
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -564,6 +1235,54 @@ func (siw *ServerInterfaceWrapper) FindOne(w http.ResponseWriter, r *http.Reques
 	handler.ServeHTTP(w, r)
 }
 
+// FindOneAndUpdate operation middleware
+func (siw *ServerInterfaceWrapper) FindOneAndUpdate(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, HttpAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{})
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.FindOneAndUpdate(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetMore operation middleware
+func (siw *ServerInterfaceWrapper) GetMore(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, HttpAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{})
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetMore(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // InsertMany operation middleware
 func (siw *ServerInterfaceWrapper) InsertMany(w http.ResponseWriter, r *http.Request) {
 
@@ -571,6 +1290,10 @@ func (siw *ServerInterfaceWrapper) InsertMany(w http.ResponseWriter, r *http.Req
 
 	ctx = context.WithValue(ctx, HttpAuthScopes, []string{})
 
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{})
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{})
+
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -591,6 +1314,10 @@ func (siw *ServerInterfaceWrapper) InsertOne(w http.ResponseWriter, r *http.Requ
 
 	ctx = context.WithValue(ctx, HttpAuthScopes, []string{})
 
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{})
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{})
+
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -604,6 +1331,102 @@ func (siw *ServerInterfaceWrapper) InsertOne(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
+// ListCollections operation middleware
+func (siw *ServerInterfaceWrapper) ListCollections(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, HttpAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{})
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListCollections(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListDatabases operation middleware
+func (siw *ServerInterfaceWrapper) ListDatabases(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, HttpAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{})
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListDatabases(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListIndexes operation middleware
+func (siw *ServerInterfaceWrapper) ListIndexes(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, HttpAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{})
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListIndexes(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReplaceOne operation middleware
+func (siw *ServerInterfaceWrapper) ReplaceOne(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, HttpAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{})
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReplaceOne(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // UpdateMany operation middleware
 func (siw *ServerInterfaceWrapper) UpdateMany(w http.ResponseWriter, r *http.Request) {
 
@@ -611,6 +1434,10 @@ func (siw *ServerInterfaceWrapper) UpdateMany(w http.ResponseWriter, r *http.Req
 
 	ctx = context.WithValue(ctx, HttpAuthScopes, []string{})
 
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{})
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{})
+
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -631,6 +1458,10 @@ func (siw *ServerInterfaceWrapper) UpdateOne(w http.ResponseWriter, r *http.Requ
 
 	ctx = context.WithValue(ctx, HttpAuthScopes, []string{})
 
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{})
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{})
+
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -765,12 +1596,23 @@ func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.H
 	}
 
 	m.HandleFunc("POST "+options.BaseURL+"/action/aggregate", wrapper.Aggregate)
+	m.HandleFunc("POST "+options.BaseURL+"/action/countDocuments", wrapper.CountDocuments)
+	m.HandleFunc("POST "+options.BaseURL+"/action/createIndexes", wrapper.CreateIndexes)
 	m.HandleFunc("POST "+options.BaseURL+"/action/deleteMany", wrapper.DeleteMany)
 	m.HandleFunc("POST "+options.BaseURL+"/action/deleteOne", wrapper.DeleteOne)
+	m.HandleFunc("POST "+options.BaseURL+"/action/distinct", wrapper.Distinct)
+	m.HandleFunc("POST "+options.BaseURL+"/action/dropIndexes", wrapper.DropIndexes)
+	m.HandleFunc("POST "+options.BaseURL+"/action/estimatedDocumentCount", wrapper.EstimatedDocumentCount)
 	m.HandleFunc("POST "+options.BaseURL+"/action/find", wrapper.Find)
 	m.HandleFunc("POST "+options.BaseURL+"/action/findOne", wrapper.FindOne)
+	m.HandleFunc("POST "+options.BaseURL+"/action/findOneAndUpdate", wrapper.FindOneAndUpdate)
+	m.HandleFunc("POST "+options.BaseURL+"/action/getMore", wrapper.GetMore)
 	m.HandleFunc("POST "+options.BaseURL+"/action/insertMany", wrapper.InsertMany)
 	m.HandleFunc("POST "+options.BaseURL+"/action/insertOne", wrapper.InsertOne)
+	m.HandleFunc("POST "+options.BaseURL+"/action/listCollections", wrapper.ListCollections)
+	m.HandleFunc("POST "+options.BaseURL+"/action/listDatabases", wrapper.ListDatabases)
+	m.HandleFunc("POST "+options.BaseURL+"/action/listIndexes", wrapper.ListIndexes)
+	m.HandleFunc("POST "+options.BaseURL+"/action/replaceOne", wrapper.ReplaceOne)
 	m.HandleFunc("POST "+options.BaseURL+"/action/updateMany", wrapper.UpdateMany)
 	m.HandleFunc("POST "+options.BaseURL+"/action/updateOne", wrapper.UpdateOne)
 
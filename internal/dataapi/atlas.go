@@ -0,0 +1,76 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataapi
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// atlasVersions contains Atlas Data API versions in URL paths.
+var atlasVersions = []string{"v1", "beta"}
+
+// atlasPath returns the Data API path for the given Atlas Data API path
+// like `/app/{appId}/endpoint/data/v1/action/find`.
+// It returns false if the path does not have Atlas prefix.
+func atlasPath(path string) (string, bool) {
+	rest, ok := strings.CutPrefix(path, "/app/")
+	if !ok {
+		return "", false
+	}
+
+	appID, rest, ok := strings.Cut(rest, "/")
+	if !ok || appID == "" {
+		return "", false
+	}
+
+	if rest, ok = strings.CutPrefix(rest, "endpoint/data/"); !ok {
+		return "", false
+	}
+
+	for _, v := range atlasVersions {
+		if p, ok := strings.CutPrefix(rest, v+"/"); ok {
+			return "/" + p, true
+		}
+	}
+
+	return "", false
+}
+
+// atlasHandler returns a handler that serves Atlas Data API paths
+// by removing the Atlas prefix from the request URL, and then calls the next handler.
+// Other paths are passed as is.
+//
+// The App ID is ignored, so existing Atlas Data API clients can be used just by changing the base URL.
+func atlasHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		p, ok := atlasPath(r.URL.Path)
+		if !ok {
+			next.ServeHTTP(rw, r)
+			return
+		}
+
+		// see http.StripPrefix
+		r2 := new(http.Request)
+		*r2 = *r
+		r2.URL = new(url.URL)
+		*r2.URL = *r.URL
+		r2.URL.Path = p
+		r2.URL.RawPath = ""
+
+		next.ServeHTTP(rw, r2)
+	})
+}
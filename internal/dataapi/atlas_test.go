@@ -0,0 +1,45 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAtlasPath(t *testing.T) {
+	t.Parallel()
+
+	for path, expected := range map[string]string{
+		"/app/data-abcde/endpoint/data/v1/action/find":        "/action/find",
+		"/app/data-abcde/endpoint/data/beta/action/insertOne": "/action/insertOne",
+		"/app/data-abcde/endpoint/data/v1/openapi.json":       "/openapi.json",
+	} {
+		actual, ok := atlasPath(path)
+		assert.True(t, ok, path)
+		assert.Equal(t, expected, actual, path)
+	}
+
+	for _, path := range []string{
+		"/action/find",
+		"/app//endpoint/data/v1/action/find",
+		"/app/data-abcde/endpoint/data/v2/action/find",
+		"/app/data-abcde/endpoint/other/v1/action/find",
+	} {
+		_, ok := atlasPath(path)
+		assert.False(t, ok, path)
+	}
+}
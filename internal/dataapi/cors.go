@@ -0,0 +1,75 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataapi
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// corsMaxAge is the time in seconds for which browsers may cache preflight responses.
+const corsMaxAge = 10 * 60
+
+// Request headers allowed for cross-origin requests,
+// including all headers used for authentication by the Data API server.
+var corsAllowedHeaders = []string{"Accept", "Authorization", "Content-Type", "apiKey", "email", "password"}
+
+// corsHandler returns a handler that allows cross-origin requests from the given origins,
+// and then calls the next handler.
+// The "*" origin allows requests from any origin.
+//
+// Preflight requests are answered directly.
+// Requests from other origins are passed to the next handler without CORS headers,
+// so browsers do not expose responses to them.
+func corsHandler(origins []string, next http.Handler) http.Handler {
+	anyOrigin := slices.Contains(origins, "*")
+
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			next.ServeHTTP(rw, r)
+			return
+		}
+
+		rw.Header().Add("Vary", "Origin")
+
+		preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+		if !anyOrigin && !slices.Contains(origins, origin) {
+			if preflight {
+				rw.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(rw, r)
+
+			return
+		}
+
+		rw.Header().Set("Access-Control-Allow-Origin", origin)
+
+		if !preflight {
+			next.ServeHTTP(rw, r)
+			return
+		}
+
+		rw.Header().Set("Access-Control-Allow-Methods", strings.Join([]string{http.MethodGet, http.MethodPost}, ", "))
+		rw.Header().Set("Access-Control-Allow-Headers", strings.Join(corsAllowedHeaders, ", "))
+		rw.Header().Set("Access-Control-Max-Age", strconv.Itoa(corsMaxAge))
+		rw.WriteHeader(http.StatusNoContent)
+	})
+}
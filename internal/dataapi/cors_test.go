@@ -0,0 +1,103 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORSHandler(t *testing.T) {
+	t.Parallel()
+
+	next := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusTeapot)
+	})
+
+	for name, tc := range map[string]struct {
+		origins []string
+		method  string
+		origin  string
+
+		code        int
+		allowOrigin string
+	}{
+		"NoOrigin": {
+			origins: []string{"https://example.com"},
+			method:  http.MethodPost,
+			code:    http.StatusTeapot,
+		},
+		"Allowed": {
+			origins:     []string{"https://example.com"},
+			method:      http.MethodPost,
+			origin:      "https://example.com",
+			code:        http.StatusTeapot,
+			allowOrigin: "https://example.com",
+		},
+		"NotAllowed": {
+			origins: []string{"https://example.com"},
+			method:  http.MethodPost,
+			origin:  "https://example.org",
+			code:    http.StatusTeapot,
+		},
+		"Any": {
+			origins:     []string{"*"},
+			method:      http.MethodPost,
+			origin:      "https://example.org",
+			code:        http.StatusTeapot,
+			allowOrigin: "https://example.org",
+		},
+		"Preflight": {
+			origins:     []string{"https://example.com"},
+			method:      http.MethodOptions,
+			origin:      "https://example.com",
+			code:        http.StatusNoContent,
+			allowOrigin: "https://example.com",
+		},
+		"PreflightNotAllowed": {
+			origins: []string{"https://example.com"},
+			method:  http.MethodOptions,
+			origin:  "https://example.org",
+			code:    http.StatusForbidden,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(tc.method, "/action/find", nil)
+
+			if tc.origin != "" {
+				req.Header.Set("Origin", tc.origin)
+			}
+
+			if tc.method == http.MethodOptions {
+				req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			}
+
+			rw := httptest.NewRecorder()
+			corsHandler(tc.origins, next).ServeHTTP(rw, req)
+
+			assert.Equal(t, tc.code, rw.Code)
+			assert.Equal(t, tc.allowOrigin, rw.Header().Get("Access-Control-Allow-Origin"))
+
+			if tc.code == http.StatusNoContent {
+				assert.Equal(t, "Accept, Authorization, Content-Type, apiKey, email, password", rw.Header().Get("Access-Control-Allow-Headers"))
+			}
+		})
+	}
+}
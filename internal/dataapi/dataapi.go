@@ -18,15 +18,19 @@
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/AlekSi/lazyerrors"
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/FerretDB/FerretDB/v2/internal/clientconn"
 	"github.com/FerretDB/FerretDB/v2/internal/dataapi/api"
 	"github.com/FerretDB/FerretDB/v2/internal/dataapi/server"
 	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
@@ -34,55 +38,143 @@
 	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
 )
 
-// Listener represents Data API TCP listener and HTTP handler.
+// DefaultMaxBodySize is the default maximum size of the request body in bytes.
+const DefaultMaxBodySize = 16 * 1024 * 1024
+
+// Timeouts of the HTTP server.
+//
+// Streamed responses extend the write deadline for each batch of documents,
+// so they are not limited by writeTimeout.
+const (
+	readHeaderTimeout = 10 * time.Second
+	readTimeout       = time.Minute
+	writeTimeout      = time.Minute
+	idleTimeout       = 2 * time.Minute
+)
+
+// Listener represents Data API listeners and HTTP handler.
 type Listener struct {
 	opts *ListenOpts
-	lis  net.Listener
-	h    http.Handler
+
+	tcpListener  net.Listener
+	unixListener net.Listener
+	tlsListener  net.Listener
+
+	drainOnce sync.Once
+	drain     chan struct{} // closed when draining starts
+
+	s *server.Server
+	h http.Handler
 }
 
 // ListenOpts represents [Listen] options.
 type ListenOpts struct {
-	L       *slog.Logger
-	M       *middleware.Middleware
-	TCPAddr string
-	Auth    bool
+	L *slog.Logger
+	M *middleware.Middleware
+
+	TCPAddr  string // empty value disables TCP listener
+	UnixAddr string // empty value disables Unix listener
+
+	TLSAddr     string // empty value disables TLS listener
+	TLSCertFile string
+	TLSKeyFile  string
+	TLSCAFile   string
+
+	Auth        bool
+	MaxBodySize int64    // zero value means DefaultMaxBodySize
+	CORSOrigins []string // empty value disables CORS; "*" allows all origins
 }
 
-// Listen creates a new Data API handler and starts listener on the given TCP address.
+// Listen creates a new Data API handler and starts listeners on the given addresses.
 // [Listener.Run] must be called on the returned value.
-func Listen(opts *ListenOpts) (*Listener, error) {
-	lis, err := net.Listen("tcp", opts.TCPAddr)
-	if err != nil {
-		return nil, lazyerrors.Error(err)
+func Listen(opts *ListenOpts) (lis *Listener, err error) {
+	lis = &Listener{
+		opts:  opts,
+		drain: make(chan struct{}),
+	}
+
+	defer func() {
+		if err != nil {
+			lis.closeListeners()
+			lis = nil
+		}
+	}()
+
+	if opts.TCPAddr != "" {
+		if lis.tcpListener, err = net.Listen("tcp", opts.TCPAddr); err != nil {
+			err = lazyerrors.Error(err)
+			return
+		}
+	}
+
+	if opts.UnixAddr != "" {
+		if lis.unixListener, err = net.Listen("unix", opts.UnixAddr); err != nil {
+			err = lazyerrors.Error(err)
+			return
+		}
+	}
+
+	if opts.TLSAddr != "" {
+		var config *tls.Config
+
+		if config, err = clientconn.TLSConfig(opts.TLSCertFile, opts.TLSKeyFile, opts.TLSCAFile); err != nil {
+			return
+		}
+
+		if lis.tlsListener, err = tls.Listen("tcp", opts.TLSAddr, config); err != nil {
+			err = lazyerrors.Error(err)
+			return
+		}
+	}
+
+	if lis.tcpListener == nil && lis.unixListener == nil && lis.tlsListener == nil {
+		err = lazyerrors.New("no Data API listener address is set")
+		return
+	}
+
+	maxBodySize := opts.MaxBodySize
+	if maxBodySize == 0 {
+		maxBodySize = DefaultMaxBodySize
 	}
 
 	s := server.New(opts.L, opts.M)
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("GET /openapi.json", s.OpenAPISpec)
+	mux.HandleFunc("/action/{action}", s.UnknownAction)
 
 	h := api.HandlerFromMux(s, mux)
 	if opts.Auth {
 		h = s.AuthMiddleware(h)
 	}
 
+	h = s.FormatMiddleware(h)
 	h = s.ConnInfoMiddleware(h)
+	h = http.MaxBytesHandler(h, maxBodySize)
+	h = atlasHandler(h)
+
+	if len(opts.CORSOrigins) > 0 {
+		// CORS preflight requests do not have credentials, so they should be handled before authentication
+		h = corsHandler(opts.CORSOrigins, h)
+	}
 
-	return &Listener{
-		opts: opts,
-		lis:  lis,
-		h:    h,
-	}, nil
+	lis.s = s
+	lis.h = h
+
+	return
 }
 
 // Run runs Data API handler until ctx is canceled.
 //
-// It exits when handler is stopped and listener closed.
+// It exits when handler is stopped and listeners closed.
 func (lis *Listener) Run(ctx context.Context) {
 	s := &http.Server{
-		Handler:  lis.h,
-		ErrorLog: slog.NewLogLogger(lis.opts.L.Handler(), slog.LevelError),
+		Handler:           lis.h,
+		ReadHeaderTimeout: readHeaderTimeout,
+		ReadTimeout:       readTimeout,
+		WriteTimeout:      writeTimeout,
+		IdleTimeout:       idleTimeout,
+		ErrorLog:          slog.NewLogLogger(lis.opts.L.Handler(), slog.LevelError),
 		BaseContext: func(net.Listener) context.Context {
 			return ctx
 		},
@@ -90,14 +182,36 @@ func (lis *Listener) Run(ctx context.Context) {
 
 	l := lis.opts.L
 
-	l.InfoContext(ctx, fmt.Sprintf("Starting Data API server on http://%s/", lis.Addr()))
-	l.InfoContext(ctx, fmt.Sprintf("http://%s/openapi.json - OpenAPI spec", lis.Addr()))
+	var wg sync.WaitGroup
 
-	go func() {
-		if err := s.Serve(lis.lis); !errors.Is(err, http.ErrServerClosed) {
-			l.LogAttrs(ctx, logging.LevelDPanic, "Serve exited with unexpected error", logging.Error(err))
+	for _, nl := range []struct {
+		lis    net.Listener
+		scheme string
+	}{
+		{lis.tcpListener, "http"},
+		{lis.unixListener, "http+unix"},
+		{lis.tlsListener, "https"},
+	} {
+		if nl.lis == nil {
+			continue
 		}
-	}()
+
+		l.InfoContext(ctx, fmt.Sprintf("Starting Data API server on %s://%s/", nl.scheme, nl.lis.Addr()))
+		l.InfoContext(ctx, fmt.Sprintf("%s://%s/openapi.json - OpenAPI spec", nl.scheme, nl.lis.Addr()))
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			// Drain closes listeners
+			if err := s.Serve(nl.lis); !errors.Is(err, http.ErrServerClosed) && !lis.Draining() {
+				l.LogAttrs(ctx, logging.LevelDPanic, "Serve exited with unexpected error", logging.Error(err))
+			}
+		}()
+	}
+
+	go lis.s.Run(ctx)
 
 	<-ctx.Done()
 
@@ -113,13 +227,70 @@ func (lis *Listener) Run(ctx context.Context) {
 		l.LogAttrs(ctx, logging.LevelDPanic, "Close exited with unexpected error", logging.Error(err))
 	}
 
+	wg.Wait()
+
 	l.InfoContext(ctx, "Data API server stopped")
 }
 
-// Addr returns TCP listener's address.
+// Drain stops accepting new connections.
+// In-flight requests are handled; established connections are closed when [Listener.Run] returns.
+//
+// Draining can't be stopped.
+func (lis *Listener) Drain() {
+	lis.drainOnce.Do(func() {
+		lis.opts.L.Info("Draining: not accepting new Data API connections")
+
+		close(lis.drain)
+		lis.closeListeners()
+	})
+}
+
+// Draining returns true if [Listener.Drain] was called.
+func (lis *Listener) Draining() bool {
+	select {
+	case <-lis.drain:
+		return true
+	default:
+		return false
+	}
+}
+
+// closeListeners closes all opened listeners.
+func (lis *Listener) closeListeners() {
+	for _, nl := range []net.Listener{lis.tcpListener, lis.unixListener, lis.tlsListener} {
+		if nl != nil {
+			_ = nl.Close()
+		}
+	}
+}
+
+// Addr returns TCP listener's address, or nil, if TCP listener is disabled.
 // It can be used to determine an actually used port, if it was zero.
 func (lis *Listener) Addr() net.Addr {
-	return lis.lis.Addr()
+	if lis.tcpListener == nil {
+		return nil
+	}
+
+	return lis.tcpListener.Addr()
+}
+
+// UnixAddr returns Unix domain socket listener's address, or nil, if Unix listener is disabled.
+func (lis *Listener) UnixAddr() net.Addr {
+	if lis.unixListener == nil {
+		return nil
+	}
+
+	return lis.unixListener.Addr()
+}
+
+// TLSAddr returns TLS listener's address, or nil, if TLS listener is disabled.
+// It can be used to determine an actually used port, if it was zero.
+func (lis *Listener) TLSAddr() net.Addr {
+	if lis.tlsListener == nil {
+		return nil
+	}
+
+	return lis.tlsListener.Addr()
 }
 
 // Describe implements [prometheus.Collector].
@@ -17,18 +17,30 @@
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/json"
+	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/netip"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 
+	"github.com/FerretDB/FerretDB/v2/internal/audit"
+	"github.com/FerretDB/FerretDB/v2/internal/dataapi"
+	"github.com/FerretDB/FerretDB/v2/internal/documentdb"
 	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
 	"github.com/FerretDB/FerretDB/v2/internal/util/setup"
 	"github.com/FerretDB/FerretDB/v2/internal/util/state"
@@ -322,6 +334,517 @@ func TestSmokeDataAPI(t *testing.T) {
 	})
 }
 
+func TestErrorsDataAPI(t *testing.T) {
+	addr, db := setupDataAPI(t, true)
+	coll := testutil.CollectionName(t)
+
+	t.Parallel()
+
+	ns := `"database": "` + db + `", "collection": "` + coll + `"`
+
+	res, err := postJSON(t, "http://"+addr+"/action/insertOne", `{`+ns+`, "document": {"_id": 1}}`)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	for name, tc := range map[string]struct {
+		action   string
+		body     string
+		status   int
+		codeName string
+	}{
+		"DuplicateKey": {
+			action:   "insertOne",
+			body:     `{` + ns + `, "document": {"_id": 1}}`,
+			status:   http.StatusConflict,
+			codeName: "DuplicateKey",
+		},
+		"BadFilter": {
+			action:   "find",
+			body:     `{` + ns + `, "filter": {"$bad": 1}}`,
+			status:   http.StatusBadRequest,
+			codeName: "BadValue",
+		},
+		"MalformedJSON": {
+			action:   "find",
+			body:     `{`,
+			status:   http.StatusBadRequest,
+			codeName: "",
+		},
+		"IndexNotFound": {
+			action:   "dropIndexes",
+			body:     `{` + ns + `, "index": "missing"}`,
+			status:   http.StatusNotFound,
+			codeName: "IndexNotFound",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := postJSON(t, "http://"+addr+"/action/"+tc.action, tc.body)
+			require.NoError(t, err)
+			assert.Equal(t, tc.status, res.StatusCode)
+
+			var actual struct {
+				Error    string `json:"error"`
+				CodeName string `json:"codeName"`
+			}
+
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&actual))
+			assert.NotEmpty(t, actual.Error)
+			assert.Equal(t, tc.codeName, actual.CodeName)
+		})
+	}
+}
+
+func TestMetadataActionsDataAPI(t *testing.T) {
+	addr, db := setupDataAPI(t, true)
+	coll := testutil.CollectionName(t)
+
+	t.Parallel()
+
+	ns := `"database": "` + db + `", "collection": "` + coll + `"`
+
+	// steps depend on each other, so they are not run in parallel
+	for _, step := range []struct {
+		name     string
+		action   string
+		body     string
+		expected string
+	}{{
+		name:     "InsertMany",
+		action:   "insertMany",
+		body:     `{` + ns + `, "documents": [{"_id": 1, "v": "a"}, {"_id": 2, "v": "b"}, {"_id": 3, "v": "a"}]}`,
+		expected: `{"insertedIds": [1, 2, 3]}`,
+	}, {
+		name:     "CountDocuments",
+		action:   "countDocuments",
+		body:     `{` + ns + `, "filter": {"v": "a"}}`,
+		expected: `{"count": 2}`,
+	}, {
+		name:     "EstimatedDocumentCount",
+		action:   "estimatedDocumentCount",
+		body:     `{` + ns + `}`,
+		expected: `{"count": 3}`,
+	}, {
+		name:     "Distinct",
+		action:   "distinct",
+		body:     `{` + ns + `, "key": "v"}`,
+		expected: `{"values": ["a", "b"]}`,
+	}, {
+		name:     "ReplaceOne",
+		action:   "replaceOne",
+		body:     `{` + ns + `, "filter": {"_id": 2}, "replacement": {"v": "c"}}`,
+		expected: `{"matchedCount": 1, "modifiedCount": 1}`,
+	}, {
+		name:   "FindOneAndUpdate",
+		action: "findOneAndUpdate",
+		body: `{` + ns + `, "filter": {"v": "a"}, "sort": {"_id": -1}, ` +
+			`"update": {"$set": {"v": "d"}}, "returnNewDocument": true}`,
+		expected: `{"document": {"_id": 3, "v": "d"}}`,
+	}, {
+		name:     "FindOneAndUpdateNone",
+		action:   "findOneAndUpdate",
+		body:     `{` + ns + `, "filter": {"v": "none"}, "update": {"$set": {"v": "e"}}}`,
+		expected: `{}`,
+	}, {
+		name:     "CreateIndexes",
+		action:   "createIndexes",
+		body:     `{` + ns + `, "indexes": [{"key": {"v": 1}, "name": "v_1"}]}`,
+		expected: `{"numIndexesBefore": 1, "numIndexesAfter": 2}`,
+	}, {
+		name:     "ListIndexes",
+		action:   "listIndexes",
+		body:     `{` + ns + `}`,
+		expected: `{"indexes": [{"v": 2, "key": {"_id": 1}, "name": "_id_"}, {"v": 2, "key": {"v": 1}, "name": "v_1"}]}`,
+	}, {
+		name:     "DropIndexes",
+		action:   "dropIndexes",
+		body:     `{` + ns + `, "index": "v_1"}`,
+		expected: `{"nIndexesWas": 2}`,
+	}, {
+		name:     "ListCollections",
+		action:   "listCollections",
+		body:     `{"database": "` + db + `", "nameOnly": true}`,
+		expected: `{"collections": [{"name": "` + coll + `", "type": "collection"}]}`,
+	}} {
+		res, err := postJSON(t, "http://"+addr+"/action/"+step.action, step.body)
+		require.NoError(t, err, step.name)
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err, step.name)
+
+		require.Equal(t, http.StatusOK, res.StatusCode, "%s: %s", step.name, body)
+		assert.JSONEq(t, step.expected, string(body), step.name)
+	}
+
+	t.Run("ListDatabases", func(t *testing.T) {
+		res, err := postJSON(t, "http://"+addr+"/action/listDatabases", `{"nameOnly": true}`)
+		require.NoError(t, err)
+
+		var actual struct {
+			Databases []struct {
+				Name string `json:"name"`
+			} `json:"databases"`
+		}
+
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&actual))
+
+		var names []string
+		for _, d := range actual.Databases {
+			names = append(names, d.Name)
+		}
+
+		assert.Contains(t, names, db)
+	})
+}
+
+func TestPaginationDataAPI(t *testing.T) {
+	addr, db := setupDataAPI(t, true)
+	coll := testutil.CollectionName(t)
+
+	t.Parallel()
+
+	docs := make([]string, 5)
+	for i := range docs {
+		docs[i] = fmt.Sprintf(`{"_id":%d}`, i+1)
+	}
+
+	res, err := postJSON(t, "http://"+addr+"/action/insertMany", `{
+		"database": "`+db+`",
+		"collection": "`+coll+`",
+		"documents": [`+strings.Join(docs, ",")+`]
+	}`)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	// page represents find, aggregate, and getMore responses.
+	type page struct {
+		Documents []json.RawMessage `json:"documents"`
+		Cursor    *string           `json:"cursor"`
+	}
+
+	post := func(t *testing.T, action, jsonBody string) page {
+		t.Helper()
+
+		res, err := postJSON(t, "http://"+addr+"/action/"+action, jsonBody)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var p page
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&p))
+
+		return p
+	}
+
+	for _, action := range []string{"find", "aggregate"} {
+		t.Run(action, func(t *testing.T) {
+			p := post(t, action, `{
+				"database": "`+db+`",
+				"collection": "`+coll+`",
+				"filter": {},
+				"sort": {"_id": 1},
+				"pipeline": [{"$sort": {"_id": 1}}],
+				"batchSize": 2
+			}`)
+			require.NotNil(t, p.Cursor)
+
+			actual := p.Documents
+
+			for p.Cursor != nil {
+				p = post(t, "getMore", `{"cursor": "`+*p.Cursor+`", "batchSize": 2}`)
+				actual = append(actual, p.Documents...)
+			}
+
+			require.Len(t, actual, len(docs))
+
+			for i, d := range actual {
+				assert.JSONEq(t, docs[i], string(d))
+			}
+		})
+	}
+
+	t.Run("Unknown", func(t *testing.T) {
+		res, err := postJSON(t, "http://"+addr+"/action/getMore", `{"cursor": "unknown"}`)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"error":"cursor not found or expired","error_code":"CursorNotFound"}`, string(body))
+	})
+}
+
+func TestFormatsDataAPI(t *testing.T) {
+	addr, db := setupDataAPI(t, true)
+	coll := testutil.CollectionName(t)
+
+	t.Parallel()
+
+	docs := make([]string, 5)
+	for i := range docs {
+		docs[i] = fmt.Sprintf(`{"_id":%d,"v":{"$numberLong":"%d"}}`, i+1, i+1)
+	}
+
+	res, err := postJSON(t, "http://"+addr+"/action/insertMany", `{
+		"database": "`+db+`",
+		"collection": "`+coll+`",
+		"documents": [`+strings.Join(docs, ",")+`]
+	}`)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	post := func(t *testing.T, action, accept, jsonBody string) *http.Response {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodPost, "http://"+addr+"/action/"+action, strings.NewReader(jsonBody))
+		require.NoError(t, err)
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", accept)
+		req.SetBasicAuth("username", "password")
+
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+
+		t.Cleanup(func() {
+			assert.NoError(t, res.Body.Close())
+		})
+
+		return res
+	}
+
+	findOne := `{"database": "` + db + `", "collection": "` + coll + `", "filter": {"_id": 1}}`
+
+	t.Run("Canonical", func(t *testing.T) {
+		res := post(t, "findOne", "application/ejson", findOne)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "application/ejson; mode=canonical", res.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"document":{"_id":{"$numberInt":"1"},"v":{"$numberLong":"1"}}}`, string(body))
+	})
+
+	t.Run("Relaxed", func(t *testing.T) {
+		res := post(t, "findOne", "application/ejson; mode=relaxed", findOne)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "application/ejson; mode=relaxed", res.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"document":{"_id":1,"v":1}}`, string(body))
+	})
+
+	t.Run("BSON", func(t *testing.T) {
+		res := post(t, "findOne", "application/bson", findOne)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "application/bson", res.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+
+		var actual bson.D
+		require.NoError(t, bson.Unmarshal(body, &actual))
+
+		expected := bson.D{{Key: "document", Value: bson.D{{Key: "_id", Value: int32(1)}, {Key: "v", Value: int64(1)}}}}
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("NDJSON", func(t *testing.T) {
+		for _, action := range []string{"find", "aggregate"} {
+			t.Run(action, func(t *testing.T) {
+				res := post(t, action, "application/x-ndjson", `{
+					"database": "`+db+`",
+					"collection": "`+coll+`",
+					"filter": {},
+					"sort": {"_id": 1},
+					"pipeline": [{"$sort": {"_id": 1}}],
+					"batchSize": 2
+				}`)
+				require.Equal(t, http.StatusOK, res.StatusCode)
+				assert.Equal(t, "application/x-ndjson", res.Header.Get("Content-Type"))
+
+				body, err := io.ReadAll(res.Body)
+				require.NoError(t, err)
+
+				lines := strings.Split(strings.TrimSuffix(string(body), "\n"), "\n")
+				require.Len(t, lines, len(docs))
+
+				for i, line := range lines {
+					assert.JSONEq(t, fmt.Sprintf(`{"_id":%d,"v":%d}`, i+1, i+1), line)
+				}
+			})
+		}
+	})
+
+	t.Run("NotAcceptable", func(t *testing.T) {
+		res := post(t, "findOne", "text/html", findOne)
+		assert.Equal(t, http.StatusNotAcceptable, res.StatusCode)
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"error":"none of the accepted media types is supported","error_code":"NotAcceptable"}`, string(body))
+	})
+}
+
+func TestAPIKeyDataAPI(t *testing.T) {
+	addr, db := setupDataAPI(t, true)
+	coll := testutil.CollectionName(t)
+
+	ctx := testutil.Ctx(t)
+
+	conn, err := pgx.Connect(ctx, testutil.PostgreSQLURL(t))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close(context.Background())
+	})
+
+	// keys are usually created with `createAPIKey` command
+	id := strings.ToLower(strings.ReplaceAll(t.Name(), "/", "")) // unique enough and without `_`
+	secret := "0123456789abcdef"
+	hash := sha256.Sum256([]byte(secret))
+	key := "fdb_" + id + "_" + secret
+
+	_, _ = documentdb.DropAPIKey(ctx, conn, id)
+
+	err = documentdb.CreateAPIKey(ctx, conn, &documentdb.APIKey{
+		ID:       id,
+		Username: "username",
+		DB:       db,
+		Hash:     hash[:],
+		ReadOnly: true,
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_, _ = documentdb.DropAPIKey(context.Background(), conn, id)
+	})
+
+	t.Parallel()
+
+	post := func(t *testing.T, action, key, jsonBody string) *http.Response {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodPost, "http://"+addr+"/action/"+action, strings.NewReader(jsonBody))
+		require.NoError(t, err)
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+key)
+
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+
+		return res
+	}
+
+	t.Run("Read", func(t *testing.T) {
+		res := post(t, "find", key, `{"database": "`+db+`", "collection": "`+coll+`"}`)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	})
+
+	t.Run("ReadOtherDatabase", func(t *testing.T) {
+		res := post(t, "find", key, `{"database": "`+db+`_other", "collection": "`+coll+`"}`)
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	})
+
+	t.Run("Write", func(t *testing.T) {
+		res := post(t, "insertOne", key, `{"database": "`+db+`", "collection": "`+coll+`", "document": {"v": 1}}`)
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	})
+
+	t.Run("WrongSecret", func(t *testing.T) {
+		res := post(t, "find", key+"0", `{"database": "`+db+`", "collection": "`+coll+`"}`)
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	})
+}
+
+func TestAuthLockoutDataAPI(t *testing.T) {
+	t.Parallel()
+
+	addr, db := setupDataAPIWithOpts(t, &setupDataAPIOpts{
+		auth:            true,
+		authMaxFailures: 2,
+		authLockout:     time.Hour,
+	})
+
+	find := func(t *testing.T, username, password string) int {
+		t.Helper()
+
+		body := `{"database": "` + db + `", "collection": "` + testutil.CollectionName(t) + `"}`
+		req, err := http.NewRequest(http.MethodPost, "http://"+addr+"/action/find", strings.NewReader(body))
+		require.NoError(t, err)
+
+		req.Header.Set("Content-Type", "application/json")
+		req.SetBasicAuth(username, password)
+
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+
+		_ = res.Body.Close()
+
+		return res.StatusCode
+	}
+
+	require.Equal(t, http.StatusOK, find(t, "username", "password"))
+
+	// different usernames, so only the peer address could be locked out
+	assert.Equal(t, http.StatusUnauthorized, find(t, "lockout1", "wrong"))
+	assert.Equal(t, http.StatusUnauthorized, find(t, "lockout2", "wrong"))
+
+	assert.Equal(t, http.StatusUnauthorized, find(t, "username", "password"), "peer should be locked out")
+}
+
+func TestAuditDataAPI(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), "audit.log")
+
+	addr, db := setupDataAPIWithOpts(t, &setupDataAPIOpts{
+		auth:      true,
+		auditPath: file,
+	})
+
+	res, err := postJSON(t, "http://"+addr+"/action/find", `{"database": "`+db+`", "collection": "`+testutil.CollectionName(t)+`"}`)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	_ = res.Body.Close()
+
+	b, err := os.ReadFile(file)
+	require.NoError(t, err)
+
+	var authenticated bool
+
+	for line := range strings.Lines(string(b)) {
+		var ev struct {
+			Atype    string `json:"atype"`
+			Category string `json:"category"`
+			Remote   string `json:"remote"`
+			User     string `json:"user"`
+		}
+
+		require.NoError(t, json.Unmarshal([]byte(line), &ev))
+
+		if ev.Category != "authentication" {
+			continue
+		}
+
+		peer, err := netip.ParseAddrPort(ev.Remote)
+		require.NoError(t, err, "remote address should be set for %s", ev.Atype)
+		assert.True(t, peer.Addr().IsLoopback())
+
+		if ev.Atype == "saslContinue" {
+			authenticated = true
+
+			assert.Equal(t, "username", ev.User)
+		}
+	}
+
+	assert.True(t, authenticated, "authentication events should be logged")
+}
+
 func TestOpenAPI(t *testing.T) {
 	t.Parallel()
 
@@ -336,7 +859,7 @@ func TestOpenAPI(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, resp.StatusCode)
 		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
-		assert.Equal(t, "53813", resp.Header.Get("Content-Length"))
+		assert.Equal(t, "114146", resp.Header.Get("Content-Length"))
 
 		body, err := io.ReadAll(resp.Body)
 		require.NoError(t, err)
@@ -364,6 +887,183 @@ func TestOpenAPI(t *testing.T) {
 	})
 }
 
+func TestListenErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("NoAddr", func(t *testing.T) {
+		t.Parallel()
+
+		lis, err := dataapi.Listen(&dataapi.ListenOpts{
+			L: testutil.Logger(t),
+		})
+		require.Error(t, err)
+		require.Nil(t, lis)
+	})
+
+	t.Run("PartialFailure", func(t *testing.T) {
+		t.Parallel()
+
+		nl, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+
+		addr := nl.Addr().String()
+		require.NoError(t, nl.Close())
+
+		lis, err := dataapi.Listen(&dataapi.ListenOpts{
+			L:        testutil.Logger(t),
+			TCPAddr:  addr,
+			UnixAddr: filepath.Join(t.TempDir(), "missing", "dataapi.sock"),
+		})
+		require.Error(t, err)
+		require.Nil(t, lis)
+
+		// TCP listener should be closed
+		nl, err = net.Listen("tcp", addr)
+		require.NoError(t, err)
+		require.NoError(t, nl.Close())
+	})
+
+	t.Run("TLSFiles", func(t *testing.T) {
+		t.Parallel()
+
+		lis, err := dataapi.Listen(&dataapi.ListenOpts{
+			L:           testutil.Logger(t),
+			TLSAddr:     "127.0.0.1:0",
+			TLSCertFile: filepath.Join(t.TempDir(), "missing.pem"),
+			TLSKeyFile:  filepath.Join(t.TempDir(), "missing-key.pem"),
+		})
+		require.Error(t, err)
+		require.Nil(t, lis)
+	})
+}
+
+func TestDrain(t *testing.T) {
+	t.Parallel()
+
+	lis, err := dataapi.Listen(&dataapi.ListenOpts{
+		L:       testutil.Logger(t),
+		TCPAddr: "127.0.0.1:0",
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(testutil.Ctx(t))
+
+	runDone := make(chan struct{})
+
+	go func() {
+		defer close(runDone)
+		lis.Run(ctx)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		<-runDone
+	})
+
+	u := "http://" + lis.Addr().String() + "/openapi.json"
+
+	resp, err := http.Get(u)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	assert.False(t, lis.Draining())
+	lis.Drain()
+	assert.True(t, lis.Draining())
+
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	_, err = client.Get(u)
+	require.Error(t, err, "new connections should not be accepted")
+}
+
+func TestListenUnix(t *testing.T) {
+	t.Parallel()
+
+	sock := filepath.Join(t.TempDir(), "dataapi.sock")
+
+	lis, err := dataapi.Listen(&dataapi.ListenOpts{
+		L:           testutil.Logger(t),
+		UnixAddr:    sock,
+		MaxBodySize: 1024,
+	})
+	require.NoError(t, err)
+	require.Nil(t, lis.Addr())
+	require.Equal(t, sock, lis.UnixAddr().String())
+
+	ctx, cancel := context.WithCancel(testutil.Ctx(t))
+
+	runDone := make(chan struct{})
+
+	go func() {
+		defer close(runDone)
+		lis.Run(ctx)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		<-runDone
+	})
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", sock)
+			},
+		},
+	}
+
+	t.Run("Spec", func(t *testing.T) {
+		resp, err := client.Get("http://dataapi/openapi.json")
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			assert.NoError(t, resp.Body.Close())
+		})
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("BodyTooLarge", func(t *testing.T) {
+		body := `{"database":"db","collection":"coll","filter":{"v":"` + strings.Repeat("x", 2048) + `"}}`
+
+		resp, err := client.Post("http://dataapi/action/find", "application/json", strings.NewReader(body))
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			assert.NoError(t, resp.Body.Close())
+		})
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+	})
+
+	t.Run("UnknownAction", func(t *testing.T) {
+		resp, err := client.Post("http://dataapi/app/data-abcde/endpoint/data/v1/action/unknown", "application/json", nil)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			assert.NoError(t, resp.Body.Close())
+		})
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"error":"no matching rule found","error_code":"NoMatchingRuleFound"}`, string(body))
+	})
+
+	t.Run("MethodNotAllowed", func(t *testing.T) {
+		resp, err := client.Get("http://dataapi/action/find")
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			assert.NoError(t, resp.Body.Close())
+		})
+
+		assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"error":"method not allowed","error_code":"MethodNotAllowed"}`, string(body))
+	})
+}
+
 // postJSON sends POST request with provided JSON to data API under provided uri.
 // It handles necessary headers, as well as authentication.
 func postJSON(tb testing.TB, uri, jsonBody string) (*http.Response, error) {
@@ -378,11 +1078,34 @@ func postJSON(tb testing.TB, uri, jsonBody string) (*http.Response, error) {
 	return http.DefaultClient.Do(req)
 }
 
+// setupDataAPIOpts represents setupDataAPIWithOpts options.
+type setupDataAPIOpts struct {
+	auth            bool
+	authMaxFailures int
+	authLockout     time.Duration
+	auditPath       string // empty value disables audit log
+}
+
 // setupDataAPI sets up clean database and the Data API handler.
 // It returns Data API address, and database name.
 func setupDataAPI(tb testing.TB, auth bool) (addr string, dbName string) {
 	tb.Helper()
 
+	return setupDataAPIWithOpts(tb, &setupDataAPIOpts{auth: auth})
+}
+
+// setupDataAPIWithOpts is a variant of setupDataAPI with options.
+func setupDataAPIWithOpts(tb testing.TB, opts *setupDataAPIOpts) (addr string, dbName string) {
+	tb.Helper()
+
+	auth := opts.auth
+
+	var auditDestination, auditFormat string
+	if opts.auditPath != "" {
+		auditDestination = audit.DestinationFile
+		auditFormat = audit.FormatJSON
+	}
+
 	uri := testutil.PostgreSQLURL(tb)
 
 	sp, err := state.NewProvider("")
@@ -399,13 +1122,41 @@ func setupDataAPI(tb testing.TB, auth bool) (addr string, dbName string) {
 		PostgreSQLURL:          uri,
 		Auth:                   auth,
 		ReplSetName:            "",
+		ReadOnly:               false,
 		SessionCleanupInterval: 0,
+		CustomCommands:         nil,
+		AuthMaxFailures:        opts.authMaxFailures,
+		AuthLockout:            opts.authLockout,
+		AuthMaxLockout:         0,
 
 		ProxyAddr:        "",
 		ProxyTLSCertFile: "",
 		ProxyTLSKeyFile:  "",
 		ProxyTLSCAFile:   "",
 
+		DiffReportFile:    "",
+		DiffIgnoredFields: nil,
+
+		ShadowQueueSize:  0,
+		ShadowSampleRate: 0,
+		ShadowCommands:   nil,
+		ShadowNamespaces: nil,
+
+		RouteRulesFile: "",
+
+		DualWriteReconciliationFile: "",
+
+		Interceptors:   nil,
+		TracerProvider: nil,
+
+		AuditDestination: auditDestination,
+		AuditPath:        opts.auditPath,
+		AuditFormat:      auditFormat,
+		AuditMaxSize:     0,
+		AuditMaxBackups:  0,
+		AuditFilter:      "",
+		AuditNamespaces:  nil,
+
 		TCPAddr:        "127.0.0.1:0",
 		UnixAddr:       "",
 		TLSAddr:        "",
@@ -415,9 +1166,18 @@ func setupDataAPI(tb testing.TB, auth bool) (addr string, dbName string) {
 		Mode:           middleware.NormalMode,
 		TestRecordsDir: "",
 
-		DataAPIAddr: "127.0.0.1:0",
-
-		MCPAddr: "",
+		DataAPIAddr:        "127.0.0.1:0",
+		DataAPIUnixAddr:    "",
+		DataAPITLSAddr:     "",
+		DataAPIMaxBodySize: 0,
+		DataAPICORSOrigins: nil,
+
+		MCPAddr:        "",
+		MCPAllowWrites: false,
+		MCPStdio:       false,
+		MCPUsername:    "",
+		MCPPassword:    "",
+		MCPAPIKey:      "",
 	})
 	require.NotNil(tb, res)
 
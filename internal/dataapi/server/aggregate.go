@@ -15,29 +15,28 @@
 package server
 
 import (
-	"fmt"
-	"log/slog"
 	"net/http"
-	"net/http/httputil"
 
 	"github.com/AlekSi/lazyerrors"
-	"github.com/FerretDB/wire/wirebson"
 
 	"github.com/FerretDB/FerretDB/v2/internal/dataapi/api"
-	"github.com/FerretDB/FerretDB/v2/internal/util/must"
 )
 
 // Aggregate implements [ServerInterface].
 func (s *Server) Aggregate(rw http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	if s.l.Enabled(ctx, slog.LevelDebug) {
-		s.l.DebugContext(ctx, fmt.Sprintf("Request:\n%s", must.NotFail(httputil.DumpRequest(r, true))))
-	}
+	s.logRequest(ctx, r)
 
 	var req api.AggregateRequestBody
 	if err := decodeJSONRequest(r, &req); err != nil {
-		http.Error(rw, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+		writeRequestError(rw, err)
+		return
+	}
+
+	cursorDoc, err := prepareDocument("batchSize", req.BatchSize)
+	if err != nil {
+		writeRequestError(rw, err)
 		return
 	}
 
@@ -45,36 +44,33 @@ func (s *Server) Aggregate(rw http.ResponseWriter, r *http.Request) {
 		"aggregate", req.Collection,
 		"$db", req.Database,
 		"pipeline", req.Pipeline,
-		"cursor", wirebson.MustDocument(),
+		"cursor", cursorDoc,
 	)
 	if err != nil {
-		http.Error(rw, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+		writeRequestError(rw, err)
 		return
 	}
 
 	resp := s.m.Handle(ctx, msg)
-	if resp == nil {
-		http.Error(rw, "internal error", http.StatusInternalServerError)
+	if !s.checkResponse(ctx, rw, resp) {
 		return
 	}
 
-	if !resp.OK() {
-		s.writeJSONError(ctx, rw, resp)
+	if getFormat(ctx) == formatNDJSON {
+		s.streamDocuments(ctx, rw, resp, req.Database, req.Collection)
 		return
 	}
 
-	cursor := resp.Document().Get("cursor").(wirebson.AnyDocument)
-	firstBatch := must.NotFail(cursor.Decode()).Get("firstBatch").(wirebson.AnyArray)
-
-	b, err := marshalSingleJSON(firstBatch)
+	b, token, err := s.firstPage(ctx, resp, req.Database, req.Collection)
 	if err != nil {
-		http.Error(rw, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+		writeInternalError(rw, lazyerrors.Error(err))
 		return
 	}
 
 	res := api.AggregateResponseBody{
 		Documents: b,
+		Cursor:    token,
 	}
 
-	s.writeJSONResponse(ctx, rw, &res)
+	s.writeResponse(ctx, rw, &res)
 }
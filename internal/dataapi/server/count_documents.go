@@ -0,0 +1,57 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net/http"
+
+	"github.com/FerretDB/FerretDB/v2/internal/dataapi/api"
+)
+
+// CountDocuments implements [ServerInterface].
+func (s *Server) CountDocuments(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	s.logRequest(ctx, r)
+
+	var req api.CountRequestBody
+	if err := decodeJSONRequest(r, &req); err != nil {
+		writeRequestError(rw, err)
+		return
+	}
+
+	msg, err := prepareRequest(
+		"count", req.Collection,
+		"$db", req.Database,
+		"query", req.Filter,
+		"limit", req.Limit,
+		"skip", req.Skip,
+	)
+	if err != nil {
+		writeRequestError(rw, err)
+		return
+	}
+
+	resp := s.m.Handle(ctx, msg)
+	if !s.checkResponse(ctx, rw, resp) {
+		return
+	}
+
+	res := api.CountResponseBody{
+		Count: resp.Document().Get("n"),
+	}
+
+	s.writeResponse(ctx, rw, &res)
+}
@@ -0,0 +1,277 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+
+	"github.com/FerretDB/FerretDB/v2/internal/clientconn/conninfo"
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+	"github.com/FerretDB/FerretDB/v2/internal/util/scram"
+)
+
+// cursorTimeout is the duration after which unused cursors expire and are closed.
+const cursorTimeout = 10 * time.Minute
+
+// cursor represents a server cursor used for Data API pagination.
+type cursor struct {
+	id         int64
+	db         string
+	collection string
+	conv       *scram.Conv      // conversation of the user that created the cursor; nil without authentication
+	apiKey     *conninfo.APIKey // API key of the user that created the cursor; nil if not used
+	lastUsed   time.Time
+}
+
+// username returns the name of the user that created the cursor.
+func (c *cursor) username() string {
+	if c.apiKey != nil {
+		return c.apiKey.Username
+	}
+
+	return c.conv.Username()
+}
+
+// cursors stores server cursors by opaque tokens returned to HTTP clients.
+//
+// Tokens are random, so they can't be guessed,
+// and are bound to the user that created the cursor.
+type cursors struct {
+	timeout time.Duration
+
+	m      sync.Mutex
+	tokens map[string]*cursor
+}
+
+// newCursors creates a new cursor storage with the given expiration timeout.
+func newCursors(timeout time.Duration) *cursors {
+	return &cursors{
+		timeout: timeout,
+		tokens:  map[string]*cursor{},
+	}
+}
+
+// add stores the cursor and returns a new token for it.
+func (cs *cursors) add(c *cursor) string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b) // never returns an error
+
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	c.lastUsed = time.Now()
+
+	cs.m.Lock()
+	defer cs.m.Unlock()
+
+	cs.tokens[token] = c
+
+	return token
+}
+
+// get returns the cursor for the given token and the username, and updates its last used time.
+// It returns nil if the cursor does not exist, expired, or was created by another user.
+func (cs *cursors) get(token, username string) *cursor {
+	cs.m.Lock()
+	defer cs.m.Unlock()
+
+	c := cs.tokens[token]
+	if c == nil || c.username() != username || time.Since(c.lastUsed) > cs.timeout {
+		return nil
+	}
+
+	c.lastUsed = time.Now()
+
+	return c
+}
+
+// remove removes the cursor with the given token.
+func (cs *cursors) remove(token string) {
+	cs.m.Lock()
+	defer cs.m.Unlock()
+
+	delete(cs.tokens, token)
+}
+
+// expired removes and returns cursors that were not used for longer than the timeout.
+func (cs *cursors) expired() []*cursor {
+	cs.m.Lock()
+	defer cs.m.Unlock()
+
+	var res []*cursor
+
+	for token, c := range cs.tokens {
+		if time.Since(c.lastUsed) > cs.timeout {
+			res = append(res, c)
+			delete(cs.tokens, token)
+		}
+	}
+
+	return res
+}
+
+// firstPage returns the first batch of documents from the `find` or `aggregate` response
+// and the token for the next page, if there are more results.
+func (s *Server) firstPage(
+	ctx context.Context, resp *middleware.Response, db, collection string,
+) (json.RawMessage, *string, error) {
+	cursorDoc, err := resp.Document().Get("cursor").(wirebson.AnyDocument).Decode()
+	if err != nil {
+		return nil, nil, lazyerrors.Error(err)
+	}
+
+	b, err := marshalResult(ctx, cursorDoc.Get("firstBatch"))
+	if err != nil {
+		return nil, nil, lazyerrors.Error(err)
+	}
+
+	id, _ := cursorDoc.Get("id").(int64)
+	if id == 0 {
+		return b, nil, nil
+	}
+
+	ci := conninfo.Get(ctx)
+
+	token := s.cursors.add(&cursor{
+		id:         id,
+		db:         db,
+		collection: collection,
+		conv:       ci.Conv(),
+		apiKey:     ci.APIKey(),
+	})
+
+	return b, &token, nil
+}
+
+// killCursor closes the server cursor on behalf of the user that created it.
+func (s *Server) killCursor(ctx context.Context, c *cursor) {
+	ci := conninfo.New()
+	defer ci.Close()
+
+	if c.conv != nil {
+		ci.SetConv(c.conv)
+	}
+
+	if c.apiKey != nil {
+		ci.SetAPIKey(c.apiKey)
+	}
+
+	msg := must.NotFail(prepareRequest(
+		"killCursors", c.collection,
+		"cursors", wirebson.MustArray(c.id),
+		"$db", c.db,
+	))
+
+	if resp := s.m.Handle(conninfo.Ctx(ctx, ci), msg); resp == nil || !resp.OK() {
+		s.l.WarnContext(ctx, "Failed to close expired cursor", slog.Int64("id", c.id))
+		return
+	}
+
+	s.l.DebugContext(ctx, "Expired cursor closed", slog.Int64("id", c.id))
+}
+
+// forEachBatch calls fn for each batch of documents from the cursor of the command response,
+// fetching the remaining batches with `getMore`.
+//
+// If fn returns an error, the cursor is closed and that error is returned.
+func (s *Server) forEachBatch(
+	ctx context.Context, resp *middleware.Response, db, collection string, fn func(*wirebson.Array) error,
+) error {
+	cursorDoc, err := resp.Document().Get("cursor").(wirebson.AnyDocument).Decode()
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	for {
+		batch, ok := cursorDoc.Get("firstBatch").(wirebson.AnyArray)
+		if !ok {
+			if batch, ok = cursorDoc.Get("nextBatch").(wirebson.AnyArray); !ok {
+				return lazyerrors.New("cursor has no batch")
+			}
+		}
+
+		var arr *wirebson.Array
+		if arr, err = batch.Decode(); err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		id, _ := cursorDoc.Get("id").(int64)
+
+		if err = fn(arr); err != nil {
+			if id != 0 {
+				msg := must.NotFail(prepareRequest(
+					"killCursors", collection,
+					"cursors", wirebson.MustArray(id),
+					"$db", db,
+				))
+
+				// the client might be gone already, but the cursor should be closed anyway
+				_ = s.m.Handle(context.WithoutCancel(ctx), msg)
+			}
+
+			return err
+		}
+
+		if id == 0 {
+			return nil
+		}
+
+		msg := must.NotFail(prepareRequest(
+			"getMore", id,
+			"collection", collection,
+			"$db", db,
+		))
+
+		if resp = s.m.Handle(ctx, msg); resp == nil || !resp.OK() {
+			return lazyerrors.Errorf("getMore failed for cursor %d", id)
+		}
+
+		if cursorDoc, err = resp.Document().Get("cursor").(wirebson.AnyDocument).Decode(); err != nil {
+			return lazyerrors.Error(err)
+		}
+	}
+}
+
+// allBatches returns all documents from the cursor of the command response, fetching the remaining batches.
+// It is used for commands that return small results without pagination, like `listIndexes`.
+func (s *Server) allBatches(
+	ctx context.Context, resp *middleware.Response, db, collection string,
+) (json.RawMessage, error) {
+	res := wirebson.MakeArray(0)
+
+	err := s.forEachBatch(ctx, resp, db, collection, func(arr *wirebson.Array) error {
+		for v := range arr.Values() {
+			if err := res.Add(v); err != nil {
+				return lazyerrors.Error(err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return marshalResult(ctx, res)
+}
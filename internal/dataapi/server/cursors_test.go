@@ -0,0 +1,77 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FerretDB/FerretDB/v2/internal/clientconn/conninfo"
+)
+
+func TestCursors(t *testing.T) {
+	t.Parallel()
+
+	cs := newCursors(time.Hour)
+
+	c := &cursor{id: 42, db: "test", collection: "values"}
+	token := cs.add(c)
+	assert.NotEmpty(t, token)
+	assert.NotEqual(t, token, cs.add(&cursor{id: 43}), "tokens should be unique")
+
+	assert.Nil(t, cs.get("unknown", ""))
+	assert.Nil(t, cs.get(token, "user"), "cursor created without authentication should not be available for user")
+
+	actual := cs.get(token, "")
+	require.NotNil(t, actual)
+	assert.Equal(t, int64(42), actual.id)
+
+	assert.Empty(t, cs.expired())
+
+	cs.remove(token)
+	assert.Nil(t, cs.get(token, ""))
+}
+
+func TestCursorsAPIKey(t *testing.T) {
+	t.Parallel()
+
+	cs := newCursors(time.Hour)
+
+	token := cs.add(&cursor{id: 42, apiKey: &conninfo.APIKey{ID: "id", Username: "user"}})
+
+	assert.Nil(t, cs.get(token, ""))
+	assert.Nil(t, cs.get(token, "other"))
+	assert.NotNil(t, cs.get(token, "user"))
+}
+
+func TestCursorsExpired(t *testing.T) {
+	t.Parallel()
+
+	cs := newCursors(time.Hour)
+
+	token := cs.add(&cursor{id: 42})
+	cs.tokens[token].lastUsed = time.Now().Add(-2 * time.Hour)
+
+	assert.Nil(t, cs.get(token, ""), "expired cursor should not be returned")
+
+	expired := cs.expired()
+	require.Len(t, expired, 1)
+	assert.Equal(t, int64(42), expired[0].id)
+
+	assert.Empty(t, cs.expired())
+}
@@ -15,29 +15,22 @@
 package server
 
 import (
-	"fmt"
-	"log/slog"
 	"net/http"
-	"net/http/httputil"
 
-	"github.com/AlekSi/lazyerrors"
 	"github.com/FerretDB/wire/wirebson"
 
 	"github.com/FerretDB/FerretDB/v2/internal/dataapi/api"
-	"github.com/FerretDB/FerretDB/v2/internal/util/must"
 )
 
 // DeleteMany implements [ServerInterface].
 func (s *Server) DeleteMany(rw http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	if s.l.Enabled(ctx, slog.LevelDebug) {
-		s.l.DebugContext(ctx, fmt.Sprintf("Request:\n%s", must.NotFail(httputil.DumpRequest(r, true))))
-	}
+	s.logRequest(ctx, r)
 
 	var req api.DeleteRequestBody
 	if err := decodeJSONRequest(r, &req); err != nil {
-		http.Error(rw, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+		writeRequestError(rw, err)
 		return
 	}
 
@@ -46,7 +39,7 @@ func (s *Server) DeleteMany(rw http.ResponseWriter, r *http.Request) {
 		"limit", float64(0),
 	)
 	if err != nil {
-		http.Error(rw, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+		writeRequestError(rw, err)
 		return
 	}
 
@@ -56,18 +49,12 @@ func (s *Server) DeleteMany(rw http.ResponseWriter, r *http.Request) {
 		"deletes", wirebson.MustArray(deleteDoc),
 	)
 	if err != nil {
-		http.Error(rw, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+		writeRequestError(rw, err)
 		return
 	}
 
 	resp := s.m.Handle(ctx, msg)
-	if resp == nil {
-		http.Error(rw, "internal error", http.StatusInternalServerError)
-		return
-	}
-
-	if !resp.OK() {
-		s.writeJSONError(ctx, rw, resp)
+	if !s.checkResponse(ctx, rw, resp) {
 		return
 	}
 
@@ -75,5 +62,5 @@ func (s *Server) DeleteMany(rw http.ResponseWriter, r *http.Request) {
 		DeletedCount: resp.Document().Get("n"),
 	}
 
-	s.writeJSONResponse(ctx, rw, &res)
+	s.writeResponse(ctx, rw, &res)
 }
@@ -0,0 +1,64 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net/http"
+
+	"github.com/AlekSi/lazyerrors"
+
+	"github.com/FerretDB/FerretDB/v2/internal/dataapi/api"
+)
+
+// Distinct implements [ServerInterface].
+func (s *Server) Distinct(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	s.logRequest(ctx, r)
+
+	var req api.DistinctRequestBody
+	if err := decodeJSONRequest(r, &req); err != nil {
+		writeRequestError(rw, err)
+		return
+	}
+
+	msg, err := prepareRequest(
+		"distinct", req.Collection,
+		"$db", req.Database,
+		"key", req.Key,
+		"query", req.Filter,
+	)
+	if err != nil {
+		writeRequestError(rw, err)
+		return
+	}
+
+	resp := s.m.Handle(ctx, msg)
+	if !s.checkResponse(ctx, rw, resp) {
+		return
+	}
+
+	b, err := marshalResult(ctx, resp.Document().Get("values"))
+	if err != nil {
+		writeInternalError(rw, lazyerrors.Error(err))
+		return
+	}
+
+	res := api.DistinctResponseBody{
+		Values: b,
+	}
+
+	s.writeResponse(ctx, rw, &res)
+}
@@ -0,0 +1,64 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/FerretDB/FerretDB/v2/internal/dataapi/api"
+)
+
+// DropIndexes implements [ServerInterface].
+func (s *Server) DropIndexes(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	s.logRequest(ctx, r)
+
+	var req api.DropIndexesRequestBody
+	if err := decodeJSONRequest(r, &req); err != nil {
+		writeRequestError(rw, err)
+		return
+	}
+
+	// index name or "*" is a string that can't be unmarshaled as Extended JSON document or array
+	var index any = req.Index
+
+	var name string
+	if json.Unmarshal(req.Index, &name) == nil {
+		index = name
+	}
+
+	msg, err := prepareRequest(
+		"dropIndexes", req.Collection,
+		"$db", req.Database,
+		"index", index,
+	)
+	if err != nil {
+		writeRequestError(rw, err)
+		return
+	}
+
+	resp := s.m.Handle(ctx, msg)
+	if !s.checkResponse(ctx, rw, resp) {
+		return
+	}
+
+	res := api.DropIndexesResponseBody{
+		NIndexesWas: resp.Document().Get("nIndexesWas"),
+	}
+
+	s.writeResponse(ctx, rw, &res)
+}
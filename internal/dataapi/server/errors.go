@@ -15,10 +15,17 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+
 	"github.com/FerretDB/FerretDB/v2/internal/dataapi/api"
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
 )
 
 var (
@@ -34,11 +41,81 @@
 			"(either email+password, api-key, or jwt) in the request header or body",
 		ErrorCode: "MissingParameter",
 	}
+
+	// SCRAM conversation failed on the client side.
+	errorAuthenticationFailed = api.Error{
+		Error:     "authentication failed",
+		ErrorCode: "AuthenticationFailed",
+	}
+
+	// The cursor token is unknown, expired, or was created by another user.
+	errorCursorNotFound = api.Error{
+		Error:     "cursor not found or expired",
+		ErrorCode: "CursorNotFound",
+	}
+
+	// The handler failed with unrecoverable error; the request may be retried.
+	errorServiceUnavailable = api.Error{
+		Error:     "service unavailable",
+		ErrorCode: "ServiceUnavailable",
+	}
+
+	// The action is not defined.
+	errorNoMatchingRule = api.Error{
+		Error:     "no matching rule found",
+		ErrorCode: "NoMatchingRuleFound",
+	}
+
+	// The action is defined, but with another HTTP method.
+	errorMethodNotAllowed = api.Error{
+		Error:     "method not allowed",
+		ErrorCode: "MethodNotAllowed",
+	}
+
+	// None of the media types in the Accept header is supported.
+	errorNotAcceptable = api.Error{
+		Error:     "none of the accepted media types is supported",
+		ErrorCode: "NotAcceptable",
+	}
 )
 
+// httpStatus returns HTTP status code for the given error code.
+//
+// Errors caused by invalid arguments are mapped to 400 Bad Request,
+// so only unexpected errors result in 500 Internal Server Error.
+func httpStatus(code mongoerrors.Code) int {
+	switch code {
+	case mongoerrors.ErrAuthenticationFailed, mongoerrors.ErrUserNotFound, mongoerrors.ErrMechanismUnavailable:
+		return http.StatusUnauthorized
+
+	case mongoerrors.ErrUnauthorized, mongoerrors.ErrIllegalOperation:
+		return http.StatusForbidden
+
+	case mongoerrors.ErrNamespaceNotFound, mongoerrors.ErrIndexNotFound, mongoerrors.ErrCursorNotFound:
+		return http.StatusNotFound
+
+	case mongoerrors.ErrDuplicateKey, mongoerrors.ErrNamespaceExists,
+		mongoerrors.ErrIndexAlreadyExists, mongoerrors.ErrIndexOptionsConflict, mongoerrors.ErrIndexKeySpecsConflict,
+		mongoerrors.ErrCursorInUse:
+		return http.StatusConflict
+
+	case mongoerrors.ErrBsonObjectTooLarge,
+		mongoerrors.ErrDocumentAfterUpdateLargerThanMaxSize, mongoerrors.ErrDocumentToUpsertLargerThanMaxSize:
+		return http.StatusRequestEntityTooLarge
+
+	case mongoerrors.ErrNotWritablePrimary, mongoerrors.ErrMaxTimeMSExpired:
+		return http.StatusServiceUnavailable
+
+	case mongoerrors.ErrUnset, mongoerrors.ErrInternalError:
+		return http.StatusInternalServerError
+
+	default:
+		return http.StatusBadRequest
+	}
+}
+
 // writeError encodes [api.Error] into JSON and writes it to w
 // with provided HTTP status code.
-// TODO https://github.com/FerretDB/FerretDB/issues/4965
 func writeError(rw http.ResponseWriter, err api.Error, code int) {
 	rw.Header().Set("Content-Type", "application/json")
 
@@ -46,3 +123,123 @@ func writeError(rw http.ResponseWriter, err api.Error, code int) {
 
 	_ = json.NewEncoder(rw).Encode(err)
 }
+
+// writeRequestError writes an error for the HTTP request that could not be decoded
+// or converted to the command.
+func writeRequestError(rw http.ResponseWriter, err error) {
+	code := http.StatusBadRequest
+
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		code = http.StatusRequestEntityTooLarge
+	}
+
+	writeError(rw, api.Error{
+		Error:     err.Error(),
+		ErrorCode: "InvalidParameter",
+	}, code)
+}
+
+// writeInternalError writes an error for the unexpected failure.
+func writeInternalError(rw http.ResponseWriter, err error) {
+	writeError(rw, api.Error{
+		Error:     err.Error(),
+		ErrorCode: "InternalError",
+	}, http.StatusInternalServerError)
+}
+
+// checkResponse returns true if the command succeeded.
+// Otherwise, it writes the error and returns false.
+//
+// A command response with write errors is also considered a failure;
+// the first write error determines the HTTP status code.
+func (s *Server) checkResponse(ctx context.Context, rw http.ResponseWriter, resp *middleware.Response) bool {
+	if resp == nil {
+		writeError(rw, errorServiceUnavailable, http.StatusServiceUnavailable)
+		return false
+	}
+
+	doc := resp.Document()
+
+	if !resp.OK() {
+		code, _ := doc.Get("code").(int32)
+		errmsg, _ := doc.Get("errmsg").(string)
+		codeName, _ := doc.Get("codeName").(string)
+
+		s.writeJSONError(ctx, rw, api.Error{
+			Code:      int(code),
+			CodeName:  codeName,
+			Errmsg:    errmsg,
+			Error:     errmsg,
+			ErrorCode: codeName,
+		})
+
+		return false
+	}
+
+	writeErrors, ok := doc.Get("writeErrors").(wirebson.AnyArray)
+	if !ok {
+		return true
+	}
+
+	res, err := writeErrorsResponse(writeErrors)
+	if err != nil {
+		writeInternalError(rw, lazyerrors.Error(err))
+		return false
+	}
+
+	if res == nil {
+		return true
+	}
+
+	s.writeJSONError(ctx, rw, *res)
+
+	return false
+}
+
+// writeErrorsResponse returns the error response for write errors.
+// It returns nil if there are no write errors.
+func writeErrorsResponse(writeErrors wirebson.AnyArray) (*api.Error, error) {
+	arr, err := writeErrors.Decode()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	if arr.Len() == 0 {
+		return nil, nil
+	}
+
+	first, err := arr.Get(0).(wirebson.AnyDocument).Decode()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	b, err := marshalSingleJSON(arr)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	code, _ := first.Get("code").(int32)
+	errmsg, _ := first.Get("errmsg").(string)
+	codeName := mongoerrors.Code(code).String()
+
+	return &api.Error{
+		Code:        int(code),
+		CodeName:    codeName,
+		Errmsg:      errmsg,
+		Error:       errmsg,
+		ErrorCode:   codeName,
+		WriteErrors: &b,
+	}, nil
+}
+
+// writeJSONError writes the error with HTTP status code based on its error code.
+//
+// Errors are always written as JSON regardless of the negotiated response format.
+func (s *Server) writeJSONError(ctx context.Context, rw http.ResponseWriter, res api.Error) {
+	rw.Header().Set("Content-Type", "application/json")
+
+	rw.WriteHeader(httpStatus(mongoerrors.Code(res.Code)))
+
+	s.writeJSONResponse(ctx, rw, &res)
+}
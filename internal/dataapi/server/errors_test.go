@@ -0,0 +1,93 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+)
+
+func TestHTTPStatus(t *testing.T) {
+	t.Parallel()
+
+	for code, expected := range map[mongoerrors.Code]int{
+		mongoerrors.ErrAuthenticationFailed: http.StatusUnauthorized,
+		mongoerrors.ErrUnauthorized:         http.StatusForbidden,
+		mongoerrors.ErrNamespaceNotFound:    http.StatusNotFound,
+		mongoerrors.ErrCursorNotFound:       http.StatusNotFound,
+		mongoerrors.ErrDuplicateKey:         http.StatusConflict,
+		mongoerrors.ErrBsonObjectTooLarge:   http.StatusRequestEntityTooLarge,
+		mongoerrors.ErrNotWritablePrimary:   http.StatusServiceUnavailable,
+		mongoerrors.ErrInternalError:        http.StatusInternalServerError,
+		mongoerrors.ErrUnset:                http.StatusInternalServerError,
+		mongoerrors.ErrBadValue:             http.StatusBadRequest,
+		mongoerrors.ErrTypeMismatch:         http.StatusBadRequest,
+	} {
+		assert.Equal(t, expected, httpStatus(code), "%s", code)
+	}
+}
+
+func TestWriteErrorsResponse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Empty", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := writeErrorsResponse(wirebson.MakeArray(0))
+		require.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("DuplicateKey", func(t *testing.T) {
+		t.Parallel()
+
+		writeErrors := wirebson.MustArray(
+			wirebson.MustDocument(
+				"index", int32(0),
+				"code", int32(mongoerrors.ErrDuplicateKey),
+				"errmsg", "duplicate key",
+			),
+		)
+
+		res, err := writeErrorsResponse(writeErrors)
+		require.NoError(t, err)
+		require.NotNil(t, res)
+
+		assert.Equal(t, int(mongoerrors.ErrDuplicateKey), res.Code)
+		assert.Equal(t, "DuplicateKey", res.CodeName)
+		assert.Equal(t, "duplicate key", res.Errmsg)
+		assert.Equal(t, "DuplicateKey", res.ErrorCode)
+		require.NotNil(t, res.WriteErrors)
+		assert.JSONEq(t, `[{"index":0,"code":11000,"errmsg":"duplicate key"}]`, string(*res.WriteErrors))
+	})
+}
+
+func TestWriteRequestError(t *testing.T) {
+	t.Parallel()
+
+	rw := httptest.NewRecorder()
+	writeRequestError(rw, &http.MaxBytesError{Limit: 1})
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rw.Code)
+	assert.Equal(t, "application/json", rw.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"error":"http: request body too large","error_code":"InvalidParameter"}`, rw.Body.String())
+}
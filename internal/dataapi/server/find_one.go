@@ -15,10 +15,7 @@
 package server
 
 import (
-	"fmt"
-	"log/slog"
 	"net/http"
-	"net/http/httputil"
 
 	"github.com/AlekSi/lazyerrors"
 	"github.com/FerretDB/wire/wirebson"
@@ -31,13 +28,11 @@
 func (s *Server) FindOne(rw http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	if s.l.Enabled(ctx, slog.LevelDebug) {
-		s.l.DebugContext(ctx, fmt.Sprintf("Request:\n%s", must.NotFail(httputil.DumpRequest(r, true))))
-	}
+	s.logRequest(ctx, r)
 
 	var req api.FindOneRequestBody
 	if err := decodeJSONRequest(r, &req); err != nil {
-		http.Error(rw, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+		writeRequestError(rw, err)
 		return
 	}
 
@@ -49,18 +44,12 @@ func (s *Server) FindOne(rw http.ResponseWriter, r *http.Request) {
 		"limit", float64(1),
 	)
 	if err != nil {
-		http.Error(rw, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+		writeRequestError(rw, err)
 		return
 	}
 
 	resp := s.m.Handle(ctx, msg)
-	if resp == nil {
-		http.Error(rw, "internal error", http.StatusInternalServerError)
-		return
-	}
-
-	if !resp.OK() {
-		s.writeJSONError(ctx, rw, resp)
+	if !s.checkResponse(ctx, rw, resp) {
 		return
 	}
 
@@ -71,19 +60,19 @@ func (s *Server) FindOne(rw http.ResponseWriter, r *http.Request) {
 
 	docs := must.NotFail(firstBatch.Decode())
 	if docs.Len() == 0 {
-		s.writeJSONResponse(ctx, rw, &res)
+		s.writeResponse(ctx, rw, &res)
 		return
 	}
 
 	doc := docs.Get(0).(wirebson.AnyDocument)
 
-	b, err := marshalSingleJSON(doc)
+	b, err := marshalResult(ctx, doc)
 	if err != nil {
-		http.Error(rw, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+		writeInternalError(rw, lazyerrors.Error(err))
 		return
 	}
 
 	res.Document = &b
 
-	s.writeJSONResponse(ctx, rw, &res)
+	s.writeResponse(ctx, rw, &res)
 }
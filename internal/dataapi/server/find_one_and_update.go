@@ -0,0 +1,76 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net/http"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+
+	"github.com/FerretDB/FerretDB/v2/internal/dataapi/api"
+)
+
+// FindOneAndUpdate implements [ServerInterface].
+func (s *Server) FindOneAndUpdate(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	s.logRequest(ctx, r)
+
+	var req api.FindOneAndUpdateRequestBody
+	if err := decodeJSONRequest(r, &req); err != nil {
+		writeRequestError(rw, err)
+		return
+	}
+
+	msg, err := prepareRequest(
+		"findAndModify", req.Collection,
+		"$db", req.Database,
+		"query", req.Filter,
+		"update", req.Update,
+		"fields", req.Projection,
+		"sort", req.Sort,
+		"upsert", req.Upsert,
+		"new", req.ReturnNewDocument,
+	)
+	if err != nil {
+		writeRequestError(rw, err)
+		return
+	}
+
+	resp := s.m.Handle(ctx, msg)
+	if !s.checkResponse(ctx, rw, resp) {
+		return
+	}
+
+	var res api.FindOneResponseBody
+
+	doc, ok := resp.Document().Get("value").(wirebson.AnyDocument)
+	if !ok {
+		// null value means that no documents matched
+		s.writeResponse(ctx, rw, &res)
+		return
+	}
+
+	b, err := marshalResult(ctx, doc)
+	if err != nil {
+		writeInternalError(rw, lazyerrors.Error(err))
+		return
+	}
+
+	res.Document = &b
+
+	s.writeResponse(ctx, rw, &res)
+}
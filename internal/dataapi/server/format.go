@@ -0,0 +1,328 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+
+	"github.com/FerretDB/FerretDB/v2/internal/dataapi/api"
+)
+
+// format represents the response format negotiated with the `Accept` header.
+type format int
+
+const (
+	formatJSON           format = iota // application/json
+	formatEJSONCanonical               // application/ejson; mode=canonical
+	formatEJSONRelaxed                 // application/ejson; mode=relaxed
+	formatNDJSON                       // application/x-ndjson
+	formatBSON                         // application/bson
+)
+
+// contentType returns the value of the `Content-Type` header for the format.
+func (f format) contentType() string {
+	switch f {
+	case formatJSON:
+		return "application/json"
+	case formatEJSONCanonical:
+		return "application/ejson; mode=canonical"
+	case formatEJSONRelaxed:
+		return "application/ejson; mode=relaxed"
+	case formatNDJSON:
+		return "application/x-ndjson"
+	case formatBSON:
+		return "application/bson"
+	default:
+		panic("unknown format " + strconv.Itoa(int(f)))
+	}
+}
+
+// mediaFormat returns the format for the given media type and its parameters.
+func mediaFormat(mediaType string, params map[string]string) (format, bool) {
+	switch mediaType {
+	case "*/*", "application/*", "application/json":
+		return formatJSON, true
+
+	case "application/ejson":
+		switch params["mode"] {
+		case "", "canonical":
+			return formatEJSONCanonical, true
+		case "relaxed":
+			return formatEJSONRelaxed, true
+		default:
+			return 0, false
+		}
+
+	case "application/x-ndjson":
+		return formatNDJSON, true
+
+	case "application/bson":
+		return formatBSON, true
+
+	default:
+		return 0, false
+	}
+}
+
+// negotiateFormat returns the response format for the given `Accept` header value.
+//
+// Media types are tried in order of their quality values;
+// media types with zero quality value, invalid or unknown are skipped.
+// Empty header selects JSON.
+// It returns false if none of the media types is acceptable.
+func negotiateFormat(accept string) (format, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return formatJSON, true
+	}
+
+	type candidate struct {
+		f format
+		q float64
+	}
+
+	var candidates []candidate
+
+	for part := range strings.SplitSeq(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(part)
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+
+		if q <= 0 {
+			continue
+		}
+
+		f, ok := mediaFormat(mediaType, params)
+		if !ok {
+			continue
+		}
+
+		candidates = append(candidates, candidate{f: f, q: q})
+	}
+
+	if len(candidates) == 0 {
+		return 0, false
+	}
+
+	// stable sort keeps the client's order for equal quality values
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		return cmp.Compare(b.q, a.q)
+	})
+
+	return candidates[0].f, true
+}
+
+// contextKey is a named unexported type for the safe use of [context.WithValue].
+type contextKey int
+
+// Context keys.
+const (
+	formatKey     contextKey = iota // for [formatCtx]/[getFormat]
+	bsonValuesKey                   // for [formatCtx]/[marshalResult]/[bsonResponse]
+)
+
+// formatCtx returns a derived context with the given response format.
+//
+// For BSON, it also stores values for [marshalResult] and [bsonResponse].
+func formatCtx(ctx context.Context, f format) context.Context {
+	ctx = context.WithValue(ctx, formatKey, f)
+
+	if f == formatBSON {
+		ctx = context.WithValue(ctx, bsonValuesKey, new(bsonValues))
+	}
+
+	return ctx
+}
+
+// getFormat returns the response format stored in ctx.
+// It returns JSON if the format is not set.
+func getFormat(ctx context.Context) format {
+	f, _ := ctx.Value(formatKey).(format)
+	return f
+}
+
+// FormatMiddleware negotiates the response format with the `Accept` header,
+// and calls the next handler with the format stored in the request context.
+//
+// If none of the requested media types is supported, it replies with 406 Not Acceptable.
+func (s *Server) FormatMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		f, ok := negotiateFormat(r.Header.Get("Accept"))
+		if !ok {
+			writeError(rw, errorNotAcceptable, http.StatusNotAcceptable)
+			return
+		}
+
+		next.ServeHTTP(rw, r.WithContext(formatCtx(r.Context(), f)))
+	})
+}
+
+// marshalResult converts wirebson value to the JSON representation
+// of the response format stored in ctx.
+//
+// For BSON, the value is stored in ctx as is, and a placeholder is returned;
+// see [bsonResponse].
+// NDJSON uses relaxed Extended JSON, like mongoexport does.
+func marshalResult(ctx context.Context, v any) (json.RawMessage, error) {
+	switch f := getFormat(ctx); f {
+	case formatJSON:
+		return marshalSingleJSON(v)
+	case formatBSON:
+		return ctx.Value(bsonValuesKey).(*bsonValues).add(v), nil
+	case formatEJSONCanonical:
+		return marshalSingleExtJSON(v, true)
+	case formatEJSONRelaxed, formatNDJSON:
+		return marshalSingleExtJSON(v, false)
+	default:
+		panic("unknown format " + strconv.Itoa(int(f)))
+	}
+}
+
+// bsonValues contains wirebson values of the BSON response.
+//
+// Placeholders returned by [marshalResult] are indexes in that slice.
+type bsonValues struct {
+	values []any
+}
+
+// add stores the given value and returns its placeholder.
+func (bv *bsonValues) add(v any) json.RawMessage {
+	bv.values = append(bv.values, v)
+	return json.RawMessage(strconv.Itoa(len(bv.values) - 1))
+}
+
+// get returns the value for the given placeholder.
+func (bv *bsonValues) get(placeholder json.RawMessage) (any, error) {
+	i, err := strconv.Atoi(string(placeholder))
+	if err != nil || i < 0 || i >= len(bv.values) {
+		return nil, lazyerrors.Errorf("unexpected placeholder %q", placeholder)
+	}
+
+	return bv.values[i], nil
+}
+
+// bsonResponse converts the response to BSON document,
+// replacing placeholders returned by [marshalResult] with wirebson values stored in ctx.
+//
+// Fields are added in the order of declaration, with the names and omitempty options of their JSON tags.
+func bsonResponse(ctx context.Context, res api.Response) (*wirebson.Document, error) {
+	bv, _ := ctx.Value(bsonValuesKey).(*bsonValues)
+	if bv == nil {
+		bv = new(bsonValues)
+	}
+
+	rv := reflect.ValueOf(res).Elem()
+	rt := rv.Type()
+
+	doc := wirebson.MakeDocument(rt.NumField())
+
+	for i := range rt.NumField() {
+		name, opts, _ := strings.Cut(rt.Field(i).Tag.Get("json"), ",")
+
+		fv := rv.Field(i)
+		if opts == "omitempty" && fv.IsZero() {
+			continue
+		}
+
+		v, err := bsonValue(bv, fv.Interface())
+		if err != nil {
+			return nil, lazyerrors.Errorf("%s: %w", name, err)
+		}
+
+		if err = doc.Add(name, v); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+	}
+
+	return doc, nil
+}
+
+// bsonValue converts the value of the response field to wirebson value.
+func bsonValue(bv *bsonValues, v any) (any, error) {
+	switch v := v.(type) {
+	case nil:
+		return wirebson.Null, nil
+
+	case json.RawMessage:
+		return bv.get(v)
+
+	case []any:
+		arr := wirebson.MakeArray(len(v))
+
+		for _, e := range v {
+			ev, err := bsonValue(bv, e)
+			if err != nil {
+				return nil, lazyerrors.Error(err)
+			}
+
+			if err = arr.Add(ev); err != nil {
+				return nil, lazyerrors.Error(err)
+			}
+		}
+
+		return arr, nil
+
+	case *json.RawMessage:
+		if v == nil {
+			return wirebson.Null, nil
+		}
+
+		return bsonValue(bv, *v)
+
+	case *[]any:
+		if v == nil {
+			return wirebson.Null, nil
+		}
+
+		return bsonValue(bv, *v)
+
+	case *any:
+		if v == nil {
+			return wirebson.Null, nil
+		}
+
+		return bsonValue(bv, *v)
+
+	case *string:
+		if v == nil {
+			return wirebson.Null, nil
+		}
+
+		return *v, nil
+
+	default:
+		// wirebson scalars; other types are rejected by wirebson
+		return v, nil
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FerretDB/FerretDB/v2/internal/dataapi/api"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+)
+
+func TestNegotiateFormat(t *testing.T) {
+	t.Parallel()
+
+	for accept, expected := range map[string]format{
+		"":                                  formatJSON,
+		"*/*":                               formatJSON,
+		"application/json":                  formatJSON,
+		"application/ejson":                 formatEJSONCanonical,
+		"application/ejson; mode=canonical": formatEJSONCanonical,
+		"application/ejson;mode=relaxed":    formatEJSONRelaxed,
+		"application/x-ndjson":              formatNDJSON,
+		"application/bson":                  formatBSON,
+		"text/html, application/bson":       formatBSON,
+		"application/json;q=0.5, application/bson": formatBSON,
+		"application/bson;q=0, application/json":   formatJSON,
+		"application/x-ndjson, application/bson":   formatNDJSON,
+	} {
+		actual, ok := negotiateFormat(accept)
+		require.True(t, ok, "%q", accept)
+		assert.Equal(t, expected, actual, "%q", accept)
+	}
+
+	for _, accept := range []string{
+		"text/html",
+		"application/ejson; mode=strict",
+		"application/json;q=0",
+		"application/json;q=foo",
+	} {
+		_, ok := negotiateFormat(accept)
+		assert.False(t, ok, "%q", accept)
+	}
+}
+
+func TestMarshalResult(t *testing.T) {
+	t.Parallel()
+
+	doc := wirebson.MustDocument(
+		"i", int32(1),
+		"l", int64(2),
+		"d", 3.5,
+	)
+
+	for f, expected := range map[format]string{
+		formatJSON:           `{"i":1,"l":2,"d":3.5}`,
+		formatEJSONCanonical: `{"i":{"$numberInt":"1"},"l":{"$numberLong":"2"},"d":{"$numberDouble":"3.5"}}`,
+		formatEJSONRelaxed:   `{"i":1,"l":2,"d":3.5}`,
+		formatNDJSON:         `{"i":1,"l":2,"d":3.5}`,
+	} {
+		actual, err := marshalResult(formatCtx(t.Context(), f), wirebson.MustArray(doc))
+		require.NoError(t, err)
+		assert.JSONEq(t, `[`+expected+`]`, string(actual), "%s", f.contentType())
+	}
+}
+
+func TestBSONResponse(t *testing.T) {
+	t.Parallel()
+
+	ctx := formatCtx(t.Context(), formatBSON)
+
+	docs, err := marshalResult(ctx, wirebson.MustArray(wirebson.MustDocument("l", int64(2))))
+	require.NoError(t, err)
+
+	id, err := marshalResult(ctx, wirebson.ObjectID{1})
+	require.NoError(t, err)
+
+	cursor := "token"
+
+	for name, tc := range map[string]struct {
+		res      api.Response
+		expected *wirebson.Document
+	}{
+		"Documents": {
+			res: &api.FindManyResponseBody{Cursor: &cursor, Documents: &docs},
+			expected: wirebson.MustDocument(
+				"cursor", "token",
+				"documents", wirebson.MustArray(wirebson.MustDocument("l", int64(2))),
+			),
+		},
+		"OmitEmpty": {
+			res: &api.FindManyResponseBody{Documents: &docs},
+			expected: wirebson.MustDocument(
+				"documents", wirebson.MustArray(wirebson.MustDocument("l", int64(2))),
+			),
+		},
+		"Null": {
+			res:      &api.FindOneResponseBody{},
+			expected: wirebson.MustDocument("document", wirebson.Null),
+		},
+		"Scalar": {
+			res:      &api.CountResponseBody{Count: int32(1)},
+			expected: wirebson.MustDocument("count", int32(1)),
+		},
+		"IDs": {
+			res:      &api.InsertManyResponseBody{InsertedIds: &[]any{id}},
+			expected: wirebson.MustDocument("insertedIds", wirebson.MustArray(wirebson.ObjectID{1})),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := bsonResponse(ctx, tc.res)
+			require.NoError(t, err)
+
+			expected := must.NotFail(tc.expected.Encode())
+			assert.Equal(t, expected, must.NotFail(actual.Encode()), "%s", actual.LogMessage())
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := bsonResponse(ctx, &api.CountResponseBody{Count: struct{}{}})
+		require.Error(t, err)
+
+		_, err = bsonResponse(ctx, &api.DistinctResponseBody{Values: json.RawMessage(`42`)})
+		require.Error(t, err)
+	})
+}
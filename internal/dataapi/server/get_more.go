@@ -0,0 +1,93 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net/http"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+
+	"github.com/FerretDB/FerretDB/v2/internal/clientconn/conninfo"
+	"github.com/FerretDB/FerretDB/v2/internal/dataapi/api"
+)
+
+// GetMore implements [ServerInterface].
+func (s *Server) GetMore(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	s.logRequest(ctx, r)
+
+	var req api.GetMoreRequestBody
+	if err := decodeJSONRequest(r, &req); err != nil {
+		writeRequestError(rw, err)
+		return
+	}
+
+	c := s.cursors.get(req.Cursor, conninfo.Get(ctx).Username())
+	if c == nil {
+		writeError(rw, errorCursorNotFound, http.StatusNotFound)
+		return
+	}
+
+	msg, err := prepareRequest(
+		"getMore", c.id,
+		"collection", c.collection,
+		"$db", c.db,
+		"batchSize", req.BatchSize,
+	)
+	if err != nil {
+		writeRequestError(rw, err)
+		return
+	}
+
+	resp := s.m.Handle(ctx, msg)
+	if !s.checkResponse(ctx, rw, resp) {
+		s.cursors.remove(req.Cursor)
+		return
+	}
+
+	if getFormat(ctx) == formatNDJSON {
+		// the rest of the results are streamed, so the cursor is not needed anymore
+		s.cursors.remove(req.Cursor)
+		s.streamDocuments(ctx, rw, resp, c.db, c.collection)
+
+		return
+	}
+
+	cursorDoc, err := resp.Document().Get("cursor").(wirebson.AnyDocument).Decode()
+	if err != nil {
+		writeInternalError(rw, lazyerrors.Error(err))
+		return
+	}
+
+	b, err := marshalResult(ctx, cursorDoc.Get("nextBatch"))
+	if err != nil {
+		writeInternalError(rw, lazyerrors.Error(err))
+		return
+	}
+
+	res := api.GetMoreResponseBody{
+		Documents: b,
+	}
+
+	if id, _ := cursorDoc.Get("id").(int64); id == 0 {
+		s.cursors.remove(req.Cursor)
+	} else {
+		res.Cursor = &req.Cursor
+	}
+
+	s.writeResponse(ctx, rw, &res)
+}
@@ -16,40 +16,35 @@
 
 import (
 	"fmt"
-	"log/slog"
 	"net/http"
-	"net/http/httputil"
 
 	"github.com/AlekSi/lazyerrors"
 	"github.com/FerretDB/wire/wirebson"
 
 	"github.com/FerretDB/FerretDB/v2/internal/dataapi/api"
-	"github.com/FerretDB/FerretDB/v2/internal/util/must"
 )
 
 // InsertMany implements [ServerInterface].
 func (s *Server) InsertMany(rw http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	if s.l.Enabled(ctx, slog.LevelDebug) {
-		s.l.DebugContext(ctx, fmt.Sprintf("Request:\n%s", must.NotFail(httputil.DumpRequest(r, true))))
-	}
+	s.logRequest(ctx, r)
 
 	var req api.InsertManyRequestBody
 	if err := decodeJSONRequest(r, &req); err != nil {
-		http.Error(rw, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+		writeRequestError(rw, err)
 		return
 	}
 
 	docsArr, err := unmarshalSingleJSON(&req.Documents)
 	if err != nil {
-		http.Error(rw, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+		writeRequestError(rw, err)
 		return
 	}
 
 	documents, err := docsArr.(wirebson.RawArray).Decode()
 	if err != nil {
-		http.Error(rw, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+		writeInternalError(rw, lazyerrors.Error(err))
 		return
 	}
 
@@ -58,7 +53,7 @@ func (s *Server) InsertMany(rw http.ResponseWriter, r *http.Request) {
 	for i, v := range documents.All() {
 		v, ok := v.(wirebson.AnyDocument)
 		if !ok {
-			http.Error(rw, fmt.Sprintf("document %d is not a valid BSON document", i), http.StatusBadRequest)
+			writeRequestError(rw, fmt.Errorf("document %d is not a valid BSON document", i))
 			return
 		}
 
@@ -66,20 +61,20 @@ func (s *Server) InsertMany(rw http.ResponseWriter, r *http.Request) {
 
 		doc, err = ensureID(v)
 		if err != nil {
-			http.Error(rw, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+			writeRequestError(rw, err)
 			return
 		}
 
 		var insertedId any
 
-		insertedId, err = wirebson.ToDriver(doc.Get("_id"))
+		insertedId, err = marshalResult(ctx, doc.Get("_id"))
 		if err != nil {
-			http.Error(rw, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+			writeInternalError(rw, lazyerrors.Error(err))
 			return
 		}
 
 		if err = documents.Replace(i, doc); err != nil {
-			http.Error(rw, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+			writeInternalError(rw, lazyerrors.Error(err))
 			return
 		}
 
@@ -92,18 +87,12 @@ func (s *Server) InsertMany(rw http.ResponseWriter, r *http.Request) {
 		"documents", documents,
 	)
 	if err != nil {
-		http.Error(rw, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+		writeRequestError(rw, err)
 		return
 	}
 
 	resp := s.m.Handle(ctx, msg)
-	if resp == nil {
-		http.Error(rw, "internal error", http.StatusInternalServerError)
-		return
-	}
-
-	if !resp.OK() {
-		s.writeJSONError(ctx, rw, resp)
+	if !s.checkResponse(ctx, rw, resp) {
 		return
 	}
 
@@ -111,5 +100,5 @@ func (s *Server) InsertMany(rw http.ResponseWriter, r *http.Request) {
 		InsertedIds: &insertedIds,
 	}
 
-	s.writeJSONResponse(ctx, rw, &res)
+	s.writeResponse(ctx, rw, &res)
 }
@@ -15,42 +15,36 @@
 package server
 
 import (
-	"fmt"
-	"log/slog"
 	"net/http"
-	"net/http/httputil"
 
 	"github.com/AlekSi/lazyerrors"
 	"github.com/FerretDB/wire/wirebson"
 	"go.mongodb.org/mongo-driver/v2/bson"
 
 	"github.com/FerretDB/FerretDB/v2/internal/dataapi/api"
-	"github.com/FerretDB/FerretDB/v2/internal/util/must"
 )
 
 // InsertOne implements [ServerInterface].
 func (s *Server) InsertOne(rw http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	if s.l.Enabled(ctx, slog.LevelDebug) {
-		s.l.DebugContext(ctx, fmt.Sprintf("Request:\n%s", must.NotFail(httputil.DumpRequest(r, true))))
-	}
+	s.logRequest(ctx, r)
 
 	var req api.InsertOneRequestBody
 	if err := decodeJSONRequest(r, &req); err != nil {
-		http.Error(rw, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+		writeRequestError(rw, err)
 		return
 	}
 
 	insert, err := unmarshalSingleJSON(&req.Document)
 	if err != nil {
-		http.Error(rw, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+		writeRequestError(rw, err)
 		return
 	}
 
 	insertDoc, ok := insert.(wirebson.AnyDocument)
 	if !ok {
-		http.Error(rw, lazyerrors.New("document must be a BSON document").Error(), http.StatusInternalServerError)
+		writeRequestError(rw, lazyerrors.New("document must be a BSON document"))
 		return
 	}
 
@@ -58,7 +52,7 @@ func (s *Server) InsertOne(rw http.ResponseWriter, r *http.Request) {
 
 	doc, err = ensureID(insertDoc)
 	if err != nil {
-		http.Error(rw, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+		writeRequestError(rw, err)
 		return
 	}
 
@@ -70,24 +64,19 @@ func (s *Server) InsertOne(rw http.ResponseWriter, r *http.Request) {
 		"documents", documents,
 	)
 	if err != nil {
-		http.Error(rw, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+		writeRequestError(rw, err)
 		return
 	}
 
 	resp := s.m.Handle(ctx, msg)
-	if resp == nil {
-		http.Error(rw, "internal error", http.StatusInternalServerError)
+	if !s.checkResponse(ctx, rw, resp) {
 		return
 	}
 
-	if !resp.OK() {
-		s.writeJSONError(ctx, rw, resp)
-		return
-	}
+	var insertedId any
 
-	insertedId, err := wirebson.ToDriver(doc.Get("_id"))
-	if err != nil {
-		http.Error(rw, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+	if insertedId, err = marshalResult(ctx, doc.Get("_id")); err != nil {
+		writeInternalError(rw, lazyerrors.Error(err))
 		return
 	}
 
@@ -95,7 +84,7 @@ func (s *Server) InsertOne(rw http.ResponseWriter, r *http.Request) {
 		InsertedId: &insertedId,
 	}
 
-	s.writeJSONResponse(ctx, rw, &res)
+	s.writeResponse(ctx, rw, &res)
 }
 
 // ensureID ensures that inserted document has an "_id" field.
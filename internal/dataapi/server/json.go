@@ -26,6 +26,23 @@
 
 // marshalSingleJSON converts wirebson value to the standard JSON representation.
 func marshalSingleJSON(v any) (json.RawMessage, error) {
+	bv, err := toDriverDeep(v)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	var buf bytes.Buffer
+
+	err = json.NewEncoder(&buf).Encode(bv)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return json.RawMessage(buf.Bytes()), nil
+}
+
+// toDriverDeep converts wirebson value to the driver's bson value, decoding raw composites.
+func toDriverDeep(v any) (any, error) {
 	var err error
 
 	switch vt := v.(type) {
@@ -48,14 +65,32 @@ func marshalSingleJSON(v any) (json.RawMessage, error) {
 		return nil, lazyerrors.Error(err)
 	}
 
-	var buf bytes.Buffer
+	return bv, nil
+}
 
-	err = json.NewEncoder(&buf).Encode(bv)
+// marshalSingleExtJSON converts wirebson value to the canonical or relaxed Extended JSON representation.
+func marshalSingleExtJSON(v any, canonical bool) (json.RawMessage, error) {
+	bv, err := toDriverDeep(v)
 	if err != nil {
 		return nil, lazyerrors.Error(err)
 	}
 
-	return json.RawMessage(buf.Bytes()), nil
+	// the driver can't marshal arrays and scalars at the top level,
+	// so the value is wrapped into a document and then extracted
+	b, err := bson.MarshalExtJSON(bson.D{{Key: "v", Value: bv}}, canonical, false)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	var wrapper struct {
+		V json.RawMessage `json:"v"`
+	}
+
+	if err = json.Unmarshal(b, &wrapper); err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return wrapper.V, nil
 }
 
 // unmarshalSingleJSON takes extended JSON object and unmarshals it into the wirebson composite.
@@ -0,0 +1,64 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net/http"
+
+	"github.com/AlekSi/lazyerrors"
+
+	"github.com/FerretDB/FerretDB/v2/internal/dataapi/api"
+)
+
+// ListDatabases implements [ServerInterface].
+func (s *Server) ListDatabases(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	s.logRequest(ctx, r)
+
+	var req api.ListDatabasesRequestBody
+	if err := decodeJSONRequest(r, &req); err != nil {
+		writeRequestError(rw, err)
+		return
+	}
+
+	msg, err := prepareRequest(
+		"listDatabases", int32(1),
+		"$db", "admin",
+		"filter", req.Filter,
+		"nameOnly", req.NameOnly,
+	)
+	if err != nil {
+		writeRequestError(rw, err)
+		return
+	}
+
+	resp := s.m.Handle(ctx, msg)
+	if !s.checkResponse(ctx, rw, resp) {
+		return
+	}
+
+	b, err := marshalResult(ctx, resp.Document().Get("databases"))
+	if err != nil {
+		writeInternalError(rw, lazyerrors.Error(err))
+		return
+	}
+
+	res := api.ListDatabasesResponseBody{
+		Databases: b,
+	}
+
+	s.writeResponse(ctx, rw, &res)
+}
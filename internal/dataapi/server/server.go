@@ -16,132 +16,125 @@
 package server
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"log/slog"
 	"net/http"
+	"net/http/httputil"
+	"net/netip"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/AlekSi/lazyerrors"
 	"github.com/FerretDB/wire/wirebson"
-	"github.com/xdg-go/scram"
 
 	"github.com/FerretDB/FerretDB/v2/internal/clientconn/conninfo"
 	"github.com/FerretDB/FerretDB/v2/internal/dataapi/api"
 	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
 	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
-	"github.com/FerretDB/FerretDB/v2/internal/util/must"
 )
 
 // New creates a new Server.
+// [Server.Run] must be called on the returned value.
 func New(l *slog.Logger, handler *middleware.Middleware) *Server {
 	return &Server{
-		l: l,
-		m: handler,
+		l:       l,
+		m:       handler,
+		cursors: newCursors(cursorTimeout),
 	}
 }
 
 // Server implements services described by OpenAPI description file.
 type Server struct {
-	l *slog.Logger
-	m *middleware.Middleware
+	l       *slog.Logger
+	m       *middleware.Middleware
+	cursors *cursors
 }
 
-// AuthMiddleware handles SCRAM authentication based on the username and password specified in request.
-// After a successful handshake it calls the next handler.
-func (s *Server) AuthMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
-		username, password, ok := r.BasicAuth()
+// Run closes expired cursors until ctx is canceled.
+func (s *Server) Run(ctx context.Context) {
+	ticker := time.NewTicker(cursorTimeout / 10)
+	defer ticker.Stop()
 
-		if !ok {
-			writeError(rw, errorNoAuthenticationSpecified, http.StatusBadRequest)
+	for {
+		select {
+		case <-ctx.Done():
 			return
+		case <-ticker.C:
+			for _, c := range s.cursors.expired() {
+				s.killCursor(ctx, c)
+			}
 		}
+	}
+}
 
-		if password == "" || username == "" {
-			writeError(rw, errorMissingAuthenticationParameter, http.StatusBadRequest)
-			return
-		}
+// requestAPIKey returns API key specified in the request
+// with `Authorization: Bearer` or `apiKey` header.
+func requestAPIKey(r *http.Request) (string, bool) {
+	if key, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return strings.TrimSpace(key), true
+	}
 
-		client, err := scram.SHA256.NewClient(username, password, "")
-		if err != nil {
-			http.Error(rw, lazyerrors.Error(err).Error(), http.StatusBadRequest)
-			return
-		}
+	if key := r.Header.Get("apiKey"); key != "" {
+		return key, true
+	}
 
-		conv := client.NewConversation()
+	return "", false
+}
 
-		payload, err := conv.Step("")
-		if err != nil {
-			http.Error(rw, lazyerrors.Error(err).Error(), http.StatusBadRequest)
-			return
-		}
+// requestCredentials returns username and password specified in the request
+// with Basic authentication or Atlas Data API `email` and `password` headers.
+func requestCredentials(r *http.Request) (string, string, bool) {
+	if username, password, ok := r.BasicAuth(); ok {
+		return username, password, true
+	}
 
-		msg := must.NotFail(prepareRequest(
-			"saslStart", int32(1),
-			"mechanism", "SCRAM-SHA-256",
-			"payload", wirebson.Binary{B: []byte(payload)},
-			// use skipEmptyExchange to complete the handshake with one `saslStart` and one `saslContinue`
-			"options", wirebson.MustDocument("skipEmptyExchange", true),
-			"$db", "admin",
-		))
-
-		resp := s.m.Handle(ctx, msg)
-		if resp == nil {
-			http.Error(rw, "internal error", http.StatusInternalServerError)
-			return
-		}
+	email, password := r.Header.Get("email"), r.Header.Get("password")
+	if email == "" && password == "" {
+		return "", "", false
+	}
 
-		if !resp.OK() {
-			s.writeJSONError(ctx, rw, resp)
-			return
-		}
+	return email, password, true
+}
 
-		convID := resp.Document().Get("conversationId").(int32)
-		payloadBytes := resp.Document().Get("payload").(wirebson.Binary).B
+// AuthMiddleware handles authentication with API key specified in request.
+// If API key is not specified, it falls back to SCRAM authentication
+// based on the username and password specified in request.
+// After a successful authentication it calls the next handler.
+func (s *Server) AuthMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
 
-		payload, err = conv.Step(string(payloadBytes))
-		if err != nil {
-			http.Error(rw, lazyerrors.Error(err).Error(), http.StatusUnauthorized)
-			return
-		}
+		if key, ok := requestAPIKey(r); ok {
+			if key == "" {
+				writeError(rw, errorMissingAuthenticationParameter, http.StatusBadRequest)
+				return
+			}
 
-		msg = must.NotFail(prepareRequest(
-			"saslContinue", int32(1),
-			"conversationId", convID,
-			"payload", wirebson.Binary{B: []byte(payload)},
-			"$db", "admin",
-		))
+			if !s.checkResponse(ctx, rw, s.m.AuthenticateAPIKey(ctx, key)) {
+				return
+			}
 
-		resp = s.m.Handle(ctx, msg)
-		if resp == nil {
-			http.Error(rw, "internal error", http.StatusInternalServerError)
-			return
-		}
+			next.ServeHTTP(rw, r.WithContext(ctx))
 
-		if !resp.OK() {
-			s.writeJSONError(ctx, rw, resp)
 			return
 		}
 
-		if !resp.Document().Get("done").(bool) {
-			http.Error(rw, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		username, password, ok := requestCredentials(r)
+
+		if !ok {
+			writeError(rw, errorNoAuthenticationSpecified, http.StatusBadRequest)
 			return
 		}
 
-		payloadBytes = resp.Document().Get("payload").(wirebson.Binary).B
-
-		if _, err = conv.Step(string(payloadBytes)); err != nil {
-			http.Error(rw, lazyerrors.Error(err).Error(), http.StatusUnauthorized)
+		if password == "" || username == "" {
+			writeError(rw, errorMissingAuthenticationParameter, http.StatusBadRequest)
 			return
 		}
 
-		if !conv.Valid() {
-			http.Error(rw, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		if !s.checkResponse(ctx, rw, s.m.AuthenticateSCRAM(ctx, username, password)) {
 			return
 		}
 
@@ -157,48 +150,95 @@ func (s *Server) ConnInfoMiddleware(next http.Handler) http.Handler {
 
 		defer ci.Close()
 
+		// remote address is not an IP address and port for Unix domain sockets; Peer stays invalid then
+		ci.Peer, _ = netip.ParseAddrPort(r.RemoteAddr)
+
 		next.ServeHTTP(rw, r.WithContext(conninfo.Ctx(r.Context(), ci)))
 	})
 }
 
-// writeJSONResponse marshals provided res document into extended JSON and
-// writes it to provided [http.ResponseWriter].
-func (s *Server) writeJSONResponse(ctx context.Context, rw http.ResponseWriter, res api.Response) {
-	rw.Header().Set("Content-Type", "application/json")
+// logRequest logs the request with its body if debug logging is enabled.
+func (s *Server) logRequest(ctx context.Context, r *http.Request) {
+	if !s.l.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
 
-	var resWriter io.Writer = rw
+	// the body could be too large, for example
+	b, err := httputil.DumpRequest(r, true)
+	if err != nil {
+		s.l.DebugContext(ctx, "Failed to dump request", logging.Error(err))
+		return
+	}
 
-	if s.l.Enabled(ctx, slog.LevelDebug) {
-		buf := new(bytes.Buffer)
+	s.l.DebugContext(ctx, fmt.Sprintf("Request:\n%s", b))
+}
+
+// writeResponse marshals provided res document into the response format stored in ctx
+// and writes it to provided [http.ResponseWriter].
+func (s *Server) writeResponse(ctx context.Context, rw http.ResponseWriter, res api.Response) {
+	f := getFormat(ctx)
 
-		resWriter = io.MultiWriter(rw, buf)
+	rw.Header().Set("Content-Type", f.contentType())
 
-		defer func() {
-			// extended JSON value writer always finish with '\n' character
-			s.l.DebugContext(ctx, fmt.Sprintf("Results:\n%s", strings.TrimSpace(buf.String())))
-		}()
+	if f == formatBSON {
+		s.writeBSONResponse(ctx, rw, res)
+		return
 	}
 
-	err := json.NewEncoder(resWriter).Encode(res)
+	s.writeJSONResponse(ctx, rw, res)
+}
+
+// writeJSONResponse marshals provided res document into JSON and
+// writes it to provided [http.ResponseWriter].
+//
+// The response is written as a single line, so it is also a valid NDJSON.
+// If res can't be marshaled, an internal error is written instead.
+func (s *Server) writeJSONResponse(ctx context.Context, rw http.ResponseWriter, res api.Response) {
+	b, err := json.Marshal(res)
 	if err != nil {
-		s.l.ErrorContext(ctx, "marshalJSON failed", logging.Error(err))
+		s.l.ErrorContext(ctx, "Failed to marshal JSON response", logging.Error(err))
+		writeInternalError(rw, lazyerrors.Error(err))
+
+		return
+	}
+
+	s.l.DebugContext(ctx, fmt.Sprintf("Results:\n%s", b))
+
+	b = append(b, '\n')
+
+	if _, err = rw.Write(b); err != nil {
+		s.l.WarnContext(ctx, "Failed to write JSON response", logging.Error(err))
 	}
 }
 
-// TODO https://github.com/FerretDB/FerretDB/issues/4965
-func (s *Server) writeJSONError(ctx context.Context, rw http.ResponseWriter, resp *middleware.Response) {
-	doc := resp.Document()
-	errmsg := doc.Get("errmsg").(string)
-	codeName := doc.Get("codeName").(string)
+// writeBSONResponse encodes provided res document into BSON and
+// writes it to provided [http.ResponseWriter].
+//
+// If res can't be encoded, an internal error is written as JSON instead.
+func (s *Server) writeBSONResponse(ctx context.Context, rw http.ResponseWriter, res api.Response) {
+	doc, err := bsonResponse(ctx, res)
+
+	var b []byte
+	if err == nil {
+		b, err = doc.Encode()
+	}
 
-	rw.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		s.l.ErrorContext(ctx, "Failed to encode BSON response", logging.Error(err))
+		writeInternalError(rw, lazyerrors.Error(err))
 
-	rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
 
-	s.writeJSONResponse(ctx, rw, &api.Error{
-		Error:     errmsg,
-		ErrorCode: codeName,
-	})
+	if s.l.Enabled(ctx, slog.LevelDebug) {
+		s.l.DebugContext(ctx, fmt.Sprintf("Results:\n%s", doc.LogMessage()))
+	}
+
+	rw.Header().Set("Content-Length", strconv.Itoa(len(b)))
+
+	if _, err = rw.Write(b); err != nil {
+		s.l.WarnContext(ctx, "Failed to write BSON response", logging.Error(err))
+	}
 }
 
 // prepareDocument creates a new bson document from the given pairs of
@@ -250,6 +290,12 @@ func prepareDocument(pairs ...any) (*wirebson.Document, error) {
 			}
 
 			v = *val
+		case *int:
+			if val == nil {
+				continue
+			}
+
+			v = int64(*val)
 		}
 
 		if v == nil {
@@ -275,11 +321,12 @@ func prepareRequest(pairs ...any) (*middleware.Request, error) {
 	return middleware.RequestDoc(doc)
 }
 
-// decodeJSONRequest takes request with JSON body and decodes it into
+// decodeJSONRequest takes request with JSON or Extended JSON body and decodes it into
 // provided oapi generated request struct.
 func decodeJSONRequest(r *http.Request, out any) error {
-	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
-		return lazyerrors.New("Content-Type must be set to application/json")
+	ct := r.Header.Get("Content-Type")
+	if !strings.HasPrefix(ct, "application/json") && !strings.HasPrefix(ct, "application/ejson") {
+		return lazyerrors.New("Content-Type must be set to application/json or application/ejson")
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&out); err != nil {
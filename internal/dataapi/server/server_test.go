@@ -17,6 +17,7 @@
 import (
 	"encoding/json"
 	"fmt"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/AlekSi/pointer"
@@ -50,7 +51,7 @@ func TestPrepareRequest(t *testing.T) {
 		},
 		"EmptyRawMessage": {
 			pairs: []any{"foo", pointer.To(json.RawMessage{})},
-			err:   fmt.Errorf("server.go:272 (server.prepareRequest): Invalid object: []"),
+			err:   fmt.Errorf("server.go:387 (server.prepareRequest): Invalid object: []"),
 		},
 	} {
 		t.Run(name, func(t *testing.T) {
@@ -65,3 +66,85 @@ func TestPrepareRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestRequestAPIKey(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		headers  map[string]string
+		expected string
+		ok       bool
+	}{
+		"None": {},
+		"Basic": {
+			headers: map[string]string{"Authorization": "Basic dXNlcjpwYXNz"},
+		},
+		"Bearer": {
+			headers:  map[string]string{"Authorization": "Bearer fdb_id_secret"},
+			expected: "fdb_id_secret",
+			ok:       true,
+		},
+		"Header": {
+			headers:  map[string]string{"apiKey": "fdb_id_secret"},
+			expected: "fdb_id_secret",
+			ok:       true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest("POST", "/action/find", nil)
+			for k, v := range tc.headers {
+				r.Header.Set(k, v)
+			}
+
+			actual, ok := requestAPIKey(r)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestRequestCredentials(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		headers  map[string]string
+		username string
+		password string
+		ok       bool
+	}{
+		"None": {},
+		"Basic": {
+			headers:  map[string]string{"Authorization": "Basic dXNlcjpwYXNz"},
+			username: "user",
+			password: "pass",
+			ok:       true,
+		},
+		"Atlas": {
+			headers:  map[string]string{"email": "user@example.com", "password": "pass"},
+			username: "user@example.com",
+			password: "pass",
+			ok:       true,
+		},
+		"AtlasMissingPassword": {
+			headers:  map[string]string{"email": "user@example.com"},
+			username: "user@example.com",
+			ok:       true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest("POST", "/action/find", nil)
+			for k, v := range tc.headers {
+				r.Header.Set(k, v)
+			}
+
+			username, password, ok := requestCredentials(r)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.username, username)
+			assert.Equal(t, tc.password, password)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+
+	"github.com/FerretDB/FerretDB/v2/internal/dataapi/api"
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
+)
+
+// streamBatchTimeout is the time for writing a single batch of streamed documents.
+const streamBatchTimeout = time.Minute
+
+// streamDocuments writes all documents from the cursor of the command response as NDJSON,
+// fetching the remaining batches with `getMore`.
+// Each batch is sent to the client as soon as it is fetched,
+// so the whole result is never kept in memory.
+// The write deadline is extended for each batch,
+// so the server's write timeout does not limit the whole stream.
+//
+// The status code is sent with the first batch, so an error in the middle of the stream
+// is written as the last line with [api.Error] object.
+func (s *Server) streamDocuments(
+	ctx context.Context, rw http.ResponseWriter, resp *middleware.Response, db, collection string,
+) {
+	rw.Header().Set("Content-Type", formatNDJSON.contentType())
+
+	rc := http.NewResponseController(rw)
+
+	var buf bytes.Buffer
+
+	err := s.forEachBatch(ctx, resp, db, collection, func(arr *wirebson.Array) error {
+		buf.Reset()
+
+		for v := range arr.Values() {
+			b, err := marshalResult(ctx, v)
+			if err != nil {
+				return lazyerrors.Error(err)
+			}
+
+			buf.Write(b)
+			buf.WriteByte('\n')
+		}
+
+		err := rc.SetWriteDeadline(time.Now().Add(streamBatchTimeout))
+		if err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return lazyerrors.Error(err)
+		}
+
+		if _, err = rw.Write(buf.Bytes()); err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		if err = rc.Flush(); err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		return nil
+	})
+	if err == nil {
+		return
+	}
+
+	s.l.WarnContext(ctx, "Failed to stream documents", logging.Error(err))
+
+	_ = json.NewEncoder(rw).Encode(api.Error{
+		Error:     err.Error(),
+		ErrorCode: "InternalError",
+	})
+}
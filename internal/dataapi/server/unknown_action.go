@@ -0,0 +1,31 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import "net/http"
+
+// UnknownAction handles requests to actions that are not defined by the OpenAPI description,
+// and requests with wrong HTTP methods.
+// Unlike the default [http.ServeMux] handlers, it replies with the Atlas Data API error body.
+func (s *Server) UnknownAction(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		rw.Header().Set("Allow", http.MethodPost)
+		writeError(rw, errorMethodNotAllowed, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	writeError(rw, errorNoMatchingRule, http.StatusNotFound)
+}
@@ -16,29 +16,25 @@
 
 import (
 	"fmt"
-	"log/slog"
 	"net/http"
-	"net/http/httputil"
 
 	"github.com/AlekSi/lazyerrors"
 	"github.com/AlekSi/pointer"
 	"github.com/FerretDB/wire/wirebson"
 
 	"github.com/FerretDB/FerretDB/v2/internal/dataapi/api"
-	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
 )
 
 // UpdateOne implements [ServerInterface].
 func (s *Server) UpdateOne(rw http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	if s.l.Enabled(ctx, slog.LevelDebug) {
-		s.l.DebugContext(ctx, fmt.Sprintf("Request:\n%s", must.NotFail(httputil.DumpRequest(r, true))))
-	}
+	s.logRequest(ctx, r)
 
 	var req api.UpdateRequestBody
 	if err := decodeJSONRequest(r, &req); err != nil {
-		http.Error(rw, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+		writeRequestError(rw, err)
 		return
 	}
 
@@ -49,7 +45,7 @@ func (s *Server) UpdateOne(rw http.ResponseWriter, r *http.Request) {
 		"multi", false,
 	)
 	if err != nil {
-		http.Error(rw, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+		writeRequestError(rw, err)
 		return
 	}
 
@@ -59,43 +55,56 @@ func (s *Server) UpdateOne(rw http.ResponseWriter, r *http.Request) {
 		"updates", wirebson.MustArray(updateDoc),
 	)
 	if err != nil {
-		http.Error(rw, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+		writeRequestError(rw, err)
 		return
 	}
 
 	resp := s.m.Handle(ctx, msg)
-	if resp == nil {
-		http.Error(rw, "internal error", http.StatusInternalServerError)
+	if !s.checkResponse(ctx, rw, resp) {
 		return
 	}
 
-	if !resp.OK() {
-		s.writeJSONError(ctx, rw, resp)
+	res, err := updateResponse(resp)
+	if err != nil {
+		writeInternalError(rw, lazyerrors.Error(err))
 		return
 	}
 
-	res := api.UpdateResponseBody{
+	s.writeResponse(ctx, rw, res)
+}
+
+// updateResponse returns the Data API response for the `update` command response.
+func updateResponse(resp *middleware.Response) (*api.UpdateResponseBody, error) {
+	res := &api.UpdateResponseBody{
 		MatchedCount:  resp.Document().Get("n").(int32),
 		ModifiedCount: resp.Document().Get("nModified").(int32),
 	}
 
-	if upsertedRaw := resp.Document().Get("upserted"); upsertedRaw != nil {
-		upserted := must.NotFail(upsertedRaw.(wirebson.AnyArray).Decode())
+	upsertedRaw := resp.Document().Get("upserted")
+	if upsertedRaw == nil {
+		return res, nil
+	}
 
-		if upserted.Len() > 0 {
-			item := must.NotFail(upserted.Get(0).(wirebson.AnyDocument).Decode())
+	upserted, err := upsertedRaw.(wirebson.AnyArray).Decode()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
 
-			var upsertedId any
+	if upserted.Len() == 0 {
+		return res, nil
+	}
 
-			upsertedId, err = wirebson.ToDriver(item.Get("_id"))
-			if err != nil {
-				http.Error(rw, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
-				return
-			}
+	item, err := upserted.Get(0).(wirebson.AnyDocument).Decode()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
 
-			res.UpsertedId = pointer.To(fmt.Sprint(upsertedId))
-		}
+	upsertedId, err := wirebson.ToDriver(item.Get("_id"))
+	if err != nil {
+		return nil, lazyerrors.Error(err)
 	}
 
-	s.writeJSONResponse(ctx, rw, &res)
+	res.UpsertedId = pointer.To(fmt.Sprint(upsertedId))
+
+	return res, nil
 }
@@ -0,0 +1,159 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package documentdb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// APIKey represents a stored API key.
+//
+// Only the unsalted SHA-256 hash of the key's secret is stored.
+// That is safe only because secrets are high-entropy random tokens (256 bits) generated by FerretDB,
+// never chosen by users: a leaked hash can't be reversed by brute force or precomputed tables,
+// so neither salt nor slow key derivation is needed.
+type APIKey struct {
+	Created  time.Time
+	ID       string
+	Username string
+	DB       string // empty value allows access to all databases
+	Hash     []byte
+	ReadOnly bool
+}
+
+// createAPIKeysTable creates the schema and the table for API keys if they do not exist.
+func createAPIKeysTable(ctx context.Context, conn *pgx.Conn) error {
+	q := `
+		CREATE SCHEMA IF NOT EXISTS ferretdb;
+		CREATE TABLE IF NOT EXISTS ferretdb.api_keys (
+			id         text PRIMARY KEY,
+			hash       bytea NOT NULL,
+			username   text NOT NULL,
+			db         text NOT NULL DEFAULT '',
+			read_only  boolean NOT NULL DEFAULT false,
+			created_at timestamptz NOT NULL DEFAULT now()
+		);
+		CREATE INDEX IF NOT EXISTS api_keys_username_idx ON ferretdb.api_keys (username)`
+
+	if _, err := conn.Exec(ctx, q); err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	return nil
+}
+
+// noAPIKeysTable returns true if the error is caused by the absence of the API keys table,
+// meaning that no keys were created yet.
+func noAPIKeysTable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	return pgErr.Code == pgerrcode.UndefinedTable || pgErr.Code == pgerrcode.InvalidSchemaName
+}
+
+// CreateAPIKey stores a new API key.
+// Created time is set by the database.
+func CreateAPIKey(ctx context.Context, conn *pgx.Conn, key *APIKey) error {
+	if err := createAPIKeysTable(ctx, conn); err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	q := `INSERT INTO ferretdb.api_keys (id, hash, username, db, read_only) VALUES ($1, $2, $3, $4, $5)`
+	if _, err := conn.Exec(ctx, q, key.ID, key.Hash, key.Username, key.DB, key.ReadOnly); err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	return nil
+}
+
+// GetAPIKey returns the API key with the given ID.
+// It returns nil if there is no such key.
+func GetAPIKey(ctx context.Context, conn *pgx.Conn, id string) (*APIKey, error) {
+	q := `SELECT id, hash, username, db, read_only, created_at FROM ferretdb.api_keys WHERE id = $1`
+
+	var key APIKey
+
+	err := conn.QueryRow(ctx, q, id).Scan(&key.ID, &key.Hash, &key.Username, &key.DB, &key.ReadOnly, &key.Created)
+	switch {
+	case err == nil:
+		return &key, nil
+	case errors.Is(err, pgx.ErrNoRows), noAPIKeysTable(err):
+		return nil, nil
+	default:
+		return nil, lazyerrors.Error(err)
+	}
+}
+
+// ListAPIKeys returns API keys of the given user sorted by creation time.
+// Empty username returns keys of all users.
+func ListAPIKeys(ctx context.Context, conn *pgx.Conn, username string) ([]*APIKey, error) {
+	q := `SELECT id, hash, username, db, read_only, created_at FROM ferretdb.api_keys ` +
+		`WHERE $1 = '' OR username = $1 ORDER BY created_at, id`
+
+	rows, err := conn.Query(ctx, q, username)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	res, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*APIKey, error) {
+		var key APIKey
+		err := row.Scan(&key.ID, &key.Hash, &key.Username, &key.DB, &key.ReadOnly, &key.Created)
+
+		return &key, err
+	})
+
+	switch {
+	case err == nil:
+		return res, nil
+	case noAPIKeysTable(err):
+		return nil, nil
+	default:
+		return nil, lazyerrors.Error(err)
+	}
+}
+
+// DropAPIKey removes the API key with the given ID.
+// It returns false if there is no such key.
+func DropAPIKey(ctx context.Context, conn *pgx.Conn, id string) (bool, error) {
+	tag, err := conn.Exec(ctx, `DELETE FROM ferretdb.api_keys WHERE id = $1`, id)
+
+	switch {
+	case err == nil:
+		return tag.RowsAffected() > 0, nil
+	case noAPIKeysTable(err):
+		return false, nil
+	default:
+		return false, lazyerrors.Error(err)
+	}
+}
+
+// DropUserAPIKeys removes all API keys of the given user.
+func DropUserAPIKeys(ctx context.Context, conn *pgx.Conn, username string) error {
+	_, err := conn.Exec(ctx, `DELETE FROM ferretdb.api_keys WHERE username = $1`, username)
+	if err != nil && !noAPIKeysTable(err) {
+		return lazyerrors.Error(err)
+	}
+
+	return nil
+}
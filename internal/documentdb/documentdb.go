@@ -27,6 +27,8 @@
 // TODO https://github.com/documentdb/documentdb/issues/25
 // TODO https://github.com/documentdb/documentdb/issues/333
 //
+// We use our own ferretdb schema for API keys; it is not accessible over the wire protocol.
+//
 // And we generate code for documentdb_core just to track changes.
 //
 //go:generate go run ./genwrap -debug -schemas=documentdb_api,documentdb_api_catalog,documentdb_api_internal,documentdb_core
@@ -8,18 +8,18 @@
 
 	"github.com/FerretDB/wire/wirebson"
 	"github.com/jackc/pgx/v5"
-	"go.opentelemetry.io/otel"
 	otelsemconv "go.opentelemetry.io/otel/semconv/v1.34.0"
 	oteltrace "go.opentelemetry.io/otel/trace"
 
 	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+	"github.com/FerretDB/FerretDB/v2/internal/util/observability"
 )
 
 // AggregateCursorFirstPage is a wrapper for
 //
 //	documentdb_api.aggregate_cursor_first_page(database text, commandspec documentdb_core.bson, cursorid bigint DEFAULT 0, OUT cursorpage documentdb_core.bson, OUT continuation documentdb_core.bson, OUT persistconnection boolean, OUT cursorid bigint).
 func AggregateCursorFirstPage(ctx context.Context, conn *pgx.Conn, l *slog.Logger, database string, commandSpec wirebson.RawDocument, cursorID int64) (outCursorPage wirebson.RawDocument, outContinuation wirebson.RawDocument, outPersistConnection bool, outCursorID int64, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.AggregateCursorFirstPage",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -40,7 +40,7 @@ func AggregateCursorFirstPage(ctx context.Context, conn *pgx.Conn, l *slog.Logge
 //
 //	documentdb_api.binary_extended_version(OUT binary_extended_version text).
 func BinaryExtendedVersion(ctx context.Context, conn *pgx.Conn, l *slog.Logger) (outBinaryExtendedVersion string, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.BinaryExtendedVersion",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -61,7 +61,7 @@ func BinaryExtendedVersion(ctx context.Context, conn *pgx.Conn, l *slog.Logger)
 //
 //	documentdb_api.binary_version(OUT binary_version text).
 func BinaryVersion(ctx context.Context, conn *pgx.Conn, l *slog.Logger) (outBinaryVersion string, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.BinaryVersion",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -82,7 +82,7 @@ func BinaryVersion(ctx context.Context, conn *pgx.Conn, l *slog.Logger) (outBina
 //
 //	documentdb_api.coll_mod(p_database_name text, p_collection_name text, p_spec documentdb_core.bson, OUT coll_mod documentdb_core.bson).
 func CollMod(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databaseName string, collectionName string, spec wirebson.RawDocument) (outCollMod wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.CollMod",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -103,7 +103,7 @@ func CollMod(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databaseName s
 //
 //	documentdb_api.coll_stats(p_database_name text, p_collection_name text, p_scale double precision DEFAULT 1, OUT coll_stats documentdb_core.bson).
 func CollStats(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databaseName string, collectionName string, scale float64) (outCollStats wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.CollStats",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -124,7 +124,7 @@ func CollStats(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databaseName
 //
 //	documentdb_api.collection(p_database_name text, p_collection_name text, OUT shard_key_value bigint, OUT object_id documentdb_core.bson, OUT document documentdb_core.bson).
 func Collection(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databaseName string, collectionName string) (outShardKeyValue int64, outObjectID wirebson.RawDocument, outDocument wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.Collection",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -145,7 +145,7 @@ func Collection(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databaseNam
 //
 //	documentdb_api.compact(p_spec documentdb_core.bson, OUT compact documentdb_core.bson).
 func Compact(ctx context.Context, conn *pgx.Conn, l *slog.Logger, spec wirebson.RawDocument) (outCompact wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.Compact",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -166,7 +166,7 @@ func Compact(ctx context.Context, conn *pgx.Conn, l *slog.Logger, spec wirebson.
 //
 //	documentdb_api.connection_status(p_spec documentdb_core.bson, OUT connection_status documentdb_core.bson).
 func ConnectionStatus(ctx context.Context, conn *pgx.Conn, l *slog.Logger, spec wirebson.RawDocument) (outConnectionStatus wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.ConnectionStatus",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -187,7 +187,7 @@ func ConnectionStatus(ctx context.Context, conn *pgx.Conn, l *slog.Logger, spec
 //
 //	documentdb_api.count_query(database text, countspec documentdb_core.bson, OUT document documentdb_core.bson).
 func CountQuery(ctx context.Context, conn *pgx.Conn, l *slog.Logger, database string, countSpec wirebson.RawDocument) (outDocument wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.CountQuery",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -208,7 +208,7 @@ func CountQuery(ctx context.Context, conn *pgx.Conn, l *slog.Logger, database st
 //
 //	documentdb_api.create_collection(p_database_name text, p_collection_name text, OUT create_collection boolean).
 func CreateCollection(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databaseName string, collectionName string) (outCreateCollection bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.CreateCollection",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -229,7 +229,7 @@ func CreateCollection(ctx context.Context, conn *pgx.Conn, l *slog.Logger, datab
 //
 //	documentdb_api.create_collection_view(dbname text, createspec documentdb_core.bson, OUT create_collection_view documentdb_core.bson).
 func CreateCollectionView(ctx context.Context, conn *pgx.Conn, l *slog.Logger, database string, createSpec wirebson.RawDocument) (outCreateCollectionView wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.CreateCollectionView",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -250,7 +250,7 @@ func CreateCollectionView(ctx context.Context, conn *pgx.Conn, l *slog.Logger, d
 //
 //	documentdb_api.create_indexes_background(p_database_name text, p_index_spec documentdb_core.bson, OUT retval documentdb_core.bson, OUT ok boolean, OUT requests documentdb_core.bson).
 func CreateIndexesBackground(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databaseName string, indexSpec wirebson.RawDocument) (outRetVal wirebson.RawDocument, outOk bool, outRequests wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.CreateIndexesBackground",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -271,7 +271,7 @@ func CreateIndexesBackground(ctx context.Context, conn *pgx.Conn, l *slog.Logger
 //
 //	documentdb_api.create_role(p_spec documentdb_core.bson, OUT create_role documentdb_core.bson).
 func CreateRole(ctx context.Context, conn *pgx.Conn, l *slog.Logger, spec wirebson.RawDocument) (outCreateRole wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.CreateRole",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -292,7 +292,7 @@ func CreateRole(ctx context.Context, conn *pgx.Conn, l *slog.Logger, spec wirebs
 //
 //	documentdb_api.create_user(p_spec documentdb_core.bson, OUT create_user documentdb_core.bson).
 func CreateUser(ctx context.Context, conn *pgx.Conn, l *slog.Logger, spec wirebson.RawDocument) (outCreateUser wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.CreateUser",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -313,7 +313,7 @@ func CreateUser(ctx context.Context, conn *pgx.Conn, l *slog.Logger, spec wirebs
 //
 //	documentdb_api.current_op_command(p_spec documentdb_core.bson, OUT document documentdb_core.bson).
 func CurrentOpCommand(ctx context.Context, conn *pgx.Conn, l *slog.Logger, spec wirebson.RawDocument) (outDocument wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.CurrentOpCommand",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -334,7 +334,7 @@ func CurrentOpCommand(ctx context.Context, conn *pgx.Conn, l *slog.Logger, spec
 //
 //	documentdb_api.cursor_get_more(database text, getmorespec documentdb_core.bson, continuationspec documentdb_core.bson, OUT cursorpage documentdb_core.bson, OUT continuation documentdb_core.bson).
 func CursorGetMore(ctx context.Context, conn *pgx.Conn, l *slog.Logger, database string, getMoreSpec wirebson.RawDocument, continuationSpec wirebson.RawDocument) (outCursorPage wirebson.RawDocument, outContinuation wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.CursorGetMore",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -355,7 +355,7 @@ func CursorGetMore(ctx context.Context, conn *pgx.Conn, l *slog.Logger, database
 //
 //	documentdb_api.db_stats(p_database_name text, p_scale double precision DEFAULT 1, p_freestorage boolean DEFAULT false, OUT db_stats documentdb_core.bson).
 func DbStats(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databaseName string, scale float64, freestorage bool) (outDbStats wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.DbStats",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -376,7 +376,7 @@ func DbStats(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databaseName s
 //
 //	documentdb_api.delete(p_database_name text, p_delete documentdb_core.bson, p_insert_documents documentdb_core.bsonsequence DEFAULT NULL, OUT p_result documentdb_core.bson, OUT p_success boolean).
 func Delete(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databaseName string, delete wirebson.RawDocument, insertDocuments []byte) (outResult wirebson.RawDocument, outSuccess bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.Delete",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -397,7 +397,7 @@ func Delete(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databaseName st
 //
 //	documentdb_api.distinct_query(database text, distinctspec documentdb_core.bson, OUT document documentdb_core.bson).
 func DistinctQuery(ctx context.Context, conn *pgx.Conn, l *slog.Logger, database string, distinctSpec wirebson.RawDocument) (outDocument wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.DistinctQuery",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -418,7 +418,7 @@ func DistinctQuery(ctx context.Context, conn *pgx.Conn, l *slog.Logger, database
 //
 //	documentdb_api.drop_collection(p_database_name text, p_collection_name text, p_write_concern documentdb_core.bson DEFAULT NULL, p_collection_uuid uuid DEFAULT NULL, p_track_changes boolean DEFAULT true, OUT drop_collection boolean).
 func DropCollection(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databaseName string, collectionName string, writeConcern wirebson.RawDocument, collectionUuid []byte, trackChanges bool) (outDropCollection bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.DropCollection",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -439,7 +439,7 @@ func DropCollection(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databas
 //
 //	documentdb_api.drop_database(p_database_name text, p_write_concern documentdb_core.bson DEFAULT NULL).
 func DropDatabase(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databaseName string, writeConcern wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.DropDatabase",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -460,7 +460,7 @@ func DropDatabase(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databaseN
 //
 //	documentdb_api.drop_role(p_spec documentdb_core.bson, OUT drop_role documentdb_core.bson).
 func DropRole(ctx context.Context, conn *pgx.Conn, l *slog.Logger, spec wirebson.RawDocument) (outDropRole wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.DropRole",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -481,7 +481,7 @@ func DropRole(ctx context.Context, conn *pgx.Conn, l *slog.Logger, spec wirebson
 //
 //	documentdb_api.drop_user(p_spec documentdb_core.bson, OUT drop_user documentdb_core.bson).
 func DropUser(ctx context.Context, conn *pgx.Conn, l *slog.Logger, spec wirebson.RawDocument) (outDropUser wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.DropUser",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -502,7 +502,7 @@ func DropUser(ctx context.Context, conn *pgx.Conn, l *slog.Logger, spec wirebson
 //
 //	documentdb_api.find_and_modify(p_database_name text, p_message documentdb_core.bson, OUT p_result documentdb_core.bson, OUT p_success boolean).
 func FindAndModify(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databaseName string, message wirebson.RawDocument) (outResult wirebson.RawDocument, outSuccess bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.FindAndModify",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -523,7 +523,7 @@ func FindAndModify(ctx context.Context, conn *pgx.Conn, l *slog.Logger, database
 //
 //	documentdb_api.find_cursor_first_page(database text, commandspec documentdb_core.bson, cursorid bigint DEFAULT 0, OUT cursorpage documentdb_core.bson, OUT continuation documentdb_core.bson, OUT persistconnection boolean, OUT cursorid bigint).
 func FindCursorFirstPage(ctx context.Context, conn *pgx.Conn, l *slog.Logger, database string, commandSpec wirebson.RawDocument, cursorID int64) (outCursorPage wirebson.RawDocument, outContinuation wirebson.RawDocument, outPersistConnection bool, outCursorID int64, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.FindCursorFirstPage",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -544,7 +544,7 @@ func FindCursorFirstPage(ctx context.Context, conn *pgx.Conn, l *slog.Logger, da
 //
 //	documentdb_api.insert(p_database_name text, p_insert documentdb_core.bson, p_insert_documents documentdb_core.bsonsequence DEFAULT NULL, OUT p_result documentdb_core.bson, OUT p_success boolean).
 func Insert(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databaseName string, insert wirebson.RawDocument, insertDocuments []byte) (outResult wirebson.RawDocument, outSuccess bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.Insert",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -565,7 +565,7 @@ func Insert(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databaseName st
 //
 //	documentdb_api.insert_one(p_database_name text, p_collection_name text, p_document documentdb_core.bson, OUT insert_one documentdb_core.bson).
 func InsertOne(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databaseName string, collectionName string, document wirebson.RawDocument) (outInsertOne wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.InsertOne",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -586,7 +586,7 @@ func InsertOne(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databaseName
 //
 //	documentdb_api.list_collections_cursor_first_page(database text, commandspec documentdb_core.bson, cursorid bigint DEFAULT 0, OUT cursorpage documentdb_core.bson, OUT continuation documentdb_core.bson, OUT persistconnection boolean, OUT cursorid bigint).
 func ListCollectionsCursorFirstPage(ctx context.Context, conn *pgx.Conn, l *slog.Logger, database string, commandSpec wirebson.RawDocument, cursorID int64) (outCursorPage wirebson.RawDocument, outContinuation wirebson.RawDocument, outPersistConnection bool, outCursorID int64, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.ListCollectionsCursorFirstPage",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -607,7 +607,7 @@ func ListCollectionsCursorFirstPage(ctx context.Context, conn *pgx.Conn, l *slog
 //
 //	documentdb_api.list_databases(p_list_databases_spec documentdb_core.bson, OUT list_databases documentdb_core.bson).
 func ListDatabases(ctx context.Context, conn *pgx.Conn, l *slog.Logger, listDatabasesSpec wirebson.RawDocument) (outListDatabases wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.ListDatabases",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -628,7 +628,7 @@ func ListDatabases(ctx context.Context, conn *pgx.Conn, l *slog.Logger, listData
 //
 //	documentdb_api.list_indexes_cursor_first_page(database text, commandspec documentdb_core.bson, cursorid bigint DEFAULT 0, OUT cursorpage documentdb_core.bson, OUT continuation documentdb_core.bson, OUT persistconnection boolean, OUT cursorid bigint).
 func ListIndexesCursorFirstPage(ctx context.Context, conn *pgx.Conn, l *slog.Logger, database string, commandSpec wirebson.RawDocument, cursorID int64) (outCursorPage wirebson.RawDocument, outContinuation wirebson.RawDocument, outPersistConnection bool, outCursorID int64, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.ListIndexesCursorFirstPage",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -649,7 +649,7 @@ func ListIndexesCursorFirstPage(ctx context.Context, conn *pgx.Conn, l *slog.Log
 //
 //	documentdb_api.rename_collection(p_database_name text, p_collection_name text, p_target_name text, p_drop_target boolean DEFAULT false).
 func RenameCollection(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databaseName string, collectionName string, targetName string, dropTarget bool) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.RenameCollection",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -670,7 +670,7 @@ func RenameCollection(ctx context.Context, conn *pgx.Conn, l *slog.Logger, datab
 //
 //	documentdb_api.reshard_collection(p_shard_key_spec documentdb_core.bson).
 func ReshardCollection(ctx context.Context, conn *pgx.Conn, l *slog.Logger, shardKeySpec wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.ReshardCollection",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -691,7 +691,7 @@ func ReshardCollection(ctx context.Context, conn *pgx.Conn, l *slog.Logger, shar
 //
 //	documentdb_api.roles_info(p_spec documentdb_core.bson, OUT roles_info documentdb_core.bson).
 func RolesInfo(ctx context.Context, conn *pgx.Conn, l *slog.Logger, spec wirebson.RawDocument) (outRolesInfo wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.RolesInfo",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -712,7 +712,7 @@ func RolesInfo(ctx context.Context, conn *pgx.Conn, l *slog.Logger, spec wirebso
 //
 //	documentdb_api.shard_collection(p_database_name text, p_collection_name text, p_shard_key documentdb_core.bson, p_is_reshard boolean DEFAULT true).
 func ShardCollection(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databaseName string, collectionName string, shardKey wirebson.RawDocument, isReshard bool) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.ShardCollection",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -733,7 +733,7 @@ func ShardCollection(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databa
 //
 //	documentdb_api.shard_collection(p_shard_key_spec documentdb_core.bson).
 func ShardCollection1(ctx context.Context, conn *pgx.Conn, l *slog.Logger, shardKeySpec wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.ShardCollection1",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -754,7 +754,7 @@ func ShardCollection1(ctx context.Context, conn *pgx.Conn, l *slog.Logger, shard
 //
 //	documentdb_api.unshard_collection(p_shard_key_spec documentdb_core.bson).
 func UnshardCollection(ctx context.Context, conn *pgx.Conn, l *slog.Logger, shardKeySpec wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.UnshardCollection",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -775,7 +775,7 @@ func UnshardCollection(ctx context.Context, conn *pgx.Conn, l *slog.Logger, shar
 //
 //	documentdb_api.update(p_database_name text, p_update documentdb_core.bson, p_insert_documents documentdb_core.bsonsequence DEFAULT NULL, OUT p_result documentdb_core.bson, OUT p_success boolean).
 func Update(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databaseName string, update wirebson.RawDocument, insertDocuments []byte) (outResult wirebson.RawDocument, outSuccess bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.Update",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -796,7 +796,7 @@ func Update(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databaseName st
 //
 //	documentdb_api.update_role(p_spec documentdb_core.bson, OUT update_role documentdb_core.bson).
 func UpdateRole(ctx context.Context, conn *pgx.Conn, l *slog.Logger, spec wirebson.RawDocument) (outUpdateRole wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.UpdateRole",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -817,7 +817,7 @@ func UpdateRole(ctx context.Context, conn *pgx.Conn, l *slog.Logger, spec wirebs
 //
 //	documentdb_api.update_user(p_spec documentdb_core.bson, OUT update_user documentdb_core.bson).
 func UpdateUser(ctx context.Context, conn *pgx.Conn, l *slog.Logger, spec wirebson.RawDocument) (outUpdateUser wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.UpdateUser",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -838,7 +838,7 @@ func UpdateUser(ctx context.Context, conn *pgx.Conn, l *slog.Logger, spec wirebs
 //
 //	documentdb_api.users_info(p_spec documentdb_core.bson, OUT users_info documentdb_core.bson).
 func UsersInfo(ctx context.Context, conn *pgx.Conn, l *slog.Logger, spec wirebson.RawDocument) (outUsersInfo wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.UsersInfo",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -859,7 +859,7 @@ func UsersInfo(ctx context.Context, conn *pgx.Conn, l *slog.Logger, spec wirebso
 //
 //	documentdb_api.validate(database text, validatespec documentdb_core.bson, OUT document documentdb_core.bson).
 func Validate(ctx context.Context, conn *pgx.Conn, l *slog.Logger, database string, validateSpec wirebson.RawDocument) (outDocument wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.Validate",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -20,12 +20,12 @@
 
 	"github.com/FerretDB/wire/wirebson"
 	"github.com/jackc/pgx/v5"
-	"go.opentelemetry.io/otel"
 	otelsemconv "go.opentelemetry.io/otel/semconv/v1.34.0"
 	oteltrace "go.opentelemetry.io/otel/trace"
 
 	"github.com/FerretDB/FerretDB/v2/internal/documentdb/bsonhex"
 	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+	"github.com/FerretDB/FerretDB/v2/internal/util/observability"
 )
 
 // DropIndexes is a wrapper for
@@ -36,7 +36,7 @@
 //
 //nolint:lll // copied from generated code
 func DropIndexes(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databaseName string, arg wirebson.RawDocument, retVal wirebson.RawDocument) (outRetVal wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api.DropIndexes",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -8,18 +8,18 @@
 
 	"github.com/FerretDB/wire/wirebson"
 	"github.com/jackc/pgx/v5"
-	"go.opentelemetry.io/otel"
 	otelsemconv "go.opentelemetry.io/otel/semconv/v1.34.0"
 	oteltrace "go.opentelemetry.io/otel/trace"
 
 	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+	"github.com/FerretDB/FerretDB/v2/internal/util/observability"
 )
 
 // BsonAggregationCount is a wrapper for
 //
 //	documentdb_api_catalog.bson_aggregation_count(databasename text, countspec documentdb_core.bson, OUT document documentdb_core.bson).
 func BsonAggregationCount(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databasename string, countSpec wirebson.RawDocument) (outDocument wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonAggregationCount",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -40,7 +40,7 @@ func BsonAggregationCount(ctx context.Context, conn *pgx.Conn, l *slog.Logger, d
 //
 //	documentdb_api_catalog.bson_aggregation_distinct(databasename text, distinctspec documentdb_core.bson, OUT document documentdb_core.bson).
 func BsonAggregationDistinct(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databasename string, distinctSpec wirebson.RawDocument) (outDocument wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonAggregationDistinct",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -61,7 +61,7 @@ func BsonAggregationDistinct(ctx context.Context, conn *pgx.Conn, l *slog.Logger
 //
 //	documentdb_api_catalog.bson_aggregation_find(databasename text, findspec documentdb_core.bson, OUT document documentdb_core.bson).
 func BsonAggregationFind(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databasename string, findSpec wirebson.RawDocument) (outDocument wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonAggregationFind",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -82,7 +82,7 @@ func BsonAggregationFind(ctx context.Context, conn *pgx.Conn, l *slog.Logger, da
 //
 //	documentdb_api_catalog.bson_aggregation_pipeline(databasename text, aggregationpipeline documentdb_core.bson, OUT document documentdb_core.bson).
 func BsonAggregationPipeline(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databasename string, aggregationpipeline wirebson.RawDocument) (outDocument wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonAggregationPipeline",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -103,7 +103,7 @@ func BsonAggregationPipeline(ctx context.Context, conn *pgx.Conn, l *slog.Logger
 //
 //	documentdb_api_catalog.bson_array_agg(anonymous documentdb_core.bson, anonymous1 text).
 func BsonArrayAgg(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 string) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonArrayAgg",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -124,7 +124,7 @@ func BsonArrayAgg(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_api_catalog.bson_array_agg(anonymous documentdb_core.bson, anonymous1 text, anonymous12 boolean).
 func BsonArrayAgg1(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 string, anonymous12 bool) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonArrayAgg1",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -145,7 +145,7 @@ func BsonArrayAgg1(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymou
 //
 //	documentdb_api_catalog.bson_array_agg_final(anonymous bytea, OUT bson_array_agg_final documentdb_core.bson).
 func BsonArrayAggFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonArrayAggFinal wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonArrayAggFinal",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -166,7 +166,7 @@ func BsonArrayAggFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anon
 //
 //	documentdb_api_catalog.bson_array_agg_transition(anonymous bytea, anonymous1 documentdb_core.bson, anonymous12 text, OUT bson_array_agg_transition bytea).
 func BsonArrayAggTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument, anonymous12 string) (outBsonArrayAggTransition struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonArrayAggTransition",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -187,7 +187,7 @@ func BsonArrayAggTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger,
 //
 //	documentdb_api_catalog.bson_array_agg_transition(anonymous bytea, anonymous1 documentdb_core.bson, anonymous12 text, anonymous123 boolean, OUT bson_array_agg_transition bytea).
 func BsonArrayAggTransition1(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument, anonymous12 string, anonymous123 bool) (outBsonArrayAggTransition struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonArrayAggTransition1",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -208,7 +208,7 @@ func BsonArrayAggTransition1(ctx context.Context, conn *pgx.Conn, l *slog.Logger
 //
 //	documentdb_api_catalog.bson_avg_final(anonymous bytea, OUT bson_avg_final documentdb_core.bson).
 func BsonAvgFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonAvgFinal wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonAvgFinal",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -229,7 +229,7 @@ func BsonAvgFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_api_catalog.bson_build_distinct_response(anonymous ARRAY, OUT bson_build_distinct_response documentdb_core.bson).
 func BsonBuildDistinctResponse(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonBuildDistinctResponse wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonBuildDistinctResponse",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -250,7 +250,7 @@ func BsonBuildDistinctResponse(ctx context.Context, conn *pgx.Conn, l *slog.Logg
 //
 //	documentdb_api_catalog.bson_distinct_agg(anonymous documentdb_core.bson).
 func BsonDistinctAgg(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDistinctAgg",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -271,7 +271,7 @@ func BsonDistinctAgg(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonym
 //
 //	documentdb_api_catalog.bson_distinct_unwind(anonymous documentdb_core.bson, anonymous1 text, OUT bson_distinct_unwind documentdb_core.bson).
 func BsonDistinctUnwind(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 string) (outBsonDistinctUnwind wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDistinctUnwind",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -292,7 +292,7 @@ func BsonDistinctUnwind(ctx context.Context, conn *pgx.Conn, l *slog.Logger, ano
 //
 //	documentdb_api_catalog.bson_dollar_add_fields(document documentdb_core.bson, pathspec documentdb_core.bson, OUT bson_dollar_add_fields documentdb_core.bson).
 func BsonDollarAddFields(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, pathSpec wirebson.RawDocument) (outBsonDollarAddFields wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarAddFields",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -313,7 +313,7 @@ func BsonDollarAddFields(ctx context.Context, conn *pgx.Conn, l *slog.Logger, do
 //
 //	documentdb_api_catalog.bson_dollar_all(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_all boolean).
 func BsonDollarAll(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarAll bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarAll",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -334,7 +334,7 @@ func BsonDollarAll(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymou
 //
 //	documentdb_api_catalog.bson_dollar_bits_all_clear(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_bits_all_clear boolean).
 func BsonDollarBitsAllClear(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarBitsAllClear bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarBitsAllClear",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -355,7 +355,7 @@ func BsonDollarBitsAllClear(ctx context.Context, conn *pgx.Conn, l *slog.Logger,
 //
 //	documentdb_api_catalog.bson_dollar_bits_all_set(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_bits_all_set boolean).
 func BsonDollarBitsAllSet(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarBitsAllSet bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarBitsAllSet",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -376,7 +376,7 @@ func BsonDollarBitsAllSet(ctx context.Context, conn *pgx.Conn, l *slog.Logger, a
 //
 //	documentdb_api_catalog.bson_dollar_bits_any_clear(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_bits_any_clear boolean).
 func BsonDollarBitsAnyClear(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarBitsAnyClear bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarBitsAnyClear",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -397,7 +397,7 @@ func BsonDollarBitsAnyClear(ctx context.Context, conn *pgx.Conn, l *slog.Logger,
 //
 //	documentdb_api_catalog.bson_dollar_bits_any_set(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_bits_any_set boolean).
 func BsonDollarBitsAnySet(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarBitsAnySet bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarBitsAnySet",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -418,7 +418,7 @@ func BsonDollarBitsAnySet(ctx context.Context, conn *pgx.Conn, l *slog.Logger, a
 //
 //	documentdb_api_catalog.bson_dollar_elemmatch(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_elemmatch boolean).
 func BsonDollarElemmatch(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarElemmatch bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarElemmatch",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -439,7 +439,7 @@ func BsonDollarElemmatch(ctx context.Context, conn *pgx.Conn, l *slog.Logger, an
 //
 //	documentdb_api_catalog.bson_dollar_eq(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_eq boolean).
 func BsonDollarEq(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarEq bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarEq",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -460,7 +460,7 @@ func BsonDollarEq(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_api_catalog.bson_dollar_eq(anonymous documentdb_core.bson, anonymous1 documentdb_core.bsonquery, OUT bson_dollar_eq boolean).
 func BsonDollarEq1(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 struct{}) (outBsonDollarEq bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarEq1",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -481,7 +481,7 @@ func BsonDollarEq1(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymou
 //
 //	documentdb_api_catalog.bson_dollar_exists(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_exists boolean).
 func BsonDollarExists(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarExists bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarExists",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -502,7 +502,7 @@ func BsonDollarExists(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anony
 //
 //	documentdb_api_catalog.bson_dollar_expr(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_expr boolean).
 func BsonDollarExpr(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarExpr bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarExpr",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -523,7 +523,7 @@ func BsonDollarExpr(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymo
 //
 //	documentdb_api_catalog.bson_dollar_facet_project(anonymous documentdb_core.bson, anonymous1 boolean, OUT bson_dollar_facet_project documentdb_core.bson).
 func BsonDollarFacetProject(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 bool) (outBsonDollarFacetProject wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarFacetProject",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -544,7 +544,7 @@ func BsonDollarFacetProject(ctx context.Context, conn *pgx.Conn, l *slog.Logger,
 //
 //	documentdb_api_catalog.bson_dollar_geointersects(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_geointersects boolean).
 func BsonDollarGeointersects(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarGeointersects bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarGeointersects",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -565,7 +565,7 @@ func BsonDollarGeointersects(ctx context.Context, conn *pgx.Conn, l *slog.Logger
 //
 //	documentdb_api_catalog.bson_dollar_geowithin(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_geowithin boolean).
 func BsonDollarGeowithin(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarGeowithin bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarGeowithin",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -586,7 +586,7 @@ func BsonDollarGeowithin(ctx context.Context, conn *pgx.Conn, l *slog.Logger, an
 //
 //	documentdb_api_catalog.bson_dollar_gt(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_gt boolean).
 func BsonDollarGt(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarGt bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarGt",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -607,7 +607,7 @@ func BsonDollarGt(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_api_catalog.bson_dollar_gt(anonymous documentdb_core.bson, anonymous1 documentdb_core.bsonquery, OUT bson_dollar_gt boolean).
 func BsonDollarGt1(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 struct{}) (outBsonDollarGt bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarGt1",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -628,7 +628,7 @@ func BsonDollarGt1(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymou
 //
 //	documentdb_api_catalog.bson_dollar_gte(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_gte boolean).
 func BsonDollarGte(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarGte bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarGte",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -649,7 +649,7 @@ func BsonDollarGte(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymou
 //
 //	documentdb_api_catalog.bson_dollar_gte(anonymous documentdb_core.bson, anonymous1 documentdb_core.bsonquery, OUT bson_dollar_gte boolean).
 func BsonDollarGte1(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 struct{}) (outBsonDollarGte bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarGte1",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -670,7 +670,7 @@ func BsonDollarGte1(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymo
 //
 //	documentdb_api_catalog.bson_dollar_in(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_in boolean).
 func BsonDollarIn(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarIn bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarIn",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -691,7 +691,7 @@ func BsonDollarIn(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_api_catalog.bson_dollar_json_schema(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_json_schema boolean).
 func BsonDollarJsonSchema(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarJsonSchema bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarJsonSchema",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -712,7 +712,7 @@ func BsonDollarJsonSchema(ctx context.Context, conn *pgx.Conn, l *slog.Logger, a
 //
 //	documentdb_api_catalog.bson_dollar_lt(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_lt boolean).
 func BsonDollarLt(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarLt bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarLt",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -733,7 +733,7 @@ func BsonDollarLt(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_api_catalog.bson_dollar_lt(anonymous documentdb_core.bson, anonymous1 documentdb_core.bsonquery, OUT bson_dollar_lt boolean).
 func BsonDollarLt1(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 struct{}) (outBsonDollarLt bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarLt1",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -754,7 +754,7 @@ func BsonDollarLt1(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymou
 //
 //	documentdb_api_catalog.bson_dollar_lte(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_lte boolean).
 func BsonDollarLte(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarLte bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarLte",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -775,7 +775,7 @@ func BsonDollarLte(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymou
 //
 //	documentdb_api_catalog.bson_dollar_lte(anonymous documentdb_core.bson, anonymous1 documentdb_core.bsonquery, OUT bson_dollar_lte boolean).
 func BsonDollarLte1(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 struct{}) (outBsonDollarLte bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarLte1",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -796,7 +796,7 @@ func BsonDollarLte1(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymo
 //
 //	documentdb_api_catalog.bson_dollar_mod(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_mod boolean).
 func BsonDollarMod(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarMod bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarMod",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -817,7 +817,7 @@ func BsonDollarMod(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymou
 //
 //	documentdb_api_catalog.bson_dollar_ne(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_ne boolean).
 func BsonDollarNe(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarNe bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarNe",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -838,7 +838,7 @@ func BsonDollarNe(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_api_catalog.bson_dollar_nin(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_nin boolean).
 func BsonDollarNin(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarNin bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarNin",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -859,7 +859,7 @@ func BsonDollarNin(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymou
 //
 //	documentdb_api_catalog.bson_dollar_project(document documentdb_core.bson, pathspec documentdb_core.bson, OUT bson_dollar_project documentdb_core.bson).
 func BsonDollarProject(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, pathSpec wirebson.RawDocument) (outBsonDollarProject wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarProject",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -880,7 +880,7 @@ func BsonDollarProject(ctx context.Context, conn *pgx.Conn, l *slog.Logger, docu
 //
 //	documentdb_api_catalog.bson_dollar_project_find(document documentdb_core.bson, pathspec documentdb_core.bson, queryspec documentdb_core.bson DEFAULT NULL, OUT bson_dollar_project_find documentdb_core.bson).
 func BsonDollarProjectFind(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, pathSpec wirebson.RawDocument, querySpec wirebson.RawDocument) (outBsonDollarProjectFind wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarProjectFind",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -901,7 +901,7 @@ func BsonDollarProjectFind(ctx context.Context, conn *pgx.Conn, l *slog.Logger,
 //
 //	documentdb_api_catalog.bson_dollar_project_geonear(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_project_geonear documentdb_core.bson).
 func BsonDollarProjectGeonear(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarProjectGeonear wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarProjectGeonear",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -922,7 +922,7 @@ func BsonDollarProjectGeonear(ctx context.Context, conn *pgx.Conn, l *slog.Logge
 //
 //	documentdb_api_catalog.bson_dollar_regex(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_regex boolean).
 func BsonDollarRegex(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarRegex bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarRegex",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -943,7 +943,7 @@ func BsonDollarRegex(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonym
 //
 //	documentdb_api_catalog.bson_dollar_replace_root(document documentdb_core.bson, pathspec documentdb_core.bson, OUT bson_dollar_replace_root documentdb_core.bson).
 func BsonDollarReplaceRoot(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, pathSpec wirebson.RawDocument) (outBsonDollarReplaceRoot wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarReplaceRoot",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -964,7 +964,7 @@ func BsonDollarReplaceRoot(ctx context.Context, conn *pgx.Conn, l *slog.Logger,
 //
 //	documentdb_api_catalog.bson_dollar_set(document documentdb_core.bson, pathspec documentdb_core.bson, OUT bson_dollar_set documentdb_core.bson).
 func BsonDollarSet(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, pathSpec wirebson.RawDocument) (outBsonDollarSet wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarSet",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -985,7 +985,7 @@ func BsonDollarSet(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document
 //
 //	documentdb_api_catalog.bson_dollar_size(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_size boolean).
 func BsonDollarSize(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarSize bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarSize",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1006,7 +1006,7 @@ func BsonDollarSize(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymo
 //
 //	documentdb_api_catalog.bson_dollar_type(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_type boolean).
 func BsonDollarType(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarType bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarType",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1027,7 +1027,7 @@ func BsonDollarType(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymo
 //
 //	documentdb_api_catalog.bson_dollar_unset(document documentdb_core.bson, pathspec documentdb_core.bson, OUT bson_dollar_unset documentdb_core.bson).
 func BsonDollarUnset(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, pathSpec wirebson.RawDocument) (outBsonDollarUnset wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarUnset",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1048,7 +1048,7 @@ func BsonDollarUnset(ctx context.Context, conn *pgx.Conn, l *slog.Logger, docume
 //
 //	documentdb_api_catalog.bson_dollar_unwind(anonymous documentdb_core.bson, anonymous1 text, OUT bson_dollar_unwind documentdb_core.bson).
 func BsonDollarUnwind(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 string) (outBsonDollarUnwind wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarUnwind",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1069,7 +1069,7 @@ func BsonDollarUnwind(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anony
 //
 //	documentdb_api_catalog.bson_dollar_unwind(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_unwind documentdb_core.bson).
 func BsonDollarUnwind1(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarUnwind wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonDollarUnwind1",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1090,7 +1090,7 @@ func BsonDollarUnwind1(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anon
 //
 //	documentdb_api_catalog.bson_expression_get(document documentdb_core.bson, expressionspec documentdb_core.bson, isnullonempty boolean DEFAULT false, OUT bson_expression_get documentdb_core.bson).
 func BsonExpressionGet(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, expressionSpec wirebson.RawDocument, isnullonempty bool) (outBsonExpressionGet wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonExpressionGet",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1111,7 +1111,7 @@ func BsonExpressionGet(ctx context.Context, conn *pgx.Conn, l *slog.Logger, docu
 //
 //	documentdb_api_catalog.bson_expression_map(document documentdb_core.bson, sourcearrayname text, expressionspec documentdb_core.bson, isnullonempty boolean DEFAULT false, OUT bson_expression_map documentdb_core.bson).
 func BsonExpressionMap(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, sourcearrayname string, expressionSpec wirebson.RawDocument, isnullonempty bool) (outBsonExpressionMap wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonExpressionMap",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1132,7 +1132,7 @@ func BsonExpressionMap(ctx context.Context, conn *pgx.Conn, l *slog.Logger, docu
 //
 //	documentdb_api_catalog.bson_first_combine(anonymous bytea, anonymous1 bytea, OUT bson_first_combine bytea).
 func BsonFirstCombine(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}) (outBsonFirstCombine struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonFirstCombine",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1153,7 +1153,7 @@ func BsonFirstCombine(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anony
 //
 //	documentdb_api_catalog.bson_first_last_final(anonymous bytea, OUT bson_first_last_final documentdb_core.bson).
 func BsonFirstLastFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonFirstLastFinal wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonFirstLastFinal",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1174,7 +1174,7 @@ func BsonFirstLastFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, ano
 //
 //	documentdb_api_catalog.bson_first_last_final_on_sorted(anonymous bytea, OUT bson_first_last_final_on_sorted documentdb_core.bson).
 func BsonFirstLastFinalOnSorted(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonFirstLastFinalOnSorted wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonFirstLastFinalOnSorted",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1195,7 +1195,7 @@ func BsonFirstLastFinalOnSorted(ctx context.Context, conn *pgx.Conn, l *slog.Log
 //
 //	documentdb_api_catalog.bson_first_transition(anonymous bytea, anonymous1 documentdb_core.bson, anonymous12 ARRAY, OUT bson_first_transition bytea).
 func BsonFirstTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument, anonymous12 struct{}) (outBsonFirstTransition struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonFirstTransition",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1216,7 +1216,7 @@ func BsonFirstTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, an
 //
 //	documentdb_api_catalog.bson_first_transition_on_sorted(anonymous bytea, anonymous1 documentdb_core.bson, OUT bson_first_transition_on_sorted bytea).
 func BsonFirstTransitionOnSorted(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument) (outBsonFirstTransitionOnSorted struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonFirstTransitionOnSorted",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1237,7 +1237,7 @@ func BsonFirstTransitionOnSorted(ctx context.Context, conn *pgx.Conn, l *slog.Lo
 //
 //	documentdb_api_catalog.bson_firstn_combine(anonymous bytea, anonymous1 bytea, OUT bson_firstn_combine bytea).
 func BsonFirstnCombine(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}) (outBsonFirstnCombine struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonFirstnCombine",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1258,7 +1258,7 @@ func BsonFirstnCombine(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anon
 //
 //	documentdb_api_catalog.bson_firstn_lastn_final(anonymous bytea, OUT bson_firstn_lastn_final documentdb_core.bson).
 func BsonFirstnLastnFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonFirstnLastnFinal wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonFirstnLastnFinal",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1279,7 +1279,7 @@ func BsonFirstnLastnFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, a
 //
 //	documentdb_api_catalog.bson_firstn_lastn_final_on_sorted(anonymous bytea, OUT bson_firstn_lastn_final_on_sorted documentdb_core.bson).
 func BsonFirstnLastnFinalOnSorted(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonFirstnLastnFinalOnSorted wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonFirstnLastnFinalOnSorted",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1300,7 +1300,7 @@ func BsonFirstnLastnFinalOnSorted(ctx context.Context, conn *pgx.Conn, l *slog.L
 //
 //	documentdb_api_catalog.bson_firstn_transition(anonymous bytea, anonymous1 documentdb_core.bson, anonymous12 bigint, anonymous123 ARRAY, OUT bson_firstn_transition bytea).
 func BsonFirstnTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument, anonymous12 int64, anonymous123 struct{}) (outBsonFirstnTransition struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonFirstnTransition",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1321,7 +1321,7 @@ func BsonFirstnTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, a
 //
 //	documentdb_api_catalog.bson_firstn_transition_on_sorted(anonymous bytea, anonymous1 documentdb_core.bson, anonymous12 bigint, OUT bson_firstn_transition_on_sorted bytea).
 func BsonFirstnTransitionOnSorted(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument, anonymous12 int64) (outBsonFirstnTransitionOnSorted struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonFirstnTransitionOnSorted",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1342,7 +1342,7 @@ func BsonFirstnTransitionOnSorted(ctx context.Context, conn *pgx.Conn, l *slog.L
 //
 //	documentdb_api_catalog.bson_geonear_distance(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_geonear_distance double precision).
 func BsonGeonearDistance(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonGeonearDistance float64, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonGeonearDistance",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1363,7 +1363,7 @@ func BsonGeonearDistance(ctx context.Context, conn *pgx.Conn, l *slog.Logger, an
 //
 //	documentdb_api_catalog.bson_gist_geography_compress(anonymous internal, OUT bson_gist_geography_compress internal).
 func BsonGistGeographyCompress(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonGistGeographyCompress struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonGistGeographyCompress",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1384,7 +1384,7 @@ func BsonGistGeographyCompress(ctx context.Context, conn *pgx.Conn, l *slog.Logg
 //
 //	documentdb_api_catalog.bson_gist_geography_consistent(anonymous internal, anonymous1 documentdb_core.bson, anonymous12 integer, OUT bson_gist_geography_consistent boolean).
 func BsonGistGeographyConsistent(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument, anonymous12 int32) (outBsonGistGeographyConsistent bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonGistGeographyConsistent",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1405,7 +1405,7 @@ func BsonGistGeographyConsistent(ctx context.Context, conn *pgx.Conn, l *slog.Lo
 //
 //	documentdb_api_catalog.bson_gist_geography_distance(anonymous internal, anonymous1 documentdb_core.bson, anonymous12 integer, OUT bson_gist_geography_distance double precision).
 func BsonGistGeographyDistance(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument, anonymous12 int32) (outBsonGistGeographyDistance float64, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonGistGeographyDistance",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1426,7 +1426,7 @@ func BsonGistGeographyDistance(ctx context.Context, conn *pgx.Conn, l *slog.Logg
 //
 //	documentdb_api_catalog.bson_gist_geography_options(anonymous internal).
 func BsonGistGeographyOptions(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonGistGeographyOptions",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1447,7 +1447,7 @@ func BsonGistGeographyOptions(ctx context.Context, conn *pgx.Conn, l *slog.Logge
 //
 //	documentdb_api_catalog.bson_gist_geometry_2d_compress(anonymous internal, OUT bson_gist_geometry_2d_compress internal).
 func BsonGistGeometry2dCompress(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonGistGeometry2dCompress struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonGistGeometry2dCompress",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1468,7 +1468,7 @@ func BsonGistGeometry2dCompress(ctx context.Context, conn *pgx.Conn, l *slog.Log
 //
 //	documentdb_api_catalog.bson_gist_geometry_2d_options(anonymous internal).
 func BsonGistGeometry2dOptions(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonGistGeometry2dOptions",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1489,7 +1489,7 @@ func BsonGistGeometry2dOptions(ctx context.Context, conn *pgx.Conn, l *slog.Logg
 //
 //	documentdb_api_catalog.bson_gist_geometry_consistent_2d(anonymous internal, anonymous1 documentdb_core.bson, anonymous12 integer, OUT bson_gist_geometry_consistent_2d boolean).
 func BsonGistGeometryConsistent2d(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument, anonymous12 int32) (outBsonGistGeometryConsistent2d bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonGistGeometryConsistent2d",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1510,7 +1510,7 @@ func BsonGistGeometryConsistent2d(ctx context.Context, conn *pgx.Conn, l *slog.L
 //
 //	documentdb_api_catalog.bson_gist_geometry_distance_2d(anonymous internal, anonymous1 documentdb_core.bson, anonymous12 integer, OUT bson_gist_geometry_distance_2d double precision).
 func BsonGistGeometryDistance2d(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument, anonymous12 int32) (outBsonGistGeometryDistance2d float64, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonGistGeometryDistance2d",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1531,7 +1531,7 @@ func BsonGistGeometryDistance2d(ctx context.Context, conn *pgx.Conn, l *slog.Log
 //
 //	documentdb_api_catalog.bson_last_combine(anonymous bytea, anonymous1 bytea, OUT bson_last_combine bytea).
 func BsonLastCombine(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}) (outBsonLastCombine struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonLastCombine",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1552,7 +1552,7 @@ func BsonLastCombine(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonym
 //
 //	documentdb_api_catalog.bson_last_transition(anonymous bytea, anonymous1 documentdb_core.bson, anonymous12 ARRAY, OUT bson_last_transition bytea).
 func BsonLastTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument, anonymous12 struct{}) (outBsonLastTransition struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonLastTransition",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1573,7 +1573,7 @@ func BsonLastTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, ano
 //
 //	documentdb_api_catalog.bson_last_transition_on_sorted(anonymous bytea, anonymous1 documentdb_core.bson, OUT bson_last_transition_on_sorted bytea).
 func BsonLastTransitionOnSorted(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument) (outBsonLastTransitionOnSorted struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonLastTransitionOnSorted",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1594,7 +1594,7 @@ func BsonLastTransitionOnSorted(ctx context.Context, conn *pgx.Conn, l *slog.Log
 //
 //	documentdb_api_catalog.bson_lastn_combine(anonymous bytea, anonymous1 bytea, OUT bson_lastn_combine bytea).
 func BsonLastnCombine(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}) (outBsonLastnCombine struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonLastnCombine",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1615,7 +1615,7 @@ func BsonLastnCombine(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anony
 //
 //	documentdb_api_catalog.bson_lastn_transition(anonymous bytea, anonymous1 documentdb_core.bson, anonymous12 bigint, anonymous123 ARRAY, OUT bson_lastn_transition bytea).
 func BsonLastnTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument, anonymous12 int64, anonymous123 struct{}) (outBsonLastnTransition struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonLastnTransition",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1636,7 +1636,7 @@ func BsonLastnTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, an
 //
 //	documentdb_api_catalog.bson_lastn_transition_on_sorted(anonymous bytea, anonymous1 documentdb_core.bson, anonymous12 bigint, OUT bson_lastn_transition_on_sorted bytea).
 func BsonLastnTransitionOnSorted(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument, anonymous12 int64) (outBsonLastnTransitionOnSorted struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonLastnTransitionOnSorted",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1657,7 +1657,7 @@ func BsonLastnTransitionOnSorted(ctx context.Context, conn *pgx.Conn, l *slog.Lo
 //
 //	documentdb_api_catalog.bson_lookup_unwind(anonymous documentdb_core.bson, anonymous1 text, OUT bson_lookup_unwind documentdb_core.bson).
 func BsonLookupUnwind(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 string) (outBsonLookupUnwind wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonLookupUnwind",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1678,7 +1678,7 @@ func BsonLookupUnwind(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anony
 //
 //	documentdb_api_catalog.bson_max_combine(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_max_combine documentdb_core.bson).
 func BsonMaxCombine(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonMaxCombine wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonMaxCombine",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1699,7 +1699,7 @@ func BsonMaxCombine(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymo
 //
 //	documentdb_api_catalog.bson_max_transition(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_max_transition documentdb_core.bson).
 func BsonMaxTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonMaxTransition wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonMaxTransition",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1720,7 +1720,7 @@ func BsonMaxTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anon
 //
 //	documentdb_api_catalog.bson_min_combine(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_min_combine documentdb_core.bson).
 func BsonMinCombine(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonMinCombine wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonMinCombine",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1741,7 +1741,7 @@ func BsonMinCombine(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymo
 //
 //	documentdb_api_catalog.bson_min_max_final(anonymous documentdb_core.bson, OUT bson_min_max_final documentdb_core.bson).
 func BsonMinMaxFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument) (outBsonMinMaxFinal wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonMinMaxFinal",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1762,7 +1762,7 @@ func BsonMinMaxFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonym
 //
 //	documentdb_api_catalog.bson_min_transition(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_min_transition documentdb_core.bson).
 func BsonMinTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonMinTransition wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonMinTransition",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1783,7 +1783,7 @@ func BsonMinTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anon
 //
 //	documentdb_api_catalog.bson_object_agg(anonymous documentdb_core.bson).
 func BsonObjectAgg(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonObjectAgg",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1804,7 +1804,7 @@ func BsonObjectAgg(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymou
 //
 //	documentdb_api_catalog.bson_object_agg_final(anonymous bytea, OUT bson_object_agg_final documentdb_core.bson).
 func BsonObjectAggFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonObjectAggFinal wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonObjectAggFinal",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1825,7 +1825,7 @@ func BsonObjectAggFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, ano
 //
 //	documentdb_api_catalog.bson_object_agg_transition(anonymous bytea, anonymous1 documentdb_core.bson, OUT bson_object_agg_transition bytea).
 func BsonObjectAggTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument) (outBsonObjectAggTransition struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonObjectAggTransition",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1846,7 +1846,7 @@ func BsonObjectAggTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger
 //
 //	documentdb_api_catalog.bson_orderby(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_orderby documentdb_core.bson).
 func BsonOrderby(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonOrderby wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonOrderby",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1867,7 +1867,7 @@ func BsonOrderby(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_api_catalog.bson_query_match(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_query_match boolean).
 func BsonQueryMatch(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonQueryMatch bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonQueryMatch",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1888,7 +1888,7 @@ func BsonQueryMatch(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymo
 //
 //	documentdb_api_catalog.bson_sum_avg_combine(anonymous bytea, anonymous1 bytea, OUT bson_sum_avg_combine bytea).
 func BsonSumAvgCombine(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}) (outBsonSumAvgCombine struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonSumAvgCombine",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1909,7 +1909,7 @@ func BsonSumAvgCombine(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anon
 //
 //	documentdb_api_catalog.bson_sum_avg_transition(anonymous bytea, anonymous1 documentdb_core.bson, OUT bson_sum_avg_transition bytea).
 func BsonSumAvgTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument) (outBsonSumAvgTransition struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonSumAvgTransition",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1930,7 +1930,7 @@ func BsonSumAvgTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, a
 //
 //	documentdb_api_catalog.bson_sum_final(anonymous bytea, OUT bson_sum_final documentdb_core.bson).
 func BsonSumFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonSumFinal wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonSumFinal",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1951,7 +1951,7 @@ func BsonSumFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_api_catalog.bson_true_match(anonymous documentdb_core.bson, OUT bson_true_match boolean).
 func BsonTrueMatch(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument) (outBsonTrueMatch bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonTrueMatch",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1972,7 +1972,7 @@ func BsonTrueMatch(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymou
 //
 //	documentdb_api_catalog.bson_validate_geography(p_document documentdb_core.bson, p_keypath text, OUT bson_validate_geography documentdb_core.bson).
 func BsonValidateGeography(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, keypath string) (outBsonValidateGeography wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonValidateGeography",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1993,7 +1993,7 @@ func BsonValidateGeography(ctx context.Context, conn *pgx.Conn, l *slog.Logger,
 //
 //	documentdb_api_catalog.bson_validate_geometry(p_document documentdb_core.bson, p_keypath text, OUT bson_validate_geometry documentdb_core.bson).
 func BsonValidateGeometry(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, keypath string) (outBsonValidateGeometry wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.BsonValidateGeometry",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2014,7 +2014,7 @@ func BsonValidateGeometry(ctx context.Context, conn *pgx.Conn, l *slog.Logger, d
 //
 //	documentdb_api_catalog.bsonaverage(anonymous documentdb_core.bson).
 func Bsonaverage(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.Bsonaverage",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2035,7 +2035,7 @@ func Bsonaverage(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_api_catalog.bsonfirst(anonymous documentdb_core.bson, anonymous1 ARRAY).
 func Bsonfirst(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 struct{}) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.Bsonfirst",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2056,7 +2056,7 @@ func Bsonfirst(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wi
 //
 //	documentdb_api_catalog.bsonfirstn(anonymous documentdb_core.bson, anonymous1 bigint, anonymous12 ARRAY).
 func Bsonfirstn(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 int64, anonymous12 struct{}) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.Bsonfirstn",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2077,7 +2077,7 @@ func Bsonfirstn(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous w
 //
 //	documentdb_api_catalog.bsonfirstnonsorted(anonymous documentdb_core.bson, anonymous1 bigint).
 func Bsonfirstnonsorted(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 int64) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.Bsonfirstnonsorted",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2098,7 +2098,7 @@ func Bsonfirstnonsorted(ctx context.Context, conn *pgx.Conn, l *slog.Logger, ano
 //
 //	documentdb_api_catalog.bsonfirstonsorted(anonymous documentdb_core.bson).
 func Bsonfirstonsorted(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.Bsonfirstonsorted",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2119,7 +2119,7 @@ func Bsonfirstonsorted(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anon
 //
 //	documentdb_api_catalog.bsonlast(anonymous documentdb_core.bson, anonymous1 ARRAY).
 func Bsonlast(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 struct{}) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.Bsonlast",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2140,7 +2140,7 @@ func Bsonlast(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wir
 //
 //	documentdb_api_catalog.bsonlastn(anonymous documentdb_core.bson, anonymous1 bigint, anonymous12 ARRAY).
 func Bsonlastn(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 int64, anonymous12 struct{}) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.Bsonlastn",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2161,7 +2161,7 @@ func Bsonlastn(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wi
 //
 //	documentdb_api_catalog.bsonlastnonsorted(anonymous documentdb_core.bson, anonymous1 bigint).
 func Bsonlastnonsorted(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 int64) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.Bsonlastnonsorted",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2182,7 +2182,7 @@ func Bsonlastnonsorted(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anon
 //
 //	documentdb_api_catalog.bsonlastonsorted(anonymous documentdb_core.bson).
 func Bsonlastonsorted(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.Bsonlastonsorted",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2203,7 +2203,7 @@ func Bsonlastonsorted(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anony
 //
 //	documentdb_api_catalog.bsonmax(anonymous documentdb_core.bson).
 func Bsonmax(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.Bsonmax",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2224,7 +2224,7 @@ func Bsonmax(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wire
 //
 //	documentdb_api_catalog.bsonmin(anonymous documentdb_core.bson).
 func Bsonmin(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.Bsonmin",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2245,7 +2245,7 @@ func Bsonmin(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wire
 //
 //	documentdb_api_catalog.bsonsum(anonymous documentdb_core.bson).
 func Bsonsum(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.Bsonsum",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2266,7 +2266,7 @@ func Bsonsum(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wire
 //
 //	documentdb_api_catalog.documentdbrumhandler(anonymous internal, OUT documentdbrumhandler index_am_handler).
 func Documentdbrumhandler(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outDocumentdbrumhandler struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.Documentdbrumhandler",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2287,7 +2287,7 @@ func Documentdbrumhandler(ctx context.Context, conn *pgx.Conn, l *slog.Logger, a
 //
 //	documentdb_api_catalog.dollar_support(anonymous internal, OUT dollar_support internal).
 func DollarSupport(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outDollarSupport struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.DollarSupport",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2308,7 +2308,7 @@ func DollarSupport(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymou
 //
 //	documentdb_api_catalog.gin_bson_can_pre_consistent(anonymous smallint, anonymous1 documentdb_core.bson, anonymous12 integer, anonymous123 internal, anonymous1234 internal, anonymous12345 internal, OUT gin_bson_can_pre_consistent boolean).
 func GinBsonCanPreConsistent(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument, anonymous12 int32, anonymous123 struct{}, anonymous1234 struct{}, anonymous12345 struct{}) (outGinBsonCanPreConsistent bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.GinBsonCanPreConsistent",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2329,7 +2329,7 @@ func GinBsonCanPreConsistent(ctx context.Context, conn *pgx.Conn, l *slog.Logger
 //
 //	documentdb_api_catalog.gin_bson_compare(anonymous bytea, anonymous1 bytea, OUT gin_bson_compare boolean).
 func GinBsonCompare(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}) (outGinBsonCompare bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.GinBsonCompare",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2350,7 +2350,7 @@ func GinBsonCompare(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymo
 //
 //	documentdb_api_catalog.gin_bson_compare_partial(anonymous bytea, anonymous1 bytea, anonymous12 smallint, anonymous123 internal, OUT gin_bson_compare_partial integer).
 func GinBsonComparePartial(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}, anonymous12 struct{}, anonymous123 struct{}) (outGinBsonComparePartial int32, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.GinBsonComparePartial",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2371,7 +2371,7 @@ func GinBsonComparePartial(ctx context.Context, conn *pgx.Conn, l *slog.Logger,
 //
 //	documentdb_api_catalog.gin_bson_consistent(anonymous internal, anonymous1 smallint, anonymous12 anyelement, anonymous123 integer, anonymous1234 internal, anonymous12345 internal, OUT gin_bson_consistent boolean).
 func GinBsonConsistent(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}, anonymous12 struct{}, anonymous123 int32, anonymous1234 struct{}, anonymous12345 struct{}) (outGinBsonConsistent bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.GinBsonConsistent",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2392,7 +2392,7 @@ func GinBsonConsistent(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anon
 //
 //	documentdb_api_catalog.gin_bson_exclusion_pre_consistent(anonymous internal, anonymous1 smallint, anonymous12 documentdb_api_catalog.shard_key_and_document, anonymous123 integer, anonymous1234 internal, anonymous12345 internal, anonymous123456 internal, anonymous1234567 internal).
 func GinBsonExclusionPreConsistent(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}, anonymous12 struct{}, anonymous123 int32, anonymous1234 struct{}, anonymous12345 struct{}, anonymous123456 struct{}, anonymous1234567 struct{}) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.GinBsonExclusionPreConsistent",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2413,7 +2413,7 @@ func GinBsonExclusionPreConsistent(ctx context.Context, conn *pgx.Conn, l *slog.
 //
 //	documentdb_api_catalog.gin_bson_extract_query(anonymous documentdb_core.bson, anonymous1 internal, anonymous12 smallint, anonymous123 internal, anonymous1234 internal, anonymous12345 internal, anonymous123456 internal, OUT gin_bson_extract_query internal).
 func GinBsonExtractQuery(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 struct{}, anonymous12 struct{}, anonymous123 struct{}, anonymous1234 struct{}, anonymous12345 struct{}, anonymous123456 struct{}) (outGinBsonExtractQuery struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.GinBsonExtractQuery",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2434,7 +2434,7 @@ func GinBsonExtractQuery(ctx context.Context, conn *pgx.Conn, l *slog.Logger, an
 //
 //	documentdb_api_catalog.gin_bson_pre_consistent(anonymous internal, anonymous1 smallint, anonymous12 documentdb_core.bson, anonymous123 integer, anonymous1234 internal, anonymous12345 internal, anonymous123456 internal, anonymous1234567 internal).
 func GinBsonPreConsistent(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}, anonymous12 wirebson.RawDocument, anonymous123 int32, anonymous1234 struct{}, anonymous12345 struct{}, anonymous123456 struct{}, anonymous1234567 struct{}) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.GinBsonPreConsistent",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2455,7 +2455,7 @@ func GinBsonPreConsistent(ctx context.Context, conn *pgx.Conn, l *slog.Logger, a
 //
 //	documentdb_api_catalog.gin_bson_single_path_extract_value(anonymous documentdb_core.bson, anonymous1 internal, OUT gin_bson_single_path_extract_value internal).
 func GinBsonSinglePathExtractValue(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 struct{}) (outGinBsonSinglePathExtractValue struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.GinBsonSinglePathExtractValue",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2476,7 +2476,7 @@ func GinBsonSinglePathExtractValue(ctx context.Context, conn *pgx.Conn, l *slog.
 //
 //	documentdb_api_catalog.gin_bson_single_path_options(anonymous internal).
 func GinBsonSinglePathOptions(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.GinBsonSinglePathOptions",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2497,7 +2497,7 @@ func GinBsonSinglePathOptions(ctx context.Context, conn *pgx.Conn, l *slog.Logge
 //
 //	documentdb_api_catalog.gin_bson_wildcard_project_extract_value(anonymous documentdb_core.bson, anonymous1 internal, OUT gin_bson_wildcard_project_extract_value internal).
 func GinBsonWildcardProjectExtractValue(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 struct{}) (outGinBsonWildcardProjectExtractValue struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.GinBsonWildcardProjectExtractValue",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2518,7 +2518,7 @@ func GinBsonWildcardProjectExtractValue(ctx context.Context, conn *pgx.Conn, l *
 //
 //	documentdb_api_catalog.gin_bson_wildcard_project_options(anonymous internal).
 func GinBsonWildcardProjectOptions(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.GinBsonWildcardProjectOptions",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2539,7 +2539,7 @@ func GinBsonWildcardProjectOptions(ctx context.Context, conn *pgx.Conn, l *slog.
 //
 //	documentdb_api_catalog.query_match_support(anonymous internal, OUT query_match_support internal).
 func QueryMatchSupport(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outQueryMatchSupport struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_catalog.QueryMatchSupport",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
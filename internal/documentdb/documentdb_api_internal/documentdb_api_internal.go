@@ -8,18 +8,18 @@
 
 	"github.com/FerretDB/wire/wirebson"
 	"github.com/jackc/pgx/v5"
-	"go.opentelemetry.io/otel"
 	otelsemconv "go.opentelemetry.io/otel/semconv/v1.34.0"
 	oteltrace "go.opentelemetry.io/otel/trace"
 
 	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+	"github.com/FerretDB/FerretDB/v2/internal/util/observability"
 )
 
 // AggregationSupport is a wrapper for
 //
 //	documentdb_api_internal.aggregation_support(anonymous internal, OUT aggregation_support internal).
 func AggregationSupport(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outAggregationSupport struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.AggregationSupport",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -40,7 +40,7 @@ func AggregationSupport(ctx context.Context, conn *pgx.Conn, l *slog.Logger, ano
 //
 //	documentdb_api_internal.apply_extension_data_table_upgrade(anonymous integer, anonymous1 integer, anonymous12 integer).
 func ApplyExtensionDataTableUpgrade(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous int32, anonymous1 int32, anonymous12 int32) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.ApplyExtensionDataTableUpgrade",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -61,7 +61,7 @@ func ApplyExtensionDataTableUpgrade(ctx context.Context, conn *pgx.Conn, l *slog
 //
 //	documentdb_api_internal.authenticate_with_scram_sha256(p_user_name text, p_auth_msg text, p_client_proof text, OUT authenticate_with_scram_sha256 documentdb_core.bson).
 func AuthenticateWithScramSha256(ctx context.Context, conn *pgx.Conn, l *slog.Logger, userName string, authMsg string, clientProof string) (outAuthenticateWithScramSha256 wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.AuthenticateWithScramSha256",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -82,7 +82,7 @@ func AuthenticateWithScramSha256(ctx context.Context, conn *pgx.Conn, l *slog.Lo
 //
 //	documentdb_api_internal.bson_add_to_set(anonymous documentdb_core.bson).
 func BsonAddToSet(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonAddToSet",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -103,7 +103,7 @@ func BsonAddToSet(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_api_internal.bson_add_to_set_final(anonymous bytea, OUT bson_add_to_set_final documentdb_core.bson).
 func BsonAddToSetFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonAddToSetFinal wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonAddToSetFinal",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -124,7 +124,7 @@ func BsonAddToSetFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anon
 //
 //	documentdb_api_internal.bson_add_to_set_transition(anonymous bytea, anonymous1 documentdb_core.bson, OUT bson_add_to_set_transition bytea).
 func BsonAddToSetTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument) (outBsonAddToSetTransition struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonAddToSetTransition",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -145,7 +145,7 @@ func BsonAddToSetTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger,
 //
 //	documentdb_api_internal.bson_array_agg_minvtransition(anonymous bytea, anonymous1 documentdb_core.bson, anonymous12 text, anonymous123 boolean, OUT bson_array_agg_minvtransition bytea).
 func BsonArrayAggMinvtransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument, anonymous12 string, anonymous123 bool) (outBsonArrayAggMinvtransition struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonArrayAggMinvtransition",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -166,7 +166,7 @@ func BsonArrayAggMinvtransition(ctx context.Context, conn *pgx.Conn, l *slog.Log
 //
 //	documentdb_api_internal.bson_const_fill(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson).
 func BsonConstFill(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonConstFill",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -187,7 +187,7 @@ func BsonConstFill(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymou
 //
 //	documentdb_api_internal.bson_covariance_pop_final(anonymous bytea, OUT bson_covariance_pop_final documentdb_core.bson).
 func BsonCovariancePopFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonCovariancePopFinal wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonCovariancePopFinal",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -208,7 +208,7 @@ func BsonCovariancePopFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger,
 //
 //	documentdb_api_internal.bson_covariance_pop_samp_combine(anonymous bytea, anonymous1 bytea, OUT bson_covariance_pop_samp_combine bytea).
 func BsonCovariancePopSampCombine(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}) (outBsonCovariancePopSampCombine struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonCovariancePopSampCombine",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -229,7 +229,7 @@ func BsonCovariancePopSampCombine(ctx context.Context, conn *pgx.Conn, l *slog.L
 //
 //	documentdb_api_internal.bson_covariance_pop_samp_invtransition(anonymous bytea, anonymous1 documentdb_core.bson, anonymous12 documentdb_core.bson, OUT bson_covariance_pop_samp_invtransition bytea).
 func BsonCovariancePopSampInvtransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument, anonymous12 wirebson.RawDocument) (outBsonCovariancePopSampInvtransition struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonCovariancePopSampInvtransition",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -250,7 +250,7 @@ func BsonCovariancePopSampInvtransition(ctx context.Context, conn *pgx.Conn, l *
 //
 //	documentdb_api_internal.bson_covariance_pop_samp_transition(anonymous bytea, anonymous1 documentdb_core.bson, anonymous12 documentdb_core.bson, OUT bson_covariance_pop_samp_transition bytea).
 func BsonCovariancePopSampTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument, anonymous12 wirebson.RawDocument) (outBsonCovariancePopSampTransition struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonCovariancePopSampTransition",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -271,7 +271,7 @@ func BsonCovariancePopSampTransition(ctx context.Context, conn *pgx.Conn, l *slo
 //
 //	documentdb_api_internal.bson_covariance_samp_final(anonymous bytea, OUT bson_covariance_samp_final documentdb_core.bson).
 func BsonCovarianceSampFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonCovarianceSampFinal wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonCovarianceSampFinal",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -292,7 +292,7 @@ func BsonCovarianceSampFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger
 //
 //	documentdb_api_internal.bson_dense_rank().
 func BsonDenseRank(ctx context.Context, conn *pgx.Conn, l *slog.Logger) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDenseRank",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -313,7 +313,7 @@ func BsonDenseRank(ctx context.Context, conn *pgx.Conn, l *slog.Logger) (err err
 //
 //	documentdb_api_internal.bson_densify_full(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson).
 func BsonDensifyFull(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDensifyFull",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -334,7 +334,7 @@ func BsonDensifyFull(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonym
 //
 //	documentdb_api_internal.bson_densify_partition(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson).
 func BsonDensifyPartition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDensifyPartition",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -355,7 +355,7 @@ func BsonDensifyPartition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, a
 //
 //	documentdb_api_internal.bson_densify_range(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson).
 func BsonDensifyRange(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDensifyRange",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -376,7 +376,7 @@ func BsonDensifyRange(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anony
 //
 //	documentdb_api_internal.bson_derivative_transition(anonymous bytea, anonymous1 documentdb_core.bson, anonymous12 documentdb_core.bson, anonymous123 bigint, OUT bson_derivative_transition bytea).
 func BsonDerivativeTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument, anonymous12 wirebson.RawDocument, anonymous123 int64) (outBsonDerivativeTransition struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDerivativeTransition",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -397,7 +397,7 @@ func BsonDerivativeTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logge
 //
 //	documentdb_api_internal.bson_distinct_array_agg_final(anonymous bytea, OUT bson_distinct_array_agg_final documentdb_core.bson).
 func BsonDistinctArrayAggFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonDistinctArrayAggFinal wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDistinctArrayAggFinal",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -418,7 +418,7 @@ func BsonDistinctArrayAggFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logg
 //
 //	documentdb_api_internal.bson_distinct_array_agg_transition(anonymous bytea, anonymous1 documentdb_core.bson, OUT bson_distinct_array_agg_transition bytea).
 func BsonDistinctArrayAggTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument) (outBsonDistinctArrayAggTransition struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDistinctArrayAggTransition",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -439,7 +439,7 @@ func BsonDistinctArrayAggTransition(ctx context.Context, conn *pgx.Conn, l *slog
 //
 //	documentdb_api_internal.bson_document_add_score_field(anonymous documentdb_core.bson, anonymous1 double precision, OUT bson_document_add_score_field documentdb_core.bson).
 func BsonDocumentAddScoreField(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 float64) (outBsonDocumentAddScoreField wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDocumentAddScoreField",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -460,7 +460,7 @@ func BsonDocumentAddScoreField(ctx context.Context, conn *pgx.Conn, l *slog.Logg
 //
 //	documentdb_api_internal.bson_document_number().
 func BsonDocumentNumber(ctx context.Context, conn *pgx.Conn, l *slog.Logger) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDocumentNumber",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -481,7 +481,7 @@ func BsonDocumentNumber(ctx context.Context, conn *pgx.Conn, l *slog.Logger) (er
 //
 //	documentdb_api_internal.bson_dollar_add_fields(document documentdb_core.bson, pathspec documentdb_core.bson, letvariablespec documentdb_core.bson, OUT bson_dollar_add_fields documentdb_core.bson).
 func BsonDollarAddFields(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, pathSpec wirebson.RawDocument, letVariableSpec wirebson.RawDocument) (outBsonDollarAddFields wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarAddFields",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -502,7 +502,7 @@ func BsonDollarAddFields(ctx context.Context, conn *pgx.Conn, l *slog.Logger, do
 //
 //	documentdb_api_internal.bson_dollar_add_fields(document documentdb_core.bson, pathspec documentdb_core.bson, letvariablespec documentdb_core.bson, collationstring text, OUT bson_dollar_add_fields documentdb_core.bson).
 func BsonDollarAddFields1(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, pathSpec wirebson.RawDocument, letVariableSpec wirebson.RawDocument, collationstring string) (outBsonDollarAddFields wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarAddFields1",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -523,7 +523,7 @@ func BsonDollarAddFields1(ctx context.Context, conn *pgx.Conn, l *slog.Logger, d
 //
 //	documentdb_api_internal.bson_dollar_bucket_auto(document documentdb_core.bson, spec documentdb_core.bson).
 func BsonDollarBucketAuto(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, spec wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarBucketAuto",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -544,7 +544,7 @@ func BsonDollarBucketAuto(ctx context.Context, conn *pgx.Conn, l *slog.Logger, d
 //
 //	documentdb_api_internal.bson_dollar_eq(anonymous documentdb_core.bson, anonymous1 documentdb_api_internal.bsonindexbounds, OUT bson_dollar_eq boolean).
 func BsonDollarEq(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 struct{}) (outBsonDollarEq bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarEq",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -565,7 +565,7 @@ func BsonDollarEq(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_api_internal.bson_dollar_expr(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, anonymous12 documentdb_core.bson, OUT bson_dollar_expr boolean).
 func BsonDollarExpr(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument, anonymous12 wirebson.RawDocument) (outBsonDollarExpr bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarExpr",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -586,7 +586,7 @@ func BsonDollarExpr(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymo
 //
 //	documentdb_api_internal.bson_dollar_extract_merge_filter(anonymous documentdb_core.bson, anonymous1 text, OUT bson_dollar_extract_merge_filter documentdb_core.bson).
 func BsonDollarExtractMergeFilter(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 string) (outBsonDollarExtractMergeFilter wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarExtractMergeFilter",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -607,7 +607,7 @@ func BsonDollarExtractMergeFilter(ctx context.Context, conn *pgx.Conn, l *slog.L
 //
 //	documentdb_api_internal.bson_dollar_fullscan(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_fullscan boolean).
 func BsonDollarFullscan(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarFullscan bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarFullscan",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -628,7 +628,7 @@ func BsonDollarFullscan(ctx context.Context, conn *pgx.Conn, l *slog.Logger, ano
 //
 //	documentdb_api_internal.bson_dollar_gt(anonymous documentdb_core.bson, anonymous1 documentdb_api_internal.bsonindexbounds, OUT bson_dollar_gt boolean).
 func BsonDollarGt(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 struct{}) (outBsonDollarGt bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarGt",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -649,7 +649,7 @@ func BsonDollarGt(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_api_internal.bson_dollar_gte(anonymous documentdb_core.bson, anonymous1 documentdb_api_internal.bsonindexbounds, OUT bson_dollar_gte boolean).
 func BsonDollarGte(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 struct{}) (outBsonDollarGte bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarGte",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -670,7 +670,7 @@ func BsonDollarGte(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymou
 //
 //	documentdb_api_internal.bson_dollar_index_hint(document documentdb_core.bson, index_name text, key_document documentdb_core.bson, is_sparse boolean, OUT bson_dollar_index_hint boolean).
 func BsonDollarIndexHint(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, indexName string, keyDocument wirebson.RawDocument, isSparse bool) (outBsonDollarIndexHint bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarIndexHint",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -691,7 +691,7 @@ func BsonDollarIndexHint(ctx context.Context, conn *pgx.Conn, l *slog.Logger, do
 //
 //	documentdb_api_internal.bson_dollar_inverse_match(document documentdb_core.bson, spec documentdb_core.bson, OUT bson_dollar_inverse_match boolean).
 func BsonDollarInverseMatch(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, spec wirebson.RawDocument) (outBsonDollarInverseMatch bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarInverseMatch",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -712,7 +712,7 @@ func BsonDollarInverseMatch(ctx context.Context, conn *pgx.Conn, l *slog.Logger,
 //
 //	documentdb_api_internal.bson_dollar_lookup_expression_eval_merge(document documentdb_core.bson, pathspec documentdb_core.bson, variablespec documentdb_core.bson, OUT bson_dollar_lookup_expression_eval_merge documentdb_core.bson).
 func BsonDollarLookupExpressionEvalMerge(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, pathSpec wirebson.RawDocument, variableSpec wirebson.RawDocument) (outBsonDollarLookupExpressionEvalMerge wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarLookupExpressionEvalMerge",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -733,7 +733,7 @@ func BsonDollarLookupExpressionEvalMerge(ctx context.Context, conn *pgx.Conn, l
 //
 //	documentdb_api_internal.bson_dollar_lookup_extract_filter_array(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_lookup_extract_filter_array ARRAY).
 func BsonDollarLookupExtractFilterArray(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarLookupExtractFilterArray struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarLookupExtractFilterArray",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -754,7 +754,7 @@ func BsonDollarLookupExtractFilterArray(ctx context.Context, conn *pgx.Conn, l *
 //
 //	documentdb_api_internal.bson_dollar_lookup_extract_filter_expression(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_lookup_extract_filter_expression documentdb_core.bson).
 func BsonDollarLookupExtractFilterExpression(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarLookupExtractFilterExpression wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarLookupExtractFilterExpression",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -775,7 +775,7 @@ func BsonDollarLookupExtractFilterExpression(ctx context.Context, conn *pgx.Conn
 //
 //	documentdb_api_internal.bson_dollar_lookup_filter_support(anonymous internal, OUT bson_dollar_lookup_filter_support internal).
 func BsonDollarLookupFilterSupport(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonDollarLookupFilterSupport struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarLookupFilterSupport",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -796,7 +796,7 @@ func BsonDollarLookupFilterSupport(ctx context.Context, conn *pgx.Conn, l *slog.
 //
 //	documentdb_api_internal.bson_dollar_lookup_join_filter(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, anonymous12 text, OUT bson_dollar_lookup_join_filter boolean).
 func BsonDollarLookupJoinFilter(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument, anonymous12 string) (outBsonDollarLookupJoinFilter bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarLookupJoinFilter",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -817,7 +817,7 @@ func BsonDollarLookupJoinFilter(ctx context.Context, conn *pgx.Conn, l *slog.Log
 //
 //	documentdb_api_internal.bson_dollar_lookup_project(anonymous documentdb_core.bson, anonymous1 ARRAY, anonymous12 text, OUT bson_dollar_lookup_project documentdb_core.bson).
 func BsonDollarLookupProject(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 struct{}, anonymous12 string) (outBsonDollarLookupProject wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarLookupProject",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -838,7 +838,7 @@ func BsonDollarLookupProject(ctx context.Context, conn *pgx.Conn, l *slog.Logger
 //
 //	documentdb_api_internal.bson_dollar_lt(anonymous documentdb_core.bson, anonymous1 documentdb_api_internal.bsonindexbounds, OUT bson_dollar_lt boolean).
 func BsonDollarLt(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 struct{}) (outBsonDollarLt bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarLt",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -859,7 +859,7 @@ func BsonDollarLt(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_api_internal.bson_dollar_lte(anonymous documentdb_core.bson, anonymous1 documentdb_api_internal.bsonindexbounds, OUT bson_dollar_lte boolean).
 func BsonDollarLte(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 struct{}) (outBsonDollarLte bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarLte",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -880,7 +880,7 @@ func BsonDollarLte(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymou
 //
 //	documentdb_api_internal.bson_dollar_merge_add_object_id(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_merge_add_object_id documentdb_core.bson).
 func BsonDollarMergeAddObjectId(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarMergeAddObjectId wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarMergeAddObjectId",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -901,7 +901,7 @@ func BsonDollarMergeAddObjectId(ctx context.Context, conn *pgx.Conn, l *slog.Log
 //
 //	documentdb_api_internal.bson_dollar_merge_add_object_id(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, anonymous12 documentdb_core.bson, OUT bson_dollar_merge_add_object_id documentdb_core.bson).
 func BsonDollarMergeAddObjectId1(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument, anonymous12 wirebson.RawDocument) (outBsonDollarMergeAddObjectId wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarMergeAddObjectId1",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -922,7 +922,7 @@ func BsonDollarMergeAddObjectId1(ctx context.Context, conn *pgx.Conn, l *slog.Lo
 //
 //	documentdb_api_internal.bson_dollar_merge_documents(document documentdb_core.bson, pathspec documentdb_core.bson, overridearray boolean, OUT bson_dollar_merge_documents documentdb_core.bson).
 func BsonDollarMergeDocuments(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, pathSpec wirebson.RawDocument, overridearray bool) (outBsonDollarMergeDocuments wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarMergeDocuments",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -943,7 +943,7 @@ func BsonDollarMergeDocuments(ctx context.Context, conn *pgx.Conn, l *slog.Logge
 //
 //	documentdb_api_internal.bson_dollar_merge_documents_at_path(leftdocument documentdb_core.bson, rightdocument documentdb_core.bson, fieldpath text, OUT bson_dollar_merge_documents_at_path documentdb_core.bson).
 func BsonDollarMergeDocumentsAtPath(ctx context.Context, conn *pgx.Conn, l *slog.Logger, leftdocument wirebson.RawDocument, rightdocument wirebson.RawDocument, fieldpath string) (outBsonDollarMergeDocumentsAtPath wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarMergeDocumentsAtPath",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -964,7 +964,7 @@ func BsonDollarMergeDocumentsAtPath(ctx context.Context, conn *pgx.Conn, l *slog
 //
 //	documentdb_api_internal.bson_dollar_merge_fail_when_not_matched(anonymous documentdb_core.bson, anonymous1 text, OUT bson_dollar_merge_fail_when_not_matched documentdb_core.bson).
 func BsonDollarMergeFailWhenNotMatched(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 string) (outBsonDollarMergeFailWhenNotMatched wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarMergeFailWhenNotMatched",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -985,7 +985,7 @@ func BsonDollarMergeFailWhenNotMatched(ctx context.Context, conn *pgx.Conn, l *s
 //
 //	documentdb_api_internal.bson_dollar_merge_filter_support(anonymous internal, OUT bson_dollar_merge_filter_support internal).
 func BsonDollarMergeFilterSupport(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonDollarMergeFilterSupport struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarMergeFilterSupport",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1006,7 +1006,7 @@ func BsonDollarMergeFilterSupport(ctx context.Context, conn *pgx.Conn, l *slog.L
 //
 //	documentdb_api_internal.bson_dollar_merge_generate_object_id(anonymous documentdb_core.bson, OUT bson_dollar_merge_generate_object_id documentdb_core.bson).
 func BsonDollarMergeGenerateObjectId(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument) (outBsonDollarMergeGenerateObjectId wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarMergeGenerateObjectId",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1027,7 +1027,7 @@ func BsonDollarMergeGenerateObjectId(ctx context.Context, conn *pgx.Conn, l *slo
 //
 //	documentdb_api_internal.bson_dollar_merge_handle_when_matched(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, anonymous12 integer, OUT bson_dollar_merge_handle_when_matched documentdb_core.bson).
 func BsonDollarMergeHandleWhenMatched(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument, anonymous12 int32) (outBsonDollarMergeHandleWhenMatched wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarMergeHandleWhenMatched",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1048,7 +1048,7 @@ func BsonDollarMergeHandleWhenMatched(ctx context.Context, conn *pgx.Conn, l *sl
 //
 //	documentdb_api_internal.bson_dollar_merge_handle_when_matched(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, anonymous12 integer, anonymous123 documentdb_core.bson, anonymous1234 integer, OUT bson_dollar_merge_handle_when_matched documentdb_core.bson).
 func BsonDollarMergeHandleWhenMatched1(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument, anonymous12 int32, anonymous123 wirebson.RawDocument, anonymous1234 int32) (outBsonDollarMergeHandleWhenMatched wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarMergeHandleWhenMatched1",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1069,7 +1069,7 @@ func BsonDollarMergeHandleWhenMatched1(ctx context.Context, conn *pgx.Conn, l *s
 //
 //	documentdb_api_internal.bson_dollar_merge_join(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, anonymous12 text, OUT bson_dollar_merge_join boolean).
 func BsonDollarMergeJoin(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument, anonymous12 string) (outBsonDollarMergeJoin bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarMergeJoin",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1090,7 +1090,7 @@ func BsonDollarMergeJoin(ctx context.Context, conn *pgx.Conn, l *slog.Logger, an
 //
 //	documentdb_api_internal.bson_dollar_not_gt(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_not_gt boolean).
 func BsonDollarNotGt(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarNotGt bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarNotGt",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1111,7 +1111,7 @@ func BsonDollarNotGt(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonym
 //
 //	documentdb_api_internal.bson_dollar_not_gte(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_not_gte boolean).
 func BsonDollarNotGte(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarNotGte bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarNotGte",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1132,7 +1132,7 @@ func BsonDollarNotGte(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anony
 //
 //	documentdb_api_internal.bson_dollar_not_lt(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_not_lt boolean).
 func BsonDollarNotLt(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarNotLt bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarNotLt",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1153,7 +1153,7 @@ func BsonDollarNotLt(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonym
 //
 //	documentdb_api_internal.bson_dollar_not_lte(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_not_lte boolean).
 func BsonDollarNotLte(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarNotLte bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarNotLte",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1174,7 +1174,7 @@ func BsonDollarNotLte(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anony
 //
 //	documentdb_api_internal.bson_dollar_project(document documentdb_core.bson, pathspec documentdb_core.bson, variablespec documentdb_core.bson, OUT bson_dollar_project documentdb_core.bson).
 func BsonDollarProject(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, pathSpec wirebson.RawDocument, variableSpec wirebson.RawDocument) (outBsonDollarProject wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarProject",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1195,7 +1195,7 @@ func BsonDollarProject(ctx context.Context, conn *pgx.Conn, l *slog.Logger, docu
 //
 //	documentdb_api_internal.bson_dollar_project(document documentdb_core.bson, pathspec documentdb_core.bson, variablespec documentdb_core.bson, collationstring text, OUT bson_dollar_project documentdb_core.bson).
 func BsonDollarProject1(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, pathSpec wirebson.RawDocument, variableSpec wirebson.RawDocument, collationstring string) (outBsonDollarProject wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarProject1",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1216,7 +1216,7 @@ func BsonDollarProject1(ctx context.Context, conn *pgx.Conn, l *slog.Logger, doc
 //
 //	documentdb_api_internal.bson_dollar_project_find(document documentdb_core.bson, pathspec documentdb_core.bson, queryspec documentdb_core.bson, letvariablespec documentdb_core.bson, OUT bson_dollar_project_find documentdb_core.bson).
 func BsonDollarProjectFind(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, pathSpec wirebson.RawDocument, querySpec wirebson.RawDocument, letVariableSpec wirebson.RawDocument) (outBsonDollarProjectFind wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarProjectFind",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1237,7 +1237,7 @@ func BsonDollarProjectFind(ctx context.Context, conn *pgx.Conn, l *slog.Logger,
 //
 //	documentdb_api_internal.bson_dollar_project_find(document documentdb_core.bson, pathspec documentdb_core.bson, queryspec documentdb_core.bson, letvariablespec documentdb_core.bson, collationstring text, OUT bson_dollar_project_find documentdb_core.bson).
 func BsonDollarProjectFind1(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, pathSpec wirebson.RawDocument, querySpec wirebson.RawDocument, letVariableSpec wirebson.RawDocument, collationstring string) (outBsonDollarProjectFind wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarProjectFind1",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1258,7 +1258,7 @@ func BsonDollarProjectFind1(ctx context.Context, conn *pgx.Conn, l *slog.Logger,
 //
 //	documentdb_api_internal.bson_dollar_range(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_range boolean).
 func BsonDollarRange(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarRange bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarRange",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1279,7 +1279,7 @@ func BsonDollarRange(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonym
 //
 //	documentdb_api_internal.bson_dollar_redact(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, anonymous12 text, anonymous123 documentdb_core.bson, OUT bson_dollar_redact documentdb_core.bson).
 func BsonDollarRedact(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument, anonymous12 string, anonymous123 wirebson.RawDocument) (outBsonDollarRedact wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarRedact",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1300,7 +1300,7 @@ func BsonDollarRedact(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anony
 //
 //	documentdb_api_internal.bson_dollar_redact(document documentdb_core.bson, redactspec documentdb_core.bson, redactspectext text, variablespec documentdb_core.bson, collationstring text, OUT bson_dollar_redact documentdb_core.bson).
 func BsonDollarRedact1(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, redactSpec wirebson.RawDocument, redactspectext string, variableSpec wirebson.RawDocument, collationstring string) (outBsonDollarRedact wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarRedact1",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1321,7 +1321,7 @@ func BsonDollarRedact1(ctx context.Context, conn *pgx.Conn, l *slog.Logger, docu
 //
 //	documentdb_api_internal.bson_dollar_replace_root(document documentdb_core.bson, pathspec documentdb_core.bson, variablespec documentdb_core.bson, OUT bson_dollar_replace_root documentdb_core.bson).
 func BsonDollarReplaceRoot(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, pathSpec wirebson.RawDocument, variableSpec wirebson.RawDocument) (outBsonDollarReplaceRoot wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarReplaceRoot",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1342,7 +1342,7 @@ func BsonDollarReplaceRoot(ctx context.Context, conn *pgx.Conn, l *slog.Logger,
 //
 //	documentdb_api_internal.bson_dollar_replace_root(document documentdb_core.bson, pathspec documentdb_core.bson, variablespec documentdb_core.bson, collationstring text, OUT bson_dollar_replace_root documentdb_core.bson).
 func BsonDollarReplaceRoot1(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, pathSpec wirebson.RawDocument, variableSpec wirebson.RawDocument, collationstring string) (outBsonDollarReplaceRoot wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarReplaceRoot1",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1363,7 +1363,7 @@ func BsonDollarReplaceRoot1(ctx context.Context, conn *pgx.Conn, l *slog.Logger,
 //
 //	documentdb_api_internal.bson_dollar_selectivity(anonymous internal, anonymous1 oid, anonymous12 internal, anonymous123 integer, OUT bson_dollar_selectivity double precision).
 func BsonDollarSelectivity(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}, anonymous12 struct{}, anonymous123 int32) (outBsonDollarSelectivity float64, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarSelectivity",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1384,7 +1384,7 @@ func BsonDollarSelectivity(ctx context.Context, conn *pgx.Conn, l *slog.Logger,
 //
 //	documentdb_api_internal.bson_dollar_text(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_dollar_text boolean).
 func BsonDollarText(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonDollarText bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonDollarText",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1405,7 +1405,7 @@ func BsonDollarText(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymo
 //
 //	documentdb_api_internal.bson_exp_moving_avg(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, anonymous12 boolean).
 func BsonExpMovingAvg(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument, anonymous12 bool) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonExpMovingAvg",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1426,7 +1426,7 @@ func BsonExpMovingAvg(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anony
 //
 //	documentdb_api_internal.bson_expression_get(document documentdb_core.bson, expressionspec documentdb_core.bson, isnullonempty boolean, variablespec documentdb_core.bson, OUT bson_expression_get documentdb_core.bson).
 func BsonExpressionGet(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, expressionSpec wirebson.RawDocument, isnullonempty bool, variableSpec wirebson.RawDocument) (outBsonExpressionGet wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonExpressionGet",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1447,7 +1447,7 @@ func BsonExpressionGet(ctx context.Context, conn *pgx.Conn, l *slog.Logger, docu
 //
 //	documentdb_api_internal.bson_expression_get(document documentdb_core.bson, expressionspec documentdb_core.bson, isnullonempty boolean, variablespec documentdb_core.bson, collationstring text, OUT bson_expression_get documentdb_core.bson).
 func BsonExpressionGet1(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, expressionSpec wirebson.RawDocument, isnullonempty bool, variableSpec wirebson.RawDocument, collationstring string) (outBsonExpressionGet wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonExpressionGet1",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1468,7 +1468,7 @@ func BsonExpressionGet1(ctx context.Context, conn *pgx.Conn, l *slog.Logger, doc
 //
 //	documentdb_api_internal.bson_expression_map(document documentdb_core.bson, sourcearrayname text, expressionspec documentdb_core.bson, isnullonempty boolean, variablespec documentdb_core.bson, OUT bson_expression_map documentdb_core.bson).
 func BsonExpressionMap(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, sourcearrayname string, expressionSpec wirebson.RawDocument, isnullonempty bool, variableSpec wirebson.RawDocument) (outBsonExpressionMap wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonExpressionMap",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1489,7 +1489,7 @@ func BsonExpressionMap(ctx context.Context, conn *pgx.Conn, l *slog.Logger, docu
 //
 //	documentdb_api_internal.bson_expression_partition_by_fields_get(document documentdb_core.bson, expressionspec documentdb_core.bson, OUT bson_expression_partition_by_fields_get documentdb_core.bson).
 func BsonExpressionPartitionByFieldsGet(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, expressionSpec wirebson.RawDocument) (outBsonExpressionPartitionByFieldsGet wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonExpressionPartitionByFieldsGet",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1510,7 +1510,7 @@ func BsonExpressionPartitionByFieldsGet(ctx context.Context, conn *pgx.Conn, l *
 //
 //	documentdb_api_internal.bson_expression_partition_get(document documentdb_core.bson, expressionspec documentdb_core.bson, isnullonempty boolean DEFAULT false, OUT bson_expression_partition_get documentdb_core.bson).
 func BsonExpressionPartitionGet(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, expressionSpec wirebson.RawDocument, isnullonempty bool) (outBsonExpressionPartitionGet wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonExpressionPartitionGet",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1531,7 +1531,7 @@ func BsonExpressionPartitionGet(ctx context.Context, conn *pgx.Conn, l *slog.Log
 //
 //	documentdb_api_internal.bson_expression_partition_get(document documentdb_core.bson, expressionspec documentdb_core.bson, isnullonempty boolean, variablespec documentdb_core.bson, OUT bson_expression_partition_get documentdb_core.bson).
 func BsonExpressionPartitionGet1(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, expressionSpec wirebson.RawDocument, isnullonempty bool, variableSpec wirebson.RawDocument) (outBsonExpressionPartitionGet wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonExpressionPartitionGet1",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1552,7 +1552,7 @@ func BsonExpressionPartitionGet1(ctx context.Context, conn *pgx.Conn, l *slog.Lo
 //
 //	documentdb_api_internal.bson_expression_partition_get(document documentdb_core.bson, expressionspec documentdb_core.bson, isnullonempty boolean, variablespec documentdb_core.bson, collationstring text, OUT bson_expression_partition_get documentdb_core.bson).
 func BsonExpressionPartitionGet12(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, expressionSpec wirebson.RawDocument, isnullonempty bool, variableSpec wirebson.RawDocument, collationstring string) (outBsonExpressionPartitionGet wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonExpressionPartitionGet12",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1573,7 +1573,7 @@ func BsonExpressionPartitionGet12(ctx context.Context, conn *pgx.Conn, l *slog.L
 //
 //	documentdb_api_internal.bson_extract_vector(document documentdb_core.bson, path text, OUT bson_extract_vector public.vector).
 func BsonExtractVector(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, path string) (outBsonExtractVector struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonExtractVector",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1594,7 +1594,7 @@ func BsonExtractVector(ctx context.Context, conn *pgx.Conn, l *slog.Logger, docu
 //
 //	documentdb_api_internal.bson_first_transition(anonymous bytea, anonymous1 documentdb_core.bson, anonymous12 ARRAY, anonymous123 documentdb_core.bson DEFAULT NULL, OUT bson_first_transition bytea).
 func BsonFirstTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument, anonymous12 struct{}, anonymous123 wirebson.RawDocument) (outBsonFirstTransition struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonFirstTransition",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1615,7 +1615,7 @@ func BsonFirstTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, an
 //
 //	documentdb_api_internal.bson_first_transition_on_sorted(anonymous bytea, anonymous1 documentdb_core.bson, anonymous12 documentdb_core.bson DEFAULT NULL, OUT bson_first_transition_on_sorted bytea).
 func BsonFirstTransitionOnSorted(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument, anonymous12 wirebson.RawDocument) (outBsonFirstTransitionOnSorted struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonFirstTransitionOnSorted",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1636,7 +1636,7 @@ func BsonFirstTransitionOnSorted(ctx context.Context, conn *pgx.Conn, l *slog.Lo
 //
 //	documentdb_api_internal.bson_firstn_final(anonymous bytea, OUT bson_firstn_final documentdb_core.bson).
 func BsonFirstnFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonFirstnFinal wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonFirstnFinal",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1657,7 +1657,7 @@ func BsonFirstnFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonym
 //
 //	documentdb_api_internal.bson_firstn_transition(anonymous bytea, anonymous1 documentdb_core.bson, anonymous12 bigint, anonymous123 ARRAY, anonymous1234 documentdb_core.bson DEFAULT NULL, OUT bson_firstn_transition bytea).
 func BsonFirstnTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument, anonymous12 int64, anonymous123 struct{}, anonymous1234 wirebson.RawDocument) (outBsonFirstnTransition struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonFirstnTransition",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1678,7 +1678,7 @@ func BsonFirstnTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, a
 //
 //	documentdb_api_internal.bson_firstn_transition_on_sorted(anonymous bytea, anonymous1 documentdb_core.bson, anonymous12 bigint, anonymous123 documentdb_core.bson DEFAULT NULL, OUT bson_firstn_transition_on_sorted bytea).
 func BsonFirstnTransitionOnSorted(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument, anonymous12 int64, anonymous123 wirebson.RawDocument) (outBsonFirstnTransitionOnSorted struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonFirstnTransitionOnSorted",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1699,7 +1699,7 @@ func BsonFirstnTransitionOnSorted(ctx context.Context, conn *pgx.Conn, l *slog.L
 //
 //	documentdb_api_internal.bson_geonear_within_range(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_geonear_within_range boolean).
 func BsonGeonearWithinRange(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonGeonearWithinRange bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonGeonearWithinRange",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1720,7 +1720,7 @@ func BsonGeonearWithinRange(ctx context.Context, conn *pgx.Conn, l *slog.Logger,
 //
 //	documentdb_api_internal.bson_index_transform(anonymous bytea, anonymous1 bytea, anonymous12 smallint, anonymous123 internal, OUT bson_index_transform bytea).
 func BsonIndexTransform(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}, anonymous12 struct{}, anonymous123 struct{}) (outBsonIndexTransform struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonIndexTransform",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1741,7 +1741,7 @@ func BsonIndexTransform(ctx context.Context, conn *pgx.Conn, l *slog.Logger, ano
 //
 //	documentdb_api_internal.bson_integral_derivative_final(anonymous bytea, OUT bson_integral_derivative_final documentdb_core.bson).
 func BsonIntegralDerivativeFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonIntegralDerivativeFinal wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonIntegralDerivativeFinal",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1762,7 +1762,7 @@ func BsonIntegralDerivativeFinal(ctx context.Context, conn *pgx.Conn, l *slog.Lo
 //
 //	documentdb_api_internal.bson_integral_transition(anonymous bytea, anonymous1 documentdb_core.bson, anonymous12 documentdb_core.bson, anonymous123 bigint, OUT bson_integral_transition bytea).
 func BsonIntegralTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument, anonymous12 wirebson.RawDocument, anonymous123 int64) (outBsonIntegralTransition struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonIntegralTransition",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1783,7 +1783,7 @@ func BsonIntegralTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger,
 //
 //	documentdb_api_internal.bson_last_transition(anonymous bytea, anonymous1 documentdb_core.bson, anonymous12 ARRAY, anonymous123 documentdb_core.bson DEFAULT NULL, OUT bson_last_transition bytea).
 func BsonLastTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument, anonymous12 struct{}, anonymous123 wirebson.RawDocument) (outBsonLastTransition struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonLastTransition",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1804,7 +1804,7 @@ func BsonLastTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, ano
 //
 //	documentdb_api_internal.bson_last_transition_on_sorted(anonymous bytea, anonymous1 documentdb_core.bson, anonymous12 documentdb_core.bson DEFAULT NULL, OUT bson_last_transition_on_sorted bytea).
 func BsonLastTransitionOnSorted(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument, anonymous12 wirebson.RawDocument) (outBsonLastTransitionOnSorted struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonLastTransitionOnSorted",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1825,7 +1825,7 @@ func BsonLastTransitionOnSorted(ctx context.Context, conn *pgx.Conn, l *slog.Log
 //
 //	documentdb_api_internal.bson_lastn_final(anonymous bytea, OUT bson_lastn_final documentdb_core.bson).
 func BsonLastnFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonLastnFinal wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonLastnFinal",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1846,7 +1846,7 @@ func BsonLastnFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymo
 //
 //	documentdb_api_internal.bson_lastn_transition(anonymous bytea, anonymous1 documentdb_core.bson, anonymous12 bigint, anonymous123 ARRAY, anonymous1234 documentdb_core.bson DEFAULT NULL, OUT bson_lastn_transition bytea).
 func BsonLastnTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument, anonymous12 int64, anonymous123 struct{}, anonymous1234 wirebson.RawDocument) (outBsonLastnTransition struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonLastnTransition",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1867,7 +1867,7 @@ func BsonLastnTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, an
 //
 //	documentdb_api_internal.bson_lastn_transition_on_sorted(anonymous bytea, anonymous1 documentdb_core.bson, anonymous12 bigint, anonymous123 documentdb_core.bson DEFAULT NULL, OUT bson_lastn_transition_on_sorted bytea).
 func BsonLastnTransitionOnSorted(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument, anonymous12 int64, anonymous123 wirebson.RawDocument) (outBsonLastnTransitionOnSorted struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonLastnTransitionOnSorted",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1888,7 +1888,7 @@ func BsonLastnTransitionOnSorted(ctx context.Context, conn *pgx.Conn, l *slog.Lo
 //
 //	documentdb_api_internal.bson_linear_fill(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson).
 func BsonLinearFill(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonLinearFill",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1909,7 +1909,7 @@ func BsonLinearFill(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymo
 //
 //	documentdb_api_internal.bson_locf_fill(anonymous documentdb_core.bson).
 func BsonLocfFill(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonLocfFill",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1930,7 +1930,7 @@ func BsonLocfFill(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_api_internal.bson_maxminn_combine(anonymous bytea, anonymous1 bytea, OUT bson_maxminn_combine bytea).
 func BsonMaxminnCombine(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}) (outBsonMaxminnCombine struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonMaxminnCombine",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1951,7 +1951,7 @@ func BsonMaxminnCombine(ctx context.Context, conn *pgx.Conn, l *slog.Logger, ano
 //
 //	documentdb_api_internal.bson_maxminn_final(anonymous bytea, OUT bson_maxminn_final documentdb_core.bson).
 func BsonMaxminnFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonMaxminnFinal wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonMaxminnFinal",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1972,7 +1972,7 @@ func BsonMaxminnFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anony
 //
 //	documentdb_api_internal.bson_maxn_transition(anonymous bytea, anonymous1 documentdb_core.bson, OUT bson_maxn_transition bytea).
 func BsonMaxnTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument) (outBsonMaxnTransition struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonMaxnTransition",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1993,7 +1993,7 @@ func BsonMaxnTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, ano
 //
 //	documentdb_api_internal.bson_merge_objects(anonymous documentdb_core.bson, anonymous1 bigint, anonymous12 ARRAY, anonymous123 documentdb_core.bson).
 func BsonMergeObjects(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 int64, anonymous12 struct{}, anonymous123 wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonMergeObjects",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2014,7 +2014,7 @@ func BsonMergeObjects(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anony
 //
 //	documentdb_api_internal.bson_merge_objects_final(anonymous bytea, OUT bson_merge_objects_final documentdb_core.bson).
 func BsonMergeObjectsFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonMergeObjectsFinal wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonMergeObjectsFinal",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2035,7 +2035,7 @@ func BsonMergeObjectsFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger,
 //
 //	documentdb_api_internal.bson_merge_objects_on_sorted(anonymous documentdb_core.bson).
 func BsonMergeObjectsOnSorted(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonMergeObjectsOnSorted",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2056,7 +2056,7 @@ func BsonMergeObjectsOnSorted(ctx context.Context, conn *pgx.Conn, l *slog.Logge
 //
 //	documentdb_api_internal.bson_merge_objects_transition(anonymous bytea, anonymous1 documentdb_core.bson, anonymous12 bigint, anonymous123 ARRAY, anonymous1234 documentdb_core.bson, OUT bson_merge_objects_transition bytea).
 func BsonMergeObjectsTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument, anonymous12 int64, anonymous123 struct{}, anonymous1234 wirebson.RawDocument) (outBsonMergeObjectsTransition struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonMergeObjectsTransition",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2077,7 +2077,7 @@ func BsonMergeObjectsTransition(ctx context.Context, conn *pgx.Conn, l *slog.Log
 //
 //	documentdb_api_internal.bson_merge_objects_transition_on_sorted(anonymous bytea, anonymous1 documentdb_core.bson, OUT bson_merge_objects_transition_on_sorted bytea).
 func BsonMergeObjectsTransitionOnSorted(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument) (outBsonMergeObjectsTransitionOnSorted struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonMergeObjectsTransitionOnSorted",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2098,7 +2098,7 @@ func BsonMergeObjectsTransitionOnSorted(ctx context.Context, conn *pgx.Conn, l *
 //
 //	documentdb_api_internal.bson_minn_transition(anonymous bytea, anonymous1 documentdb_core.bson, OUT bson_minn_transition bytea).
 func BsonMinnTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument) (outBsonMinnTransition struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonMinnTransition",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2119,7 +2119,7 @@ func BsonMinnTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, ano
 //
 //	documentdb_api_internal.bson_orderby(document documentdb_core.bson, filter documentdb_core.bson, collationstring text, OUT bson_orderby documentdb_core.bson).
 func BsonOrderby(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, filter wirebson.RawDocument, collationstring string) (outBsonOrderby wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonOrderby",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2140,7 +2140,7 @@ func BsonOrderby(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document w
 //
 //	documentdb_api_internal.bson_orderby_compare(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_orderby_compare integer).
 func BsonOrderbyCompare(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonOrderbyCompare int32, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonOrderbyCompare",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2161,7 +2161,7 @@ func BsonOrderbyCompare(ctx context.Context, conn *pgx.Conn, l *slog.Logger, ano
 //
 //	documentdb_api_internal.bson_orderby_compare_sort_support(anonymous internal).
 func BsonOrderbyCompareSortSupport(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonOrderbyCompareSortSupport",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2182,7 +2182,7 @@ func BsonOrderbyCompareSortSupport(ctx context.Context, conn *pgx.Conn, l *slog.
 //
 //	documentdb_api_internal.bson_orderby_eq(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_orderby_eq boolean).
 func BsonOrderbyEq(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonOrderbyEq bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonOrderbyEq",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2203,7 +2203,7 @@ func BsonOrderbyEq(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymou
 //
 //	documentdb_api_internal.bson_orderby_gt(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_orderby_gt boolean).
 func BsonOrderbyGt(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonOrderbyGt bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonOrderbyGt",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2224,7 +2224,7 @@ func BsonOrderbyGt(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymou
 //
 //	documentdb_api_internal.bson_orderby_lt(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_orderby_lt boolean).
 func BsonOrderbyLt(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonOrderbyLt bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonOrderbyLt",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2245,7 +2245,7 @@ func BsonOrderbyLt(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymou
 //
 //	documentdb_api_internal.bson_orderby_partition(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, anonymous12 boolean, OUT bson_orderby_partition documentdb_core.bson).
 func BsonOrderbyPartition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument, anonymous12 bool) (outBsonOrderbyPartition wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonOrderbyPartition",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2266,7 +2266,7 @@ func BsonOrderbyPartition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, a
 //
 //	documentdb_api_internal.bson_orderby_partition(document documentdb_core.bson, filter documentdb_core.bson, istimerangewindow boolean, collationstring text, OUT bson_orderby_partition documentdb_core.bson).
 func BsonOrderbyPartition1(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, filter wirebson.RawDocument, istimerangewindow bool, collationstring string) (outBsonOrderbyPartition wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonOrderbyPartition1",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2287,7 +2287,7 @@ func BsonOrderbyPartition1(ctx context.Context, conn *pgx.Conn, l *slog.Logger,
 //
 //	documentdb_api_internal.bson_orderby_reverse(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_orderby_reverse documentdb_core.bson).
 func BsonOrderbyReverse(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonOrderbyReverse wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonOrderbyReverse",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2308,7 +2308,7 @@ func BsonOrderbyReverse(ctx context.Context, conn *pgx.Conn, l *slog.Logger, ano
 //
 //	documentdb_api_internal.bson_query_match(document documentdb_core.bson, query documentdb_core.bson, variablespec documentdb_core.bson, collationstring text, OUT bson_query_match boolean).
 func BsonQueryMatch(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, query wirebson.RawDocument, variableSpec wirebson.RawDocument, collationstring string) (outBsonQueryMatch bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonQueryMatch",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2329,7 +2329,7 @@ func BsonQueryMatch(ctx context.Context, conn *pgx.Conn, l *slog.Logger, documen
 //
 //	documentdb_api_internal.bson_query_to_tsquery(query documentdb_core.bson, textsearch text DEFAULT NULL, OUT bson_query_to_tsquery tsquery).
 func BsonQueryToTsquery(ctx context.Context, conn *pgx.Conn, l *slog.Logger, query wirebson.RawDocument, textsearch string) (outBsonQueryToTsquery struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonQueryToTsquery",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2350,7 +2350,7 @@ func BsonQueryToTsquery(ctx context.Context, conn *pgx.Conn, l *slog.Logger, que
 //
 //	documentdb_api_internal.bson_rank().
 func BsonRank(ctx context.Context, conn *pgx.Conn, l *slog.Logger) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonRank",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2371,7 +2371,7 @@ func BsonRank(ctx context.Context, conn *pgx.Conn, l *slog.Logger) (err error) {
 //
 //	documentdb_api_internal.bson_rum_composite_ordering(anonymous bytea, anonymous1 documentdb_core.bson, anonymous12 smallint, anonymous123 internal, OUT bson_rum_composite_ordering documentdb_core.bson).
 func BsonRumCompositeOrdering(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument, anonymous12 struct{}, anonymous123 struct{}) (outBsonRumCompositeOrdering wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonRumCompositeOrdering",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2392,7 +2392,7 @@ func BsonRumCompositeOrdering(ctx context.Context, conn *pgx.Conn, l *slog.Logge
 //
 //	documentdb_api_internal.bson_search_param(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_search_param boolean).
 func BsonSearchParam(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonSearchParam bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonSearchParam",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2413,7 +2413,7 @@ func BsonSearchParam(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonym
 //
 //	documentdb_api_internal.bson_shift(anonymous documentdb_core.bson, anonymous1 integer, anonymous12 documentdb_core.bson).
 func BsonShift(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 int32, anonymous12 wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonShift",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2434,7 +2434,7 @@ func BsonShift(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wi
 //
 //	documentdb_api_internal.bson_std_dev_pop_final(anonymous bytea, OUT bson_std_dev_pop_final documentdb_core.bson).
 func BsonStdDevPopFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonStdDevPopFinal wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonStdDevPopFinal",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2455,7 +2455,7 @@ func BsonStdDevPopFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, ano
 //
 //	documentdb_api_internal.bson_std_dev_pop_samp_combine(anonymous bytea, anonymous1 bytea, OUT bson_std_dev_pop_samp_combine bytea).
 func BsonStdDevPopSampCombine(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}) (outBsonStdDevPopSampCombine struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonStdDevPopSampCombine",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2476,7 +2476,7 @@ func BsonStdDevPopSampCombine(ctx context.Context, conn *pgx.Conn, l *slog.Logge
 //
 //	documentdb_api_internal.bson_std_dev_pop_samp_transition(anonymous bytea, anonymous1 documentdb_core.bson, OUT bson_std_dev_pop_samp_transition bytea).
 func BsonStdDevPopSampTransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument) (outBsonStdDevPopSampTransition struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonStdDevPopSampTransition",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2497,7 +2497,7 @@ func BsonStdDevPopSampTransition(ctx context.Context, conn *pgx.Conn, l *slog.Lo
 //
 //	documentdb_api_internal.bson_std_dev_pop_samp_winfunc_invtransition(anonymous bytea, anonymous1 documentdb_core.bson, OUT bson_std_dev_pop_samp_winfunc_invtransition bytea).
 func BsonStdDevPopSampWinfuncInvtransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument) (outBsonStdDevPopSampWinfuncInvtransition struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonStdDevPopSampWinfuncInvtransition",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2518,7 +2518,7 @@ func BsonStdDevPopSampWinfuncInvtransition(ctx context.Context, conn *pgx.Conn,
 //
 //	documentdb_api_internal.bson_std_dev_pop_winfunc_final(anonymous bytea, OUT bson_std_dev_pop_winfunc_final documentdb_core.bson).
 func BsonStdDevPopWinfuncFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonStdDevPopWinfuncFinal wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonStdDevPopWinfuncFinal",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2539,7 +2539,7 @@ func BsonStdDevPopWinfuncFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logg
 //
 //	documentdb_api_internal.bson_std_dev_samp_final(anonymous bytea, OUT bson_std_dev_samp_final documentdb_core.bson).
 func BsonStdDevSampFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonStdDevSampFinal wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonStdDevSampFinal",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2560,7 +2560,7 @@ func BsonStdDevSampFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, an
 //
 //	documentdb_api_internal.bson_std_dev_samp_winfunc_final(anonymous bytea, OUT bson_std_dev_samp_winfunc_final documentdb_core.bson).
 func BsonStdDevSampWinfuncFinal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonStdDevSampWinfuncFinal wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonStdDevSampWinfuncFinal",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2581,7 +2581,7 @@ func BsonStdDevSampWinfuncFinal(ctx context.Context, conn *pgx.Conn, l *slog.Log
 //
 //	documentdb_api_internal.bson_sum_avg_minvtransition(anonymous bytea, anonymous1 documentdb_core.bson, OUT bson_sum_avg_minvtransition bytea).
 func BsonSumAvgMinvtransition(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument) (outBsonSumAvgMinvtransition struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonSumAvgMinvtransition",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2602,7 +2602,7 @@ func BsonSumAvgMinvtransition(ctx context.Context, conn *pgx.Conn, l *slog.Logge
 //
 //	documentdb_api_internal.bson_text_meta_qual(anonymous documentdb_core.bson, anonymous1 tsquery, anonymous12 bytea, anonymous123 boolean, OUT bson_text_meta_qual boolean).
 func BsonTextMetaQual(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 struct{}, anonymous12 struct{}, anonymous123 bool) (outBsonTextMetaQual bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonTextMetaQual",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2623,7 +2623,7 @@ func BsonTextMetaQual(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anony
 //
 //	documentdb_api_internal.bson_text_tsquery(anonymous documentdb_core.bson, anonymous1 tsquery, OUT bson_text_tsquery boolean).
 func BsonTextTsquery(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 struct{}) (outBsonTextTsquery bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonTextTsquery",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2644,7 +2644,7 @@ func BsonTextTsquery(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonym
 //
 //	documentdb_api_internal.bson_unique_exclusion_index_equal(anonymous documentdb_api_catalog.shard_key_and_document, anonymous1 documentdb_api_catalog.shard_key_and_document, OUT bson_unique_exclusion_index_equal boolean).
 func BsonUniqueExclusionIndexEqual(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}) (outBsonUniqueExclusionIndexEqual bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonUniqueExclusionIndexEqual",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2665,7 +2665,7 @@ func BsonUniqueExclusionIndexEqual(ctx context.Context, conn *pgx.Conn, l *slog.
 //
 //	documentdb_api_internal.bson_unique_index_equal(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_unique_index_equal boolean).
 func BsonUniqueIndexEqual(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonUniqueIndexEqual bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonUniqueIndexEqual",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2686,7 +2686,7 @@ func BsonUniqueIndexEqual(ctx context.Context, conn *pgx.Conn, l *slog.Logger, a
 //
 //	documentdb_api_internal.bson_unique_shard_path_equal(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_unique_shard_path_equal boolean).
 func BsonUniqueShardPathEqual(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonUniqueShardPathEqual bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonUniqueShardPathEqual",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2707,7 +2707,7 @@ func BsonUniqueShardPathEqual(ctx context.Context, conn *pgx.Conn, l *slog.Logge
 //
 //	documentdb_api_internal.bson_update_document(document documentdb_core.bson, updatespec documentdb_core.bson, queryspec documentdb_core.bson, arrayfilters documentdb_core.bson DEFAULT NULL, buildupdatedesc boolean DEFAULT false, variablespec documentdb_core.bson DEFAULT NULL, OUT newdocument documentdb_core.bson, OUT updatedesc documentdb_core.bson).
 func BsonUpdateDocument(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, updateSpec wirebson.RawDocument, querySpec wirebson.RawDocument, arrayfilters wirebson.RawDocument, buildupdatedesc bool, variableSpec wirebson.RawDocument) (outNewdocument wirebson.RawDocument, outUpdatedesc wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonUpdateDocument",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2728,7 +2728,7 @@ func BsonUpdateDocument(ctx context.Context, conn *pgx.Conn, l *slog.Logger, doc
 //
 //	documentdb_api_internal.bson_update_returned_value(shard_key_id bigint, OUT bson_update_returned_value integer).
 func BsonUpdateReturnedValue(ctx context.Context, conn *pgx.Conn, l *slog.Logger, shardKeyId int64) (outBsonUpdateReturnedValue int32, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonUpdateReturnedValue",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2749,7 +2749,7 @@ func BsonUpdateReturnedValue(ctx context.Context, conn *pgx.Conn, l *slog.Logger
 //
 //	documentdb_api_internal.bson_value_dollar_all(anonymous internal, anonymous1 documentdb_core.bson, OUT bson_value_dollar_all internal).
 func BsonValueDollarAll(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument) (outBsonValueDollarAll struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonValueDollarAll",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2770,7 +2770,7 @@ func BsonValueDollarAll(ctx context.Context, conn *pgx.Conn, l *slog.Logger, ano
 //
 //	documentdb_api_internal.bson_value_dollar_bits_all_clear(anonymous internal, anonymous1 documentdb_core.bson, OUT bson_value_dollar_bits_all_clear internal).
 func BsonValueDollarBitsAllClear(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument) (outBsonValueDollarBitsAllClear struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonValueDollarBitsAllClear",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2791,7 +2791,7 @@ func BsonValueDollarBitsAllClear(ctx context.Context, conn *pgx.Conn, l *slog.Lo
 //
 //	documentdb_api_internal.bson_value_dollar_bits_all_set(anonymous internal, anonymous1 documentdb_core.bson, OUT bson_value_dollar_bits_all_set internal).
 func BsonValueDollarBitsAllSet(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument) (outBsonValueDollarBitsAllSet struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonValueDollarBitsAllSet",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2812,7 +2812,7 @@ func BsonValueDollarBitsAllSet(ctx context.Context, conn *pgx.Conn, l *slog.Logg
 //
 //	documentdb_api_internal.bson_value_dollar_bits_any_clear(anonymous internal, anonymous1 documentdb_core.bson, OUT bson_value_dollar_bits_any_clear internal).
 func BsonValueDollarBitsAnyClear(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument) (outBsonValueDollarBitsAnyClear struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonValueDollarBitsAnyClear",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2833,7 +2833,7 @@ func BsonValueDollarBitsAnyClear(ctx context.Context, conn *pgx.Conn, l *slog.Lo
 //
 //	documentdb_api_internal.bson_value_dollar_bits_any_set(anonymous internal, anonymous1 documentdb_core.bson, OUT bson_value_dollar_bits_any_set internal).
 func BsonValueDollarBitsAnySet(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument) (outBsonValueDollarBitsAnySet struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonValueDollarBitsAnySet",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2854,7 +2854,7 @@ func BsonValueDollarBitsAnySet(ctx context.Context, conn *pgx.Conn, l *slog.Logg
 //
 //	documentdb_api_internal.bson_value_dollar_elemmatch(anonymous internal, anonymous1 documentdb_core.bson, OUT bson_value_dollar_elemmatch internal).
 func BsonValueDollarElemmatch(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument) (outBsonValueDollarElemmatch struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonValueDollarElemmatch",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2875,7 +2875,7 @@ func BsonValueDollarElemmatch(ctx context.Context, conn *pgx.Conn, l *slog.Logge
 //
 //	documentdb_api_internal.bson_value_dollar_eq(anonymous internal, anonymous1 documentdb_core.bson, OUT bson_value_dollar_eq internal).
 func BsonValueDollarEq(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument) (outBsonValueDollarEq struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonValueDollarEq",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2896,7 +2896,7 @@ func BsonValueDollarEq(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anon
 //
 //	documentdb_api_internal.bson_value_dollar_exists(anonymous internal, anonymous1 documentdb_core.bson, OUT bson_value_dollar_exists internal).
 func BsonValueDollarExists(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument) (outBsonValueDollarExists struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonValueDollarExists",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2917,7 +2917,7 @@ func BsonValueDollarExists(ctx context.Context, conn *pgx.Conn, l *slog.Logger,
 //
 //	documentdb_api_internal.bson_value_dollar_gt(anonymous internal, anonymous1 documentdb_core.bson, OUT bson_value_dollar_gt internal).
 func BsonValueDollarGt(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument) (outBsonValueDollarGt struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonValueDollarGt",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2938,7 +2938,7 @@ func BsonValueDollarGt(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anon
 //
 //	documentdb_api_internal.bson_value_dollar_gte(anonymous internal, anonymous1 documentdb_core.bson, OUT bson_value_dollar_gte internal).
 func BsonValueDollarGte(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument) (outBsonValueDollarGte struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonValueDollarGte",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2959,7 +2959,7 @@ func BsonValueDollarGte(ctx context.Context, conn *pgx.Conn, l *slog.Logger, ano
 //
 //	documentdb_api_internal.bson_value_dollar_in(anonymous internal, anonymous1 documentdb_core.bson, OUT bson_value_dollar_in internal).
 func BsonValueDollarIn(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument) (outBsonValueDollarIn struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonValueDollarIn",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -2980,7 +2980,7 @@ func BsonValueDollarIn(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anon
 //
 //	documentdb_api_internal.bson_value_dollar_lt(anonymous internal, anonymous1 documentdb_core.bson, OUT bson_value_dollar_lt internal).
 func BsonValueDollarLt(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument) (outBsonValueDollarLt struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonValueDollarLt",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3001,7 +3001,7 @@ func BsonValueDollarLt(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anon
 //
 //	documentdb_api_internal.bson_value_dollar_lte(anonymous internal, anonymous1 documentdb_core.bson, OUT bson_value_dollar_lte internal).
 func BsonValueDollarLte(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument) (outBsonValueDollarLte struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonValueDollarLte",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3022,7 +3022,7 @@ func BsonValueDollarLte(ctx context.Context, conn *pgx.Conn, l *slog.Logger, ano
 //
 //	documentdb_api_internal.bson_value_dollar_mod(anonymous internal, anonymous1 documentdb_core.bson, OUT bson_value_dollar_mod internal).
 func BsonValueDollarMod(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument) (outBsonValueDollarMod struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonValueDollarMod",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3043,7 +3043,7 @@ func BsonValueDollarMod(ctx context.Context, conn *pgx.Conn, l *slog.Logger, ano
 //
 //	documentdb_api_internal.bson_value_dollar_ne(anonymous internal, anonymous1 documentdb_core.bson, OUT bson_value_dollar_ne internal).
 func BsonValueDollarNe(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument) (outBsonValueDollarNe struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonValueDollarNe",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3064,7 +3064,7 @@ func BsonValueDollarNe(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anon
 //
 //	documentdb_api_internal.bson_value_dollar_nin(anonymous internal, anonymous1 documentdb_core.bson, OUT bson_value_dollar_nin internal).
 func BsonValueDollarNin(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument) (outBsonValueDollarNin struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonValueDollarNin",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3085,7 +3085,7 @@ func BsonValueDollarNin(ctx context.Context, conn *pgx.Conn, l *slog.Logger, ano
 //
 //	documentdb_api_internal.bson_value_dollar_regex(anonymous internal, anonymous1 documentdb_core.bson, OUT bson_value_dollar_regex internal).
 func BsonValueDollarRegex(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument) (outBsonValueDollarRegex struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonValueDollarRegex",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3106,7 +3106,7 @@ func BsonValueDollarRegex(ctx context.Context, conn *pgx.Conn, l *slog.Logger, a
 //
 //	documentdb_api_internal.bson_value_dollar_size(anonymous internal, anonymous1 documentdb_core.bson, OUT bson_value_dollar_size internal).
 func BsonValueDollarSize(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument) (outBsonValueDollarSize struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonValueDollarSize",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3127,7 +3127,7 @@ func BsonValueDollarSize(ctx context.Context, conn *pgx.Conn, l *slog.Logger, an
 //
 //	documentdb_api_internal.bson_value_dollar_type(anonymous internal, anonymous1 documentdb_core.bson, OUT bson_value_dollar_type internal).
 func BsonValueDollarType(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument) (outBsonValueDollarType struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonValueDollarType",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3148,7 +3148,7 @@ func BsonValueDollarType(ctx context.Context, conn *pgx.Conn, l *slog.Logger, an
 //
 //	documentdb_api_internal.bsoncovariancepop(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson).
 func Bsoncovariancepop(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.Bsoncovariancepop",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3169,7 +3169,7 @@ func Bsoncovariancepop(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anon
 //
 //	documentdb_api_internal.bsoncovariancesamp(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson).
 func Bsoncovariancesamp(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.Bsoncovariancesamp",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3190,7 +3190,7 @@ func Bsoncovariancesamp(ctx context.Context, conn *pgx.Conn, l *slog.Logger, ano
 //
 //	documentdb_api_internal.bsonderivative(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, anonymous12 bigint).
 func Bsonderivative(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument, anonymous12 int64) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.Bsonderivative",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3211,7 +3211,7 @@ func Bsonderivative(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymo
 //
 //	documentdb_api_internal.bsonfirst(anonymous documentdb_core.bson, anonymous1 ARRAY, anonymous12 documentdb_core.bson).
 func Bsonfirst(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 struct{}, anonymous12 wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.Bsonfirst",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3232,7 +3232,7 @@ func Bsonfirst(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wi
 //
 //	documentdb_api_internal.bsonfirstn(anonymous documentdb_core.bson, anonymous1 bigint, anonymous12 ARRAY, anonymous123 documentdb_core.bson).
 func Bsonfirstn(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 int64, anonymous12 struct{}, anonymous123 wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.Bsonfirstn",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3253,7 +3253,7 @@ func Bsonfirstn(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous w
 //
 //	documentdb_api_internal.bsonfirstnonsorted(anonymous documentdb_core.bson, anonymous1 bigint, anonymous12 documentdb_core.bson).
 func Bsonfirstnonsorted(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 int64, anonymous12 wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.Bsonfirstnonsorted",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3274,7 +3274,7 @@ func Bsonfirstnonsorted(ctx context.Context, conn *pgx.Conn, l *slog.Logger, ano
 //
 //	documentdb_api_internal.bsonfirstonsorted(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson).
 func Bsonfirstonsorted(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.Bsonfirstonsorted",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3295,7 +3295,7 @@ func Bsonfirstonsorted(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anon
 //
 //	documentdb_api_internal.bsonindexbounds_in(anonymous cstring, OUT bsonindexbounds_in documentdb_api_internal.bsonindexbounds).
 func BsonindexboundsIn(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonindexboundsIn struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonindexboundsIn",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3316,7 +3316,7 @@ func BsonindexboundsIn(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anon
 //
 //	documentdb_api_internal.bsonindexbounds_out(anonymous documentdb_api_internal.bsonindexbounds, OUT bsonindexbounds_out cstring).
 func BsonindexboundsOut(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonindexboundsOut struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonindexboundsOut",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3337,7 +3337,7 @@ func BsonindexboundsOut(ctx context.Context, conn *pgx.Conn, l *slog.Logger, ano
 //
 //	documentdb_api_internal.bsonindexbounds_recv(anonymous internal, OUT bsonindexbounds_recv documentdb_api_internal.bsonindexbounds).
 func BsonindexboundsRecv(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonindexboundsRecv struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonindexboundsRecv",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3358,7 +3358,7 @@ func BsonindexboundsRecv(ctx context.Context, conn *pgx.Conn, l *slog.Logger, an
 //
 //	documentdb_api_internal.bsonindexbounds_send(anonymous documentdb_api_internal.bsonindexbounds, OUT bsonindexbounds_send bytea).
 func BsonindexboundsSend(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonindexboundsSend struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonindexboundsSend",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3379,7 +3379,7 @@ func BsonindexboundsSend(ctx context.Context, conn *pgx.Conn, l *slog.Logger, an
 //
 //	documentdb_api_internal.bsonintegral(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, anonymous12 bigint).
 func Bsonintegral(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument, anonymous12 int64) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.Bsonintegral",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3400,7 +3400,7 @@ func Bsonintegral(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_api_internal.bsonlast(anonymous documentdb_core.bson, anonymous1 ARRAY, anonymous12 documentdb_core.bson).
 func Bsonlast(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 struct{}, anonymous12 wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.Bsonlast",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3421,7 +3421,7 @@ func Bsonlast(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wir
 //
 //	documentdb_api_internal.bsonlastn(anonymous documentdb_core.bson, anonymous1 bigint, anonymous12 ARRAY, anonymous123 documentdb_core.bson).
 func Bsonlastn(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 int64, anonymous12 struct{}, anonymous123 wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.Bsonlastn",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3442,7 +3442,7 @@ func Bsonlastn(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wi
 //
 //	documentdb_api_internal.bsonlastnonsorted(anonymous documentdb_core.bson, anonymous1 bigint, anonymous12 documentdb_core.bson).
 func Bsonlastnonsorted(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 int64, anonymous12 wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.Bsonlastnonsorted",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3463,7 +3463,7 @@ func Bsonlastnonsorted(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anon
 //
 //	documentdb_api_internal.bsonlastonsorted(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson).
 func Bsonlastonsorted(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.Bsonlastonsorted",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3484,7 +3484,7 @@ func Bsonlastonsorted(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anony
 //
 //	documentdb_api_internal.bsonmaxn(anonymous documentdb_core.bson).
 func Bsonmaxn(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.Bsonmaxn",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3505,7 +3505,7 @@ func Bsonmaxn(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wir
 //
 //	documentdb_api_internal.bsonmedian(anonymous documentdb_core.bson, anonymous1 integer, anonymous12 documentdb_core.bson).
 func Bsonmedian(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 int32, anonymous12 wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.Bsonmedian",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3526,7 +3526,7 @@ func Bsonmedian(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous w
 //
 //	documentdb_api_internal.bsonminn(anonymous documentdb_core.bson).
 func Bsonminn(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.Bsonminn",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3547,7 +3547,7 @@ func Bsonminn(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wir
 //
 //	documentdb_api_internal.bsonpercentile(anonymous documentdb_core.bson, anonymous1 integer, anonymous12 documentdb_core.bson).
 func Bsonpercentile(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 int32, anonymous12 wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.Bsonpercentile",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3568,7 +3568,7 @@ func Bsonpercentile(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymo
 //
 //	documentdb_api_internal.bsonquery_eq(anonymous documentdb_core.bsonquery, anonymous1 documentdb_api_internal.bsonindexbounds, OUT bsonquery_eq boolean).
 func BsonqueryEq(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}) (outBsonqueryEq bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonqueryEq",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3589,7 +3589,7 @@ func BsonqueryEq(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_api_internal.bsonquery_gt(anonymous documentdb_core.bsonquery, anonymous1 documentdb_api_internal.bsonindexbounds, OUT bsonquery_gt boolean).
 func BsonqueryGt(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}) (outBsonqueryGt bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonqueryGt",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3610,7 +3610,7 @@ func BsonqueryGt(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_api_internal.bsonquery_gte(anonymous documentdb_core.bsonquery, anonymous1 documentdb_api_internal.bsonindexbounds, OUT bsonquery_gte boolean).
 func BsonqueryGte(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}) (outBsonqueryGte bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonqueryGte",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3631,7 +3631,7 @@ func BsonqueryGte(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_api_internal.bsonquery_lt(anonymous documentdb_core.bsonquery, anonymous1 documentdb_api_internal.bsonindexbounds, OUT bsonquery_lt boolean).
 func BsonqueryLt(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}) (outBsonqueryLt bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonqueryLt",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3652,7 +3652,7 @@ func BsonqueryLt(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_api_internal.bsonquery_lte(anonymous documentdb_core.bsonquery, anonymous1 documentdb_api_internal.bsonindexbounds, OUT bsonquery_lte boolean).
 func BsonqueryLte(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}) (outBsonqueryLte bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.BsonqueryLte",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3673,7 +3673,7 @@ func BsonqueryLte(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_api_internal.bsonstddevpop(anonymous documentdb_core.bson).
 func Bsonstddevpop(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.Bsonstddevpop",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3694,7 +3694,7 @@ func Bsonstddevpop(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymou
 //
 //	documentdb_api_internal.bsonstddevsamp(anonymous documentdb_core.bson).
 func Bsonstddevsamp(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.Bsonstddevsamp",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3715,7 +3715,7 @@ func Bsonstddevsamp(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymo
 //
 //	documentdb_api_internal.check_build_index_status(p_arg documentdb_core.bson, OUT retval documentdb_core.bson, OUT ok boolean, OUT complete boolean).
 func CheckBuildIndexStatus(ctx context.Context, conn *pgx.Conn, l *slog.Logger, arg wirebson.RawDocument) (outRetVal wirebson.RawDocument, outOk bool, outComplete bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.CheckBuildIndexStatus",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3736,7 +3736,7 @@ func CheckBuildIndexStatus(ctx context.Context, conn *pgx.Conn, l *slog.Logger,
 //
 //	documentdb_api_internal.check_build_index_status_internal(p_arg documentdb_core.bson, OUT check_build_index_status_internal documentdb_core.bson).
 func CheckBuildIndexStatusInternal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, arg wirebson.RawDocument) (outCheckBuildIndexStatusInternal wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.CheckBuildIndexStatusInternal",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3757,7 +3757,7 @@ func CheckBuildIndexStatusInternal(ctx context.Context, conn *pgx.Conn, l *slog.
 //
 //	documentdb_api_internal.coll_stats_aggregation(p_database_name text, p_collection_name text, p_collstatsspec documentdb_core.bson, OUT coll_stats_aggregation documentdb_core.bson).
 func CollStatsAggregation(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databaseName string, collectionName string, collstatsSpec wirebson.RawDocument) (outCollStatsAggregation wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.CollStatsAggregation",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3778,7 +3778,7 @@ func CollStatsAggregation(ctx context.Context, conn *pgx.Conn, l *slog.Logger, d
 //
 //	documentdb_api_internal.coll_stats_worker(p_database_name text, p_collection_name text, p_scale double precision DEFAULT 1, OUT coll_stats_worker documentdb_core.bson).
 func CollStatsWorker(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databaseName string, collectionName string, scale float64) (outCollStatsWorker wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.CollStatsWorker",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3799,7 +3799,7 @@ func CollStatsWorker(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databa
 //
 //	documentdb_api_internal.collection_update_trigger(OUT collection_update_trigger trigger).
 func CollectionUpdateTrigger(ctx context.Context, conn *pgx.Conn, l *slog.Logger) (outCollectionUpdateTrigger struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.CollectionUpdateTrigger",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3820,7 +3820,7 @@ func CollectionUpdateTrigger(ctx context.Context, conn *pgx.Conn, l *slog.Logger
 //
 //	documentdb_api_internal.command_feature_counter_stats(reset_stats_after_read boolean, OUT feature_name text, OUT usage_count integer).
 func CommandFeatureCounterStats(ctx context.Context, conn *pgx.Conn, l *slog.Logger, resetStatsAfterRead bool) (outFeatureName string, outUsageCount int32, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.CommandFeatureCounterStats",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3841,7 +3841,7 @@ func CommandFeatureCounterStats(ctx context.Context, conn *pgx.Conn, l *slog.Log
 //
 //	documentdb_api_internal.create_builtin_id_index(collection_id bigint, register_id_index boolean DEFAULT true).
 func CreateBuiltinIdIndex(ctx context.Context, conn *pgx.Conn, l *slog.Logger, collectionId int64, registerIdIndex bool) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.CreateBuiltinIdIndex",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3862,7 +3862,7 @@ func CreateBuiltinIdIndex(ctx context.Context, conn *pgx.Conn, l *slog.Logger, c
 //
 //	documentdb_api_internal.create_indexes_background_internal(p_database_name text, p_arg documentdb_core.bson, OUT create_indexes_background_internal documentdb_core.bson).
 func CreateIndexesBackgroundInternal(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databaseName string, arg wirebson.RawDocument) (outCreateIndexesBackgroundInternal wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.CreateIndexesBackgroundInternal",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3883,7 +3883,7 @@ func CreateIndexesBackgroundInternal(ctx context.Context, conn *pgx.Conn, l *slo
 //
 //	documentdb_api_internal.create_indexes_non_concurrently(p_database_name text, p_arg documentdb_core.bson, p_skip_check_collection_create boolean DEFAULT false, OUT create_indexes_non_concurrently documentdb_core.bson).
 func CreateIndexesNonConcurrently(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databaseName string, arg wirebson.RawDocument, skipCheckCollectionCreate bool) (outCreateIndexesNonConcurrently wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.CreateIndexesNonConcurrently",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3904,7 +3904,7 @@ func CreateIndexesNonConcurrently(ctx context.Context, conn *pgx.Conn, l *slog.L
 //
 //	documentdb_api_internal.current_cursor_state(anonymous documentdb_core.bson, OUT current_cursor_state documentdb_core.bson).
 func CurrentCursorState(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument) (outCurrentCursorState wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.CurrentCursorState",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3925,7 +3925,7 @@ func CurrentCursorState(ctx context.Context, conn *pgx.Conn, l *slog.Logger, ano
 //
 //	documentdb_api_internal.current_op_aggregation(p_spec documentdb_core.bson, OUT document documentdb_core.bson).
 func CurrentOpAggregation(ctx context.Context, conn *pgx.Conn, l *slog.Logger, spec wirebson.RawDocument) (outDocument wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.CurrentOpAggregation",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3946,7 +3946,7 @@ func CurrentOpAggregation(ctx context.Context, conn *pgx.Conn, l *slog.Logger, s
 //
 //	documentdb_api_internal.current_op_worker(p_spec documentdb_core.bson, OUT document documentdb_core.bson).
 func CurrentOpWorker(ctx context.Context, conn *pgx.Conn, l *slog.Logger, spec wirebson.RawDocument) (outDocument wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.CurrentOpWorker",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3967,7 +3967,7 @@ func CurrentOpWorker(ctx context.Context, conn *pgx.Conn, l *slog.Logger, spec w
 //
 //	documentdb_api_internal.cursor_directory_cleanup(expiry_time_seconds bigint DEFAULT NULL).
 func CursorDirectoryCleanup(ctx context.Context, conn *pgx.Conn, l *slog.Logger, expiryTimeSeconds int64) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.CursorDirectoryCleanup",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -3988,7 +3988,7 @@ func CursorDirectoryCleanup(ctx context.Context, conn *pgx.Conn, l *slog.Logger,
 //
 //	documentdb_api_internal.cursor_state(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT cursor_state boolean).
 func CursorState(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outCursorState bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.CursorState",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4009,7 +4009,7 @@ func CursorState(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_api_internal.db_stats_worker(p_collection_ids ARRAY, OUT db_stats_worker documentdb_core.bson).
 func DbStatsWorker(ctx context.Context, conn *pgx.Conn, l *slog.Logger, collectionIds struct{}) (outDbStatsWorker wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.DbStatsWorker",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4030,7 +4030,7 @@ func DbStatsWorker(ctx context.Context, conn *pgx.Conn, l *slog.Logger, collecti
 //
 //	documentdb_api_internal.delete_cursors(cursorids ARRAY, OUT delete_cursors documentdb_core.bson).
 func DeleteCursors(ctx context.Context, conn *pgx.Conn, l *slog.Logger, cursorids struct{}) (outDeleteCursors wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.DeleteCursors",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4051,7 +4051,7 @@ func DeleteCursors(ctx context.Context, conn *pgx.Conn, l *slog.Logger, cursorid
 //
 //	documentdb_api_internal.delete_one(p_collection_id bigint, p_shard_key_value bigint, p_query documentdb_core.bson, p_sort documentdb_core.bson, p_return_document boolean, p_return_fields documentdb_core.bson, OUT o_is_row_deleted boolean, OUT o_result_deleted_document documentdb_core.bson).
 func DeleteOne(ctx context.Context, conn *pgx.Conn, l *slog.Logger, collectionId int64, shardKeyValue int64, query wirebson.RawDocument, sort wirebson.RawDocument, returnDocument bool, returnFields wirebson.RawDocument) (outOIsRowDeleted bool, outOResultDeletedDocument wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.DeleteOne",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4072,7 +4072,7 @@ func DeleteOne(ctx context.Context, conn *pgx.Conn, l *slog.Logger, collectionId
 //
 //	documentdb_api_internal.delete_worker(p_collection_id bigint, p_shard_key_value bigint, p_shard_oid regclass, p_update_internal_spec documentdb_core.bson, p_update_internal_docs documentdb_core.bsonsequence, OUT delete_worker documentdb_core.bson).
 func DeleteWorker(ctx context.Context, conn *pgx.Conn, l *slog.Logger, collectionId int64, shardKeyValue int64, shardOid struct{}, updateInternalSpec wirebson.RawDocument, updateInternalDocs []byte) (outDeleteWorker wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.DeleteWorker",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4093,7 +4093,7 @@ func DeleteWorker(ctx context.Context, conn *pgx.Conn, l *slog.Logger, collectio
 //
 //	documentdb_api_internal.documentdb_core_bson_to_bson(anonymous documentdb_core.bson, OUT documentdb_core_bson_to_bson documentdb_core.bson).
 func DocumentdbCoreBsonToBson(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument) (outDocumentdbCoreBsonToBson wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.DocumentdbCoreBsonToBson",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4114,7 +4114,7 @@ func DocumentdbCoreBsonToBson(ctx context.Context, conn *pgx.Conn, l *slog.Logge
 //
 //	documentdb_api_internal.documentdb_get_next_collection_id(OUT documentdb_get_next_collection_id bigint).
 func DocumentdbGetNextCollectionId(ctx context.Context, conn *pgx.Conn, l *slog.Logger) (outDocumentdbGetNextCollectionId int64, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.DocumentdbGetNextCollectionId",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4135,7 +4135,7 @@ func DocumentdbGetNextCollectionId(ctx context.Context, conn *pgx.Conn, l *slog.
 //
 //	documentdb_api_internal.documentdb_get_next_collection_index_id(OUT documentdb_get_next_collection_index_id integer).
 func DocumentdbGetNextCollectionIndexId(ctx context.Context, conn *pgx.Conn, l *slog.Logger) (outDocumentdbGetNextCollectionIndexId int32, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.DocumentdbGetNextCollectionIndexId",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4156,7 +4156,7 @@ func DocumentdbGetNextCollectionIndexId(ctx context.Context, conn *pgx.Conn, l *
 //
 //	documentdb_api_internal.empty_data_table(OUT shard_key_value bigint, OUT object_id documentdb_core.bson, OUT document documentdb_core.bson).
 func EmptyDataTable(ctx context.Context, conn *pgx.Conn, l *slog.Logger) (outShardKeyValue int64, outObjectID wirebson.RawDocument, outDocument wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.EmptyDataTable",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4177,7 +4177,7 @@ func EmptyDataTable(ctx context.Context, conn *pgx.Conn, l *slog.Logger) (outSha
 //
 //	documentdb_api_internal.ensure_valid_db_coll(anonymous text, anonymous1 text, OUT ensure_valid_db_coll boolean).
 func EnsureValidDbColl(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous string, anonymous1 string) (outEnsureValidDbColl bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.EnsureValidDbColl",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4198,7 +4198,7 @@ func EnsureValidDbColl(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anon
 //
 //	documentdb_api_internal.generate_unique_shard_document(p_document documentdb_core.bson, p_shard_key_value bigint, p_unique_spec documentdb_core.bson, p_sparse boolean, OUT generate_unique_shard_document documentdb_core.bson).
 func GenerateUniqueShardDocument(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, shardKeyValue int64, uniqueSpec wirebson.RawDocument, sparse bool) (outGenerateUniqueShardDocument wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.GenerateUniqueShardDocument",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4219,7 +4219,7 @@ func GenerateUniqueShardDocument(ctx context.Context, conn *pgx.Conn, l *slog.Lo
 //
 //	documentdb_api_internal.get_bloat_stats_worker(p_collection_id bigint, OUT get_bloat_stats_worker documentdb_core.bson).
 func GetBloatStatsWorker(ctx context.Context, conn *pgx.Conn, l *slog.Logger, collectionId int64) (outGetBloatStatsWorker wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.GetBloatStatsWorker",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4240,7 +4240,7 @@ func GetBloatStatsWorker(ctx context.Context, conn *pgx.Conn, l *slog.Logger, co
 //
 //	documentdb_api_internal.get_shard_key_value(p_shard_key documentdb_core.bson, p_collection_id bigint, p_document documentdb_core.bson, OUT get_shard_key_value bigint).
 func GetShardKeyValue(ctx context.Context, conn *pgx.Conn, l *slog.Logger, shardKey wirebson.RawDocument, collectionId int64, document wirebson.RawDocument) (outGetShardKeyValue int64, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.GetShardKeyValue",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4261,7 +4261,7 @@ func GetShardKeyValue(ctx context.Context, conn *pgx.Conn, l *slog.Logger, shard
 //
 //	documentdb_api_internal.gin_bson_composite_path_compare_partial(anonymous bytea, anonymous1 bytea, anonymous12 smallint, anonymous123 internal, OUT gin_bson_composite_path_compare_partial integer).
 func GinBsonCompositePathComparePartial(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}, anonymous12 struct{}, anonymous123 struct{}) (outGinBsonCompositePathComparePartial int32, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.GinBsonCompositePathComparePartial",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4282,7 +4282,7 @@ func GinBsonCompositePathComparePartial(ctx context.Context, conn *pgx.Conn, l *
 //
 //	documentdb_api_internal.gin_bson_composite_path_consistent(anonymous internal, anonymous1 smallint, anonymous12 anyelement, anonymous123 integer, anonymous1234 internal, anonymous12345 internal, OUT gin_bson_composite_path_consistent boolean).
 func GinBsonCompositePathConsistent(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}, anonymous12 struct{}, anonymous123 int32, anonymous1234 struct{}, anonymous12345 struct{}) (outGinBsonCompositePathConsistent bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.GinBsonCompositePathConsistent",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4303,7 +4303,7 @@ func GinBsonCompositePathConsistent(ctx context.Context, conn *pgx.Conn, l *slog
 //
 //	documentdb_api_internal.gin_bson_composite_path_extract_query(anonymous documentdb_core.bson, anonymous1 internal, anonymous12 smallint, anonymous123 internal, anonymous1234 internal, anonymous12345 internal, anonymous123456 internal, OUT gin_bson_composite_path_extract_query internal).
 func GinBsonCompositePathExtractQuery(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 struct{}, anonymous12 struct{}, anonymous123 struct{}, anonymous1234 struct{}, anonymous12345 struct{}, anonymous123456 struct{}) (outGinBsonCompositePathExtractQuery struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.GinBsonCompositePathExtractQuery",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4324,7 +4324,7 @@ func GinBsonCompositePathExtractQuery(ctx context.Context, conn *pgx.Conn, l *sl
 //
 //	documentdb_api_internal.gin_bson_composite_path_extract_value(anonymous documentdb_core.bson, anonymous1 internal, OUT gin_bson_composite_path_extract_value internal).
 func GinBsonCompositePathExtractValue(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 struct{}) (outGinBsonCompositePathExtractValue struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.GinBsonCompositePathExtractValue",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4345,7 +4345,7 @@ func GinBsonCompositePathExtractValue(ctx context.Context, conn *pgx.Conn, l *sl
 //
 //	documentdb_api_internal.gin_bson_composite_path_options(anonymous internal).
 func GinBsonCompositePathOptions(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.GinBsonCompositePathOptions",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4366,7 +4366,7 @@ func GinBsonCompositePathOptions(ctx context.Context, conn *pgx.Conn, l *slog.Lo
 //
 //	documentdb_api_internal.gin_bson_exclusion_consistent(anonymous internal, anonymous1 smallint, anonymous12 anyelement, anonymous123 integer, anonymous1234 internal, anonymous12345 internal, OUT gin_bson_exclusion_consistent boolean).
 func GinBsonExclusionConsistent(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}, anonymous12 struct{}, anonymous123 int32, anonymous1234 struct{}, anonymous12345 struct{}) (outGinBsonExclusionConsistent bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.GinBsonExclusionConsistent",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4387,7 +4387,7 @@ func GinBsonExclusionConsistent(ctx context.Context, conn *pgx.Conn, l *slog.Log
 //
 //	documentdb_api_internal.gin_bson_exclusion_extract_query(anonymous documentdb_api_catalog.shard_key_and_document, anonymous1 internal, anonymous12 smallint, anonymous123 internal, anonymous1234 internal, anonymous12345 internal, anonymous123456 internal).
 func GinBsonExclusionExtractQuery(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}, anonymous12 struct{}, anonymous123 struct{}, anonymous1234 struct{}, anonymous12345 struct{}, anonymous123456 struct{}) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.GinBsonExclusionExtractQuery",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4408,7 +4408,7 @@ func GinBsonExclusionExtractQuery(ctx context.Context, conn *pgx.Conn, l *slog.L
 //
 //	documentdb_api_internal.gin_bson_exclusion_extract_value(anonymous documentdb_api_catalog.shard_key_and_document, anonymous1 internal, OUT gin_bson_exclusion_extract_value internal).
 func GinBsonExclusionExtractValue(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}) (outGinBsonExclusionExtractValue struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.GinBsonExclusionExtractValue",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4429,7 +4429,7 @@ func GinBsonExclusionExtractValue(ctx context.Context, conn *pgx.Conn, l *slog.L
 //
 //	documentdb_api_internal.gin_bson_exclusion_options(anonymous internal).
 func GinBsonExclusionOptions(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.GinBsonExclusionOptions",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4450,7 +4450,7 @@ func GinBsonExclusionOptions(ctx context.Context, conn *pgx.Conn, l *slog.Logger
 //
 //	documentdb_api_internal.gin_bson_hashed_consistent(anonymous internal, anonymous1 smallint, anonymous12 anyelement, anonymous123 integer, anonymous1234 internal, anonymous12345 internal, OUT gin_bson_hashed_consistent boolean).
 func GinBsonHashedConsistent(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}, anonymous12 struct{}, anonymous123 int32, anonymous1234 struct{}, anonymous12345 struct{}) (outGinBsonHashedConsistent bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.GinBsonHashedConsistent",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4471,7 +4471,7 @@ func GinBsonHashedConsistent(ctx context.Context, conn *pgx.Conn, l *slog.Logger
 //
 //	documentdb_api_internal.gin_bson_hashed_extract_query(anonymous documentdb_core.bson, anonymous1 internal, anonymous12 smallint, anonymous123 internal, anonymous1234 internal, anonymous12345 internal, anonymous123456 internal).
 func GinBsonHashedExtractQuery(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 struct{}, anonymous12 struct{}, anonymous123 struct{}, anonymous1234 struct{}, anonymous12345 struct{}, anonymous123456 struct{}) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.GinBsonHashedExtractQuery",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4492,7 +4492,7 @@ func GinBsonHashedExtractQuery(ctx context.Context, conn *pgx.Conn, l *slog.Logg
 //
 //	documentdb_api_internal.gin_bson_hashed_extract_value(anonymous documentdb_core.bson, anonymous1 internal, OUT gin_bson_hashed_extract_value internal).
 func GinBsonHashedExtractValue(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 struct{}) (outGinBsonHashedExtractValue struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.GinBsonHashedExtractValue",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4513,7 +4513,7 @@ func GinBsonHashedExtractValue(ctx context.Context, conn *pgx.Conn, l *slog.Logg
 //
 //	documentdb_api_internal.gin_bson_hashed_options(anonymous internal).
 func GinBsonHashedOptions(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.GinBsonHashedOptions",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4534,7 +4534,7 @@ func GinBsonHashedOptions(ctx context.Context, conn *pgx.Conn, l *slog.Logger, a
 //
 //	documentdb_api_internal.gin_bson_unique_shard_consistent(anonymous internal, anonymous1 smallint, anonymous12 anyelement, anonymous123 integer, anonymous1234 internal, anonymous12345 internal, OUT gin_bson_unique_shard_consistent boolean).
 func GinBsonUniqueShardConsistent(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}, anonymous12 struct{}, anonymous123 int32, anonymous1234 struct{}, anonymous12345 struct{}) (outGinBsonUniqueShardConsistent bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.GinBsonUniqueShardConsistent",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4555,7 +4555,7 @@ func GinBsonUniqueShardConsistent(ctx context.Context, conn *pgx.Conn, l *slog.L
 //
 //	documentdb_api_internal.gin_bson_unique_shard_extract_query(anonymous documentdb_core.bson, anonymous1 internal, anonymous12 smallint, anonymous123 internal, anonymous1234 internal, anonymous12345 internal, anonymous123456 internal).
 func GinBsonUniqueShardExtractQuery(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 struct{}, anonymous12 struct{}, anonymous123 struct{}, anonymous1234 struct{}, anonymous12345 struct{}, anonymous123456 struct{}) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.GinBsonUniqueShardExtractQuery",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4576,7 +4576,7 @@ func GinBsonUniqueShardExtractQuery(ctx context.Context, conn *pgx.Conn, l *slog
 //
 //	documentdb_api_internal.gin_bson_unique_shard_extract_value(anonymous documentdb_core.bson, anonymous1 internal, OUT gin_bson_unique_shard_extract_value internal).
 func GinBsonUniqueShardExtractValue(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 struct{}) (outGinBsonUniqueShardExtractValue struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.GinBsonUniqueShardExtractValue",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4597,7 +4597,7 @@ func GinBsonUniqueShardExtractValue(ctx context.Context, conn *pgx.Conn, l *slog
 //
 //	documentdb_api_internal.gin_bson_unique_shard_pre_consistent(anonymous internal, anonymous1 smallint, anonymous12 documentdb_core.bson, anonymous123 integer, anonymous1234 internal, anonymous12345 internal, anonymous123456 internal, anonymous1234567 internal).
 func GinBsonUniqueShardPreConsistent(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}, anonymous12 wirebson.RawDocument, anonymous123 int32, anonymous1234 struct{}, anonymous12345 struct{}, anonymous123456 struct{}, anonymous1234567 struct{}) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.GinBsonUniqueShardPreConsistent",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4618,7 +4618,7 @@ func GinBsonUniqueShardPreConsistent(ctx context.Context, conn *pgx.Conn, l *slo
 //
 //	documentdb_api_internal.index_build_is_in_progress(p_index_id integer, OUT index_build_is_in_progress boolean).
 func IndexBuildIsInProgress(ctx context.Context, conn *pgx.Conn, l *slog.Logger, indexId int32) (outIndexBuildIsInProgress bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.IndexBuildIsInProgress",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4639,7 +4639,7 @@ func IndexBuildIsInProgress(ctx context.Context, conn *pgx.Conn, l *slog.Logger,
 //
 //	documentdb_api_internal.index_spec_as_bson(index_spec documentdb_api_catalog.index_spec_type, for_get_indexes boolean DEFAULT false, namespacename text DEFAULT NULL, OUT index_spec_as_bson documentdb_core.bson).
 func IndexSpecAsBson(ctx context.Context, conn *pgx.Conn, l *slog.Logger, indexSpec struct{}, forGetIndexes bool, namespacename string) (outIndexSpecAsBson wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.IndexSpecAsBson",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4660,7 +4660,7 @@ func IndexSpecAsBson(ctx context.Context, conn *pgx.Conn, l *slog.Logger, indexS
 //
 //	documentdb_api_internal.index_spec_options_are_equivalent(left_index_spec documentdb_api_catalog.index_spec_type, right_index_spec documentdb_api_catalog.index_spec_type, OUT index_spec_options_are_equivalent boolean).
 func IndexSpecOptionsAreEquivalent(ctx context.Context, conn *pgx.Conn, l *slog.Logger, leftIndexSpec struct{}, rightIndexSpec struct{}) (outIndexSpecOptionsAreEquivalent bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.IndexSpecOptionsAreEquivalent",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4681,7 +4681,7 @@ func IndexSpecOptionsAreEquivalent(ctx context.Context, conn *pgx.Conn, l *slog.
 //
 //	documentdb_api_internal.index_stats_aggregation(p_database_name text, p_collection_name text, OUT index_stats_aggregation documentdb_core.bson).
 func IndexStatsAggregation(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databaseName string, collectionName string) (outIndexStatsAggregation wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.IndexStatsAggregation",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4702,7 +4702,7 @@ func IndexStatsAggregation(ctx context.Context, conn *pgx.Conn, l *slog.Logger,
 //
 //	documentdb_api_internal.index_stats_worker(p_database_name text, p_collection_name text, OUT index_stats_worker documentdb_core.bson).
 func IndexStatsWorker(ctx context.Context, conn *pgx.Conn, l *slog.Logger, databaseName string, collectionName string) (outIndexStatsWorker wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.IndexStatsWorker",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4723,7 +4723,7 @@ func IndexStatsWorker(ctx context.Context, conn *pgx.Conn, l *slog.Logger, datab
 //
 //	documentdb_api_internal.insert_one(p_collection_id bigint, p_shard_key_value bigint, p_document documentdb_core.bson, OUT insert_one boolean).
 func InsertOne(ctx context.Context, conn *pgx.Conn, l *slog.Logger, collectionId int64, shardKeyValue int64, document wirebson.RawDocument) (outInsertOne bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.InsertOne",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4744,7 +4744,7 @@ func InsertOne(ctx context.Context, conn *pgx.Conn, l *slog.Logger, collectionId
 //
 //	documentdb_api_internal.insert_worker(p_collection_id bigint, p_shard_key_value bigint, p_shard_oid regclass, p_insert_internal_spec documentdb_core.bson, p_insert_internal_docs documentdb_core.bsonsequence, OUT insert_worker documentdb_core.bson).
 func InsertWorker(ctx context.Context, conn *pgx.Conn, l *slog.Logger, collectionId int64, shardKeyValue int64, shardOid struct{}, insertInternalSpec wirebson.RawDocument, insertInternalDocs []byte) (outInsertWorker wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.InsertWorker",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4765,7 +4765,7 @@ func InsertWorker(ctx context.Context, conn *pgx.Conn, l *slog.Logger, collectio
 //
 //	documentdb_api_internal.invalidate_collection_cache().
 func InvalidateCollectionCache(ctx context.Context, conn *pgx.Conn, l *slog.Logger) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.InvalidateCollectionCache",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4786,7 +4786,7 @@ func InvalidateCollectionCache(ctx context.Context, conn *pgx.Conn, l *slog.Logg
 //
 //	documentdb_api_internal.record_id_index(p_collection_id bigint).
 func RecordIdIndex(ctx context.Context, conn *pgx.Conn, l *slog.Logger, collectionId int64) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.RecordIdIndex",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4807,7 +4807,7 @@ func RecordIdIndex(ctx context.Context, conn *pgx.Conn, l *slog.Logger, collecti
 //
 //	documentdb_api_internal.rum_bson_single_path_extract_tsvector(anonymous documentdb_core.bson, anonymous1 internal, anonymous12 internal, anonymous123 internal, anonymous1234 internal, OUT rum_bson_single_path_extract_tsvector internal).
 func RumBsonSinglePathExtractTsvector(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 struct{}, anonymous12 struct{}, anonymous123 struct{}, anonymous1234 struct{}) (outRumBsonSinglePathExtractTsvector struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.RumBsonSinglePathExtractTsvector",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4828,7 +4828,7 @@ func RumBsonSinglePathExtractTsvector(ctx context.Context, conn *pgx.Conn, l *sl
 //
 //	documentdb_api_internal.rum_bson_text_path_options(anonymous internal).
 func RumBsonTextPathOptions(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.RumBsonTextPathOptions",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4849,7 +4849,7 @@ func RumBsonTextPathOptions(ctx context.Context, conn *pgx.Conn, l *slog.Logger,
 //
 //	documentdb_api_internal.schedule_background_index_build_jobs(p_force_override boolean DEFAULT false).
 func ScheduleBackgroundIndexBuildJobs(ctx context.Context, conn *pgx.Conn, l *slog.Logger, forceOverride bool) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.ScheduleBackgroundIndexBuildJobs",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4870,7 +4870,7 @@ func ScheduleBackgroundIndexBuildJobs(ctx context.Context, conn *pgx.Conn, l *sl
 //
 //	documentdb_api_internal.schema_validation_against_update(p_eval_state bytea, p_target_document documentdb_core.bson, p_source_document documentdb_core.bson, p_is_moderate boolean, OUT schema_validation_against_update boolean).
 func SchemaValidationAgainstUpdate(ctx context.Context, conn *pgx.Conn, l *slog.Logger, evalState struct{}, targetDocument wirebson.RawDocument, sourceDocument wirebson.RawDocument, isModerate bool) (outSchemaValidationAgainstUpdate bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.SchemaValidationAgainstUpdate",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4891,7 +4891,7 @@ func SchemaValidationAgainstUpdate(ctx context.Context, conn *pgx.Conn, l *slog.
 //
 //	documentdb_api_internal.scram_sha256_get_salt_and_iterations(p_user_name text, OUT scram_sha256_get_salt_and_iterations documentdb_core.bson).
 func ScramSha256GetSaltAndIterations(ctx context.Context, conn *pgx.Conn, l *slog.Logger, userName string) (outScramSha256GetSaltAndIterations wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.ScramSha256GetSaltAndIterations",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4912,7 +4912,7 @@ func ScramSha256GetSaltAndIterations(ctx context.Context, conn *pgx.Conn, l *slo
 //
 //	documentdb_api_internal.tdigest_add_double(anonymous internal, anonymous1 documentdb_core.bson, anonymous12 integer, anonymous123 documentdb_core.bson, OUT tdigest_add_double internal).
 func TdigestAddDouble(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument, anonymous12 int32, anonymous123 wirebson.RawDocument) (outTdigestAddDouble struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.TdigestAddDouble",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4933,7 +4933,7 @@ func TdigestAddDouble(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anony
 //
 //	documentdb_api_internal.tdigest_add_double_array(anonymous internal, anonymous1 documentdb_core.bson, anonymous12 integer, anonymous123 documentdb_core.bson, OUT tdigest_add_double_array internal).
 func TdigestAddDoubleArray(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 wirebson.RawDocument, anonymous12 int32, anonymous123 wirebson.RawDocument) (outTdigestAddDoubleArray struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.TdigestAddDoubleArray",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4954,7 +4954,7 @@ func TdigestAddDoubleArray(ctx context.Context, conn *pgx.Conn, l *slog.Logger,
 //
 //	documentdb_api_internal.tdigest_array_percentiles(anonymous internal, OUT tdigest_array_percentiles documentdb_core.bson).
 func TdigestArrayPercentiles(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outTdigestArrayPercentiles wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.TdigestArrayPercentiles",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4975,7 +4975,7 @@ func TdigestArrayPercentiles(ctx context.Context, conn *pgx.Conn, l *slog.Logger
 //
 //	documentdb_api_internal.tdigest_combine(anonymous internal, anonymous1 internal, OUT tdigest_combine internal).
 func TdigestCombine(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}) (outTdigestCombine struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.TdigestCombine",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -4996,7 +4996,7 @@ func TdigestCombine(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymo
 //
 //	documentdb_api_internal.tdigest_deserial(anonymous bytea, anonymous1 internal, OUT tdigest_deserial internal).
 func TdigestDeserial(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}) (outTdigestDeserial struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.TdigestDeserial",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -5017,7 +5017,7 @@ func TdigestDeserial(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonym
 //
 //	documentdb_api_internal.tdigest_percentile(anonymous internal, OUT tdigest_percentile documentdb_core.bson).
 func TdigestPercentile(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outTdigestPercentile wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.TdigestPercentile",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -5038,7 +5038,7 @@ func TdigestPercentile(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anon
 //
 //	documentdb_api_internal.tdigest_serial(anonymous internal, OUT tdigest_serial bytea).
 func TdigestSerial(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outTdigestSerial struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.TdigestSerial",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -5059,7 +5059,7 @@ func TdigestSerial(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymou
 //
 //	documentdb_api_internal.trigger_validate_dbname(OUT trigger_validate_dbname trigger).
 func TriggerValidateDbname(ctx context.Context, conn *pgx.Conn, l *slog.Logger) (outTriggerValidateDbname struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.TriggerValidateDbname",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -5080,7 +5080,7 @@ func TriggerValidateDbname(ctx context.Context, conn *pgx.Conn, l *slog.Logger)
 //
 //	documentdb_api_internal.update_bson_document(document documentdb_core.bson, updatespec documentdb_core.bson, queryspec documentdb_core.bson, arrayfilters documentdb_core.bson DEFAULT NULL, variablespec documentdb_core.bson DEFAULT NULL, OUT newdocument documentdb_core.bson).
 func UpdateBsonDocument(ctx context.Context, conn *pgx.Conn, l *slog.Logger, document wirebson.RawDocument, updateSpec wirebson.RawDocument, querySpec wirebson.RawDocument, arrayfilters wirebson.RawDocument, variableSpec wirebson.RawDocument) (outNewdocument wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.UpdateBsonDocument",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -5101,7 +5101,7 @@ func UpdateBsonDocument(ctx context.Context, conn *pgx.Conn, l *slog.Logger, doc
 //
 //	documentdb_api_internal.update_one(p_collection_id bigint, p_shard_key_value bigint, p_query documentdb_core.bson, p_update documentdb_core.bson, p_shard_key documentdb_core.bson, p_is_upsert boolean, p_sort documentdb_core.bson, p_return_old_or_new boolean, p_return_fields documentdb_core.bson, p_array_filters documentdb_core.bson, OUT o_is_row_updated boolean, OUT o_update_skipped boolean, OUT o_is_retry boolean, OUT o_reinsert_document documentdb_core.bson, OUT o_upserted_object_id bytea, OUT o_result_document documentdb_core.bson).
 func UpdateOne(ctx context.Context, conn *pgx.Conn, l *slog.Logger, collectionId int64, shardKeyValue int64, query wirebson.RawDocument, update wirebson.RawDocument, shardKey wirebson.RawDocument, isUpsert bool, sort wirebson.RawDocument, returnOldOrNew bool, returnFields wirebson.RawDocument, arrayFilters wirebson.RawDocument) (outOIsRowUpdated bool, outOUpdateSkipped bool, outOIsRetry bool, outOReinsertDocument wirebson.RawDocument, outOUpsertedObjectId struct{}, outOResultDocument wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.UpdateOne",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -5122,7 +5122,7 @@ func UpdateOne(ctx context.Context, conn *pgx.Conn, l *slog.Logger, collectionId
 //
 //	documentdb_api_internal.update_worker(p_collection_id bigint, p_shard_key_value bigint, p_shard_oid regclass, p_update_internal_spec documentdb_core.bson, p_update_internal_docs documentdb_core.bsonsequence, OUT update_worker documentdb_core.bson).
 func UpdateWorker(ctx context.Context, conn *pgx.Conn, l *slog.Logger, collectionId int64, shardKeyValue int64, shardOid struct{}, updateInternalSpec wirebson.RawDocument, updateInternalDocs []byte) (outUpdateWorker wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.UpdateWorker",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -5143,7 +5143,7 @@ func UpdateWorker(ctx context.Context, conn *pgx.Conn, l *slog.Logger, collectio
 //
 //	documentdb_api_internal.validate_dbname(dbname text).
 func ValidateDbname(ctx context.Context, conn *pgx.Conn, l *slog.Logger, database string) (err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_api_internal.ValidateDbname",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -8,18 +8,18 @@
 
 	"github.com/FerretDB/wire/wirebson"
 	"github.com/jackc/pgx/v5"
-	"go.opentelemetry.io/otel"
 	otelsemconv "go.opentelemetry.io/otel/semconv/v1.34.0"
 	oteltrace "go.opentelemetry.io/otel/trace"
 
 	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+	"github.com/FerretDB/FerretDB/v2/internal/util/observability"
 )
 
 // BsonCompare is a wrapper for
 //
 //	documentdb_core.bson_compare(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_compare integer).
 func BsonCompare(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonCompare int32, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonCompare",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -40,7 +40,7 @@ func BsonCompare(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_core.bson_equal(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_equal boolean).
 func BsonEqual(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonEqual bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonEqual",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -61,7 +61,7 @@ func BsonEqual(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wi
 //
 //	documentdb_core.bson_from_bytea(anonymous bytea, OUT bson_from_bytea documentdb_core.bson).
 func BsonFromBytea(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonFromBytea wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonFromBytea",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -82,7 +82,7 @@ func BsonFromBytea(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymou
 //
 //	documentdb_core.bson_get_value(anonymous documentdb_core.bson, anonymous1 text, OUT bson_get_value documentdb_core.bson).
 func BsonGetValue(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 string) (outBsonGetValue wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonGetValue",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -103,7 +103,7 @@ func BsonGetValue(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_core.bson_get_value_text(anonymous documentdb_core.bson, anonymous1 text, OUT bson_get_value_text text).
 func BsonGetValueText(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 string) (outBsonGetValueText string, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonGetValueText",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -124,7 +124,7 @@ func BsonGetValueText(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anony
 //
 //	documentdb_core.bson_gt(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_gt boolean).
 func BsonGt(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonGt bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonGt",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -145,7 +145,7 @@ func BsonGt(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wireb
 //
 //	documentdb_core.bson_gte(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_gte boolean).
 func BsonGte(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonGte bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonGte",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -166,7 +166,7 @@ func BsonGte(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wire
 //
 //	documentdb_core.bson_hash_int4(anonymous documentdb_core.bson, OUT bson_hash_int4 integer).
 func BsonHashInt4(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument) (outBsonHashInt4 int32, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonHashInt4",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -187,7 +187,7 @@ func BsonHashInt4(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_core.bson_hash_int8(anonymous documentdb_core.bson, anonymous1 bigint, OUT bson_hash_int8 bigint).
 func BsonHashInt8(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 int64) (outBsonHashInt8 int64, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonHashInt8",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -208,7 +208,7 @@ func BsonHashInt8(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_core.bson_hex_to_bson(anonymous cstring, OUT bson_hex_to_bson documentdb_core.bson).
 func BsonHexToBson(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonHexToBson wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonHexToBson",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -229,7 +229,7 @@ func BsonHexToBson(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymou
 //
 //	documentdb_core.bson_in(anonymous cstring, OUT bson_in documentdb_core.bson).
 func BsonIn(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonIn wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonIn",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -250,7 +250,7 @@ func BsonIn(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struc
 //
 //	documentdb_core.bson_in_range_interval(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, anonymous12 interval, anonymous123 boolean, anonymous1234 boolean, OUT bson_in_range_interval boolean).
 func BsonInRangeInterval(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument, anonymous12 struct{}, anonymous123 bool, anonymous1234 bool) (outBsonInRangeInterval bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonInRangeInterval",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -271,7 +271,7 @@ func BsonInRangeInterval(ctx context.Context, conn *pgx.Conn, l *slog.Logger, an
 //
 //	documentdb_core.bson_in_range_numeric(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, anonymous12 documentdb_core.bson, anonymous123 boolean, anonymous1234 boolean, OUT bson_in_range_numeric boolean).
 func BsonInRangeNumeric(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument, anonymous12 wirebson.RawDocument, anonymous123 bool, anonymous1234 bool) (outBsonInRangeNumeric bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonInRangeNumeric",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -292,7 +292,7 @@ func BsonInRangeNumeric(ctx context.Context, conn *pgx.Conn, l *slog.Logger, ano
 //
 //	documentdb_core.bson_json_to_bson(anonymous text, OUT bson_json_to_bson documentdb_core.bson).
 func BsonJsonToBson(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous string) (outBsonJsonToBson wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonJsonToBson",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -313,7 +313,7 @@ func BsonJsonToBson(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymo
 //
 //	documentdb_core.bson_lt(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_lt boolean).
 func BsonLt(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonLt bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonLt",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -334,7 +334,7 @@ func BsonLt(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wireb
 //
 //	documentdb_core.bson_lte(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_lte boolean).
 func BsonLte(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonLte bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonLte",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -355,7 +355,7 @@ func BsonLte(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wire
 //
 //	documentdb_core.bson_not_equal(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_not_equal boolean).
 func BsonNotEqual(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonNotEqual bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonNotEqual",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -376,7 +376,7 @@ func BsonNotEqual(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_core.bson_object_keys(anonymous documentdb_core.bson, OUT bson_object_keys text).
 func BsonObjectKeys(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument) (outBsonObjectKeys string, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonObjectKeys",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -397,7 +397,7 @@ func BsonObjectKeys(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymo
 //
 //	documentdb_core.bson_operator_selectivity(anonymous internal, anonymous1 oid, anonymous12 internal, anonymous123 integer, OUT bson_operator_selectivity double precision).
 func BsonOperatorSelectivity(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}, anonymous12 struct{}, anonymous123 int32) (outBsonOperatorSelectivity float64, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonOperatorSelectivity",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -418,7 +418,7 @@ func BsonOperatorSelectivity(ctx context.Context, conn *pgx.Conn, l *slog.Logger
 //
 //	documentdb_core.bson_out(anonymous documentdb_core.bson, OUT bson_out cstring).
 func BsonOut(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument) (outBsonOut struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonOut",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -439,7 +439,7 @@ func BsonOut(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wire
 //
 //	documentdb_core.bson_recv(anonymous internal, OUT bson_recv documentdb_core.bson).
 func BsonRecv(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonRecv wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonRecv",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -460,7 +460,7 @@ func BsonRecv(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous str
 //
 //	documentdb_core.bson_repath_and_build(anonymous "any", OUT bson_repath_and_build documentdb_core.bson).
 func BsonRepathAndBuild(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonRepathAndBuild wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonRepathAndBuild",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -481,7 +481,7 @@ func BsonRepathAndBuild(ctx context.Context, conn *pgx.Conn, l *slog.Logger, ano
 //
 //	documentdb_core.bson_send(anonymous documentdb_core.bson, OUT bson_send bytea).
 func BsonSend(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument) (outBsonSend struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonSend",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -502,7 +502,7 @@ func BsonSend(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wir
 //
 //	documentdb_core.bson_to_bson_hex(anonymous documentdb_core.bson, OUT bson_to_bson_hex cstring).
 func BsonToBsonHex(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument) (outBsonToBsonHex struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonToBsonHex",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -523,7 +523,7 @@ func BsonToBsonHex(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymou
 //
 //	documentdb_core.bson_to_bsonsequence(anonymous documentdb_core.bson, OUT bson_to_bsonsequence documentdb_core.bsonsequence).
 func BsonToBsonsequence(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument) (outBsonToBsonsequence []byte, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonToBsonsequence",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -544,7 +544,7 @@ func BsonToBsonsequence(ctx context.Context, conn *pgx.Conn, l *slog.Logger, ano
 //
 //	documentdb_core.bson_to_bytea(anonymous documentdb_core.bson, OUT bson_to_bytea bytea).
 func BsonToBytea(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument) (outBsonToBytea struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonToBytea",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -565,7 +565,7 @@ func BsonToBytea(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_core.bson_to_json_string(anonymous documentdb_core.bson, OUT bson_to_json_string cstring).
 func BsonToJsonString(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument) (outBsonToJsonString struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonToJsonString",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -586,7 +586,7 @@ func BsonToJsonString(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anony
 //
 //	documentdb_core.bson_typanalyze(anonymous internal, OUT bson_typanalyze boolean).
 func BsonTypanalyze(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonTypanalyze bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonTypanalyze",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -607,7 +607,7 @@ func BsonTypanalyze(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymo
 //
 //	documentdb_core.bson_unique_index_equal(anonymous documentdb_core.bson, anonymous1 documentdb_core.bson, OUT bson_unique_index_equal boolean).
 func BsonUniqueIndexEqual(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 wirebson.RawDocument) (outBsonUniqueIndexEqual bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonUniqueIndexEqual",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -628,7 +628,7 @@ func BsonUniqueIndexEqual(ctx context.Context, conn *pgx.Conn, l *slog.Logger, a
 //
 //	documentdb_core.bsonquery_compare(anonymous documentdb_core.bsonquery, anonymous1 documentdb_core.bsonquery, OUT bsonquery_compare integer).
 func BsonqueryCompare(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}) (outBsonqueryCompare int32, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonqueryCompare",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -649,7 +649,7 @@ func BsonqueryCompare(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anony
 //
 //	documentdb_core.bsonquery_compare(anonymous documentdb_core.bson, anonymous1 documentdb_core.bsonquery, OUT bsonquery_compare integer).
 func BsonqueryCompare1(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous wirebson.RawDocument, anonymous1 struct{}) (outBsonqueryCompare int32, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonqueryCompare1",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -670,7 +670,7 @@ func BsonqueryCompare1(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anon
 //
 //	documentdb_core.bsonquery_equal(anonymous documentdb_core.bsonquery, anonymous1 documentdb_core.bsonquery, OUT bsonquery_equal boolean).
 func BsonqueryEqual(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}) (outBsonqueryEqual bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonqueryEqual",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -691,7 +691,7 @@ func BsonqueryEqual(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymo
 //
 //	documentdb_core.bsonquery_gt(anonymous documentdb_core.bsonquery, anonymous1 documentdb_core.bsonquery, OUT bsonquery_gt boolean).
 func BsonqueryGt(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}) (outBsonqueryGt bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonqueryGt",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -712,7 +712,7 @@ func BsonqueryGt(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_core.bsonquery_gte(anonymous documentdb_core.bsonquery, anonymous1 documentdb_core.bsonquery, OUT bsonquery_gte boolean).
 func BsonqueryGte(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}) (outBsonqueryGte bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonqueryGte",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -733,7 +733,7 @@ func BsonqueryGte(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_core.bsonquery_in(anonymous cstring, OUT bsonquery_in documentdb_core.bsonquery).
 func BsonqueryIn(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonqueryIn struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonqueryIn",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -754,7 +754,7 @@ func BsonqueryIn(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_core.bsonquery_lt(anonymous documentdb_core.bsonquery, anonymous1 documentdb_core.bsonquery, OUT bsonquery_lt boolean).
 func BsonqueryLt(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}) (outBsonqueryLt bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonqueryLt",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -775,7 +775,7 @@ func BsonqueryLt(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_core.bsonquery_lte(anonymous documentdb_core.bsonquery, anonymous1 documentdb_core.bsonquery, OUT bsonquery_lte boolean).
 func BsonqueryLte(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}) (outBsonqueryLte bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonqueryLte",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -796,7 +796,7 @@ func BsonqueryLte(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_core.bsonquery_not_equal(anonymous documentdb_core.bsonquery, anonymous1 documentdb_core.bsonquery, OUT bsonquery_not_equal boolean).
 func BsonqueryNotEqual(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}, anonymous1 struct{}) (outBsonqueryNotEqual bool, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonqueryNotEqual",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -817,7 +817,7 @@ func BsonqueryNotEqual(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anon
 //
 //	documentdb_core.bsonquery_out(anonymous documentdb_core.bsonquery, OUT bsonquery_out cstring).
 func BsonqueryOut(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonqueryOut struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonqueryOut",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -838,7 +838,7 @@ func BsonqueryOut(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous
 //
 //	documentdb_core.bsonquery_recv(anonymous internal, OUT bsonquery_recv documentdb_core.bsonquery).
 func BsonqueryRecv(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonqueryRecv struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonqueryRecv",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -859,7 +859,7 @@ func BsonqueryRecv(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymou
 //
 //	documentdb_core.bsonquery_send(anonymous documentdb_core.bsonquery, OUT bsonquery_send bytea).
 func BsonquerySend(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonquerySend struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonquerySend",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -880,7 +880,7 @@ func BsonquerySend(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymou
 //
 //	documentdb_core.bsonsequence_from_bytea(anonymous bytea, OUT bsonsequence_from_bytea documentdb_core.bsonsequence).
 func BsonsequenceFromBytea(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonsequenceFromBytea []byte, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonsequenceFromBytea",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -901,7 +901,7 @@ func BsonsequenceFromBytea(ctx context.Context, conn *pgx.Conn, l *slog.Logger,
 //
 //	documentdb_core.bsonsequence_get_bson(anonymous documentdb_core.bsonsequence, OUT bsonsequence_get_bson documentdb_core.bson).
 func BsonsequenceGetBson(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous []byte) (outBsonsequenceGetBson wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonsequenceGetBson",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -922,7 +922,7 @@ func BsonsequenceGetBson(ctx context.Context, conn *pgx.Conn, l *slog.Logger, an
 //
 //	documentdb_core.bsonsequence_in(anonymous cstring, OUT bsonsequence_in documentdb_core.bsonsequence).
 func BsonsequenceIn(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonsequenceIn []byte, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonsequenceIn",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -943,7 +943,7 @@ func BsonsequenceIn(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymo
 //
 //	documentdb_core.bsonsequence_out(anonymous documentdb_core.bsonsequence, OUT bsonsequence_out cstring).
 func BsonsequenceOut(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous []byte) (outBsonsequenceOut struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonsequenceOut",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -964,7 +964,7 @@ func BsonsequenceOut(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonym
 //
 //	documentdb_core.bsonsequence_recv(anonymous internal, OUT bsonsequence_recv documentdb_core.bsonsequence).
 func BsonsequenceRecv(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outBsonsequenceRecv []byte, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonsequenceRecv",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -985,7 +985,7 @@ func BsonsequenceRecv(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anony
 //
 //	documentdb_core.bsonsequence_send(anonymous documentdb_core.bsonsequence, OUT bsonsequence_send bytea).
 func BsonsequenceSend(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous []byte) (outBsonsequenceSend struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonsequenceSend",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1006,7 +1006,7 @@ func BsonsequenceSend(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anony
 //
 //	documentdb_core.bsonsequence_to_bytea(anonymous documentdb_core.bsonsequence, OUT bsonsequence_to_bytea bytea).
 func BsonsequenceToBytea(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous []byte) (outBsonsequenceToBytea struct{}, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.BsonsequenceToBytea",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -1027,7 +1027,7 @@ func BsonsequenceToBytea(ctx context.Context, conn *pgx.Conn, l *slog.Logger, an
 //
 //	documentdb_core.row_get_bson(anonymous record, OUT row_get_bson documentdb_core.bson).
 func RowGetBson(ctx context.Context, conn *pgx.Conn, l *slog.Logger, anonymous struct{}) (outRowGetBson wirebson.RawDocument, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb_core.RowGetBson",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
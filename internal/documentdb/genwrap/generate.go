@@ -41,11 +41,11 @@
 
 	"github.com/FerretDB/wire/wirebson"
 	"github.com/jackc/pgx/v5"
-	"go.opentelemetry.io/otel"
 	otelsemconv "go.opentelemetry.io/otel/semconv/v1.34.0"
 	oteltrace "go.opentelemetry.io/otel/trace"
 
 	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+	"github.com/FerretDB/FerretDB/v2/internal/util/observability"
 )
 `))
 
@@ -61,7 +61,7 @@ type headerData struct {
 //
 //	{{.Comment}}.
 func {{.FuncName}}({{.Params}}) ({{.Returns}}) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"{{.Package}}.{{.FuncName}}",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
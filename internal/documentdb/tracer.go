@@ -23,12 +23,12 @@
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/tracelog"
 	"github.com/prometheus/client_golang/prometheus"
-	"go.opentelemetry.io/otel"
 	otelcodes "go.opentelemetry.io/otel/codes"
 	otelsemconv "go.opentelemetry.io/otel/semconv/v1.34.0"
 	oteltrace "go.opentelemetry.io/otel/trace"
 
 	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
+	"github.com/FerretDB/FerretDB/v2/internal/util/observability"
 )
 
 // contextKey is a named unexported type for the safe use of [context.WithValue].
@@ -140,7 +140,7 @@ func (t *tracer) TraceConnectEnd(ctx context.Context, data pgx.TraceConnectEndDa
 // It is called at the beginning of [pgx.Conn.Prepare] calls.
 // The returned context is used for the rest of the call and will be passed to [tracer.TracePrepareEnd].
 func (t *tracer) TracePrepareStart(ctx context.Context, conn *pgx.Conn, data pgx.TracePrepareStartData) context.Context {
-	ctx, _ = otel.Tracer("").Start(
+	ctx, _ = observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb.Prepare",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -177,7 +177,7 @@ func (t *tracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.T
 
 	t.requests.With(prometheus.Labels{}).Inc()
 
-	ctx, _ = otel.Tracer("").Start(
+	ctx, _ = observability.Tracer(ctx).Start(
 		ctx,
 		"documentdb.Query",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
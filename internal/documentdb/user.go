@@ -16,6 +16,7 @@
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 
@@ -92,3 +93,20 @@ func CreateUser(ctx context.Context, conn *pgx.Conn, l *slog.Logger, docV wirebs
 
 	return res, nil
 }
+
+// IsSuperuser returns true if the user has PostgreSQL's SUPERUSER privileges,
+// that is, the user was created with the `clusterAdmin` role (see [CreateUser]).
+// It returns false if there is no such user.
+func IsSuperuser(ctx context.Context, conn *pgx.Conn, username string) (bool, error) {
+	var super bool
+
+	err := conn.QueryRow(ctx, `SELECT rolsuper FROM pg_roles WHERE rolname = $1`, username).Scan(&super)
+	switch {
+	case err == nil:
+		return super, nil
+	case errors.Is(err, pgx.ErrNoRows):
+		return false, nil
+	default:
+		return false, lazyerrors.Error(err)
+	}
+}
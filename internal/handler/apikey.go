@@ -0,0 +1,280 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/FerretDB/FerretDB/v2/internal/clientconn/conninfo"
+	"github.com/FerretDB/FerretDB/v2/internal/documentdb"
+	"github.com/FerretDB/FerretDB/v2/internal/documentdb/documentdb_api"
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+)
+
+// apiKeyPrefix is the prefix of all API keys.
+// It makes keys easy to recognize, for example, by secret scanners.
+const apiKeyPrefix = "fdb"
+
+// newAPIKey generates a new API key.
+// It returns the key ID, the secret's hash to store, and the full key to return to the user.
+func newAPIKey() (string, []byte, string) {
+	id := make([]byte, 8)
+	_, _ = rand.Read(id) // never returns an error
+
+	secret := make([]byte, 32)
+	_, _ = rand.Read(secret)
+
+	idS := hex.EncodeToString(id)
+	secretS := hex.EncodeToString(secret)
+
+	return idS, hashAPIKeySecret(secretS), apiKeyPrefix + "_" + idS + "_" + secretS
+}
+
+// parseAPIKey returns the key ID and the secret from the full API key.
+// It returns false if the key is malformed.
+func parseAPIKey(key string) (string, string, bool) {
+	parts := strings.Split(key, "_")
+	if len(parts) != 3 || parts[0] != apiKeyPrefix || parts[1] == "" || parts[2] == "" {
+		return "", "", false
+	}
+
+	return parts[1], parts[2], true
+}
+
+// hashAPIKeySecret returns the hash of the API key secret.
+//
+// Secrets are 256-bit random values generated by [newAPIKey], so a fast unsalted hash is enough.
+// It must not be used for user-chosen secrets like passwords.
+func hashAPIKeySecret(secret string) []byte {
+	h := sha256.Sum256([]byte(secret))
+	return h[:]
+}
+
+// checkAPIKeySecret returns true if the secret matches the stored key.
+func checkAPIKeySecret(key *documentdb.APIKey, secret string) bool {
+	return subtle.ConstantTimeCompare(key.Hash, hashAPIKeySecret(secret)) == 1
+}
+
+// userExists returns true if the user with the given name exists.
+func (h *Handler) userExists(ctx context.Context, username string) (bool, error) {
+	spec := must.NotFail(wirebson.MustDocument(
+		"usersInfo", username,
+		"$db", "admin",
+	).Encode())
+
+	var res wirebson.RawDocument
+
+	var err error
+	err = h.p.WithConn(func(conn *pgx.Conn) error {
+		res, err = documentdb_api.UsersInfo(ctx, conn, h.L, spec)
+		return err
+	})
+	if err != nil {
+		return false, lazyerrors.Error(err)
+	}
+
+	resDoc, err := res.Decode()
+	if err != nil {
+		return false, lazyerrors.Error(err)
+	}
+
+	users, ok := resDoc.Get("users").(wirebson.AnyArray)
+	if !ok {
+		return false, lazyerrors.Errorf("unexpected response: %s", resDoc.LogMessage())
+	}
+
+	arr, err := users.Decode()
+	if err != nil {
+		return false, lazyerrors.Error(err)
+	}
+
+	return arr.Len() > 0, nil
+}
+
+// isAdmin returns true if the client is authenticated as an administrator,
+// that is, a user with the `clusterAdmin` role.
+// All clients are administrators if authentication is disabled.
+func (h *Handler) isAdmin(ctx context.Context) (bool, error) {
+	if !h.Auth {
+		return true, nil
+	}
+
+	ci := conninfo.Get(ctx)
+	if !ci.Authenticated() {
+		return false, nil
+	}
+
+	username := ci.Username()
+
+	var admin bool
+
+	var err error
+	err = h.p.WithConn(func(conn *pgx.Conn) error {
+		admin, err = documentdb.IsSuperuser(ctx, conn, username)
+		return err
+	})
+	if err != nil {
+		return false, lazyerrors.Error(err)
+	}
+
+	return admin, nil
+}
+
+// checkAdminCommand returns an error if the administrative command is not run against the admin database,
+// or the client is not an administrator authenticated with username and password.
+func (h *Handler) checkAdminCommand(ctx context.Context, doc *wirebson.Document) error {
+	command := doc.Command()
+
+	if dbName, _ := doc.Get("$db").(string); dbName != "admin" {
+		return mongoerrors.New(
+			mongoerrors.ErrUnauthorized,
+			fmt.Sprintf("%s may only be run against the admin database.", command),
+		)
+	}
+
+	if conninfo.Get(ctx).APIKey() != nil {
+		return errAPIKeyManagement(command)
+	}
+
+	admin, err := h.isAdmin(ctx)
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	if !admin {
+		return mongoerrors.New(
+			mongoerrors.ErrUnauthorized,
+			fmt.Sprintf("Command %s requires the clusterAdmin role", command),
+		)
+	}
+
+	return nil
+}
+
+// apiKeyOwner returns the user whose API keys the client may manage with the given command.
+//
+// Administrators may manage keys of any user; empty user means all users.
+// Other clients may manage only their own keys; empty user means the client itself.
+func apiKeyOwner(command, client, user string, admin bool) (string, error) {
+	if admin {
+		return user, nil
+	}
+
+	if user == "" {
+		user = client
+	}
+
+	if client == "" || user != client {
+		return "", mongoerrors.New(
+			mongoerrors.ErrUnauthorized,
+			fmt.Sprintf("Command %s requires the clusterAdmin role to manage API keys of other users", command),
+		)
+	}
+
+	return user, nil
+}
+
+// checkAPIKeyScope returns an error if the command is not allowed by the scope of API key
+// used for authentication.
+func checkAPIKeyScope(key *conninfo.APIKey, cmd *command, doc *wirebson.Document) error {
+	command := doc.Command()
+
+	if key.DB != "" {
+		dbs, err := commandDatabases(doc)
+		if err != nil {
+			return err
+		}
+
+		for _, dbName := range dbs {
+			if dbName != key.DB {
+				return mongoerrors.New(
+					mongoerrors.ErrUnauthorized,
+					fmt.Sprintf("not authorized on %s to execute command %s with API key %s", dbName, command, key.ID),
+				)
+			}
+		}
+	}
+
+	if !key.ReadOnly {
+		return nil
+	}
+
+	write := cmd.write
+
+	if !write && command == "aggregate" {
+		var err error
+		if write, err = pipelineWrites(doc); err != nil {
+			return err
+		}
+	}
+
+	if write {
+		return mongoerrors.New(
+			mongoerrors.ErrUnauthorized,
+			fmt.Sprintf("Command %s is not allowed with read-only API key %s", command, key.ID),
+		)
+	}
+
+	return nil
+}
+
+// commandDatabases returns all databases accessed by the command:
+// the current one and ones referenced by namespaces in other databases,
+// such as `renameCollection` targets and aggregation stages like `$lookup` or `$merge`.
+func commandDatabases(doc *wirebson.Document) ([]string, error) {
+	dbName, _ := doc.Get("$db").(string)
+	res := []string{dbName}
+
+	switch command := doc.Command(); command {
+	case "aggregate":
+		dbs, err := pipelineDatabases(doc.Get("pipeline"))
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, dbs...)
+
+	case "renameCollection":
+		// both namespaces are full, like "db.coll"
+		for _, field := range []string{command, "to"} {
+			ns, _ := doc.Get(field).(string)
+			nsDB, _, _ := strings.Cut(ns, ".")
+			res = append(res, nsDB)
+		}
+	}
+
+	return res, nil
+}
+
+// errAPIKeyManagement returns an error for API key management commands
+// called by the client authenticated with API key.
+// That prevents leaked keys from being used to create new ones.
+func errAPIKeyManagement(command string) error {
+	return mongoerrors.New(
+		mongoerrors.ErrUnauthorized,
+		fmt.Sprintf("Command %s requires authentication with username and password", command),
+	)
+}
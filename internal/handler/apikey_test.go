@@ -0,0 +1,248 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"testing"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FerretDB/FerretDB/v2/internal/clientconn/conninfo"
+	"github.com/FerretDB/FerretDB/v2/internal/documentdb"
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+)
+
+func TestAPIKey(t *testing.T) {
+	t.Parallel()
+
+	id, hash, key := newAPIKey()
+
+	parsedID, secret, ok := parseAPIKey(key)
+	require.True(t, ok)
+	assert.Equal(t, id, parsedID)
+
+	stored := &documentdb.APIKey{ID: id, Hash: hash}
+	assert.True(t, checkAPIKeySecret(stored, secret))
+	assert.False(t, checkAPIKeySecret(stored, secret+"0"))
+
+	otherID, _, otherKey := newAPIKey()
+	assert.NotEqual(t, id, otherID)
+	assert.NotEqual(t, key, otherKey)
+
+	for _, malformed := range []string{
+		"",
+		"fdb",
+		"fdb__secret",
+		"fdb_id_",
+		"xyz_id_secret",
+		"fdb_id_secret_more",
+	} {
+		_, _, ok = parseAPIKey(malformed)
+		assert.False(t, ok, "%q", malformed)
+	}
+}
+
+func TestCheckAPIKeyScope(t *testing.T) {
+	t.Parallel()
+
+	find := &command{}
+	insert := &command{write: true}
+
+	for name, tc := range map[string]struct {
+		key   *conninfo.APIKey
+		cmd   *command
+		doc   *wirebson.Document
+		allow bool
+	}{
+		"AnyDB": {
+			key:   &conninfo.APIKey{},
+			cmd:   insert,
+			doc:   wirebson.MustDocument("insert", "coll", "$db", "test"),
+			allow: true,
+		},
+		"SameDB": {
+			key:   &conninfo.APIKey{DB: "test"},
+			cmd:   find,
+			doc:   wirebson.MustDocument("find", "coll", "$db", "test"),
+			allow: true,
+		},
+		"OtherDB": {
+			key: &conninfo.APIKey{DB: "test"},
+			cmd: find,
+			doc: wirebson.MustDocument("find", "coll", "$db", "other"),
+		},
+		"LookupOtherDB": {
+			key: &conninfo.APIKey{DB: "test"},
+			cmd: find,
+			doc: wirebson.MustDocument("aggregate", "coll", "pipeline", wirebson.MustArray(
+				wirebson.MustDocument("$lookup", wirebson.MustDocument(
+					"from", wirebson.MustDocument("db", "other", "coll", "coll"),
+					"as", "res",
+				)),
+			), "$db", "test"),
+		},
+		"LookupSameDB": {
+			key: &conninfo.APIKey{DB: "test"},
+			cmd: find,
+			doc: wirebson.MustDocument("aggregate", "coll", "pipeline", wirebson.MustArray(
+				wirebson.MustDocument("$lookup", wirebson.MustDocument(
+					"from", "other",
+					"as", "res",
+				)),
+			), "$db", "test"),
+			allow: true,
+		},
+		"UnionWithNestedOtherDB": {
+			key: &conninfo.APIKey{DB: "test"},
+			cmd: find,
+			doc: wirebson.MustDocument("aggregate", "coll", "pipeline", wirebson.MustArray(
+				wirebson.MustDocument("$facet", wirebson.MustDocument(
+					"f", wirebson.MustArray(
+						wirebson.MustDocument("$unionWith", wirebson.MustDocument(
+							"coll", "coll",
+							"pipeline", wirebson.MustArray(
+								wirebson.MustDocument("$lookup", wirebson.MustDocument("db", "other", "from", "coll")),
+							),
+						)),
+					),
+				)),
+			), "$db", "test"),
+		},
+		"MergeOtherDB": {
+			key: &conninfo.APIKey{DB: "test"},
+			cmd: find,
+			doc: wirebson.MustDocument("aggregate", "coll", "pipeline", wirebson.MustArray(
+				wirebson.MustDocument("$merge", wirebson.MustDocument(
+					"into", wirebson.MustDocument("db", "other", "coll", "coll"),
+				)),
+			), "$db", "test"),
+		},
+		"OutOtherDB": {
+			key: &conninfo.APIKey{DB: "test"},
+			cmd: find,
+			doc: wirebson.MustDocument("aggregate", "coll", "pipeline", wirebson.MustArray(
+				wirebson.MustDocument("$out", wirebson.MustDocument("db", "other", "coll", "coll")),
+			), "$db", "test"),
+		},
+		"OutSameDB": {
+			key: &conninfo.APIKey{DB: "test"},
+			cmd: find,
+			doc: wirebson.MustDocument("aggregate", "coll", "pipeline", wirebson.MustArray(
+				wirebson.MustDocument("$out", "other"),
+			), "$db", "test"),
+			allow: true,
+		},
+		"RenameOtherDB": {
+			key: &conninfo.APIKey{DB: "admin"},
+			cmd: insert,
+			doc: wirebson.MustDocument("renameCollection", "test.coll", "to", "test.other", "$db", "admin"),
+		},
+		"RenameSameDB": {
+			key:   &conninfo.APIKey{DB: "test"},
+			cmd:   insert,
+			doc:   wirebson.MustDocument("renameCollection", "test.coll", "to", "test.other", "$db", "test"),
+			allow: true,
+		},
+		"ReadOnlyRead": {
+			key:   &conninfo.APIKey{ReadOnly: true},
+			cmd:   find,
+			doc:   wirebson.MustDocument("find", "coll", "$db", "test"),
+			allow: true,
+		},
+		"ReadOnlyWrite": {
+			key: &conninfo.APIKey{ReadOnly: true},
+			cmd: insert,
+			doc: wirebson.MustDocument("insert", "coll", "$db", "test"),
+		},
+		"ReadOnlyAggregateOut": {
+			key: &conninfo.APIKey{ReadOnly: true},
+			cmd: find,
+			doc: wirebson.MustDocument("aggregate", "coll", "pipeline", wirebson.MustArray(
+				wirebson.MustDocument("$out", "other"),
+			), "$db", "test"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkAPIKeyScope(tc.key, tc.cmd, tc.doc)
+			if tc.allow {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestAPIKeyOwner(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		client   string
+		user     string
+		admin    bool
+		expected string
+		err      bool
+	}{
+		"AdminOther": {
+			client:   "admin",
+			user:     "other",
+			admin:    true,
+			expected: "other",
+		},
+		"AdminAll": {
+			client: "admin",
+			admin:  true,
+		},
+		"Own": {
+			client:   "user",
+			user:     "user",
+			expected: "user",
+		},
+		"OwnDefault": {
+			client:   "user",
+			expected: "user",
+		},
+		"Other": {
+			client: "user",
+			user:   "admin",
+			err:    true,
+		},
+		"NoClient": {
+			user: "user",
+			err:  true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := apiKeyOwner("createAPIKey", tc.client, tc.user, tc.admin)
+			if tc.err {
+				var e *mongoerrors.Error
+				require.ErrorAs(t, err, &e)
+				assert.Equal(t, int32(mongoerrors.ErrUnauthorized), e.Code)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
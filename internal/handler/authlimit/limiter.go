@@ -0,0 +1,385 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package authlimit provides protection against authentication brute-force attacks.
+package authlimit
+
+import (
+	"cmp"
+	"context"
+	"log/slog"
+	"net/netip"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Parts of Prometheus metric names.
+const (
+	namespace = "ferretdb"
+	subsystem = "auth"
+)
+
+// Kinds of tracked authentication sources.
+const (
+	KindUsername = "username"
+	KindPeer     = "peer"
+)
+
+// Default limiter configuration.
+const (
+	DefaultMaxFailures = 5
+	DefaultLockout     = 30 * time.Second
+	DefaultMaxLockout  = time.Hour
+)
+
+// Opts represents limiter configuration.
+type Opts struct {
+	// Number of consecutive failed authentication attempts before lockout.
+	// Zero value disables the limiter.
+	MaxFailures int
+
+	// Duration of the first lockout; it is doubled for each subsequent lockout.
+	Lockout time.Duration
+
+	// Maximum duration of a single lockout.
+	// Failures and lockouts are forgotten after that time without failures.
+	MaxLockout time.Duration
+
+	L *slog.Logger
+}
+
+// key represents a tracked authentication source.
+type key struct {
+	kind  string
+	value string
+}
+
+// entry represents the state of a tracked authentication source.
+type entry struct {
+	failures    int // consecutive failures since the last lockout
+	lockouts    int // lockouts since the source was forgotten
+	lastFailure time.Time
+	lockedUntil time.Time
+}
+
+// Lockout represents an active lockout.
+type Lockout struct {
+	Kind     string // KindUsername or KindPeer
+	Value    string
+	Lockouts int // number of consecutive lockouts, including this one
+	Until    time.Time
+}
+
+// Limiter tracks failed authentication attempts per username and per peer IP address,
+// and locks them out with exponential backoff.
+//
+//nolint:vet // for readability
+type Limiter struct {
+	opts *Opts
+	now  func() time.Time
+
+	m       sync.Mutex
+	entries map[key]*entry
+
+	failures *prometheus.CounterVec
+	lockouts *prometheus.CounterVec
+	rejected *prometheus.CounterVec
+	locked   *prometheus.Desc
+}
+
+// New returns a new limiter.
+// Zero Lockout and MaxLockout values are replaced with defaults.
+func New(opts *Opts) *Limiter {
+	o := *opts
+
+	if o.Lockout <= 0 {
+		o.Lockout = DefaultLockout
+	}
+
+	if o.MaxLockout <= 0 {
+		o.MaxLockout = DefaultMaxLockout
+	}
+
+	return &Limiter{
+		opts:    &o,
+		now:     time.Now,
+		entries: map[key]*entry{},
+
+		failures: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Subsystem: subsystem,
+				Name:      "failures_total",
+				Help:      "Total number of failed authentication attempts.",
+			},
+			[]string{"kind"},
+		),
+		lockouts: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Subsystem: subsystem,
+				Name:      "lockouts_total",
+				Help:      "Total number of authentication lockouts.",
+			},
+			[]string{"kind"},
+		),
+		rejected: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Subsystem: subsystem,
+				Name:      "rejected_total",
+				Help:      "Total number of authentication attempts rejected due to lockouts.",
+			},
+			[]string{"kind"},
+		),
+		locked: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "locked"),
+			"The current number of active authentication lockouts.",
+			[]string{"kind"},
+			nil,
+		),
+	}
+}
+
+// enabled returns true if the limiter is enabled.
+func (l *Limiter) enabled() bool {
+	return l.opts.MaxFailures > 0
+}
+
+// keys returns tracked sources for the given username and peer.
+// Empty username and invalid peer (for Unix domain sockets and in-process connections) are not tracked.
+func keys(username string, peer netip.Addr) []key {
+	res := make([]key, 0, 2)
+
+	if username != "" {
+		res = append(res, key{kind: KindUsername, value: username})
+	}
+
+	if peer.IsValid() {
+		res = append(res, key{kind: KindPeer, value: peer.Unmap().String()})
+	}
+
+	return res
+}
+
+// Locked returns true if the given username or peer is locked out.
+// Rejected attempts are logged.
+func (l *Limiter) Locked(ctx context.Context, username string, peer netip.Addr) bool {
+	if !l.enabled() {
+		return false
+	}
+
+	now := l.now()
+
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	for _, k := range keys(username, peer) {
+		e := l.entries[k]
+		if e == nil || !now.Before(e.lockedUntil) {
+			continue
+		}
+
+		l.rejected.WithLabelValues(k.kind).Inc()
+
+		l.opts.L.WarnContext(
+			ctx, "Authentication attempt rejected due to lockout",
+			slog.String("kind", k.kind), slog.String("value", k.value), slog.Time("until", e.lockedUntil),
+		)
+
+		return true
+	}
+
+	return false
+}
+
+// Failure records a failed authentication attempt for the given username and peer,
+// locking them out if there were too many consecutive failures.
+func (l *Limiter) Failure(ctx context.Context, username string, peer netip.Addr) {
+	if !l.enabled() {
+		return
+	}
+
+	now := l.now()
+
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	for _, k := range keys(username, peer) {
+		l.failures.WithLabelValues(k.kind).Inc()
+
+		e := l.entries[k]
+		if e == nil || l.expired(e, now) {
+			e = new(entry)
+			l.entries[k] = e
+		}
+
+		e.failures++
+		e.lastFailure = now
+
+		if e.failures < l.opts.MaxFailures {
+			continue
+		}
+
+		e.failures = 0
+		e.lockouts++
+
+		d := l.opts.Lockout << min(e.lockouts-1, 30)
+		if d <= 0 || d > l.opts.MaxLockout { // overflow or cap
+			d = l.opts.MaxLockout
+		}
+
+		e.lockedUntil = now.Add(d)
+
+		l.lockouts.WithLabelValues(k.kind).Inc()
+
+		l.opts.L.WarnContext(
+			ctx, "Too many failed authentication attempts, locking out",
+			slog.String("kind", k.kind), slog.String("value", k.value),
+			slog.Int("lockouts", e.lockouts), slog.Duration("duration", d),
+		)
+	}
+}
+
+// Success records a successful authentication attempt for the given username,
+// forgetting its failures and previous lockouts.
+//
+// Peer failures are not reset: otherwise, a client with one valid account could alternate
+// its logins with guesses against other accounts and never reach the limit.
+// They are forgotten after MaxLockout without failures instead.
+func (l *Limiter) Success(username string) {
+	if !l.enabled() || username == "" {
+		return
+	}
+
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	delete(l.entries, key{kind: KindUsername, value: username})
+}
+
+// expired returns true if the given entry is not locked out and should be forgotten.
+func (l *Limiter) expired(e *entry, now time.Time) bool {
+	return !now.Before(e.lockedUntil) && now.Sub(e.lastFailure) > l.opts.MaxLockout
+}
+
+// Cleanup forgets sources without recent failures.
+func (l *Limiter) Cleanup() {
+	now := l.now()
+
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	for k, e := range l.entries {
+		if l.expired(e, now) {
+			delete(l.entries, k)
+		}
+	}
+}
+
+// Lockouts returns active lockouts sorted by kind and value.
+func (l *Limiter) Lockouts() []Lockout {
+	now := l.now()
+
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	var res []Lockout
+
+	for k, e := range l.entries {
+		if !now.Before(e.lockedUntil) {
+			continue
+		}
+
+		res = append(res, Lockout{
+			Kind:     k.kind,
+			Value:    k.value,
+			Lockouts: e.lockouts,
+			Until:    e.lockedUntil,
+		})
+	}
+
+	slices.SortFunc(res, func(a, b Lockout) int {
+		return cmp.Or(cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.Value, b.Value))
+	})
+
+	return res
+}
+
+// Clear forgets failures and lockouts of sources with the given kind and value;
+// empty kind or value matches any.
+// It returns the number of cleared active lockouts.
+func (l *Limiter) Clear(ctx context.Context, kind, value string) int {
+	now := l.now()
+
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	var res int
+
+	for k, e := range l.entries {
+		if (kind != "" && k.kind != kind) || (value != "" && k.value != value) {
+			continue
+		}
+
+		if now.Before(e.lockedUntil) {
+			res++
+		}
+
+		delete(l.entries, k)
+	}
+
+	l.opts.L.InfoContext(
+		ctx, "Authentication lockouts cleared",
+		slog.String("kind", kind), slog.String("value", value), slog.Int("cleared", res),
+	)
+
+	return res
+}
+
+// Describe implements [prometheus.Collector].
+func (l *Limiter) Describe(ch chan<- *prometheus.Desc) {
+	l.failures.Describe(ch)
+	l.lockouts.Describe(ch)
+	l.rejected.Describe(ch)
+	ch <- l.locked
+}
+
+// Collect implements [prometheus.Collector].
+func (l *Limiter) Collect(ch chan<- prometheus.Metric) {
+	l.failures.Collect(ch)
+	l.lockouts.Collect(ch)
+	l.rejected.Collect(ch)
+
+	locked := map[string]int{
+		KindUsername: 0,
+		KindPeer:     0,
+	}
+
+	for _, lo := range l.Lockouts() {
+		locked[lo.Kind]++
+	}
+
+	for kind, n := range locked {
+		ch <- prometheus.MustNewConstMetric(l.locked, prometheus.GaugeValue, float64(n), kind)
+	}
+}
+
+// check interfaces
+var (
+	_ prometheus.Collector = (*Limiter)(nil)
+)
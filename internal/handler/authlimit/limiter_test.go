@@ -0,0 +1,141 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authlimit
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ftestutil "github.com/FerretDB/FerretDB/v2/internal/util/testutil"
+)
+
+func TestLimiter(t *testing.T) {
+	t.Parallel()
+
+	ctx := ftestutil.Ctx(t)
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	l := New(&Opts{
+		MaxFailures: 3,
+		Lockout:     time.Minute,
+		MaxLockout:  3 * time.Minute,
+		L:           ftestutil.Logger(t),
+	})
+	l.now = func() time.Time { return now }
+
+	peer := netip.MustParseAddr("192.0.2.1")
+	other := netip.MustParseAddr("192.0.2.2")
+
+	for range 2 {
+		l.Failure(ctx, "alice", peer)
+	}
+
+	assert.False(t, l.Locked(ctx, "alice", peer))
+
+	l.Failure(ctx, "alice", peer)
+
+	assert.True(t, l.Locked(ctx, "alice", peer))
+	assert.True(t, l.Locked(ctx, "alice", other), "username is locked for all peers")
+	assert.True(t, l.Locked(ctx, "bob", peer), "peer is locked for all usernames")
+	assert.False(t, l.Locked(ctx, "bob", other))
+	assert.False(t, l.Locked(ctx, "bob", netip.Addr{}))
+
+	expected := []Lockout{
+		{Kind: KindPeer, Value: "192.0.2.1", Lockouts: 1, Until: now.Add(time.Minute)},
+		{Kind: KindUsername, Value: "alice", Lockouts: 1, Until: now.Add(time.Minute)},
+	}
+	assert.Equal(t, expected, l.Lockouts())
+
+	t.Run("Backoff", func(t *testing.T) {
+		now = now.Add(time.Minute)
+		assert.False(t, l.Locked(ctx, "alice", peer))
+
+		for range 3 {
+			l.Failure(ctx, "alice", other)
+		}
+
+		lockouts := l.Lockouts()
+		require.Len(t, lockouts, 2)
+		assert.Equal(t, KindPeer, lockouts[0].Kind)
+		assert.Equal(t, "192.0.2.2", lockouts[0].Value)
+		assert.Equal(t, now.Add(time.Minute), lockouts[0].Until)
+		assert.Equal(t, KindUsername, lockouts[1].Kind)
+		assert.Equal(t, 2, lockouts[1].Lockouts)
+		assert.Equal(t, now.Add(2*time.Minute), lockouts[1].Until, "lockout duration should be doubled")
+
+		now = now.Add(2 * time.Minute)
+
+		for range 3 {
+			l.Failure(ctx, "alice", netip.Addr{})
+		}
+
+		lockouts = l.Lockouts()
+		require.Len(t, lockouts, 1)
+		assert.Equal(t, now.Add(3*time.Minute), lockouts[0].Until, "lockout duration should be capped")
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		assert.Equal(t, 0, l.Clear(ctx, KindUsername, "bob"))
+		assert.Equal(t, 1, l.Clear(ctx, KindUsername, ""))
+		assert.Empty(t, l.Lockouts())
+		assert.False(t, l.Locked(ctx, "alice", peer))
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		for range 2 {
+			l.Failure(ctx, "carol", peer)
+		}
+
+		l.Success("carol")
+		l.Failure(ctx, "carol", peer)
+
+		assert.False(t, l.Locked(ctx, "carol", netip.Addr{}), "username failures should be reset")
+		assert.True(t, l.Locked(ctx, "dave", peer), "peer failures should not be reset")
+	})
+
+	t.Run("Cleanup", func(t *testing.T) {
+		now = now.Add(time.Hour)
+
+		l.Cleanup()
+		assert.Empty(t, l.entries)
+	})
+
+	assert.Equal(t, float64(3), testutil.ToFloat64(l.lockouts.WithLabelValues(KindUsername)))
+	assert.Equal(t, float64(3), testutil.ToFloat64(l.lockouts.WithLabelValues(KindPeer)))
+	assert.Equal(t, float64(2), testutil.ToFloat64(l.rejected.WithLabelValues(KindUsername)))
+	assert.Equal(t, float64(2), testutil.ToFloat64(l.rejected.WithLabelValues(KindPeer)))
+}
+
+func TestLimiterDisabled(t *testing.T) {
+	t.Parallel()
+
+	ctx := ftestutil.Ctx(t)
+
+	l := New(&Opts{L: ftestutil.Logger(t)})
+	peer := netip.MustParseAddr("192.0.2.1")
+
+	for range 100 {
+		l.Failure(ctx, "alice", peer)
+	}
+
+	assert.False(t, l.Locked(ctx, "alice", peer))
+	assert.Empty(t, l.Lockouts())
+}
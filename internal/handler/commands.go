@@ -33,6 +33,10 @@ type command struct {
 	// anonymous indicates that the command does not require authentication.
 	anonymous bool
 
+	// write indicates that the command modifies data, indexes, collections, or users.
+	// Such commands are rejected in read-only mode.
+	write bool
+
 	// handler processes this command.
 	//
 	// The passed context is canceled when the client disconnects.
@@ -52,10 +56,14 @@ func (h *Handler) initCommands() {
 			Help:    "Returns aggregated data.",
 		},
 		"authenticate": {
-			// TODO https://github.com/FerretDB/FerretDB/issues/1731
 			anonymous: true,
 			Help:      "", // hidden while not implemented
 		},
+		"authenticateAPIKey": {
+			handler:   h.msgAuthenticateAPIKey,
+			anonymous: true,
+			Help:      "Authenticates the connection with API key.",
+		},
 		"buildInfo": {
 			handler:   h.msgBuildInfo,
 			anonymous: true,
@@ -67,12 +75,18 @@ func (h *Handler) initCommands() {
 			Help:      "", // hidden
 		},
 		"bulkWrite": {
+			write: true,
 			// TODO https://github.com/documentdb/documentdb/issues/108
 			// TODO https://github.com/FerretDB/FerretDB/issues/4910
 			Help: "", // hidden while not implemented
 		},
+		"clearAuthLockouts": {
+			handler: h.msgClearAuthLockouts,
+			Help:    "Clears authentication lockouts of usernames and peer addresses.",
+		},
 		"collMod": {
 			handler: h.msgCollMod,
+			write:   true,
 			Help:    "Adds options to a collection or modify view definitions.",
 		},
 		"collStats": {
@@ -81,6 +95,7 @@ func (h *Handler) initCommands() {
 		},
 		"compact": {
 			handler: h.msgCompact,
+			write:   true,
 			Help:    "Reduces the disk space collection takes and refreshes its statistics.",
 		},
 		"connPoolStats": {
@@ -100,14 +115,22 @@ func (h *Handler) initCommands() {
 		},
 		"create": {
 			handler: h.msgCreate,
+			write:   true,
 			Help:    "Creates the collection.",
 		},
+		"createAPIKey": {
+			handler: h.msgCreateAPIKey,
+			write:   true,
+			Help:    "Creates a new API key for the user.",
+		},
 		"createIndexes": {
 			handler: h.msgCreateIndexes,
+			write:   true,
 			Help:    "Creates indexes on a collection.",
 		},
 		"createUser": {
 			handler: h.msgCreateUser,
+			write:   true,
 			Help:    "Creates a new user.",
 		},
 		"currentOp": {
@@ -128,6 +151,7 @@ func (h *Handler) initCommands() {
 		},
 		"delete": {
 			handler: h.msgDelete,
+			write:   true,
 			Help:    "Deletes documents matched by the query.",
 		},
 		"distinct": {
@@ -136,22 +160,27 @@ func (h *Handler) initCommands() {
 		},
 		"drop": {
 			handler: h.msgDrop,
+			write:   true,
 			Help:    "Drops the collection.",
 		},
 		"dropAllUsersFromDatabase": {
 			handler: h.msgDropAllUsersFromDatabase,
+			write:   true,
 			Help:    "Drops all user from database.",
 		},
 		"dropDatabase": {
 			handler: h.msgDropDatabase,
+			write:   true,
 			Help:    "Drops production database.",
 		},
 		"dropIndexes": {
 			handler: h.msgDropIndexes,
+			write:   true,
 			Help:    "Drops indexes on a collection.",
 		},
 		"dropUser": {
 			handler: h.msgDropUser,
+			write:   true,
 			Help:    "Drops user.",
 		},
 		"endSessions": {
@@ -172,10 +201,12 @@ func (h *Handler) initCommands() {
 		},
 		"findAndModify": {
 			handler: h.msgFindAndModify,
+			write:   true,
 			Help:    "Updates or deletes, and returns a document matched by the query.",
 		},
 		"findandmodify": { // old lowercase variant
 			handler: h.msgFindAndModify,
+			write:   true,
 			Help:    "", // hidden
 		},
 		"getCmdLineOpts": {
@@ -209,6 +240,7 @@ func (h *Handler) initCommands() {
 		},
 		"insert": {
 			handler: h.msgInsert,
+			write:   true,
 			Help:    "Inserts documents into the database.",
 		},
 		"isMaster": {
@@ -237,6 +269,14 @@ func (h *Handler) initCommands() {
 			handler: h.msgKillSessions,
 			Help:    "Kills sessions.",
 		},
+		"listAPIKeys": {
+			handler: h.msgListAPIKeys,
+			Help:    "Returns a list of API keys without secrets.",
+		},
+		"listAuthLockouts": {
+			handler: h.msgListAuthLockouts,
+			Help:    "Returns a list of active authentication lockouts.",
+		},
 		"listCollections": {
 			handler: h.msgListCollections,
 			Help:    "Returns the information of the collections and views in the database.",
@@ -269,12 +309,19 @@ func (h *Handler) initCommands() {
 		},
 		"reIndex": {
 			handler: h.msgReIndex,
+			write:   true,
 			Help:    "Drops and recreates all indexes except default _id index of a collection.",
 		},
 		"renameCollection": {
 			handler: h.msgRenameCollection,
+			write:   true,
 			Help:    "Changes the name of an existing collection.",
 		},
+		"revokeAPIKey": {
+			handler: h.msgRevokeAPIKey,
+			write:   true,
+			Help:    "Revokes the API key.",
+		},
 		"saslStart": {
 			handler:   h.msgSASLStart,
 			anonymous: true,
@@ -299,10 +346,12 @@ func (h *Handler) initCommands() {
 		},
 		"update": {
 			handler: h.msgUpdate,
+			write:   true,
 			Help:    "Updates documents that are matched by the query.",
 		},
 		"updateUser": {
 			handler: h.msgUpdateUser,
+			write:   true,
 			Help:    "Updates user.",
 		},
 		"usersInfo": {
@@ -0,0 +1,212 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/FerretDB/FerretDB/v2/internal/clientconn/conninfo"
+	"github.com/FerretDB/FerretDB/v2/internal/documentdb"
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+)
+
+// CustomCommand represents an OP_MSG command registered in addition to built-in commands,
+// for example, by the user of embeddable package.
+//
+// Custom commands are handled like built-in commands:
+// they pass through middleware (so metrics and tracing work),
+// authentication, API key scope, and read-only mode checks.
+//
+//nolint:vet // for readability
+type CustomCommand struct {
+	// Name is the command name (the first field of the request document). Required.
+	Name string
+
+	// Help is shown in the `listCommands` command output.
+	// If empty, the command is hidden, but still can be used.
+	Help string
+
+	// Anonymous indicates that the command does not require authentication.
+	Anonymous bool
+
+	// Write indicates that the command modifies data.
+	// Such commands are rejected in read-only mode and for read-only API keys.
+	Write bool
+
+	// Handler processes the command. Required.
+	Handler CustomCommandHandler
+}
+
+// CustomCommandHandler processes a single custom command request.
+//
+// The passed context is canceled when the client disconnects.
+//
+// It returns a response document; `ok` field is added if it is not present.
+// Returned errors are converted to protocol errors like errors of built-in commands.
+type CustomCommandHandler func(ctx context.Context, req *CustomRequest) (*wirebson.Document, error)
+
+// CustomRequest represents a custom command request.
+type CustomRequest struct {
+	// Document is the deeply decoded request document, including the command name and `$db` field.
+	Document *wirebson.Document
+
+	// Username is the name of the authenticated user.
+	// It is empty if authentication is disabled or the command is anonymous and the client is not authenticated.
+	Username string
+
+	p *documentdb.Pool
+}
+
+// WithConn calls the given function with a connection from the DocumentDB connection pool.
+// The connection is returned to the pool after the function returns;
+// calling its methods after that returns an error.
+func (req *CustomRequest) WithConn(f func(*CustomConn) error) error {
+	return req.p.WithConn(func(conn *pgx.Conn) error {
+		c := &CustomConn{conn: conn}
+		defer func() { c.conn = nil }()
+
+		return f(c)
+	})
+}
+
+// CustomConn represents a DocumentDB connection available to custom command handlers.
+//
+// It exposes only statement execution, not the underlying driver connection,
+// so handlers can't keep or close it.
+type CustomConn struct {
+	conn *pgx.Conn
+}
+
+// errConnReleased is returned when [CustomConn] is used after it was returned to the pool.
+var errConnReleased = errors.New("connection is already returned to the pool")
+
+// Exec executes the given SQL statement with arguments and returns the number of affected rows.
+func (c *CustomConn) Exec(ctx context.Context, sql string, args ...any) (int64, error) {
+	if c.conn == nil {
+		return 0, errConnReleased
+	}
+
+	tag, err := c.conn.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, lazyerrors.Error(err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// Query executes the given SQL query with arguments and returns all rows.
+// Each row contains values of all columns in order.
+//
+// All rows are read before returning, so the connection could be used again.
+func (c *CustomConn) Query(ctx context.Context, sql string, args ...any) ([][]any, error) {
+	if c.conn == nil {
+		return nil, errConnReleased
+	}
+
+	rows, err := c.conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	res, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) ([]any, error) {
+		return row.Values()
+	})
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return res, nil
+}
+
+// ValidateCustomCommands checks that custom commands have names and handlers,
+// and do not conflict with built-in commands or each other.
+func ValidateCustomCommands(cmds []*CustomCommand) error {
+	var h Handler
+	h.initCommands()
+
+	return h.addCustomCommands(cmds)
+}
+
+// addCustomCommands validates and adds custom commands to the commands map.
+func (h *Handler) addCustomCommands(cmds []*CustomCommand) error {
+	for _, c := range cmds {
+		if c.Name == "" {
+			return errors.New("custom command name is empty")
+		}
+
+		if c.Handler == nil {
+			return fmt.Errorf("custom command %q has no handler", c.Name)
+		}
+
+		if _, ok := h.commands[c.Name]; ok {
+			return fmt.Errorf("command %q is already registered", c.Name)
+		}
+
+		h.commands[c.Name] = &command{
+			anonymous: c.Anonymous,
+			write:     c.Write,
+			handler:   h.customCommandHandler(c.Handler),
+			Help:      c.Help,
+		}
+	}
+
+	return nil
+}
+
+// customCommandHandler returns a command handler that calls the given custom command handler.
+func (h *Handler) customCommandHandler(f CustomCommandHandler) commandHandler {
+	return func(connCtx context.Context, req *middleware.Request) (*middleware.Response, error) {
+		if _, _, err := h.s.CreateOrUpdateByLSID(connCtx, req.Document()); err != nil {
+			return nil, err
+		}
+
+		doc, err := req.DocumentDeep()
+		if err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		var username string
+		if ci := conninfo.Get(connCtx); ci.Authenticated() {
+			username = ci.Username()
+		}
+
+		res, err := f(connCtx, &CustomRequest{
+			Document: doc,
+			Username: username,
+			p:        h.p,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if res == nil {
+			res = wirebson.MakeDocument(1)
+		}
+
+		if res.Get("ok") == nil {
+			if err = res.Add("ok", float64(1)); err != nil {
+				return nil, lazyerrors.Error(err)
+			}
+		}
+
+		return middleware.ResponseDoc(req, res)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FerretDB/FerretDB/v2/internal/clientconn/conninfo"
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/handler/session"
+	"github.com/FerretDB/FerretDB/v2/internal/util/testutil"
+)
+
+func TestValidateCustomCommands(t *testing.T) {
+	t.Parallel()
+
+	f := func(context.Context, *CustomRequest) (*wirebson.Document, error) { return nil, nil }
+
+	for name, tc := range map[string]struct {
+		cmds []*CustomCommand
+		err  string
+	}{
+		"Valid": {
+			cmds: []*CustomCommand{{Name: "myCommand", Handler: f}},
+		},
+		"NoName": {
+			cmds: []*CustomCommand{{Handler: f}},
+			err:  "custom command name is empty",
+		},
+		"NoHandler": {
+			cmds: []*CustomCommand{{Name: "myCommand"}},
+			err:  `custom command "myCommand" has no handler`,
+		},
+		"BuiltIn": {
+			cmds: []*CustomCommand{{Name: "find", Handler: f}},
+			err:  `command "find" is already registered`,
+		},
+		"Duplicate": {
+			cmds: []*CustomCommand{{Name: "myCommand", Handler: f}, {Name: "myCommand", Handler: f}},
+			err:  `command "myCommand" is already registered`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateCustomCommands(tc.cmds)
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestCustomCommandHandler(t *testing.T) {
+	t.Parallel()
+
+	l := testutil.Logger(t)
+	s := session.NewRegistry(0, l)
+	t.Cleanup(s.Stop)
+
+	h := &Handler{
+		NewOpts: &NewOpts{L: l},
+		s:       s,
+	}
+
+	var actual *CustomRequest
+
+	cmd := h.customCommandHandler(func(_ context.Context, req *CustomRequest) (*wirebson.Document, error) {
+		actual = req
+		return wirebson.MustDocument("answer", int32(42)), nil
+	})
+
+	req, err := middleware.RequestDoc(wirebson.MustDocument(
+		"myCommand", int32(1),
+		"nested", wirebson.MustDocument("k", "v"),
+		"$db", "admin",
+	))
+	require.NoError(t, err)
+
+	ctx := conninfo.Ctx(t.Context(), conninfo.New())
+
+	resp, err := cmd(ctx, req)
+	require.NoError(t, err)
+
+	require.NotNil(t, actual)
+	assert.Equal(t, "myCommand", actual.Document.Command())
+	assert.IsType(t, new(wirebson.Document), actual.Document.Get("nested"))
+	assert.Empty(t, actual.Username)
+
+	expected := wirebson.MustDocument("answer", int32(42), "ok", float64(1))
+	testutil.AssertEqual(t, expected, resp.Document())
+}
@@ -20,6 +20,7 @@
 	"fmt"
 	"log/slog"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/AlekSi/lazyerrors"
@@ -28,6 +29,7 @@
 
 	"github.com/FerretDB/FerretDB/v2/internal/clientconn/conninfo"
 	"github.com/FerretDB/FerretDB/v2/internal/documentdb"
+	"github.com/FerretDB/FerretDB/v2/internal/handler/authlimit"
 	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
 	"github.com/FerretDB/FerretDB/v2/internal/handler/session"
 	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
@@ -63,9 +65,11 @@
 type Handler struct {
 	*NewOpts
 
-	p        *documentdb.Pool
-	commands map[string]*command
-	s        *session.Registry
+	p         *documentdb.Pool
+	commands  map[string]*command
+	s         *session.Registry
+	authLimit *authlimit.Limiter
+	readOnly  atomic.Bool
 
 	runM   sync.Mutex
 	runCtx context.Context
@@ -80,12 +84,19 @@ type NewOpts struct {
 	Auth          bool
 	TCPHost       string
 	ReplSetName   string
+	ReadOnly      bool
 
 	L             *slog.Logger
 	Metrics       *middleware.Metrics
 	StateProvider *state.Provider
 
 	SessionCleanupInterval time.Duration
+
+	AuthMaxFailures int           // zero value disables authentication lockouts
+	AuthLockout     time.Duration // zero value means authlimit.DefaultLockout
+	AuthMaxLockout  time.Duration // zero value means authlimit.DefaultMaxLockout
+
+	CustomCommands []*CustomCommand
 }
 
 // New returns a new handler.
@@ -106,13 +117,59 @@ func New(opts *NewOpts) (*Handler, error) {
 		NewOpts: opts,
 		p:       p,
 		s:       session.NewRegistry(sessionTimeout, opts.L),
+		authLimit: authlimit.New(&authlimit.Opts{
+			MaxFailures: opts.AuthMaxFailures,
+			Lockout:     opts.AuthLockout,
+			MaxLockout:  opts.AuthMaxLockout,
+			L:           logging.WithName(opts.L, "authlimit"),
+		}),
 	}
 
+	h.readOnly.Store(opts.ReadOnly)
+
 	h.initCommands()
 
+	if err = h.addCustomCommands(opts.CustomCommands); err != nil {
+		h.s.Stop()
+		p.Close()
+
+		return nil, err
+	}
+
 	return h, nil
 }
 
+// Stats returns the number of active sessions and open cursors.
+func (h *Handler) Stats() (sessions, cursors int) {
+	return h.s.Stats()
+}
+
+// ReadOnly returns true if the handler is in read-only mode.
+//
+// It implements [middleware.ReadOnlyChecker].
+func (h *Handler) ReadOnly() bool {
+	return h.readOnly.Load()
+}
+
+// SetReadOnly enables or disables read-only mode.
+//
+// In read-only mode, commands that modify data, indexes, collections, or users
+// are rejected by the middleware (see [middleware.ReadOnlyChecker]).
+// Commands that are already running are not affected.
+func (h *Handler) SetReadOnly(readOnly bool) {
+	if h.readOnly.Swap(readOnly) == readOnly {
+		return
+	}
+
+	h.L.Info("Read-only mode changed", slog.Bool("read_only", readOnly))
+}
+
+// WriteCommand implements [middleware.ReadOnlyChecker].
+func (h *Handler) WriteCommand(command string) bool {
+	cmd := h.commands[command]
+	return cmd != nil && cmd.write
+}
+
 // Run implements [middleware.Handler].
 //
 // When this method returns, handler is stopped and pool is closed.
@@ -150,6 +207,8 @@ func (h *Handler) Run(ctx context.Context) {
 			for _, cursorID := range cursorIDs {
 				_ = h.p.KillCursor(ctx, cursorID)
 			}
+
+			h.authLimit.Cleanup()
 		}
 	}
 }
@@ -192,13 +251,13 @@ func (h *Handler) Handle(ctx context.Context, req *middleware.Request) (*middlew
 			)), nil
 		}
 
+		ci := conninfo.Get(ctx)
+
 		if h.Auth && !cmd.anonymous {
-			conv := conninfo.Get(ctx).Conv()
-			succeed := conv.Succeed()
-			username := conv.Username()
+			username := ci.Username()
 
-			if !succeed {
-				if conv == nil {
+			if !ci.Authenticated() {
+				if ci.Conv() == nil {
 					h.L.WarnContext(ctx, "No existing conversation")
 				} else {
 					h.L.WarnContext(ctx, "Conversation did not succeed", slog.String("username", username))
@@ -213,6 +272,12 @@ func (h *Handler) Handle(ctx context.Context, req *middleware.Request) (*middlew
 			h.L.DebugContext(ctx, "Authentication passed", slog.String("username", username))
 		}
 
+		if key := ci.APIKey(); key != nil && !cmd.anonymous {
+			if err := checkAPIKeyScope(key, cmd, req.Document()); err != nil {
+				return middleware.ResponseErr(req, mongoerrors.Make(ctx, err, "", h.L)), nil
+			}
+		}
+
 		resp, err := cmd.handler(ctx, req)
 		if err != nil {
 			// TODO https://github.com/FerretDB/FerretDB/issues/4965
@@ -239,15 +304,18 @@ func (h *Handler) Handle(ctx context.Context, req *middleware.Request) (*middlew
 func (h *Handler) Describe(ch chan<- *prometheus.Desc) {
 	h.p.Describe(ch)
 	h.s.Describe(ch)
+	h.authLimit.Describe(ch)
 }
 
 // Collect implements [prometheus.Collector].
 func (h *Handler) Collect(ch chan<- prometheus.Metric) {
 	h.p.Collect(ch)
 	h.s.Collect(ch)
+	h.authLimit.Collect(ch)
 }
 
 // check interfaces
 var (
-	_ middleware.Handler = (*Handler)(nil)
+	_ middleware.Handler         = (*Handler)(nil)
+	_ middleware.ReadOnlyChecker = (*Handler)(nil)
 )
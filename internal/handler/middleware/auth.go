@@ -0,0 +1,127 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"context"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/xdg-go/scram"
+
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+)
+
+// AuthenticateSCRAM authenticates the connection stored in ctx with the given username and password.
+//
+// It is used by HTTP interfaces that receive credentials with each request.
+// It plays the client side of SCRAM-SHA-256 conversation by sending `saslStart` and `saslContinue` commands
+// through the middleware, so authentication lockout, audit log, and metrics
+// work the same way as for wire protocol clients.
+//
+// It returns the last response: a normal one if authentication succeeded,
+// an error one if it failed, or nil if unrecoverable error occurred.
+func (m *Middleware) AuthenticateSCRAM(ctx context.Context, username, password string) *Response {
+	req := must.NotFail(RequestDoc(wirebson.MustDocument(
+		"saslStart", int32(1),
+		"mechanism", "SCRAM-SHA-256",
+		"$db", "admin",
+	)))
+
+	if username == "" || password == "" {
+		return authenticationFailed(req)
+	}
+
+	client, err := scram.SHA256.NewClient(username, password, "")
+	if err != nil {
+		return authenticationFailed(req)
+	}
+
+	conv := client.NewConversation()
+
+	payload, err := conv.Step("")
+	if err != nil {
+		return authenticationFailed(req)
+	}
+
+	req = must.NotFail(RequestDoc(wirebson.MustDocument(
+		"saslStart", int32(1),
+		"mechanism", "SCRAM-SHA-256",
+		"payload", wirebson.Binary{B: []byte(payload)},
+		// use skipEmptyExchange to complete the handshake with one `saslStart` and one `saslContinue`
+		"options", wirebson.MustDocument("skipEmptyExchange", true),
+		"$db", "admin",
+	)))
+
+	resp := m.Handle(ctx, req)
+	if resp == nil || !resp.OK() {
+		return resp
+	}
+
+	convID, _ := resp.Document().Get("conversationId").(int32)
+	serverPayload, _ := resp.Document().Get("payload").(wirebson.Binary)
+
+	if payload, err = conv.Step(string(serverPayload.B)); err != nil {
+		return authenticationFailed(req)
+	}
+
+	req = must.NotFail(RequestDoc(wirebson.MustDocument(
+		"saslContinue", int32(1),
+		"conversationId", convID,
+		"payload", wirebson.Binary{B: []byte(payload)},
+		"$db", "admin",
+	)))
+
+	resp = m.Handle(ctx, req)
+	if resp == nil || !resp.OK() {
+		return resp
+	}
+
+	if done, _ := resp.Document().Get("done").(bool); !done {
+		return authenticationFailed(req)
+	}
+
+	serverPayload, _ = resp.Document().Get("payload").(wirebson.Binary)
+
+	if _, err = conv.Step(string(serverPayload.B)); err != nil || !conv.Valid() {
+		return authenticationFailed(req)
+	}
+
+	return resp
+}
+
+// AuthenticateAPIKey authenticates the connection stored in ctx with the given API key.
+//
+// Like [Middleware.AuthenticateSCRAM], it sends the authentication command through the middleware.
+// API key authentication takes a single round trip without expensive key derivation.
+func (m *Middleware) AuthenticateAPIKey(ctx context.Context, key string) *Response {
+	req := must.NotFail(RequestDoc(wirebson.MustDocument(
+		"authenticateAPIKey", int32(1),
+		"key", key,
+		"$db", "admin",
+	)))
+
+	if key == "" {
+		return authenticationFailed(req)
+	}
+
+	return m.Handle(ctx, req)
+}
+
+// authenticationFailed returns an error response for the given authentication request
+// if the conversation could not be completed on the client side.
+func authenticationFailed(req *Request) *Response {
+	return ResponseErr(req, mongoerrors.New(mongoerrors.ErrAuthenticationFailed, "Authentication failed."))
+}
@@ -0,0 +1,115 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+	"github.com/FerretDB/FerretDB/v2/internal/util/testutil"
+)
+
+func TestAuthenticate(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		doc      *wirebson.Document // response of the handler
+		auth     func(ctx context.Context, m *Middleware) *Response
+		code     mongoerrors.Code
+		commands []string
+	}{
+		"SCRAMEmptyPassword": {
+			auth: func(ctx context.Context, m *Middleware) *Response {
+				return m.AuthenticateSCRAM(ctx, "username", "")
+			},
+			code: mongoerrors.ErrAuthenticationFailed,
+		},
+		"SCRAMRejected": {
+			doc: wirebson.MustDocument(
+				"ok", float64(0),
+				"errmsg", "Authentication failed.",
+				"code", int32(mongoerrors.ErrAuthenticationFailed),
+				"codeName", "AuthenticationFailed",
+			),
+			auth: func(ctx context.Context, m *Middleware) *Response {
+				return m.AuthenticateSCRAM(ctx, "username", "password")
+			},
+			code:     mongoerrors.ErrAuthenticationFailed,
+			commands: []string{"saslStart"},
+		},
+		"SCRAMInvalidServerPayload": {
+			doc: wirebson.MustDocument(
+				"conversationId", int32(1),
+				"done", false,
+				"payload", wirebson.Binary{B: []byte("invalid")},
+				"ok", float64(1),
+			),
+			auth: func(ctx context.Context, m *Middleware) *Response {
+				return m.AuthenticateSCRAM(ctx, "username", "password")
+			},
+			code:     mongoerrors.ErrAuthenticationFailed,
+			commands: []string{"saslStart"},
+		},
+		"APIKeyEmpty": {
+			auth: func(ctx context.Context, m *Middleware) *Response {
+				return m.AuthenticateAPIKey(ctx, "")
+			},
+			code: mongoerrors.ErrAuthenticationFailed,
+		},
+		"APIKey": {
+			doc: wirebson.MustDocument("ok", float64(1)),
+			auth: func(ctx context.Context, m *Middleware) *Response {
+				return m.AuthenticateAPIKey(ctx, "key")
+			},
+			commands: []string{"authenticateAPIKey"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			docdb := &testHandler{
+				Collector: prometheus.NewRegistry(),
+				doc:       tc.doc,
+				handled:   make(chan *Request, 10),
+			}
+
+			m := New(&NewOpts{
+				Mode:    NormalMode,
+				DocDB:   docdb,
+				Metrics: NewMetrics(),
+				L:       testutil.Logger(t),
+			})
+
+			resp := tc.auth(testutil.Ctx(t), m)
+			require.NotNil(t, resp)
+			assert.Equal(t, tc.code, resp.ErrorCode())
+
+			close(docdb.handled)
+
+			var commands []string
+			for req := range docdb.handled {
+				commands = append(commands, req.Document().Command())
+			}
+
+			assert.Equal(t, tc.commands, commands)
+		})
+	}
+}
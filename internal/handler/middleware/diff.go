@@ -0,0 +1,274 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/FerretDB/FerretDB/v2/internal/util/bsondiff"
+	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
+)
+
+// Diff results used as metric label values.
+const (
+	diffMatch = "match"
+	diffDiff  = "diff"
+)
+
+// sensitiveCommands contains commands with credentials or secrets in requests or responses.
+// Reports are never written for them.
+var sensitiveCommands = []string{
+	"authenticate",
+	"authenticateAPIKey",
+	"createAPIKey",
+	"createUser",
+	"saslContinue",
+	"saslStart",
+	"updateUser",
+}
+
+// sensitiveRequest returns true if the request contains credentials or secrets
+// and should not be written to reports.
+func sensitiveRequest(req *Request) bool {
+	doc := req.Document()
+
+	if slices.Contains(sensitiveCommands, doc.Command()) {
+		return true
+	}
+
+	// hello and isMaster may carry the first SCRAM step
+	return doc.Get("speculativeAuthenticate") != nil
+}
+
+// DiffReporter writes structured reports about differing responses in diff modes.
+//
+// Each report is a single line of Canonical Extended JSON v2.
+type DiffReporter struct {
+	m sync.Mutex
+	f *os.File
+}
+
+// NewDiffReporter creates a new reporter that appends reports to the file with the given path.
+// [DiffReporter.Close] must be called on the returned value.
+func NewDiffReporter(path string) (*DiffReporter, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return &DiffReporter{
+		f: f,
+	}, nil
+}
+
+// Report writes a single report.
+func (dr *DiffReporter) Report(report *wirebson.Document) error {
+	b, err := report.MarshalJSON()
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	b = append(b, '\n')
+
+	dr.m.Lock()
+	defer dr.m.Unlock()
+
+	if _, err = dr.f.Write(b); err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	return nil
+}
+
+// Close closes the underlying file.
+func (dr *DiffReporter) Close() error {
+	dr.m.Lock()
+	defer dr.m.Unlock()
+
+	if err := dr.f.Close(); err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	return nil
+}
+
+// compareResponses compares normalized DocumentDB and proxy responses,
+// updates metrics, and writes a report for differing responses if reporter is configured.
+// It returns true if responses differ, and true if the report was written.
+// In the latter case, only a one-line summary is logged; callers should not log the full diff.
+// It does nothing if either response is nil.
+func (m *Middleware) compareResponses(ctx context.Context, req *Request, docdb, proxy *Response) (differ, reported bool) {
+	if docdb == nil || proxy == nil {
+		return
+	}
+
+	rules := m.opts.DiffRules
+	if rules == nil {
+		rules = bsondiff.DefaultRules()
+	}
+
+	diffs, err := diffResponses(rules, docdb, proxy)
+	if err != nil {
+		m.opts.L.WarnContext(ctx, "Failed to compare responses", logging.Error(err))
+		return
+	}
+
+	command := req.Document().Command()
+
+	result := diffMatch
+	if len(diffs) > 0 {
+		result = diffDiff
+	}
+
+	m.opts.Metrics.diffs.With(prometheus.Labels{
+		"command": command,
+		"result":  result,
+	}).Inc()
+
+	if len(diffs) == 0 {
+		return
+	}
+
+	differ = true
+
+	if m.opts.DiffReporter == nil {
+		return
+	}
+
+	if sensitiveRequest(req) {
+		m.opts.L.DebugContext(ctx, "Diff report skipped for sensitive command", slog.String("command", command))
+		return
+	}
+
+	report, err := diffReport(rules, req, docdb, proxy, diffs)
+	if err == nil {
+		err = m.opts.DiffReporter.Report(report)
+	}
+
+	if err != nil {
+		m.opts.L.WarnContext(ctx, "Failed to write diff report", logging.Error(err))
+		return
+	}
+
+	m.opts.L.InfoContext(
+		ctx, "Responses differ, diff report written",
+		slog.String("command", command),
+		slog.Int("request_id", int(req.WireHeader().RequestID)),
+		slog.Int("differences", len(diffs)),
+	)
+
+	reported = true
+
+	return
+}
+
+// diffResponses returns field-level differences between normalized responses.
+func diffResponses(rules *bsondiff.Rules, docdb, proxy *Response) ([]bsondiff.Difference, error) {
+	d, err := rules.Normalize(docdb.DocumentRaw())
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	p, err := rules.Normalize(proxy.DocumentRaw())
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return bsondiff.Compare(d, p)
+}
+
+// diffReport returns a report document for differing responses.
+func diffReport(
+	rules *bsondiff.Rules, req *Request, docdb, proxy *Response, diffs []bsondiff.Difference,
+) (*wirebson.Document, error) {
+	merged, err := req.DocumentMerged()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	reqDoc, err := rules.Normalize(merged)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	docdbDoc, err := docdb.DocumentDeep()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	proxyDoc, err := proxy.DocumentDeep()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	command := req.Document().Command()
+
+	namespace, _ := req.Document().Get("$db").(string)
+	if collection, ok := req.Document().Get(command).(string); ok && namespace != "" {
+		namespace += "." + collection
+	}
+
+	differences, err := differencesArray(diffs)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return wirebson.NewDocument(
+		"time", time.Now().UTC(),
+		"requestId", req.WireHeader().RequestID,
+		"command", command,
+		"namespace", namespace,
+		"request", reqDoc,
+		"docdb", docdbDoc,
+		"proxy", proxyDoc,
+		"differences", differences,
+	)
+}
+
+// differencesArray returns an array of documents describing differences between DocumentDB and proxy responses.
+func differencesArray(diffs []bsondiff.Difference) (*wirebson.Array, error) {
+	res := wirebson.MakeArray(len(diffs))
+
+	for _, d := range diffs {
+		doc := wirebson.MustDocument("path", d.Path)
+
+		if d.A != nil {
+			if err := doc.Add("docdb", d.A); err != nil {
+				return nil, lazyerrors.Error(err)
+			}
+		}
+
+		if d.B != nil {
+			if err := doc.Add("proxy", d.B); err != nil {
+				return nil, lazyerrors.Error(err)
+			}
+		}
+
+		if err := res.Add(doc); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+	}
+
+	return res, nil
+}
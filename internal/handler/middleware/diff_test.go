@@ -0,0 +1,139 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+	ftestutil "github.com/FerretDB/FerretDB/v2/internal/util/testutil"
+)
+
+func TestCompareResponses(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "diff.jsonl")
+	dr, err := NewDiffReporter(path)
+	require.NoError(t, err)
+
+	m := &Middleware{
+		opts: &NewOpts{
+			Metrics:      NewMetrics(),
+			L:            ftestutil.Logger(t),
+			DiffReporter: dr,
+		},
+	}
+
+	req := must.NotFail(RequestDoc(wirebson.MustDocument(
+		"find", "values",
+		"$db", "test",
+	)))
+
+	docdb := must.NotFail(ResponseDoc(req, wirebson.MustDocument(
+		"n", int32(1),
+		"localTime", int64(1),
+		"ok", float64(1),
+	)))
+
+	ctx := ftestutil.Ctx(t)
+
+	proxy := must.NotFail(ResponseDoc(req, wirebson.MustDocument(
+		"n", int32(1),
+		"localTime", int64(2),
+		"ok", float64(1),
+	)))
+	differ, reported := m.compareResponses(ctx, req, docdb, proxy)
+	assert.False(t, differ)
+	assert.False(t, reported)
+
+	proxy = must.NotFail(ResponseDoc(req, wirebson.MustDocument(
+		"n", int32(2),
+		"ok", float64(1),
+	)))
+	differ, reported = m.compareResponses(ctx, req, docdb, proxy)
+	assert.True(t, differ)
+	assert.True(t, reported)
+
+	insert := requestWithSequence(t,
+		wirebson.MustDocument("insert", "values", "$db", "test"),
+		"documents", wirebson.MustDocument("_id", int32(1)),
+	)
+	differ, reported = m.compareResponses(
+		ctx, insert,
+		must.NotFail(ResponseDoc(insert, wirebson.MustDocument("n", int32(1), "ok", float64(1)))),
+		must.NotFail(ResponseDoc(insert, wirebson.MustDocument("n", int32(0), "ok", float64(1)))),
+	)
+	assert.True(t, differ)
+	assert.True(t, reported)
+
+	for _, doc := range []*wirebson.Document{
+		wirebson.MustDocument("saslStart", int32(1), "payload", wirebson.Binary{B: []byte("n,,n=user,r=nonce")}, "$db", "admin"),
+		wirebson.MustDocument("hello", int32(1), "speculativeAuthenticate", wirebson.MustDocument(), "$db", "admin"),
+	} {
+		sensitive := must.NotFail(RequestDoc(doc))
+		docdb = must.NotFail(ResponseDoc(sensitive, wirebson.MustDocument("payload", "docdb", "ok", float64(1))))
+		proxy = must.NotFail(ResponseDoc(sensitive, wirebson.MustDocument("payload", "proxy", "ok", float64(1))))
+		differ, reported := m.compareResponses(ctx, sensitive, docdb, proxy)
+		assert.True(t, differ)
+		assert.False(t, reported)
+	}
+
+	require.NoError(t, dr.Close())
+
+	fi, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), fi.Mode().Perm())
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.opts.Metrics.diffs.WithLabelValues("find", diffMatch)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.opts.Metrics.diffs.WithLabelValues("find", diffDiff)))
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	require.Len(t, lines, 2)
+
+	type reportLine struct {
+		Command     string         `json:"command"`
+		Namespace   string         `json:"namespace"`
+		Request     map[string]any `json:"request"`
+		Differences []struct {
+			Path string `json:"path"`
+		} `json:"differences"`
+	}
+
+	var report reportLine
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &report))
+
+	assert.Equal(t, "find", report.Command)
+	assert.Equal(t, "test.values", report.Namespace)
+	require.Len(t, report.Differences, 1)
+	assert.Equal(t, "n", report.Differences[0].Path)
+
+	report = reportLine{}
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &report))
+
+	assert.Equal(t, "insert", report.Command)
+	assert.Len(t, report.Request["documents"], 1, "document sequence should be included into the request")
+}
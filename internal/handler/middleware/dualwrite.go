@@ -0,0 +1,249 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/FerretDB/FerretDB/v2/internal/util/bsondiff"
+	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
+)
+
+// Dual-write results used as metric label values.
+const (
+	dualWriteMatch    = "match"
+	dualWriteDiverged = "diverged"
+)
+
+// dualWriteCommands contains commands that are sent to both handlers in dual-write modes.
+//
+// User management commands are not included because users are managed separately in each store.
+var dualWriteCommands = []string{
+	"collMod",
+	"create",
+	"createIndexes",
+	"delete",
+	"drop",
+	"dropDatabase",
+	"dropIndexes",
+	"findAndModify",
+	"findandmodify", // old lowercase variant
+	"insert",
+	"renameCollection",
+	"update",
+}
+
+// dualWritePrimary returns the handler that serves reads and whose responses are sent to the client.
+func dualWritePrimary(mode Mode) Route {
+	switch mode {
+	case DualWriteNormalMode:
+		return RouteDocumentDB
+	case DualWriteProxyMode:
+		return RouteProxy
+	case NormalMode, ProxyMode, DiffNormalMode, DiffProxyMode, ShadowNormalMode, ShadowProxyMode,
+		RouteMode:
+		fallthrough
+	default:
+		panic("not reached")
+	}
+}
+
+// isDualWrite returns true if the request should be sent to both handlers in dual-write modes.
+func isDualWrite(req *Request) bool {
+	doc := req.Document()
+	command := doc.Command()
+
+	if slices.Contains(dualWriteCommands, command) {
+		return true
+	}
+
+	// like in the read-only mode, `$out` and `$merge` stages make aggregation a write command
+	return command == "aggregate" && pipelineWrites(doc)
+}
+
+// dualWrite sends write requests to both handlers and other requests to the primary handler only.
+// Handshake and authentication commands are also sent to both handlers,
+// so both of them see the same connection state.
+//
+// The primary handler's response is returned.
+// It returns nil if unrecoverable error occurs in the primary handler.
+func (m *Middleware) dualWrite(ctx context.Context, req *Request) *Response {
+	primary := dualWritePrimary(m.opts.Mode)
+
+	command := req.Document().Command()
+	write := isDualWrite(req)
+
+	if !write && !slices.Contains(connectionCommands, command) {
+		switch primary {
+		case RouteDocumentDB:
+			return m.dispatchDocDB(ctx, req)
+		case RouteProxy:
+			return m.dispatchProxy(ctx, req)
+		default:
+			panic("not reached")
+		}
+	}
+
+	docdb, proxy := m.dispatch(ctx, req)
+
+	if write {
+		m.checkWrite(ctx, req, docdb, proxy)
+	}
+
+	switch primary {
+	case RouteDocumentDB:
+		return docdb
+	case RouteProxy:
+		return proxy
+	default:
+		panic("not reached")
+	}
+}
+
+// checkWrite compares normalized write results of both handlers, updates metrics,
+// and writes a reconciliation log entry if results diverge.
+func (m *Middleware) checkWrite(ctx context.Context, req *Request, docdb, proxy *Response) {
+	rules := m.opts.DiffRules
+	if rules == nil {
+		rules = bsondiff.DefaultRules()
+	}
+
+	var diffs []bsondiff.Difference
+
+	diverged := docdb == nil || proxy == nil
+	if !diverged {
+		var err error
+		if diffs, err = diffResponses(rules, docdb, proxy); err != nil {
+			m.opts.L.WarnContext(ctx, "Failed to compare write results", logging.Error(err))
+			return
+		}
+
+		diverged = len(diffs) > 0
+	}
+
+	command := req.Document().Command()
+
+	result := dualWriteMatch
+	if diverged {
+		result = dualWriteDiverged
+	}
+
+	m.opts.Metrics.dualWrites.With(prometheus.Labels{
+		"command": command,
+		"result":  result,
+	}).Inc()
+
+	if !diverged {
+		return
+	}
+
+	m.opts.L.WarnContext(
+		ctx, "Write results diverged",
+		slog.String("command", command), slog.Bool("docdb", docdb != nil), slog.Bool("proxy", proxy != nil),
+	)
+
+	if m.opts.ReconciliationLog == nil {
+		// the reconciliation log contains both results; log them only if it is disabled
+		if docdb != nil && proxy != nil {
+			m.logDiff(ctx, docdb, proxy)
+		}
+
+		return
+	}
+
+	entry, err := reconciliationEntry(rules, dualWritePrimary(m.opts.Mode), req, docdb, proxy, diffs)
+	if err == nil {
+		err = m.opts.ReconciliationLog.Report(entry)
+	}
+
+	if err != nil {
+		m.opts.L.WarnContext(ctx, "Failed to write reconciliation log entry", logging.Error(err))
+	}
+}
+
+// reconciliationEntry returns a reconciliation log entry for diverged write results.
+//
+// The entry contains the whole request, including documents from OP_MSG document sequences,
+// so it could be re-applied to the store that is behind.
+// Responses are absent if the corresponding handler failed with unrecoverable error.
+func reconciliationEntry(
+	rules *bsondiff.Rules, primary Route, req *Request, docdb, proxy *Response, diffs []bsondiff.Difference,
+) (*wirebson.Document, error) {
+	reqDoc, err := rules.Normalize(req.DocumentRaw())
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	documents, err := req.DocumentSequences()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	command := req.Document().Command()
+
+	namespace, _ := req.Document().Get("$db").(string)
+	if collection, ok := req.Document().Get(command).(string); ok && namespace != "" {
+		namespace += "." + collection
+	}
+
+	entry := wirebson.MustDocument(
+		"time", time.Now().UTC(),
+		"requestId", req.WireHeader().RequestID,
+		"command", command,
+		"namespace", namespace,
+		"primary", string(primary),
+		"request", reqDoc,
+		"documents", documents,
+	)
+
+	for _, r := range []struct {
+		name string
+		resp *Response
+	}{
+		{"docdb", docdb},
+		{"proxy", proxy},
+	} {
+		if r.resp == nil {
+			continue
+		}
+
+		var doc *wirebson.Document
+		if doc, err = r.resp.DocumentDeep(); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		if err = entry.Add(r.name, doc); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+	}
+
+	differences, err := differencesArray(diffs)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	if err = entry.Add("differences", differences); err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,166 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+	ftestutil "github.com/FerretDB/FerretDB/v2/internal/util/testutil"
+)
+
+func TestIsDualWrite(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		doc      *wirebson.Document
+		expected bool
+	}{
+		"Insert": {
+			doc:      wirebson.MustDocument("insert", "values", "$db", "test"),
+			expected: true,
+		},
+		"Find": {
+			doc:      wirebson.MustDocument("find", "values", "$db", "test"),
+			expected: false,
+		},
+		"AggregateMatch": {
+			doc: wirebson.MustDocument(
+				"aggregate", "values",
+				"pipeline", wirebson.MustArray(wirebson.MustDocument("$match", wirebson.MakeDocument(0))),
+				"$db", "test",
+			),
+			expected: false,
+		},
+		"AggregateOut": {
+			doc: wirebson.MustDocument(
+				"aggregate", "values",
+				"pipeline", wirebson.MustArray(wirebson.MustDocument("$out", "other")),
+				"$db", "test",
+			),
+			expected: true,
+		},
+		"CreateUser": {
+			doc:      wirebson.MustDocument("createUser", "user", "$db", "test"),
+			expected: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := must.NotFail(RequestDoc(tc.doc))
+			assert.Equal(t, tc.expected, isDualWrite(req))
+		})
+	}
+}
+
+func TestDualWriteMode(t *testing.T) {
+	t.Parallel()
+
+	docdb := &testHandler{
+		Collector: prometheus.NewRegistry(),
+		doc:       wirebson.MustDocument("n", int32(1), "ok", float64(1)),
+		handled:   make(chan *Request, 10),
+	}
+
+	proxy := &testHandler{
+		Collector: prometheus.NewRegistry(),
+		doc:       wirebson.MustDocument("n", int32(2), "ok", float64(1)),
+		handled:   make(chan *Request, 10),
+	}
+
+	file := filepath.Join(t.TempDir(), "reconciliation.jsonl")
+	rl, err := NewDiffReporter(file)
+	require.NoError(t, err)
+
+	m := New(&NewOpts{
+		Mode:              DualWriteProxyMode,
+		DocDB:             docdb,
+		Proxy:             proxy,
+		Metrics:           NewMetrics(),
+		L:                 ftestutil.Logger(t),
+		ReconciliationLog: rl,
+	})
+
+	ctx := ftestutil.Ctx(t)
+
+	find := must.NotFail(RequestDoc(wirebson.MustDocument("find", "values", "$db", "test")))
+	resp := m.Handle(ctx, find)
+	require.NotNil(t, resp)
+	assert.Equal(t, int32(2), resp.Document().Get("n"))
+	assert.Len(t, proxy.handled, 1)
+	assert.Empty(t, docdb.handled, "reads should be sent to the primary only")
+
+	<-proxy.handled
+
+	insert := requestWithSequence(
+		t,
+		wirebson.MustDocument("insert", "values", "$db", "test"),
+		"documents",
+		wirebson.MustDocument("_id", int32(1)),
+		wirebson.MustDocument("_id", int32(2)),
+	)
+	resp = m.Handle(ctx, insert)
+	require.NotNil(t, resp)
+	assert.Equal(t, int32(2), resp.Document().Get("n"), "primary response should be returned")
+	assert.Len(t, proxy.handled, 1)
+	assert.Len(t, docdb.handled, 1)
+
+	dw := m.opts.Metrics.dualWrites
+	assert.Equal(t, float64(1), testutil.ToFloat64(dw.WithLabelValues("insert", dualWriteDiverged)))
+	assert.Equal(t, float64(0), testutil.ToFloat64(dw.WithLabelValues("insert", dualWriteMatch)))
+
+	require.NoError(t, rl.Close())
+
+	f, err := os.Open(file)
+	require.NoError(t, err)
+
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	require.True(t, s.Scan())
+
+	var entry wirebson.Document
+	require.NoError(t, entry.UnmarshalJSON(s.Bytes()))
+
+	assert.Equal(t, "insert", entry.Get("command"))
+	assert.Equal(t, "test.values", entry.Get("namespace"))
+	assert.Equal(t, "proxy", entry.Get("primary"))
+
+	sequences, ok := entry.Get("documents").(*wirebson.Document)
+	require.True(t, ok)
+	assert.Equal(t, []string{"documents"}, sequences.FieldNames())
+
+	documents, ok := sequences.Get("documents").(*wirebson.Array)
+	require.True(t, ok)
+	require.Equal(t, 2, documents.Len())
+	assert.Equal(t, int32(1), documents.Get(0).(*wirebson.Document).Get("_id"))
+
+	differences, ok := entry.Get("differences").(*wirebson.Array)
+	require.True(t, ok)
+	require.Equal(t, 1, differences.Len())
+	assert.Equal(t, "n", differences.Get(0).(*wirebson.Document).Get("path"))
+
+	assert.False(t, s.Scan(), "only one entry expected")
+}
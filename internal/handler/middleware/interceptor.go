@@ -0,0 +1,167 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"context"
+	"fmt"
+
+	otelattribute "go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+)
+
+// Interceptor represents a pair of hooks called by [Middleware] for every request.
+//
+// Interceptors are called in order before the request is dispatched to handlers,
+// and in reverse order after the response is produced.
+// Each hook call has its own OpenTelemetry span.
+type Interceptor struct {
+	// Name is used in spans and logs. Required.
+	Name string
+
+	// Before is called before the request is dispatched.
+	//
+	// It may return a different request to replace the passed one (nil keeps it as is),
+	// a response to skip the rest of the chain and dispatching (for example, for caching),
+	// or an error to reject the request ([*mongoerrors.Error] for a specific code).
+	// In the last two cases, After hooks of only the previous interceptors are called.
+	//
+	// Nil value skips the hook.
+	Before func(ctx context.Context, req *Request) (*Request, *Response, error)
+
+	// After is called after the response is produced.
+	// It is not called if unrecoverable error occurred, and there is no response.
+	//
+	// It may return a different response to replace the passed one (nil keeps it as is),
+	// or an error to replace it with an error response ([*mongoerrors.Error] for a specific code).
+	//
+	// Nil value skips the hook.
+	After func(ctx context.Context, req *Request, resp *Response) (*Response, error)
+}
+
+// ValidateInterceptors checks that interceptors have unique names.
+func ValidateInterceptors(interceptors []*Interceptor) error {
+	names := make(map[string]struct{}, len(interceptors))
+
+	for i, ic := range interceptors {
+		if ic == nil {
+			return fmt.Errorf("interceptor %d is nil", i)
+		}
+
+		if ic.Name == "" {
+			return fmt.Errorf("interceptor %d: name is empty", i)
+		}
+
+		if _, ok := names[ic.Name]; ok {
+			return fmt.Errorf("interceptor %q is duplicated", ic.Name)
+		}
+
+		names[ic.Name] = struct{}{}
+	}
+
+	return nil
+}
+
+// intercept calls interceptors around the given handle function.
+// It returns nil if unrecoverable error occurs.
+func (m *Middleware) intercept(ctx context.Context, req *Request, handle func(context.Context, *Request) *Response) *Response {
+	var resp *Response
+
+	var i int
+	for ; i < len(m.opts.Interceptors); i++ {
+		ic := m.opts.Interceptors[i]
+		if ic.Before == nil {
+			continue
+		}
+
+		var r *Request
+		if r, resp = m.before(ctx, ic, req); resp != nil {
+			break
+		}
+
+		req = r
+	}
+
+	if resp == nil {
+		resp = handle(ctx, req)
+	}
+
+	for i--; i >= 0; i-- {
+		if resp == nil {
+			return nil
+		}
+
+		ic := m.opts.Interceptors[i]
+		if ic.After == nil {
+			continue
+		}
+
+		resp = m.after(ctx, ic, req, resp)
+	}
+
+	return resp
+}
+
+// before calls the Before hook of the given interceptor in a separate span.
+// It returns either a request to continue with, or a response to stop with.
+func (m *Middleware) before(ctx context.Context, ic *Interceptor, req *Request) (*Request, *Response) {
+	ctx, span := m.startInterceptorSpan(ctx, ic, "before")
+	defer span.End()
+
+	r, resp, err := ic.Before(ctx, req)
+
+	switch {
+	case err != nil:
+		return nil, ResponseErr(req, mongoerrors.Make(ctx, err, "", m.opts.L))
+	case resp != nil:
+		return nil, resp
+	case r != nil:
+		return r, nil
+	default:
+		return req, nil
+	}
+}
+
+// after calls the After hook of the given interceptor in a separate span.
+// It returns the response to continue with.
+func (m *Middleware) after(ctx context.Context, ic *Interceptor, req *Request, resp *Response) *Response {
+	ctx, span := m.startInterceptorSpan(ctx, ic, "after")
+	defer span.End()
+
+	r, err := ic.After(ctx, req, resp)
+
+	switch {
+	case err != nil:
+		return ResponseErr(req, mongoerrors.Make(ctx, err, "", m.opts.L))
+	case r != nil:
+		return r
+	default:
+		return resp
+	}
+}
+
+// startInterceptorSpan starts a new OpenTelemetry span for the interceptor's hook.
+func (m *Middleware) startInterceptorSpan(ctx context.Context, ic *Interceptor, hook string) (context.Context, oteltrace.Span) {
+	return m.tracer.Start(
+		ctx,
+		"middleware.Handle/interceptor",
+		oteltrace.WithAttributes(
+			otelattribute.String("db.ferretdb.interceptor", ic.Name),
+			otelattribute.String("db.ferretdb.interceptor_hook", hook),
+		),
+	)
+}
@@ -0,0 +1,171 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+	"github.com/FerretDB/FerretDB/v2/internal/util/testutil"
+)
+
+func TestInterceptors(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+
+	docdb := &testHandler{
+		Collector: prometheus.NewRegistry(),
+		doc:       wirebson.MustDocument("n", int32(1), "ok", float64(1)),
+		handled:   make(chan *Request, 10),
+	}
+
+	m := New(&NewOpts{
+		Mode:    NormalMode,
+		DocDB:   docdb,
+		Metrics: NewMetrics(),
+		L:       testutil.Logger(t),
+		Interceptors: []*Interceptor{
+			{
+				Name: "first",
+				Before: func(_ context.Context, req *Request) (*Request, *Response, error) {
+					calls = append(calls, "first.before")
+
+					if req.Document().Command() != "find" {
+						return nil, nil, nil
+					}
+
+					doc := must.NotFail(req.DocumentDeep())
+					must.NoError(doc.Add("filter", wirebson.MustDocument("tenant", "a")))
+
+					r, err := req.WithDocument(doc)
+					return r, nil, err
+				},
+				After: func(_ context.Context, req *Request, resp *Response) (*Response, error) {
+					calls = append(calls, "first.after")
+
+					if req.Document().Command() != "count" {
+						return nil, nil
+					}
+
+					return ResponseDoc(req, wirebson.MustDocument("n", int32(42), "ok", float64(1)))
+				},
+			},
+			{
+				Name: "second",
+				Before: func(_ context.Context, req *Request) (*Request, *Response, error) {
+					calls = append(calls, "second.before")
+
+					switch req.Document().Command() {
+					case "drop":
+						return nil, nil, mongoerrors.New(mongoerrors.ErrUnauthorized, "drop is not allowed")
+					case "ping":
+						resp, err := ResponseDoc(req, wirebson.MustDocument("ok", float64(1), "cached", true))
+						return nil, resp, err
+					default:
+						return nil, nil, nil
+					}
+				},
+			},
+			{
+				Name: "third",
+				After: func(context.Context, *Request, *Response) (*Response, error) {
+					calls = append(calls, "third.after")
+					return nil, nil
+				},
+			},
+		},
+	})
+
+	ctx := testutil.Ctx(t)
+
+	t.Run("Replace", func(t *testing.T) {
+		calls = nil
+
+		req := must.NotFail(RequestDoc(wirebson.MustDocument("find", "values", "$db", "test")))
+		resp := m.Handle(ctx, req)
+		require.NotNil(t, resp)
+		assert.Equal(t, int32(1), resp.Document().Get("n"))
+		assert.Equal(t, req.WireHeader().RequestID, resp.WireHeader().ResponseTo)
+
+		handled := <-docdb.handled
+		assert.Equal(t, wirebson.MustDocument("tenant", "a"), handled.Document().Get("filter"))
+		assert.Equal(t, req.WireHeader().RequestID, handled.WireHeader().RequestID)
+
+		expected := []string{"first.before", "second.before", "third.after", "first.after"}
+		assert.Equal(t, expected, calls)
+	})
+
+	t.Run("ReplaceResponse", func(t *testing.T) {
+		calls = nil
+
+		req := must.NotFail(RequestDoc(wirebson.MustDocument("count", "values", "$db", "test")))
+		resp := m.Handle(ctx, req)
+		require.NotNil(t, resp)
+		assert.Equal(t, int32(42), resp.Document().Get("n"))
+
+		<-docdb.handled
+	})
+
+	t.Run("Reject", func(t *testing.T) {
+		calls = nil
+
+		req := must.NotFail(RequestDoc(wirebson.MustDocument("drop", "values", "$db", "test")))
+		resp := m.Handle(ctx, req)
+		require.NotNil(t, resp)
+		assert.False(t, resp.OK())
+		assert.Equal(t, mongoerrors.ErrUnauthorized, resp.ErrorCode())
+		assert.Empty(t, docdb.handled)
+
+		expected := []string{"first.before", "second.before", "first.after"}
+		assert.Equal(t, expected, calls)
+	})
+
+	t.Run("Respond", func(t *testing.T) {
+		calls = nil
+
+		req := must.NotFail(RequestDoc(wirebson.MustDocument("ping", int32(1), "$db", "admin")))
+		resp := m.Handle(ctx, req)
+		require.NotNil(t, resp)
+		assert.Equal(t, true, resp.Document().Get("cached"))
+		assert.Empty(t, docdb.handled)
+
+		expected := []string{"first.before", "second.before", "first.after"}
+		assert.Equal(t, expected, calls)
+	})
+}
+
+func TestValidateInterceptors(t *testing.T) {
+	t.Parallel()
+
+	err := ValidateInterceptors([]*Interceptor{{Name: "a"}, {Name: "b"}})
+	require.NoError(t, err)
+
+	err = ValidateInterceptors([]*Interceptor{{Name: "a"}, {Name: ""}})
+	require.EqualError(t, err, "interceptor 1: name is empty")
+
+	err = ValidateInterceptors([]*Interceptor{{Name: "a"}, {Name: "a"}})
+	require.EqualError(t, err, `interceptor "a" is duplicated`)
+
+	err = ValidateInterceptors([]*Interceptor{nil})
+	require.EqualError(t, err, "interceptor 0 is nil")
+}
@@ -32,8 +32,12 @@
 
 // Metrics represents middleware Metrics.
 type Metrics struct {
-	requests  *prometheus.CounterVec
-	responses *prometheus.CounterVec
+	requests   *prometheus.CounterVec
+	responses  *prometheus.CounterVec
+	diffs      *prometheus.CounterVec
+	shadow     *prometheus.CounterVec
+	routed     *prometheus.CounterVec
+	dualWrites *prometheus.CounterVec
 }
 
 // CommandMetrics represents command results metrics.
@@ -70,6 +74,46 @@ func NewMetrics() *Metrics {
 			},
 			[]string{"opcode", "command", "argument", "result"},
 		),
+
+		diffs: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Subsystem: subsystem,
+				Name:      "diffs_total",
+				Help:      "Total number of compared DocumentDB and proxy responses in diff modes.",
+			},
+			[]string{"command", "result"},
+		),
+
+		shadow: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Subsystem: subsystem,
+				Name:      "shadow_requests_total",
+				Help:      "Total number of requests considered for sending to the secondary handler in shadow modes.",
+			},
+			[]string{"command", "result"},
+		),
+
+		routed: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Subsystem: subsystem,
+				Name:      "routed_requests_total",
+				Help:      "Total number of requests routed to handlers in route mode.",
+			},
+			[]string{"route", "rule"},
+		),
+
+		dualWrites: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Subsystem: subsystem,
+				Name:      "dual_writes_total",
+				Help:      "Total number of write requests sent to both handlers in dual-write modes.",
+			},
+			[]string{"command", "result"},
+		),
 	}
 
 	m.requests.With(prometheus.Labels{
@@ -90,12 +134,20 @@ func NewMetrics() *Metrics {
 func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
 	m.requests.Describe(ch)
 	m.responses.Describe(ch)
+	m.diffs.Describe(ch)
+	m.shadow.Describe(ch)
+	m.routed.Describe(ch)
+	m.dualWrites.Describe(ch)
 }
 
 // Collect implements [prometheus.Collector].
 func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
 	m.requests.Collect(ch)
 	m.responses.Collect(ch)
+	m.diffs.Collect(ch)
+	m.shadow.Collect(ch)
+	m.routed.Collect(ch)
+	m.dualWrites.Collect(ch)
 }
 
 // GetResponses returns a map with all response metrics:
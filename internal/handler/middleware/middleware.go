@@ -32,16 +32,31 @@
 	oteltrace "go.opentelemetry.io/otel/trace"
 
 	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+	"github.com/FerretDB/FerretDB/v2/internal/util/bsondiff"
 	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
 	"github.com/FerretDB/FerretDB/v2/internal/util/must"
 	"github.com/FerretDB/FerretDB/v2/internal/util/observability"
 )
 
+// connectionCommands contains handshake and authentication commands that change the connection state.
+var connectionCommands = []string{
+	"authenticate",
+	"authenticateAPIKey",
+	"hello",
+	"isMaster",
+	"ismaster",
+	"logout",
+	"saslContinue",
+	"saslStart",
+}
+
 // Middleware connects listeners and handlers.
 //
 //nolint:vet // for readability
 type Middleware struct {
-	opts *NewOpts
+	opts   *NewOpts
+	s      *shadow // only for shadow modes
+	tracer oteltrace.Tracer
 
 	runM   sync.Mutex
 	runCtx context.Context
@@ -57,6 +72,25 @@ type NewOpts struct {
 	Proxy   Handler
 	Metrics *Metrics
 	L       *slog.Logger
+
+	// Diff modes
+	DiffRules    *bsondiff.Rules // nil means bsondiff.DefaultRules()
+	DiffReporter *DiffReporter   // nil disables reports
+
+	// Shadow modes
+	Shadow *ShadowOpts // nil means defaults
+
+	// Route mode
+	Router *Router
+
+	// Dual-write modes
+	ReconciliationLog *DiffReporter // nil disables log
+
+	Interceptors []*Interceptor // must pass ValidateInterceptors
+
+	ReadOnly ReadOnlyChecker // nil disables read-only mode
+
+	TracerProvider oteltrace.TracerProvider // nil means the global provider
 }
 
 // New returns a new middleware.
@@ -77,13 +111,37 @@ func New(opts *NewOpts) *Middleware {
 	case DiffProxyMode:
 		must.NotBeZero(opts.DocDB)
 		must.NotBeZero(opts.Proxy)
+	case ShadowNormalMode, ShadowProxyMode:
+		must.NotBeZero(opts.DocDB)
+		must.NotBeZero(opts.Proxy)
+	case RouteMode:
+		must.NotBeZero(opts.DocDB)
+		must.NotBeZero(opts.Proxy)
+		must.NotBeZero(opts.Router)
+	case DualWriteNormalMode, DualWriteProxyMode:
+		must.NotBeZero(opts.DocDB)
+		must.NotBeZero(opts.Proxy)
 	default:
 		panic("not reached")
 	}
 
-	return &Middleware{
-		opts: opts,
+	must.NoError(ValidateInterceptors(opts.Interceptors))
+
+	tp := opts.TracerProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+
+	m := &Middleware{
+		opts:   opts,
+		tracer: tp.Tracer(""),
 	}
+
+	if opts.Mode == ShadowNormalMode || opts.Mode == ShadowProxyMode {
+		m.s = newShadow(opts.Shadow)
+	}
+
+	return m
 }
 
 // Run implements [Handler].
@@ -92,6 +150,10 @@ func (m *Middleware) Run(ctx context.Context) {
 	m.runCtx = ctx
 	m.runM.Unlock()
 
+	if m.s != nil {
+		m.runShadow(ctx)
+	}
+
 	<-ctx.Done()
 	m.runWG.Wait()
 }
@@ -134,19 +196,42 @@ func (m *Middleware) Handle(ctx context.Context, req *Request) (resp *Response)
 		m.opts.L.DebugContext(ctx, fmt.Sprintf("<<< %s\n%s", req.WireHeader(), req.WireBody().StringIndent()))
 	}
 
-	docdb, proxy := m.dispatch(ctx, req)
+	resp = m.intercept(ctx, req, m.handle)
+
+	return
+}
+
+// handle sends the request to handlers according to the mode.
+// It returns nil if unrecoverable error occurs.
+func (m *Middleware) handle(ctx context.Context, req *Request) (resp *Response) {
+	// checked there to reject writes in all modes before they reach any handler
+	if err := m.checkReadOnly(req); err != nil {
+		return ResponseErr(req, err)
+	}
 
 	switch m.opts.Mode {
 	case NormalMode:
-		resp = docdb
+		resp = m.dispatchDocDB(ctx, req)
 	case ProxyMode:
-		resp = proxy
+		resp = m.dispatchProxy(ctx, req)
 	case DiffNormalMode:
-		m.logDiff(ctx, docdb, proxy)
+		docdb, proxy := m.dispatch(ctx, req)
+		m.diff(ctx, req, docdb, proxy)
 		resp = docdb
 	case DiffProxyMode:
-		m.logDiff(ctx, docdb, proxy)
+		docdb, proxy := m.dispatch(ctx, req)
+		m.diff(ctx, req, docdb, proxy)
 		resp = proxy
+	case ShadowNormalMode:
+		resp = m.dispatchDocDB(ctx, req)
+		m.shadow(ctx, req, resp)
+	case ShadowProxyMode:
+		resp = m.dispatchProxy(ctx, req)
+		m.shadow(ctx, req, resp)
+	case RouteMode:
+		resp = m.route(ctx, req)
+	case DualWriteNormalMode, DualWriteProxyMode:
+		resp = m.dualWrite(ctx, req)
 	default:
 		panic("not reached")
 	}
@@ -154,7 +239,7 @@ func (m *Middleware) Handle(ctx context.Context, req *Request) (resp *Response)
 	return
 }
 
-// dispatch sends the request to both handlers.
+// dispatch sends the request to both handlers concurrently.
 // It returns nil for the given handler if it is not enabled, or if unrecoverable error occurs in it.
 func (m *Middleware) dispatch(ctx context.Context, req *Request) (docdb, proxy *Response) {
 	var wg sync.WaitGroup
@@ -163,15 +248,7 @@ func (m *Middleware) dispatch(ctx context.Context, req *Request) (docdb, proxy *
 		wg.Add(1)
 
 		go func() {
-			dCtx, _ := otel.Tracer("").Start(ctx, "middleware.Handle/documentdb")
-
-			//exhaustruct:enforce
-			d := &dispatcher{
-				h:         m.opts.DocDB,
-				l:         m.opts.L.With("handler", "documentdb"),
-				responses: m.opts.Metrics.responses,
-			}
-			docdb = d.Dispatch(dCtx, req)
+			docdb = m.dispatchDocDB(ctx, req)
 
 			wg.Done()
 		}()
@@ -181,15 +258,7 @@ func (m *Middleware) dispatch(ctx context.Context, req *Request) (docdb, proxy *
 		wg.Add(1)
 
 		go func() {
-			dCtx, _ := otel.Tracer("").Start(ctx, "middleware.Handle/proxy")
-
-			//exhaustruct:enforce
-			d := &dispatcher{
-				h:         m.opts.Proxy,
-				l:         m.opts.L.With("handler", "proxy"),
-				responses: m.opts.Metrics.responses,
-			}
-			proxy = d.Dispatch(dCtx, req)
+			proxy = m.dispatchProxy(ctx, req)
 
 			wg.Done()
 		}()
@@ -200,6 +269,61 @@ func (m *Middleware) dispatch(ctx context.Context, req *Request) (docdb, proxy *
 	return
 }
 
+// dispatchDocDB sends the request to the DocumentDB handler.
+// It returns nil if unrecoverable error occurs.
+func (m *Middleware) dispatchDocDB(ctx context.Context, req *Request) *Response {
+	dCtx, _ := m.tracer.Start(ctx, "middleware.Handle/documentdb")
+
+	//exhaustruct:enforce
+	d := &dispatcher{
+		h:         m.opts.DocDB,
+		l:         m.opts.L.With("handler", "documentdb"),
+		responses: m.opts.Metrics.responses,
+	}
+
+	return d.Dispatch(dCtx, req)
+}
+
+// dispatchProxy sends the request to the proxy handler.
+// It returns nil if unrecoverable error occurs.
+func (m *Middleware) dispatchProxy(ctx context.Context, req *Request) *Response {
+	dCtx, _ := m.tracer.Start(ctx, "middleware.Handle/proxy")
+
+	//exhaustruct:enforce
+	d := &dispatcher{
+		h:         m.opts.Proxy,
+		l:         m.opts.L.With("handler", "proxy"),
+		responses: m.opts.Metrics.responses,
+	}
+
+	return d.Dispatch(dCtx, req)
+}
+
+// route sends the request to the handler selected by the router.
+// It returns nil if unrecoverable error occurs.
+func (m *Middleware) route(ctx context.Context, req *Request) *Response {
+	route, rule := m.opts.Router.Route(req)
+
+	m.opts.Metrics.routed.With(prometheus.Labels{
+		"route": string(route),
+		"rule":  rule,
+	}).Inc()
+
+	oteltrace.SpanFromContext(ctx).SetAttributes(
+		otelattribute.String("db.ferretdb.route", string(route)),
+		otelattribute.String("db.ferretdb.route_rule", rule),
+	)
+
+	switch route {
+	case RouteDocumentDB:
+		return m.dispatchDocDB(ctx, req)
+	case RouteProxy:
+		return m.dispatchProxy(ctx, req)
+	default:
+		panic("not reached")
+	}
+}
+
 // startSpan starts a new OpenTelemetry span for the request and returns the derived context.
 func (m *Middleware) startSpan(ctx context.Context, req *Request) context.Context {
 	comment, _ := req.Document().Get("comment").(string)
@@ -221,7 +345,7 @@ func (m *Middleware) startSpan(ctx context.Context, req *Request) context.Contex
 		collection, _ = req.Document().Get(command).(string)
 	}
 
-	ctx, _ = otel.Tracer("").Start(
+	ctx, _ = m.tracer.Start(
 		ctx,
 		"middleware.Handle",
 		oteltrace.WithAttributes(
@@ -260,6 +384,14 @@ func (m *Middleware) endSpan(ctx context.Context, resp *Response) {
 	span.End()
 }
 
+// diff compares the DocumentDB and proxy responses in diff modes,
+// and logs the full diff unless it was written to the diff report.
+func (m *Middleware) diff(ctx context.Context, req *Request, docdb, proxy *Response) {
+	if _, reported := m.compareResponses(ctx, req, docdb, proxy); !reported {
+		m.logDiff(ctx, docdb, proxy)
+	}
+}
+
 // logDiff logs the diff between the DocumentDB and proxy responses.
 // It does nothing if either response is nil or logging for the given level is disabled.
 func (m *Middleware) logDiff(ctx context.Context, docdb, proxy *Response) {
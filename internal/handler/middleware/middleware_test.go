@@ -0,0 +1,86 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	otelsdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+	"github.com/FerretDB/FerretDB/v2/internal/util/observability"
+	"github.com/FerretDB/FerretDB/v2/internal/util/testutil"
+)
+
+// tracingHandler is a [Handler] that starts a span with a tracer from the request context.
+type tracingHandler struct {
+	testHandler
+}
+
+// Handle implements [Handler].
+func (h *tracingHandler) Handle(ctx context.Context, req *Request) (*Response, error) {
+	_, span := observability.Tracer(ctx).Start(ctx, "tracingHandler.Handle")
+	defer span.End()
+
+	return h.testHandler.Handle(ctx, req)
+}
+
+func TestTracerProvider(t *testing.T) {
+	t.Parallel()
+
+	global := otel.GetTracerProvider()
+
+	sr := tracetest.NewSpanRecorder()
+	tp := otelsdktrace.NewTracerProvider(otelsdktrace.WithSpanProcessor(sr))
+	t.Cleanup(func() { require.NoError(t, tp.Shutdown(context.Background())) })
+
+	docdb := &tracingHandler{
+		testHandler: testHandler{
+			Collector: prometheus.NewRegistry(),
+			doc:       wirebson.MustDocument("ok", float64(1)),
+		},
+	}
+
+	m := New(&NewOpts{
+		Mode:           NormalMode,
+		DocDB:          docdb,
+		Metrics:        NewMetrics(),
+		L:              testutil.Logger(t),
+		TracerProvider: tp,
+	})
+
+	req := must.NotFail(RequestDoc(wirebson.MustDocument("ping", int32(1), "$db", "admin")))
+	resp := m.Handle(testutil.Ctx(t), req)
+	require.NotNil(t, resp)
+	assert.True(t, resp.OK())
+
+	var names []string
+	for _, s := range sr.Started() {
+		names = append(names, s.Name())
+	}
+
+	assert.Contains(t, names, "middleware.Handle")
+	assert.Contains(t, names, "middleware.Handle/documentdb")
+	assert.Contains(t, names, "tracingHandler.Handle")
+
+	assert.Equal(t, global, otel.GetTracerProvider(), "global tracer provider must not change")
+}
@@ -31,6 +31,25 @@
 	// DiffProxyMode both handles requests and proxies them, then logs the diff.
 	// Only the proxy response is sent to the client.
 	DiffProxyMode Mode = "diff-proxy"
+
+	// ShadowNormalMode handles requests and returns the FerretDB response to the client immediately.
+	// Some requests are also proxied in the background, then the diff is logged.
+	ShadowNormalMode Mode = "shadow-normal"
+
+	// ShadowProxyMode proxies requests and returns the proxy response to the client immediately.
+	// Some requests are also handled in the background, then the diff is logged.
+	ShadowProxyMode Mode = "shadow-proxy"
+
+	// RouteMode either handles or proxies each request depending on routing rules.
+	RouteMode Mode = "route"
+
+	// DualWriteNormalMode sends write requests to both FerretDB and the proxy, and other requests only to FerretDB.
+	// Only the FerretDB response is sent to the client.
+	DualWriteNormalMode Mode = "dual-write-normal"
+
+	// DualWriteProxyMode sends write requests to both FerretDB and the proxy, and other requests only to the proxy.
+	// Only the proxy response is sent to the client.
+	DualWriteProxyMode Mode = "dual-write-proxy"
 )
 
 // AllModes includes all operation modes, with the first one being the default.
@@ -39,4 +58,9 @@
 	string(ProxyMode),
 	string(DiffNormalMode),
 	string(DiffProxyMode),
+	string(ShadowNormalMode),
+	string(ShadowProxyMode),
+	string(RouteMode),
+	string(DualWriteNormalMode),
+	string(DualWriteProxyMode),
 }
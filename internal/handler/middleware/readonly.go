@@ -0,0 +1,88 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/FerretDB/wire/wirebson"
+
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+)
+
+// ReadOnlyChecker reports whether read-only mode is enabled and which commands modify data.
+//
+// It is implemented by the DocumentDB handler that knows all built-in and custom commands.
+// The middleware uses it to reject writes before dispatching them to any handler,
+// so read-only mode is enforced in all operation modes, including proxy ones.
+type ReadOnlyChecker interface {
+	// ReadOnly returns true if read-only mode is enabled.
+	ReadOnly() bool
+
+	// WriteCommand returns true if the given command modifies data, indexes, collections, or users.
+	WriteCommand(command string) bool
+}
+
+// checkReadOnly returns an error if the request modifies data and read-only mode is enabled.
+func (m *Middleware) checkReadOnly(req *Request) *mongoerrors.Error {
+	if m.opts.ReadOnly == nil || !m.opts.ReadOnly.ReadOnly() {
+		return nil
+	}
+
+	doc := req.Document()
+	command := doc.Command()
+
+	if !m.opts.ReadOnly.WriteCommand(command) && !(command == "aggregate" && pipelineWrites(doc)) {
+		return nil
+	}
+
+	return mongoerrors.New(
+		mongoerrors.ErrIllegalOperation,
+		fmt.Sprintf("Command %s is not allowed in read-only mode", command),
+	)
+}
+
+// pipelineWrites returns true if the aggregation pipeline contains `$out` or `$merge` stage.
+// Invalid pipelines are considered read-only; the handler returns a proper error for them.
+func pipelineWrites(doc *wirebson.Document) bool {
+	pipeline, ok := doc.Get("pipeline").(wirebson.AnyArray)
+	if !ok {
+		return false
+	}
+
+	arr, err := pipeline.Decode()
+	if err != nil {
+		return false
+	}
+
+	for v := range arr.Values() {
+		stageDoc, ok := v.(wirebson.AnyDocument)
+		if !ok {
+			continue
+		}
+
+		stage, err := stageDoc.Decode()
+		if err != nil {
+			continue
+		}
+
+		switch stage.Command() {
+		case "$out", "$merge":
+			return true
+		}
+	}
+
+	return false
+}
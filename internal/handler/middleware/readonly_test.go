@@ -0,0 +1,95 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+	"github.com/FerretDB/FerretDB/v2/internal/util/testutil"
+)
+
+// testReadOnly is a [ReadOnlyChecker] for tests.
+type testReadOnly struct {
+	readOnly atomic.Bool
+}
+
+// ReadOnly implements [ReadOnlyChecker].
+func (ro *testReadOnly) ReadOnly() bool {
+	return ro.readOnly.Load()
+}
+
+// WriteCommand implements [ReadOnlyChecker].
+func (ro *testReadOnly) WriteCommand(command string) bool {
+	return slices.Contains([]string{"insert", "drop"}, command)
+}
+
+func TestReadOnlyProxy(t *testing.T) {
+	t.Parallel()
+
+	proxy := &testHandler{
+		Collector: prometheus.NewRegistry(),
+		doc:       wirebson.MustDocument("ok", float64(1)),
+		handled:   make(chan *Request, 10),
+	}
+
+	ro := new(testReadOnly)
+
+	m := New(&NewOpts{
+		Mode:     ProxyMode,
+		Proxy:    proxy,
+		Metrics:  NewMetrics(),
+		L:        testutil.Logger(t),
+		ReadOnly: ro,
+	})
+
+	ctx := testutil.Ctx(t)
+
+	insert := must.NotFail(RequestDoc(wirebson.MustDocument("insert", "values", "$db", "test")))
+	find := must.NotFail(RequestDoc(wirebson.MustDocument("find", "values", "$db", "test")))
+	out := must.NotFail(RequestDoc(wirebson.MustDocument(
+		"aggregate", "values",
+		"pipeline", wirebson.MustArray(wirebson.MustDocument("$out", "other")),
+		"$db", "test",
+	)))
+
+	resp := m.Handle(ctx, insert)
+	require.NotNil(t, resp)
+	assert.True(t, resp.OK())
+	<-proxy.handled
+
+	ro.readOnly.Store(true)
+
+	for _, req := range []*Request{insert, out} {
+		resp = m.Handle(ctx, req)
+		require.NotNil(t, resp)
+		assert.Equal(t, mongoerrors.ErrIllegalOperation, resp.ErrorCode())
+	}
+
+	assert.Empty(t, proxy.handled)
+
+	resp = m.Handle(ctx, find)
+	require.NotNil(t, resp)
+	assert.True(t, resp.OK())
+	<-proxy.handled
+}
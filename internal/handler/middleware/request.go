@@ -15,6 +15,8 @@
 package middleware
 
 import (
+	"bytes"
+	"encoding/binary"
 	"fmt"
 	"sync/atomic"
 
@@ -149,3 +151,178 @@ func (req *Request) DocumentDeep() (*wirebson.Document, error) {
 	// we might want to cache it in the future if there are many callers
 	return req.DocumentRaw().DecodeDeep()
 }
+
+// DocumentSequences returns deeply decoded documents from OpMsg document sequences (sections of kind 1)
+// keyed by sequence identifiers (like `documents` for `insert` or `ops` and `nsInfo` for `bulkWrite`).
+// The result is empty for requests without document sequences.
+func (req *Request) DocumentSequences() (*wirebson.Document, error) {
+	res := wirebson.MakeDocument(0)
+
+	msg, ok := req.body.(*wire.OpMsg)
+	if !ok {
+		return res, nil
+	}
+
+	// OpMsg does not expose sequence identifiers, so sections are parsed from the wire representation
+	b, err := msg.MarshalBinary()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	if msg.Flags.FlagSet(wire.OpMsgChecksumPresent) {
+		b = b[:len(b)-4]
+	}
+
+	b = b[4:]
+
+	for len(b) > 0 {
+		kind := b[0]
+		b = b[1:]
+
+		if len(b) < 4 {
+			return nil, lazyerrors.Errorf("unexpected section length %d", len(b))
+		}
+
+		l := int(binary.LittleEndian.Uint32(b))
+		if l < 5 || l > len(b) {
+			return nil, lazyerrors.Errorf("unexpected section size %d", l)
+		}
+
+		section := b[:l]
+		b = b[l:]
+
+		if kind != 1 {
+			continue
+		}
+
+		i := bytes.IndexByte(section[4:], 0)
+		if i < 0 {
+			return nil, lazyerrors.New("unterminated sequence identifier")
+		}
+
+		identifier := string(section[4 : 4+i])
+
+		var docs *wirebson.Array
+		if docs, err = decodeSequence(section[4+i+1:]); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		if err = res.Add(identifier, docs); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+	}
+
+	return res, nil
+}
+
+// decodeSequence returns deeply decoded documents from a single OpMsg document sequence.
+func decodeSequence(seq []byte) (*wirebson.Array, error) {
+	res := wirebson.MakeArray(0)
+
+	for len(seq) > 0 {
+		l, err := wirebson.FindRaw(seq)
+		if err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		var doc *wirebson.Document
+		if doc, err = wirebson.RawDocument(seq[:l]).DecodeDeep(); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		if err = res.Add(doc); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		seq = seq[l:]
+	}
+
+	return res, nil
+}
+
+// DocumentMerged returns the deeply decoded request document
+// with OpMsg document sequences (sections of kind 1) added as array fields
+// named after sequence identifiers.
+// Unlike [Request.DocumentDeep], it contains all documents of the request.
+func (req *Request) DocumentMerged() (*wirebson.Document, error) {
+	doc, err := req.DocumentDeep()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	seqs, err := req.DocumentSequences()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	for identifier, docs := range seqs.All() {
+		if err = doc.Add(identifier, docs); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+	}
+
+	return doc, nil
+}
+
+// WithDocument returns a new request with the given document
+// and the same request ID, opcode, and flags.
+// Error is returned if the document cannot be decoded.
+//
+// For OpMsg, document sequences are not preserved;
+// the given document should contain them as array fields (see [Request.DocumentMerged]).
+// For OpQuery, the return fields selector is not preserved.
+func (req *Request) WithDocument(doc wirebson.AnyDocument) (*Request, error) {
+	must.NotBeZero(doc)
+
+	raw, err := doc.Encode()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	var body wire.MsgBody
+
+	switch b := req.body.(type) {
+	case *wire.OpMsg:
+		var msg *wire.OpMsg
+		if msg, err = wire.NewOpMsg(raw); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		msg.Flags = b.Flags &^ wire.OpMsgFlags(wire.OpMsgChecksumPresent)
+		body = msg
+
+	case *wire.OpQuery:
+		var query *wire.OpQuery
+		if query, err = wire.NewOpQuery(raw); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		query.FullCollectionName = b.FullCollectionName
+		query.Flags = b.Flags
+		query.NumberToSkip = b.NumberToSkip
+		query.NumberToReturn = b.NumberToReturn
+		body = query
+
+	default:
+		return nil, lazyerrors.Errorf("unsupported body type %T", req.body)
+	}
+
+	header := &wire.MsgHeader{
+		MessageLength: int32(wire.MsgHeaderLen + body.Size()),
+		RequestID:     req.header.RequestID,
+		OpCode:        req.header.OpCode,
+	}
+
+	d, err := doc.Decode()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	d.Freeze()
+
+	return &Request{
+		header: header,
+		body:   body,
+		doc:    d,
+	}, nil
+}
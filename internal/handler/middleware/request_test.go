@@ -0,0 +1,284 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/FerretDB/wire"
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+)
+
+// testSequence represents OP_MSG document sequence (section of kind 1) for tests.
+type testSequence struct {
+	identifier string
+	docs       []*wirebson.Document
+}
+
+// requestWithSequences returns OP_MSG request with the given section 0 document
+// and document sequences.
+func requestWithSequences(t *testing.T, doc *wirebson.Document, seqs ...testSequence) *Request {
+	t.Helper()
+
+	var b bytes.Buffer
+	b.Write([]byte{0, 0, 0, 0}) // flags
+	b.WriteByte(0)
+	b.Write(must.NotFail(doc.Encode()))
+
+	for _, s := range seqs {
+		var seq []byte
+		for _, d := range s.docs {
+			seq = append(seq, must.NotFail(d.Encode())...)
+		}
+
+		b.WriteByte(1)
+		b.Write(binary.LittleEndian.AppendUint32(nil, uint32(4+len(s.identifier)+1+len(seq))))
+		b.WriteString(s.identifier)
+		b.WriteByte(0)
+		b.Write(seq)
+	}
+
+	var msg wire.OpMsg
+	require.NoError(t, msg.UnmarshalBinaryNocopy(b.Bytes()))
+
+	header := &wire.MsgHeader{
+		MessageLength: int32(wire.MsgHeaderLen + b.Len()),
+		RequestID:     lastRequestID.Add(1),
+		OpCode:        wire.OpCodeMsg,
+	}
+
+	return must.NotFail(RequestWire(header, &msg))
+}
+
+// requestWithSequence returns OP_MSG request with the given section 0 document
+// and a single document sequence.
+func requestWithSequence(t *testing.T, doc *wirebson.Document, identifier string, docs ...*wirebson.Document) *Request {
+	t.Helper()
+
+	return requestWithSequences(t, doc, testSequence{identifier: identifier, docs: docs})
+}
+
+func TestRequestDocumentMerged(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		req      *Request
+		expected *wirebson.Document
+	}{
+		"Insert": {
+			req: requestWithSequence(
+				t,
+				wirebson.MustDocument("insert", "values", "$db", "test"),
+				"documents",
+				wirebson.MustDocument("_id", int32(1)),
+				wirebson.MustDocument("_id", int32(2)),
+			),
+			expected: wirebson.MustDocument(
+				"insert", "values",
+				"$db", "test",
+				"documents", wirebson.MustArray(
+					wirebson.MustDocument("_id", int32(1)),
+					wirebson.MustDocument("_id", int32(2)),
+				),
+			),
+		},
+		"BulkWrite": {
+			req: requestWithSequences(
+				t,
+				wirebson.MustDocument("bulkWrite", int32(1), "$db", "admin"),
+				testSequence{
+					identifier: "ops",
+					docs: []*wirebson.Document{
+						wirebson.MustDocument("insert", int32(0), "document", wirebson.MustDocument("_id", int32(1))),
+					},
+				},
+				testSequence{
+					identifier: "nsInfo",
+					docs: []*wirebson.Document{
+						wirebson.MustDocument("ns", "test.values"),
+					},
+				},
+			),
+			expected: wirebson.MustDocument(
+				"bulkWrite", int32(1),
+				"$db", "admin",
+				"ops", wirebson.MustArray(
+					wirebson.MustDocument("insert", int32(0), "document", wirebson.MustDocument("_id", int32(1))),
+				),
+				"nsInfo", wirebson.MustArray(
+					wirebson.MustDocument("ns", "test.values"),
+				),
+			),
+		},
+		"NoSequences": {
+			req:      must.NotFail(RequestDoc(wirebson.MustDocument("find", "values", "$db", "test"))),
+			expected: wirebson.MustDocument("find", "values", "$db", "test"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			doc, err := tc.req.DocumentMerged()
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, doc)
+
+			r, err := tc.req.WithDocument(doc)
+			require.NoError(t, err)
+			assert.Equal(t, tc.req.WireHeader().RequestID, r.WireHeader().RequestID)
+
+			actual, err := r.DocumentMerged()
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestRequestWithDocumentQuery(t *testing.T) {
+	t.Parallel()
+
+	query := wire.MustOpQuery("isMaster", int32(1))
+	query.FullCollectionName = "admin.$cmd"
+	query.NumberToReturn = -1
+
+	header := &wire.MsgHeader{
+		MessageLength: int32(wire.MsgHeaderLen + query.Size()),
+		RequestID:     lastRequestID.Add(1),
+		OpCode:        wire.OpCodeQuery,
+	}
+
+	req := must.NotFail(RequestWire(header, query))
+
+	r, err := req.WithDocument(wirebson.MustDocument("isMaster", int32(1), "comment", "replaced"))
+	require.NoError(t, err)
+	assert.Equal(t, req.WireHeader().RequestID, r.WireHeader().RequestID)
+	assert.Equal(t, wire.OpCodeQuery, r.WireHeader().OpCode)
+
+	q, ok := r.WireBody().(*wire.OpQuery)
+	require.True(t, ok)
+	assert.Equal(t, "admin.$cmd", q.FullCollectionName)
+	assert.Equal(t, int32(-1), q.NumberToReturn)
+
+	doc, err := r.DocumentMerged()
+	require.NoError(t, err)
+	assert.Equal(t, wirebson.MustDocument("isMaster", int32(1), "comment", "replaced"), doc)
+}
+
+func TestRequestDocumentSequences(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		doc      *wirebson.Document
+		seqs     []testSequence
+		expected *wirebson.Document
+	}{
+		"Insert": {
+			doc: wirebson.MustDocument("insert", "values", "$db", "test"),
+			seqs: []testSequence{{
+				identifier: "documents",
+				docs: []*wirebson.Document{
+					wirebson.MustDocument("_id", int32(1)),
+					wirebson.MustDocument("_id", int32(2)),
+				},
+			}},
+			expected: wirebson.MustDocument(
+				"documents", wirebson.MustArray(
+					wirebson.MustDocument("_id", int32(1)),
+					wirebson.MustDocument("_id", int32(2)),
+				),
+			),
+		},
+		"Update": {
+			doc: wirebson.MustDocument("update", "values", "$db", "test"),
+			seqs: []testSequence{{
+				identifier: "updates",
+				docs: []*wirebson.Document{
+					wirebson.MustDocument(
+						"q", wirebson.MustDocument("_id", int32(1)),
+						"u", wirebson.MustDocument("$set", wirebson.MustDocument("v", int32(2))),
+					),
+				},
+			}},
+			expected: wirebson.MustDocument(
+				"updates", wirebson.MustArray(
+					wirebson.MustDocument(
+						"q", wirebson.MustDocument("_id", int32(1)),
+						"u", wirebson.MustDocument("$set", wirebson.MustDocument("v", int32(2))),
+					),
+				),
+			),
+		},
+		"Delete": {
+			doc: wirebson.MustDocument("delete", "values", "$db", "test"),
+			seqs: []testSequence{{
+				identifier: "deletes",
+				docs: []*wirebson.Document{
+					wirebson.MustDocument("q", wirebson.MustDocument("_id", int32(1)), "limit", int32(1)),
+				},
+			}},
+			expected: wirebson.MustDocument(
+				"deletes", wirebson.MustArray(
+					wirebson.MustDocument("q", wirebson.MustDocument("_id", int32(1)), "limit", int32(1)),
+				),
+			),
+		},
+		"BulkWrite": {
+			doc: wirebson.MustDocument("bulkWrite", int32(1), "$db", "admin"),
+			seqs: []testSequence{
+				{
+					identifier: "ops",
+					docs: []*wirebson.Document{
+						wirebson.MustDocument("insert", int32(0), "document", wirebson.MustDocument("_id", int32(1))),
+						wirebson.MustDocument("delete", int32(0), "filter", wirebson.MustDocument("_id", int32(2))),
+					},
+				},
+				{
+					identifier: "nsInfo",
+					docs: []*wirebson.Document{
+						wirebson.MustDocument("ns", "test.values"),
+					},
+				},
+			},
+			expected: wirebson.MustDocument(
+				"ops", wirebson.MustArray(
+					wirebson.MustDocument("insert", int32(0), "document", wirebson.MustDocument("_id", int32(1))),
+					wirebson.MustDocument("delete", int32(0), "filter", wirebson.MustDocument("_id", int32(2))),
+				),
+				"nsInfo", wirebson.MustArray(
+					wirebson.MustDocument("ns", "test.values"),
+				),
+			),
+		},
+		"NoSequences": {
+			doc:      wirebson.MustDocument("find", "values", "$db", "test"),
+			expected: wirebson.MustDocument(),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := requestWithSequences(t, tc.doc, tc.seqs...)
+
+			actual, err := req.DocumentSequences()
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"slices"
+	"sync/atomic"
+
+	"github.com/AlekSi/lazyerrors"
+
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+)
+
+// Route represents a handler that serves the request in [RouteMode].
+type Route string
+
+const (
+	// RouteDocumentDB routes requests to the DocumentDB handler.
+	RouteDocumentDB Route = "documentdb"
+
+	// RouteProxy routes requests to the proxy handler.
+	RouteProxy Route = "proxy"
+)
+
+// defaultRuleName is the name of the rule used when no other rule matches.
+const defaultRuleName = "default"
+
+// RouteRule represents a single routing rule.
+//
+// Database, Collection, and Command are [path.Match] patterns;
+// empty pattern matches any value.
+// All non-empty patterns must match for the rule to match.
+type RouteRule struct {
+	Name       string `json:"name"` // used in metrics and logs
+	Database   string `json:"database"`
+	Collection string `json:"collection"`
+	Command    string `json:"command"`
+	Route      Route  `json:"route"`
+}
+
+// RouteRules represents routing configuration.
+type RouteRules struct {
+	// Default is the route for requests that do not match any rule,
+	// including requests without a database or collection (like handshake and authentication).
+	Default Route `json:"default"`
+
+	// Rules are checked in order; the first matching rule wins.
+	Rules []RouteRule `json:"rules"`
+}
+
+// validate checks routing configuration and sets default values.
+func (rr *RouteRules) validate() error {
+	if rr.Default == "" {
+		rr.Default = RouteDocumentDB
+	}
+
+	if err := checkRoute(rr.Default); err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	for i := range rr.Rules {
+		r := &rr.Rules[i]
+
+		if r.Name == "" {
+			r.Name = fmt.Sprintf("rule%d", i)
+		}
+
+		if r.Name == defaultRuleName {
+			return lazyerrors.Errorf("rule %d: name %q is reserved", i, defaultRuleName)
+		}
+
+		if err := checkRoute(r.Route); err != nil {
+			return lazyerrors.Errorf("rule %q: %w", r.Name, err)
+		}
+
+		for _, p := range []string{r.Database, r.Collection, r.Command} {
+			if _, err := path.Match(p, ""); err != nil {
+				return lazyerrors.Errorf("rule %q: invalid pattern %q: %w", r.Name, p, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkRoute returns an error if the route is not valid.
+func checkRoute(r Route) error {
+	switch r {
+	case RouteDocumentDB, RouteProxy:
+		return nil
+	default:
+		return fmt.Errorf("invalid route %q", r)
+	}
+}
+
+// match returns true if the rule matches the given request parameters.
+func (r *RouteRule) match(database, collection, command string) bool {
+	for _, pv := range [][2]string{
+		{r.Database, database},
+		{r.Collection, collection},
+		{r.Command, command},
+	} {
+		if pv[0] == "" {
+			continue
+		}
+
+		// patterns are validated already
+		if ok, _ := path.Match(pv[0], pv[1]); !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Router selects a route for each request using rules that can be reloaded at runtime.
+type Router struct {
+	path  string
+	l     *slog.Logger
+	rules atomic.Pointer[RouteRules]
+}
+
+// NewRouter creates a new router with rules loaded from the JSON file with the given path.
+func NewRouter(path string, l *slog.Logger) (*Router, error) {
+	must.NotBeZero(path)
+	must.NotBeZero(l)
+
+	r := &Router{
+		path: path,
+		l:    l,
+	}
+
+	if err := r.Reload(); err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return r, nil
+}
+
+// Reload loads rules from the file again.
+// If it fails, the previous rules are kept.
+func (r *Router) Reload() error {
+	b, err := os.ReadFile(r.path)
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	var rules RouteRules
+
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.DisallowUnknownFields()
+
+	if err = d.Decode(&rules); err != nil {
+		return lazyerrors.Errorf("%s: %w", r.path, err)
+	}
+
+	if err = rules.validate(); err != nil {
+		return lazyerrors.Errorf("%s: %w", r.path, err)
+	}
+
+	r.rules.Store(&rules)
+
+	r.l.Info(
+		"Routing rules loaded",
+		slog.String("path", r.path), slog.String("default", string(rules.Default)), slog.Int("rules", len(rules.Rules)),
+	)
+
+	return nil
+}
+
+// Rules returns the current routing rules.
+// The returned value must not be modified.
+func (r *Router) Rules() *RouteRules {
+	return r.rules.Load()
+}
+
+// Route returns the route and the name of the matched rule for the given request.
+func (r *Router) Route(req *Request) (Route, string) {
+	doc := req.Document()
+	command := doc.Command()
+	database, _ := doc.Get("$db").(string)
+
+	var collection string
+
+	switch command {
+	case "getMore":
+		collection, _ = doc.Get("collection").(string)
+	default:
+		collection, _ = doc.Get(command).(string)
+	}
+
+	rules := r.rules.Load()
+
+	// handshake, authentication, and commands without namespace are always routed to the default route
+	if database != "" && !slices.Contains(connectionCommands, command) {
+		for i := range rules.Rules {
+			if rule := &rules.Rules[i]; rule.match(database, collection, command) {
+				return rule.Route, rule.Name
+			}
+		}
+	}
+
+	return rules.Default, defaultRuleName
+}
@@ -0,0 +1,118 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+	"github.com/FerretDB/FerretDB/v2/internal/util/testutil"
+)
+
+func TestRouter(t *testing.T) {
+	t.Parallel()
+
+	p := filepath.Join(t.TempDir(), "routes.json")
+
+	require.NoError(t, os.WriteFile(p, []byte(`{
+		"default": "documentdb",
+		"rules": [
+			{"name": "legacy", "database": "legacy", "route": "proxy"},
+			{"database": "app", "collection": "events_*", "route": "proxy"},
+			{"command": "mapReduce", "route": "proxy"}
+		]
+	}`), 0o666))
+
+	r, err := NewRouter(p, testutil.Logger(t))
+	require.NoError(t, err)
+
+	for name, tc := range map[string]struct {
+		req   *wirebson.Document
+		route Route
+		rule  string
+	}{
+		"Database": {
+			req:   wirebson.MustDocument("find", "users", "$db", "legacy"),
+			route: RouteProxy,
+			rule:  "legacy",
+		},
+		"Collection": {
+			req:   wirebson.MustDocument("insert", "events_2025", "$db", "app"),
+			route: RouteProxy,
+			rule:  "rule1",
+		},
+		"GetMore": {
+			req:   wirebson.MustDocument("getMore", int64(1), "collection", "events_2025", "$db", "app"),
+			route: RouteProxy,
+			rule:  "rule1",
+		},
+		"CollectionNoMatch": {
+			req:   wirebson.MustDocument("insert", "users", "$db", "app"),
+			route: RouteDocumentDB,
+			rule:  "default",
+		},
+		"Command": {
+			req:   wirebson.MustDocument("mapReduce", "users", "$db", "app"),
+			route: RouteProxy,
+			rule:  "rule2",
+		},
+		"Authentication": {
+			req:   wirebson.MustDocument("saslStart", int32(1), "$db", "legacy"),
+			route: RouteDocumentDB,
+			rule:  "default",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			route, rule := r.Route(must.NotFail(RequestDoc(tc.req)))
+			assert.Equal(t, tc.route, route)
+			assert.Equal(t, tc.rule, rule)
+		})
+	}
+
+	t.Run("Reload", func(t *testing.T) {
+		t.Parallel()
+
+		p := filepath.Join(t.TempDir(), "routes.json")
+		require.NoError(t, os.WriteFile(p, []byte(`{"default": "proxy"}`), 0o666))
+
+		r, err := NewRouter(p, testutil.Logger(t))
+		require.NoError(t, err)
+
+		req := must.NotFail(RequestDoc(wirebson.MustDocument("find", "users", "$db", "app")))
+
+		route, _ := r.Route(req)
+		assert.Equal(t, RouteProxy, route)
+
+		require.NoError(t, os.WriteFile(p, []byte(`{"rules": [{"route": "invalid"}]}`), 0o666))
+		require.Error(t, r.Reload())
+
+		route, _ = r.Route(req)
+		assert.Equal(t, RouteProxy, route, "previous rules should be kept")
+
+		require.NoError(t, os.WriteFile(p, []byte(`{"default": "documentdb"}`), 0o666))
+		require.NoError(t, r.Reload())
+
+		route, _ = r.Route(req)
+		assert.Equal(t, RouteDocumentDB, route)
+	})
+}
@@ -0,0 +1,274 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"context"
+	"hash/maphash"
+	"log/slog"
+	"math/rand/v2"
+	"slices"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/FerretDB/FerretDB/v2/internal/clientconn/conninfo"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+)
+
+// Defaults for [ShadowOpts].
+const (
+	DefaultShadowQueueSize  = 1000
+	DefaultShadowSampleRate = 1.0
+)
+
+// shadowWorkers is the number of goroutines sending requests to the secondary handler.
+// Requests of the same client connection are always handled by the same worker in order.
+const shadowWorkers = 8
+
+// Shadow results used as metric label values.
+const (
+	shadowHandled      = "handled"
+	shadowSkipped      = "skipped"
+	shadowQueueFull    = "queue_full"
+	shadowDisconnected = "disconnected"
+	shadowDesynced     = "desynced"
+)
+
+// shadowStateCommands contains commands that change the connection or session state.
+//
+// They are always sent to the secondary handler.
+// If one of them is dropped, the secondary connection state might differ from the primary one,
+// so further requests of that client connection are not sent.
+var shadowStateCommands = append(slices.Clone(connectionCommands),
+	"endSessions",
+	"killAllSessions",
+	"killAllSessionsByPattern",
+	"killSessions",
+	"refreshSessions",
+	"startSession",
+)
+
+// ShadowOpts represents shadow modes configuration.
+type ShadowOpts struct {
+	// QueueSize is the maximum number of requests waiting to be sent to the secondary handler.
+	// Requests that do not fit are dropped; see [shadowStateCommands].
+	// Zero value means [DefaultShadowQueueSize].
+	QueueSize int
+
+	// SampleRate is a fraction of requests in the (0, 1] range that are sent to the secondary handler.
+	// Zero value means [DefaultShadowSampleRate].
+	SampleRate float64
+
+	// Commands, if not empty, limits requests sent to the secondary handler to the given commands.
+	Commands []string
+
+	// Namespaces, if not empty, limits requests sent to the secondary handler to the given
+	// databases (e.g. "db") and collections (e.g. "db.coll").
+	Namespaces []string
+}
+
+// shadowTask represents a single request to be sent to the secondary handler.
+type shadowTask struct {
+	ctx     context.Context
+	req     *Request
+	primary *Response
+}
+
+// shadow sends requests to the secondary handler in the background.
+type shadow struct {
+	opts   *ShadowOpts
+	seed   maphash.Seed
+	queues []chan *shadowTask
+}
+
+// newShadow creates a new shadow for the given options.
+func newShadow(opts *ShadowOpts) *shadow {
+	if opts == nil {
+		opts = new(ShadowOpts)
+	}
+
+	res := &shadow{
+		opts: &ShadowOpts{
+			QueueSize:  opts.QueueSize,
+			SampleRate: opts.SampleRate,
+			Commands:   opts.Commands,
+			Namespaces: opts.Namespaces,
+		},
+		seed:   maphash.MakeSeed(),
+		queues: make([]chan *shadowTask, shadowWorkers),
+	}
+
+	if res.opts.QueueSize == 0 {
+		res.opts.QueueSize = DefaultShadowQueueSize
+	}
+
+	if res.opts.SampleRate == 0 {
+		res.opts.SampleRate = DefaultShadowSampleRate
+	}
+
+	must.BeTrue(res.opts.QueueSize > 0)
+	must.BeTrue(res.opts.SampleRate > 0 && res.opts.SampleRate <= 1)
+
+	size := max(res.opts.QueueSize/shadowWorkers, 1)
+	for i := range res.queues {
+		res.queues[i] = make(chan *shadowTask, size)
+	}
+
+	return res
+}
+
+// selected returns true if the request should be sent to the secondary handler.
+func (s *shadow) selected(req *Request) bool {
+	command := req.Document().Command()
+
+	// they are always sent, so the secondary handler sees the same connection state
+	if slices.Contains(shadowStateCommands, command) {
+		return true
+	}
+
+	if len(s.opts.Commands) > 0 && !slices.Contains(s.opts.Commands, command) {
+		return false
+	}
+
+	if len(s.opts.Namespaces) > 0 {
+		database, _ := req.Document().Get("$db").(string)
+		collection, _ := req.Document().Get(command).(string)
+
+		if !slices.Contains(s.opts.Namespaces, database) && !slices.Contains(s.opts.Namespaces, database+"."+collection) {
+			return false
+		}
+	}
+
+	return s.opts.SampleRate >= 1 || rand.Float64() < s.opts.SampleRate
+}
+
+// enqueue adds the request to the queue of the worker responsible for the client connection.
+// It returns false if the queue is full.
+func (s *shadow) enqueue(t *shadowTask) bool {
+	i := maphash.Comparable(s.seed, conninfo.Get(t.ctx)) % uint64(len(s.queues))
+
+	select {
+	case s.queues[i] <- t:
+		return true
+	default:
+		return false
+	}
+}
+
+// shadow sends the request to the secondary handler in the background.
+// It never blocks.
+func (m *Middleware) shadow(ctx context.Context, req *Request, primary *Response) {
+	command := req.Document().Command()
+	ci := conninfo.Get(ctx)
+
+	result := shadowQueueFull
+
+	switch {
+	case primary == nil:
+		// unrecoverable error in the primary handler; the connection is going to be closed
+		result = shadowSkipped
+
+	case ci.ShadowDesynced():
+		result = shadowDesynced
+
+	case !m.s.selected(req):
+		result = shadowSkipped
+
+	case m.s.enqueue(&shadowTask{ctx: ctx, req: req, primary: primary}):
+		// counted by the worker
+		return
+
+	case slices.Contains(shadowStateCommands, command):
+		ci.SetShadowDesynced()
+
+		m.opts.L.WarnContext(
+			ctx, "Shadow queue is full, dropping connection state request; further requests of that connection are dropped",
+			slog.String("command", command),
+		)
+
+	default:
+		m.opts.L.DebugContext(ctx, "Shadow queue is full, dropping request", slog.String("command", command))
+	}
+
+	m.opts.Metrics.shadow.With(prometheus.Labels{
+		"command": command,
+		"result":  result,
+	}).Inc()
+}
+
+// runShadow runs shadow workers until ctx is canceled.
+// Requests left in queues are dropped.
+func (m *Middleware) runShadow(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	for _, q := range m.s.queues {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case t := <-q:
+					m.handleShadow(t)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+// handleShadow sends the request to the secondary handler and compares responses.
+func (m *Middleware) handleShadow(t *shadowTask) {
+	command := t.req.Document().Command()
+
+	result := shadowHandled
+
+	defer func() {
+		m.opts.Metrics.shadow.With(prometheus.Labels{
+			"command": command,
+			"result":  result,
+		}).Inc()
+	}()
+
+	// the client already disconnected; handlers would just return an error
+	if t.ctx.Err() != nil {
+		result = shadowDisconnected
+		return
+	}
+
+	var docdb, proxy *Response
+
+	switch m.opts.Mode {
+	case ShadowNormalMode:
+		docdb = t.primary
+		proxy = m.dispatchProxy(t.ctx, t.req)
+	case ShadowProxyMode:
+		docdb = m.dispatchDocDB(t.ctx, t.req)
+		proxy = t.primary
+	case NormalMode, ProxyMode, DiffNormalMode, DiffProxyMode, RouteMode, DualWriteNormalMode, DualWriteProxyMode:
+		fallthrough
+	default:
+		panic("not reached")
+	}
+
+	if differ, reported := m.compareResponses(t.ctx, t.req, docdb, proxy); differ && !reported {
+		m.logDiff(t.ctx, docdb, proxy)
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FerretDB/FerretDB/v2/internal/clientconn/conninfo"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+	ftestutil "github.com/FerretDB/FerretDB/v2/internal/util/testutil"
+)
+
+// testHandler is a [Handler] that responds with the given document after an optional delay.
+type testHandler struct {
+	prometheus.Collector
+	doc     *wirebson.Document
+	delay   time.Duration
+	handled chan *Request
+}
+
+// Run implements [Handler].
+func (h *testHandler) Run(ctx context.Context) {
+	<-ctx.Done()
+}
+
+// Handle implements [Handler].
+func (h *testHandler) Handle(ctx context.Context, req *Request) (*Response, error) {
+	time.Sleep(h.delay)
+
+	if h.handled != nil {
+		h.handled <- req
+	}
+
+	return ResponseDoc(req, h.doc)
+}
+
+func TestShadowSelected(t *testing.T) {
+	t.Parallel()
+
+	find := must.NotFail(RequestDoc(wirebson.MustDocument("find", "values", "$db", "test")))
+	insert := must.NotFail(RequestDoc(wirebson.MustDocument("insert", "other", "$db", "test")))
+	hello := must.NotFail(RequestDoc(wirebson.MustDocument("hello", int32(1), "$db", "admin")))
+
+	s := newShadow(nil)
+	assert.True(t, s.selected(find))
+	assert.True(t, s.selected(insert))
+
+	s = newShadow(&ShadowOpts{Commands: []string{"find"}})
+	assert.True(t, s.selected(find))
+	assert.False(t, s.selected(insert))
+	assert.True(t, s.selected(hello))
+
+	s = newShadow(&ShadowOpts{Namespaces: []string{"test.values"}})
+	assert.True(t, s.selected(find))
+	assert.False(t, s.selected(insert))
+
+	s = newShadow(&ShadowOpts{Namespaces: []string{"test"}})
+	assert.True(t, s.selected(find))
+	assert.True(t, s.selected(insert))
+
+	s = newShadow(&ShadowOpts{SampleRate: 0.000001})
+	assert.False(t, s.selected(find))
+	assert.True(t, s.selected(hello))
+}
+
+func TestShadowDesynced(t *testing.T) {
+	t.Parallel()
+
+	m := New(&NewOpts{
+		Mode: ShadowNormalMode,
+		DocDB: &testHandler{
+			Collector: prometheus.NewRegistry(),
+			doc:       wirebson.MustDocument("ok", float64(1)),
+		},
+		Proxy: &testHandler{
+			Collector: prometheus.NewRegistry(),
+			doc:       wirebson.MustDocument("ok", float64(1)),
+		},
+		Shadow:  &ShadowOpts{QueueSize: 1},
+		Metrics: NewMetrics(),
+		L:       ftestutil.Logger(t),
+	})
+
+	ci := conninfo.New()
+	defer ci.Close()
+
+	ctx := conninfo.Ctx(ftestutil.Ctx(t), ci)
+
+	// workers are not running, so only the first request fits into the queue
+	for _, doc := range []*wirebson.Document{
+		wirebson.MustDocument("find", "values", "$db", "test"),
+		wirebson.MustDocument("find", "values", "$db", "test"),
+		wirebson.MustDocument("saslStart", int32(1), "$db", "admin"),
+		wirebson.MustDocument("saslContinue", int32(1), "$db", "admin"),
+		wirebson.MustDocument("find", "values", "$db", "test"),
+	} {
+		req := must.NotFail(RequestDoc(doc))
+		m.shadow(ctx, req, must.NotFail(ResponseDoc(req, wirebson.MustDocument("ok", float64(1)))))
+	}
+
+	assert.True(t, ci.ShadowDesynced())
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.opts.Metrics.shadow.WithLabelValues("find", shadowQueueFull)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.opts.Metrics.shadow.WithLabelValues("saslStart", shadowQueueFull)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.opts.Metrics.shadow.WithLabelValues("saslContinue", shadowDesynced)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.opts.Metrics.shadow.WithLabelValues("find", shadowDesynced)))
+}
+
+func TestShadowMode(t *testing.T) {
+	t.Parallel()
+
+	const delay = time.Second
+
+	proxy := &testHandler{
+		Collector: prometheus.NewRegistry(),
+		doc:       wirebson.MustDocument("n", int32(2), "ok", float64(1)),
+		delay:     delay,
+		handled:   make(chan *Request, 1),
+	}
+
+	m := New(&NewOpts{
+		Mode: ShadowNormalMode,
+		DocDB: &testHandler{
+			Collector: prometheus.NewRegistry(),
+			doc:       wirebson.MustDocument("n", int32(1), "ok", float64(1)),
+		},
+		Proxy:   proxy,
+		Metrics: NewMetrics(),
+		L:       ftestutil.Logger(t),
+	})
+
+	ctx, cancel := context.WithCancel(ftestutil.Ctx(t))
+	done := make(chan struct{})
+
+	go func() {
+		m.Run(ctx)
+		close(done)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	req := must.NotFail(RequestDoc(wirebson.MustDocument("count", "values", "$db", "test")))
+
+	ci := conninfo.New()
+	defer ci.Close()
+
+	start := time.Now()
+	resp := m.Handle(conninfo.Ctx(ctx, ci), req)
+	require.NotNil(t, resp)
+	assert.Less(t, time.Since(start), delay, "primary response should not wait for the secondary")
+	assert.Equal(t, int32(1), resp.Document().Get("n"))
+
+	<-proxy.handled
+
+	assert.Eventually(t, func() bool {
+		return testutil.ToFloat64(m.opts.Metrics.diffs.WithLabelValues("count", diffDiff)) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.opts.Metrics.shadow.WithLabelValues("count", shadowHandled)))
+}
@@ -18,6 +18,7 @@
 	"context"
 
 	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
 
 	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
 )
@@ -47,3 +48,145 @@ func (h *Handler) msgAggregate(connCtx context.Context, req *middleware.Request)
 
 	return middleware.ResponseDoc(req, page)
 }
+
+// pipelineWrites returns true if the aggregation pipeline contains `$out` or `$merge` stage.
+func pipelineWrites(doc *wirebson.Document) (bool, error) {
+	pipelineArr, ok := doc.Get("pipeline").(wirebson.AnyArray)
+	if !ok {
+		// let DocumentDB return a proper error
+		return false, nil
+	}
+
+	pipeline, err := pipelineArr.Decode()
+	if err != nil {
+		return false, lazyerrors.Error(err)
+	}
+
+	for v := range pipeline.Values() {
+		stageDoc, ok := v.(wirebson.AnyDocument)
+		if !ok {
+			continue
+		}
+
+		var stage *wirebson.Document
+		if stage, err = stageDoc.Decode(); err != nil {
+			return false, lazyerrors.Error(err)
+		}
+
+		switch stage.Command() {
+		case "$out", "$merge":
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// pipelineDatabases returns databases that are explicitly referenced
+// by the aggregation pipeline stages, including nested pipelines.
+//
+// For example, it returns "other" for `{$lookup: {from: {db: "other", coll: "coll"}}}`.
+func pipelineDatabases(pipelineV any) ([]string, error) {
+	pipelineArr, ok := pipelineV.(wirebson.AnyArray)
+	if !ok {
+		// let DocumentDB return a proper error
+		return nil, nil
+	}
+
+	pipeline, err := pipelineArr.Decode()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	var res []string
+
+	for v := range pipeline.Values() {
+		var stage *wirebson.Document
+		if stage, err = decodeOptionalDocument(v); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		if stage == nil {
+			continue
+		}
+
+		command := stage.Command()
+
+		var spec *wirebson.Document
+		if spec, err = decodeOptionalDocument(stage.Get(command)); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		if spec == nil {
+			// collection name in the current database
+			continue
+		}
+
+		var nested []any
+
+		switch command {
+		case "$out":
+			nested = append(nested, spec)
+
+		case "$merge":
+			nested = append(nested, spec.Get("into"))
+
+		case "$lookup", "$unionWith":
+			if dbName, _ := spec.Get("db").(string); dbName != "" {
+				res = append(res, dbName)
+			}
+
+			nested = append(nested, spec.Get("from"), spec.Get("coll"))
+
+			var dbs []string
+			if dbs, err = pipelineDatabases(spec.Get("pipeline")); err != nil {
+				return nil, lazyerrors.Error(err)
+			}
+
+			res = append(res, dbs...)
+
+		case "$facet":
+			for _, v := range spec.All() {
+				var dbs []string
+				if dbs, err = pipelineDatabases(v); err != nil {
+					return nil, lazyerrors.Error(err)
+				}
+
+				res = append(res, dbs...)
+			}
+		}
+
+		for _, v := range nested {
+			var ns *wirebson.Document
+			if ns, err = decodeOptionalDocument(v); err != nil {
+				return nil, lazyerrors.Error(err)
+			}
+
+			if ns == nil {
+				continue
+			}
+
+			if dbName, _ := ns.Get("db").(string); dbName != "" {
+				res = append(res, dbName)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// decodeOptionalDocument decodes the value if it is a document.
+// It returns nil for other values.
+func decodeOptionalDocument(v any) (*wirebson.Document, error) {
+	d, ok := v.(wirebson.AnyDocument)
+	if !ok {
+		return nil, nil
+	}
+
+	doc, err := d.Decode()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return doc, nil
+}
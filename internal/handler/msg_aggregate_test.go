@@ -0,0 +1,62 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"testing"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipelineWrites(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		doc      *wirebson.Document
+		expected bool
+	}{
+		"NoPipeline": {
+			doc: wirebson.MustDocument("aggregate", "coll"),
+		},
+		"Read": {
+			doc: wirebson.MustDocument("aggregate", "coll", "pipeline", wirebson.MustArray(
+				wirebson.MustDocument("$match", wirebson.MustDocument("v", int32(42))),
+			)),
+		},
+		"Out": {
+			doc: wirebson.MustDocument("aggregate", "coll", "pipeline", wirebson.MustArray(
+				wirebson.MustDocument("$match", wirebson.MustDocument("v", int32(42))),
+				wirebson.MustDocument("$out", "other"),
+			)),
+			expected: true,
+		},
+		"Merge": {
+			doc: wirebson.MustDocument("aggregate", "coll", "pipeline", wirebson.MustArray(
+				wirebson.MustDocument("$merge", wirebson.MustDocument("into", "other")),
+			)),
+			expected: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := pipelineWrites(tc.doc)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
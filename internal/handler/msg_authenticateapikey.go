@@ -0,0 +1,113 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/FerretDB/FerretDB/v2/internal/clientconn/conninfo"
+	"github.com/FerretDB/FerretDB/v2/internal/documentdb"
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+)
+
+// msgAuthenticateAPIKey implements `authenticateAPIKey` command.
+//
+// It is a FerretDB-specific command that authenticates the connection with API key.
+// Unlike SCRAM, it takes a single round trip.
+//
+// The passed context is canceled when the client connection is closed.
+func (h *Handler) msgAuthenticateAPIKey(connCtx context.Context, req *middleware.Request) (*middleware.Response, error) {
+	doc := req.Document()
+
+	if _, _, err := h.s.CreateOrUpdateByLSID(connCtx, doc); err != nil {
+		return nil, err
+	}
+
+	keyS, err := getRequiredParam[string](doc, "key")
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	errFailed := mongoerrors.NewWithArgument(mongoerrors.ErrAuthenticationFailed, "Authentication failed.", "authenticateAPIKey")
+
+	// API keys are not tied to a username before they are verified, so only the peer is tracked
+	peer := conninfo.Get(connCtx).Peer.Addr()
+	if h.authLimit.Locked(connCtx, "", peer) {
+		return nil, errFailed
+	}
+
+	id, secret, ok := parseAPIKey(keyS)
+	if !ok {
+		h.L.DebugContext(connCtx, "authenticateAPIKey: malformed API key")
+		h.authLimit.Failure(connCtx, "", peer)
+
+		return nil, errFailed
+	}
+
+	var key *documentdb.APIKey
+
+	err = h.p.WithConn(func(conn *pgx.Conn) error {
+		key, err = documentdb.GetAPIKey(connCtx, conn, id)
+		return err
+	})
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	if key == nil || !checkAPIKeySecret(key, secret) {
+		h.L.DebugContext(connCtx, "authenticateAPIKey: unknown API key or wrong secret", slog.String("id", id))
+		h.authLimit.Failure(connCtx, "", peer)
+
+		return nil, errFailed
+	}
+
+	// keys are removed together with users, but check anyway
+	exists, err := h.userExists(connCtx, key.Username)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	if !exists {
+		h.L.WarnContext(connCtx, "authenticateAPIKey: API key of unknown user", slog.String("id", id))
+		return nil, errFailed
+	}
+
+	ci := conninfo.Get(connCtx)
+
+	if ci.SetConv(nil) {
+		h.L.DebugContext(connCtx, "authenticateAPIKey: removed existing SCRAM conversation")
+	}
+
+	ci.SetAPIKey(&conninfo.APIKey{
+		ID:       key.ID,
+		Username: key.Username,
+		DB:       key.DB,
+		ReadOnly: key.ReadOnly,
+	})
+
+	h.L.DebugContext(connCtx, "authenticateAPIKey: API key accepted", slog.String("id", id), slog.String("user", key.Username))
+
+	return middleware.ResponseDoc(req, wirebson.MustDocument(
+		"dbname", "admin",
+		"user", key.Username,
+		"ok", float64(1),
+	))
+}
@@ -0,0 +1,71 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/FerretDB/wire/wirebson"
+
+	"github.com/FerretDB/FerretDB/v2/internal/handler/authlimit"
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+)
+
+// msgClearAuthLockouts implements `clearAuthLockouts` command.
+//
+// Without parameters, all lockouts and failure counters are cleared.
+// Only administrators may run it against the admin database.
+//
+// The passed context is canceled when the client connection is closed.
+func (h *Handler) msgClearAuthLockouts(connCtx context.Context, req *middleware.Request) (*middleware.Response, error) {
+	doc := req.Document()
+
+	if _, _, err := h.s.CreateOrUpdateByLSID(connCtx, doc); err != nil {
+		return nil, err
+	}
+
+	if err := h.checkAdminCommand(connCtx, doc); err != nil {
+		return nil, err
+	}
+
+	kind, err := getOptionalParam(doc, "kind", "")
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case "", authlimit.KindUsername, authlimit.KindPeer:
+	default:
+		return nil, mongoerrors.NewWithArgument(
+			mongoerrors.ErrBadValue,
+			fmt.Sprintf("kind must be %q or %q, got %q", authlimit.KindUsername, authlimit.KindPeer, kind),
+			"kind",
+		)
+	}
+
+	value, err := getOptionalParam(doc, "value", "")
+	if err != nil {
+		return nil, err
+	}
+
+	cleared := h.authLimit.Clear(connCtx, kind, value)
+
+	return middleware.ResponseDoc(req, wirebson.MustDocument(
+		"cleared", int32(cleared),
+		"ok", float64(1),
+	))
+}
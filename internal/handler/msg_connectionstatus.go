@@ -36,7 +36,7 @@ func (h *Handler) msgConnectionStatus(connCtx context.Context, req *middleware.R
 
 	users := wirebson.MakeArray(1)
 
-	if u := conninfo.Get(connCtx).Conv().Username(); u != "" {
+	if u := conninfo.Get(connCtx).Username(); u != "" {
 		must.NoError(users.Add(wirebson.MustDocument(
 			"user", u,
 		)))
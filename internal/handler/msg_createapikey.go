@@ -0,0 +1,121 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/FerretDB/FerretDB/v2/internal/clientconn/conninfo"
+	"github.com/FerretDB/FerretDB/v2/internal/documentdb"
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+)
+
+// msgCreateAPIKey implements `createAPIKey` command.
+//
+// The full key is returned only once; only the hash of its secret is stored.
+//
+// The passed context is canceled when the client connection is closed.
+func (h *Handler) msgCreateAPIKey(connCtx context.Context, req *middleware.Request) (*middleware.Response, error) {
+	doc := req.Document()
+	command := doc.Command()
+
+	if _, _, err := h.s.CreateOrUpdateByLSID(connCtx, doc); err != nil {
+		return nil, err
+	}
+
+	if conninfo.Get(connCtx).APIKey() != nil {
+		return nil, errAPIKeyManagement(command)
+	}
+
+	user, err := getRequiredParam[string](doc, command)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := getOptionalParam(doc, "db", "")
+	if err != nil {
+		return nil, err
+	}
+
+	readOnly, err := getOptionalParam(doc, "readOnly", false)
+	if err != nil {
+		return nil, err
+	}
+
+	admin, err := h.isAdmin(connCtx)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	if _, err = apiKeyOwner(command, conninfo.Get(connCtx).Username(), user, admin); err != nil {
+		return nil, err
+	}
+
+	exists, err := h.userExists(connCtx, user)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	if !exists {
+		return nil, mongoerrors.NewWithArgument(
+			mongoerrors.ErrUserNotFound,
+			fmt.Sprintf("Could not find user \"%s\" for db \"admin\"", user),
+			command,
+		)
+	}
+
+	id, hash, key := newAPIKey()
+
+	err = h.p.WithConn(func(conn *pgx.Conn) error {
+		return documentdb.CreateAPIKey(connCtx, conn, &documentdb.APIKey{
+			ID:       id,
+			Username: user,
+			DB:       db,
+			Hash:     hash,
+			ReadOnly: readOnly,
+		})
+	})
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	h.L.InfoContext(
+		connCtx, "API key created",
+		slog.String("id", id), slog.String("user", user), slog.String("db", db), slog.Bool("readOnly", readOnly),
+	)
+
+	res := wirebson.MustDocument(
+		"keyId", id,
+		"key", key,
+		"user", user,
+	)
+
+	if db != "" {
+		must.NoError(res.Add("db", db))
+	}
+
+	must.NoError(res.Add("readOnly", readOnly))
+	must.NoError(res.Add("ok", float64(1)))
+
+	return middleware.ResponseDoc(req, res)
+}
@@ -21,6 +21,7 @@
 	"github.com/FerretDB/wire/wirebson"
 	"github.com/jackc/pgx/v5"
 
+	"github.com/FerretDB/FerretDB/v2/internal/documentdb"
 	"github.com/FerretDB/FerretDB/v2/internal/documentdb/documentdb_api"
 	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
 	"github.com/FerretDB/FerretDB/v2/internal/util/must"
@@ -55,8 +56,11 @@ func (h *Handler) msgDropUser(connCtx context.Context, req *middleware.Request)
 
 	err = h.p.WithConn(func(conn *pgx.Conn) error {
 		// TODO https://github.com/FerretDB/FerretDB-DocumentDB/issues/859
-		res, err = documentdb_api.DropUser(connCtx, conn, h.L, dropUserSpec)
-		return err
+		if res, err = documentdb_api.DropUser(connCtx, conn, h.L, dropUserSpec); err != nil {
+			return err
+		}
+
+		return documentdb.DropUserAPIKeys(connCtx, conn, user)
 	})
 	if err != nil {
 		return nil, lazyerrors.Error(err)
@@ -45,7 +45,7 @@ func (h *Handler) msgKillCursors(connCtx context.Context, req *middleware.Reques
 		return nil, err
 	}
 
-	username := conninfo.Get(connCtx).Conv().Username()
+	username := conninfo.Get(connCtx).Username()
 
 	userID, _, err := h.s.CreateOrUpdateByLSID(connCtx, doc)
 	if err != nil {
@@ -0,0 +1,86 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/FerretDB/FerretDB/v2/internal/clientconn/conninfo"
+	"github.com/FerretDB/FerretDB/v2/internal/documentdb"
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+)
+
+// msgListAPIKeys implements `listAPIKeys` command.
+//
+// Secrets and their hashes are never returned.
+//
+// The passed context is canceled when the client connection is closed.
+func (h *Handler) msgListAPIKeys(connCtx context.Context, req *middleware.Request) (*middleware.Response, error) {
+	doc := req.Document()
+
+	if _, _, err := h.s.CreateOrUpdateByLSID(connCtx, doc); err != nil {
+		return nil, err
+	}
+
+	if conninfo.Get(connCtx).APIKey() != nil {
+		return nil, errAPIKeyManagement(doc.Command())
+	}
+
+	user, err := getOptionalParam(doc, "user", "")
+	if err != nil {
+		return nil, err
+	}
+
+	admin, err := h.isAdmin(connCtx)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	if user, err = apiKeyOwner(doc.Command(), conninfo.Get(connCtx).Username(), user, admin); err != nil {
+		return nil, err
+	}
+
+	var keys []*documentdb.APIKey
+
+	err = h.p.WithConn(func(conn *pgx.Conn) error {
+		keys, err = documentdb.ListAPIKeys(connCtx, conn, user)
+		return err
+	})
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	arr := wirebson.MakeArray(len(keys))
+
+	for _, key := range keys {
+		must.NoError(arr.Add(wirebson.MustDocument(
+			"keyId", key.ID,
+			"user", key.Username,
+			"db", key.DB,
+			"readOnly", key.ReadOnly,
+			"created", key.Created,
+		)))
+	}
+
+	return middleware.ResponseDoc(req, wirebson.MustDocument(
+		"keys", arr,
+		"ok", float64(1),
+	))
+}
@@ -0,0 +1,58 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+
+	"github.com/FerretDB/wire/wirebson"
+
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+)
+
+// msgListAuthLockouts implements `listAuthLockouts` command.
+//
+// Only administrators may run it against the admin database.
+//
+// The passed context is canceled when the client connection is closed.
+func (h *Handler) msgListAuthLockouts(connCtx context.Context, req *middleware.Request) (*middleware.Response, error) {
+	doc := req.Document()
+
+	if _, _, err := h.s.CreateOrUpdateByLSID(connCtx, doc); err != nil {
+		return nil, err
+	}
+
+	if err := h.checkAdminCommand(connCtx, doc); err != nil {
+		return nil, err
+	}
+
+	lockouts := h.authLimit.Lockouts()
+	arr := wirebson.MakeArray(len(lockouts))
+
+	for _, l := range lockouts {
+		must.NoError(arr.Add(wirebson.MustDocument(
+			"kind", l.Kind,
+			"value", l.Value,
+			"lockouts", int32(l.Lockouts),
+			"until", l.Until,
+		)))
+	}
+
+	return middleware.ResponseDoc(req, wirebson.MustDocument(
+		"lockouts", arr,
+		"ok", float64(1),
+	))
+}
@@ -33,8 +33,13 @@ func (h *Handler) msgLogout(connCtx context.Context, req *middleware.Request) (*
 		return nil, err
 	}
 
-	if !conninfo.Get(connCtx).SetConv(nil) {
-		h.L.WarnContext(connCtx, "MsgLogout: no SCRAM conversation")
+	ci := conninfo.Get(connCtx)
+
+	conv := ci.SetConv(nil)
+	apiKey := ci.SetAPIKey(nil)
+
+	if !conv && !apiKey {
+		h.L.WarnContext(connCtx, "MsgLogout: no SCRAM conversation or API key")
 	}
 
 	return middleware.ResponseDoc(req, wirebson.MustDocument(
@@ -0,0 +1,102 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/FerretDB/FerretDB/v2/internal/clientconn/conninfo"
+	"github.com/FerretDB/FerretDB/v2/internal/documentdb"
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+)
+
+// msgRevokeAPIKey implements `revokeAPIKey` command.
+//
+// Connections that are already authenticated with the revoked key are not affected.
+//
+// The passed context is canceled when the client connection is closed.
+func (h *Handler) msgRevokeAPIKey(connCtx context.Context, req *middleware.Request) (*middleware.Response, error) {
+	doc := req.Document()
+	command := doc.Command()
+
+	if _, _, err := h.s.CreateOrUpdateByLSID(connCtx, doc); err != nil {
+		return nil, err
+	}
+
+	if conninfo.Get(connCtx).APIKey() != nil {
+		return nil, errAPIKeyManagement(command)
+	}
+
+	id, err := getRequiredParam[string](doc, command)
+	if err != nil {
+		return nil, err
+	}
+
+	errNotFound := mongoerrors.NewWithArgument(
+		mongoerrors.ErrBadValue,
+		fmt.Sprintf("Could not find API key %q", id),
+		command,
+	)
+
+	var key *documentdb.APIKey
+
+	err = h.p.WithConn(func(conn *pgx.Conn) error {
+		key, err = documentdb.GetAPIKey(connCtx, conn, id)
+		return err
+	})
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	if key == nil {
+		return nil, errNotFound
+	}
+
+	admin, err := h.isAdmin(connCtx)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	if _, err = apiKeyOwner(command, conninfo.Get(connCtx).Username(), key.Username, admin); err != nil {
+		return nil, err
+	}
+
+	var found bool
+
+	err = h.p.WithConn(func(conn *pgx.Conn) error {
+		found, err = documentdb.DropAPIKey(connCtx, conn, id)
+		return err
+	})
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	if !found {
+		return nil, errNotFound
+	}
+
+	h.L.InfoContext(connCtx, "API key revoked", slog.String("id", id))
+
+	return middleware.ResponseDoc(req, wirebson.MustDocument(
+		"ok", float64(1),
+	))
+}
@@ -85,6 +85,18 @@ func (h *Handler) saslContinue(ctx context.Context, doc *wirebson.Document) (*wi
 	}
 
 	username := conv.Username()
+	peer := conninfo.Get(ctx).Peer.Addr()
+
+	if h.authLimit.Locked(ctx, username, peer) {
+		conninfo.Get(ctx).SetConv(nil)
+
+		return nil, mongoerrors.NewWithArgument(
+			mongoerrors.ErrAuthenticationFailed,
+			"Authentication failed.",
+			"saslContinue",
+		)
+	}
+
 	authMsg, clientProof, err := conv.ClientFinal(string(payload.B))
 	h.L.DebugContext(
 		ctx, "saslContinue: client final",
@@ -93,6 +105,7 @@ func (h *Handler) saslContinue(ctx context.Context, doc *wirebson.Document) (*wi
 	)
 	if err != nil {
 		conninfo.Get(ctx).SetConv(nil)
+		h.authLimit.Failure(ctx, username, peer)
 
 		return nil, mongoerrors.NewWithArgument(
 			mongoerrors.ErrAuthenticationFailed,
@@ -129,6 +142,7 @@ func (h *Handler) saslContinue(ctx context.Context, doc *wirebson.Document) (*wi
 	)
 	if err != nil {
 		conninfo.Get(ctx).SetConv(nil)
+		h.authLimit.Failure(ctx, username, peer)
 
 		return nil, mongoerrors.NewWithArgument(
 			mongoerrors.ErrAuthenticationFailed,
@@ -137,6 +151,8 @@ func (h *Handler) saslContinue(ctx context.Context, doc *wirebson.Document) (*wi
 		)
 	}
 
+	h.authLimit.Success(username)
+
 	return wirebson.MustDocument(
 		"conversationId", int32(1),
 		"done", done,
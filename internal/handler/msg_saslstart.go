@@ -91,6 +91,8 @@ func (h *Handler) saslStart(ctx context.Context, doc *wirebson.Document) (*wireb
 		return nil, lazyerrors.Error(err)
 	}
 
+	peer := conninfo.Get(ctx).Peer.Addr()
+
 	skipEmptyExchange, err := getOptionalParam(options, "skipEmptyExchange", false)
 	if err != nil {
 		h.L.DebugContext(
@@ -98,6 +100,8 @@ func (h *Handler) saslStart(ctx context.Context, doc *wirebson.Document) (*wireb
 			slog.String("options", optionsDoc.LogMessage()), logging.Error(err),
 		)
 
+		h.authLimit.Failure(ctx, "", peer)
+
 		return nil, mongoerrors.NewWithArgument(
 			mongoerrors.ErrAuthenticationFailed,
 			"Authentication failed.",
@@ -120,6 +124,17 @@ func (h *Handler) saslStart(ctx context.Context, doc *wirebson.Document) (*wireb
 		slog.String("payload", string(payload.B)), slog.String("username", username), logging.Error(err),
 	)
 	if err != nil {
+		h.authLimit.Failure(ctx, username, peer)
+
+		return nil, mongoerrors.NewWithArgument(
+			mongoerrors.ErrAuthenticationFailed,
+			"Authentication failed.",
+			"saslStart",
+		)
+	}
+
+	// locked out clients get the same error as clients with wrong credentials
+	if h.authLimit.Locked(ctx, username, peer) {
 		return nil, mongoerrors.NewWithArgument(
 			mongoerrors.ErrAuthenticationFailed,
 			"Authentication failed.",
@@ -152,6 +167,9 @@ func (h *Handler) saslStart(ctx context.Context, doc *wirebson.Document) (*wireb
 		slog.String("payload", payloadS), logging.Error(err),
 	)
 	if err != nil {
+		// for example, unknown user
+		h.authLimit.Failure(ctx, username, peer)
+
 		return nil, mongoerrors.NewWithArgument(
 			mongoerrors.ErrAuthenticationFailed,
 			"Authentication failed.",
@@ -163,6 +181,10 @@ func (h *Handler) saslStart(ctx context.Context, doc *wirebson.Document) (*wireb
 		h.L.WarnContext(ctx, "saslStart: replaced existing SCRAM conversation")
 	}
 
+	if conninfo.Get(ctx).SetAPIKey(nil) {
+		h.L.DebugContext(ctx, "saslStart: removed existing API key")
+	}
+
 	return wirebson.MustDocument(
 		"conversationId", int32(1),
 		"done", false,
@@ -328,6 +328,23 @@ func (r *Registry) createOrUpdateSessions(ctx context.Context, userID UserID, se
 	}
 }
 
+// Stats returns the number of active sessions and open cursors of all users.
+// Ended sessions and implicit sessions without `lsid` are not counted.
+func (r *Registry) Stats() (sessions, cursors int) {
+	r.rw.RLock()
+	defer r.rw.RUnlock()
+
+	for _, userSessions := range r.sessions {
+		for sessionID, s := range userSessions {
+			if sessionID != uuid.Nil && !s.ended {
+				sessions++
+			}
+		}
+	}
+
+	return sessions, len(r.cursors)
+}
+
 // DeleteAllSessions removes all sessions of all users and
 // returns all cursors of removed sessions.
 func (r *Registry) DeleteAllSessions() []int64 {
@@ -132,9 +132,10 @@ func getSessionUUID(spec wirebson.AnyDocument) (uuid.UUID, error) {
 func getUserID(ctx context.Context) UserID {
 	var username, db string
 
-	if conv := conninfo.Get(ctx).Conv(); conv != nil {
+	ci := conninfo.Get(ctx)
+	if ci.Conv() != nil || ci.APIKey() != nil {
 		// TODO https://github.com/FerretDB/FerretDB-DocumentDB/issues/864
-		username = conv.Username()
+		username = ci.Username()
 		db = "admin"
 	}
 
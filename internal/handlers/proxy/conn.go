@@ -27,13 +27,13 @@
 
 	"github.com/AlekSi/lazyerrors"
 	"github.com/FerretDB/wire"
-	"go.opentelemetry.io/otel"
 	otelcodes "go.opentelemetry.io/otel/codes"
 	otelsemconv "go.opentelemetry.io/otel/semconv/v1.34.0"
 	oteltrace "go.opentelemetry.io/otel/trace"
 
 	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
 	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
+	"github.com/FerretDB/FerretDB/v2/internal/util/observability"
 )
 
 // conn represents a single connection to a wire protocol compatible service.
@@ -63,7 +63,7 @@ func newConn(ctx context.Context, opts *NewOpts) (res *conn, err error) {
 		return
 	}
 
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"proxy.newConn",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -170,7 +170,7 @@ func dialTLS(ctx context.Context, addr, certFile, keyFile, caFile string) (net.C
 // handle sends a single request to the proxy.
 // It can be called concurrently from multiple goroutines.
 func (c *conn) handle(ctx context.Context, req *middleware.Request) (resp *middleware.Response, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"proxy.conn.handle",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
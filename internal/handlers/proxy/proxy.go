@@ -22,13 +22,13 @@
 
 	"github.com/AlekSi/lazyerrors"
 	"github.com/prometheus/client_golang/prometheus"
-	"go.opentelemetry.io/otel"
 	otelcodes "go.opentelemetry.io/otel/codes"
 	oteltrace "go.opentelemetry.io/otel/trace"
 
 	"github.com/FerretDB/FerretDB/v2/internal/clientconn/conninfo"
 	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
 	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+	"github.com/FerretDB/FerretDB/v2/internal/util/observability"
 )
 
 // Parts of Prometheus metric names.
@@ -104,7 +104,7 @@ func (h *Handler) Run(ctx context.Context) {
 
 // Handle implements [middleware.Handler] by sending it to another wire protocol compatible service.
 func (h *Handler) Handle(ctx context.Context, req *middleware.Request) (resp *middleware.Response, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"proxy.Handler.Handle",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -160,7 +160,7 @@ func (h *Handler) Handle(ctx context.Context, req *middleware.Request) (resp *mi
 // getConn returns a proxy connection for the given client connection info,
 // establishing it if necessary, while preserving one-to-one mapping.
 func (h *Handler) getConn(ctx context.Context, ci *conninfo.ConnInfo) (c *conn, err error) {
-	ctx, span := otel.Tracer("").Start(
+	ctx, span := observability.Tracer(ctx).Start(
 		ctx,
 		"proxy.Handler.getConn",
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -0,0 +1,58 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+)
+
+// aggregateArgs represents the arguments for the aggregate tool.
+type aggregateArgs struct {
+	Collection string           `json:"collection"`
+	Database   string           `json:"database"`
+	Pipeline   []map[string]any `json:"pipeline" jsonschema:"aggregation pipeline stages in MongoDB Extended JSON v2 format; $out and $merge are not allowed"`
+}
+
+// aggregate returns documents produced by the aggregation pipeline.
+func (s *server) aggregate(ctx context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[aggregateArgs]) (*mcp.CallToolResult, error) { //nolint:lll // for readability
+	if s.l.Enabled(ctx, slog.LevelDebug) {
+		s.l.DebugContext(ctx, "aggregate", slog.Any("params", params))
+	}
+
+	pipeline, err := toPipeline(params.Arguments.Pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("pipeline: %w", err)
+	}
+
+	stages := must.NotFail(pipeline.Decode())
+	must.NoError(stages.Add(wirebson.MustDocument("$limit", int64(maxDocuments))))
+
+	req := wirebson.MustDocument(
+		"aggregate", params.Arguments.Collection,
+		"pipeline", stages,
+		"cursor", wirebson.MustDocument("batchSize", int64(maxDocuments)),
+		"maxTimeMS", maxTime.Milliseconds(),
+		"$db", params.Arguments.Database,
+	)
+
+	return s.handleCursor(ctx, req)
+}
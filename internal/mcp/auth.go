@@ -0,0 +1,315 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AlekSi/lazyerrors"
+
+	"github.com/FerretDB/FerretDB/v2/internal/clientconn/conninfo"
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
+)
+
+// sessionIDHeader is the header used by MCP streamable HTTP transport for session IDs.
+const sessionIDHeader = "Mcp-Session-Id"
+
+// errAuthenticationFailed is returned when credentials are missing or invalid.
+var errAuthenticationFailed = errors.New("authentication failed")
+
+// authenticate authenticates the connection stored in ctx with credentials specified in the request:
+// API key in `Authorization: Bearer` header, or username and password with Basic authentication.
+// It uses the same authentication mechanisms as the Data API.
+func (s *server) authenticate(ctx context.Context, r *http.Request) error {
+	ctx, cancel := context.WithTimeout(ctx, maxTime)
+	defer cancel()
+
+	if key, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return s.authenticateAPIKey(ctx, strings.TrimSpace(key))
+	}
+
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		return errAuthenticationFailed
+	}
+
+	return s.authenticateSCRAM(ctx, username, password)
+}
+
+// authenticateAPIKey performs authentication with the given API key.
+func (s *server) authenticateAPIKey(ctx context.Context, key string) error {
+	return s.checkAuthentication(ctx, s.m.AuthenticateAPIKey(ctx, key))
+}
+
+// authenticateSCRAM performs SCRAM-SHA-256 authentication with the given username and password.
+func (s *server) authenticateSCRAM(ctx context.Context, username, password string) error {
+	return s.checkAuthentication(ctx, s.m.AuthenticateSCRAM(ctx, username, password))
+}
+
+// checkAuthentication returns [errAuthenticationFailed] if the authentication response is not successful.
+func (s *server) checkAuthentication(ctx context.Context, resp *middleware.Response) error {
+	if resp == nil {
+		return lazyerrors.New("internal error")
+	}
+
+	if !resp.OK() {
+		s.l.DebugContext(ctx, "Authentication command failed", slog.String("error", resp.ErrorName()))
+		return errAuthenticationFailed
+	}
+
+	return nil
+}
+
+// sessionIdleTimeout is the time after which idle authenticated MCP sessions are closed.
+const sessionIdleTimeout = 30 * time.Minute
+
+// session represents an authenticated MCP session.
+type session struct {
+	username string
+	lastUsed time.Time
+	active   int // number of in-flight requests
+}
+
+// sessions tracks users that created MCP sessions.
+type sessions struct {
+	m        sync.Mutex
+	sessions map[string]*session // keyed by session ID
+}
+
+// acquire returns true if the session with the given ID exists and belongs to the given user,
+// and marks it as used by the request.
+// [sessions.release] must be called when the request is done.
+func (s *sessions) acquire(id, username string) bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	sess := s.sessions[id]
+	if sess == nil || sess.username != username {
+		return false
+	}
+
+	sess.active++
+	sess.lastUsed = time.Now()
+
+	return true
+}
+
+// release marks the session with the given ID as no longer used by the request.
+func (s *sessions) release(id string) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if sess := s.sessions[id]; sess != nil {
+		sess.active--
+		sess.lastUsed = time.Now()
+	}
+}
+
+// add adds a new session with the given ID for the given user.
+func (s *sessions) add(id, username string) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.sessions[id] = &session{
+		username: username,
+		lastUsed: time.Now(),
+	}
+}
+
+// remove removes the session with the given ID.
+func (s *sessions) remove(id string) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	delete(s.sessions, id)
+}
+
+// removeIdle removes sessions without in-flight requests that were not used since the given time,
+// and returns their IDs.
+func (s *sessions) removeIdle(since time.Time) []string {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	var res []string
+
+	for id, sess := range s.sessions {
+		if sess.active == 0 && sess.lastUsed.Before(since) {
+			delete(s.sessions, id)
+			res = append(res, id)
+		}
+	}
+
+	return res
+}
+
+// authMiddleware authenticates each request and calls the next handler.
+//
+// MCP tool calls are handled with the context of the request that created the session,
+// so all tool calls of the session are executed as the user authenticated by that request.
+// Requests for existing sessions must be authenticated as the same user.
+func (lis *Listener) authMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		if err := lis.srv.authenticate(ctx, r); err != nil {
+			lis.opts.L.DebugContext(ctx, "MCP authentication failed", logging.Error(err))
+
+			rw.Header().Set("WWW-Authenticate", `Basic realm="FerretDB"`)
+			http.Error(rw, errAuthenticationFailed.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		username := conninfo.Get(ctx).Username()
+
+		id := r.Header.Get(sessionIDHeader)
+		if id == "" {
+			next.ServeHTTP(&sessionRecorder{ResponseWriter: rw, s: &lis.sessions, username: username}, r)
+			return
+		}
+
+		// all sessions are created by authenticated requests,
+		// so unknown sessions were closed or belong to another user
+		if !lis.sessions.acquire(id, username) {
+			http.Error(rw, "session not found", http.StatusNotFound)
+			return
+		}
+
+		defer lis.sessions.release(id)
+
+		next.ServeHTTP(rw, r)
+
+		if r.Method == http.MethodDelete {
+			lis.sessions.remove(id)
+		}
+	})
+}
+
+// expireSessions closes authenticated sessions that were idle for longer than the given timeout
+// until ctx is canceled.
+//
+// The MCP handler keeps sessions until the client deletes them,
+// so they are closed with the same DELETE request.
+func (lis *Listener) expireSessions(ctx context.Context, mcpHandler http.Handler, timeout time.Duration) {
+	ticker := time.NewTicker(timeout / 10)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for _, id := range lis.sessions.removeIdle(time.Now().Add(-timeout)) {
+			lis.opts.L.DebugContext(ctx, "Closing idle MCP session", slog.String("id", id))
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodDelete, "/mcp", nil)
+			if err != nil {
+				lis.opts.L.ErrorContext(ctx, "Failed to close idle MCP session", logging.Error(err))
+				continue
+			}
+
+			req.Header.Set("Accept", "application/json, text/event-stream")
+			req.Header.Set(sessionIDHeader, id)
+
+			mcpHandler.ServeHTTP(new(discardResponseWriter), req)
+		}
+	}
+}
+
+// discardResponseWriter is a [http.ResponseWriter] that discards the response.
+type discardResponseWriter struct {
+	h http.Header
+}
+
+// Header implements [http.ResponseWriter].
+func (rw *discardResponseWriter) Header() http.Header {
+	if rw.h == nil {
+		rw.h = http.Header{}
+	}
+
+	return rw.h
+}
+
+// Write implements [http.ResponseWriter].
+func (rw *discardResponseWriter) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+// WriteHeader implements [http.ResponseWriter].
+func (rw *discardResponseWriter) WriteHeader(int) {}
+
+// sessionRecorder is a [http.ResponseWriter] that records the user of a new session
+// before the session ID is sent to the client.
+type sessionRecorder struct {
+	http.ResponseWriter
+	s        *sessions
+	username string
+	recorded bool
+}
+
+// record stores the session ID set by the MCP handler, if any.
+func (sr *sessionRecorder) record() {
+	if sr.recorded {
+		return
+	}
+
+	sr.recorded = true
+
+	id := sr.Header().Get(sessionIDHeader)
+	if id == "" {
+		return
+	}
+
+	sr.s.add(id, sr.username)
+}
+
+// WriteHeader implements [http.ResponseWriter].
+func (sr *sessionRecorder) WriteHeader(statusCode int) {
+	sr.record()
+	sr.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write implements [http.ResponseWriter].
+func (sr *sessionRecorder) Write(b []byte) (int, error) {
+	sr.record()
+	return sr.ResponseWriter.Write(b)
+}
+
+// Flush implements [http.Flusher].
+func (sr *sessionRecorder) Flush() {
+	sr.record()
+	http.NewResponseController(sr.ResponseWriter).Flush() //nolint:errcheck // the same as http.Flusher
+}
+
+// Unwrap returns the underlying [http.ResponseWriter] for [http.ResponseController].
+func (sr *sessionRecorder) Unwrap() http.ResponseWriter {
+	return sr.ResponseWriter
+}
+
+// check interfaces
+var (
+	_ http.ResponseWriter = (*sessionRecorder)(nil)
+	_ http.Flusher        = (*sessionRecorder)(nil)
+	_ http.ResponseWriter = (*discardResponseWriter)(nil)
+)
@@ -0,0 +1,95 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FerretDB/FerretDB/v2/internal/util/testutil"
+)
+
+func TestSessions(t *testing.T) {
+	t.Parallel()
+
+	s := &sessions{
+		sessions: map[string]*session{},
+	}
+
+	s.add("a", "alice")
+	s.add("b", "bob")
+
+	assert.True(t, s.acquire("a", "alice"))
+	assert.False(t, s.acquire("a", "bob"))
+	assert.False(t, s.acquire("c", "alice"))
+
+	// sessions with in-flight requests are not removed
+	assert.Equal(t, []string{"b"}, s.removeIdle(time.Now().Add(time.Hour)))
+
+	s.release("a")
+	assert.Equal(t, []string{"a"}, s.removeIdle(time.Now().Add(time.Hour)))
+
+	assert.False(t, s.acquire("a", "alice"))
+	assert.Empty(t, s.sessions)
+}
+
+func TestExpireSessions(t *testing.T) {
+	t.Parallel()
+
+	lis := &Listener{
+		opts: &ListenOpts{
+			L: testutil.Logger(t),
+		},
+		sessions: sessions{
+			sessions: map[string]*session{},
+		},
+	}
+
+	lis.sessions.add("idle", "alice")
+
+	var m sync.Mutex
+	var deleted []string
+
+	h := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		m.Lock()
+		defer m.Unlock()
+
+		assert.Equal(t, http.MethodDelete, r.Method)
+		deleted = append(deleted, r.Header.Get(sessionIDHeader))
+
+		rw.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx, cancel := context.WithCancel(testutil.Ctx(t))
+	defer cancel()
+
+	go lis.expireSessions(ctx, h, 10*time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		m.Lock()
+		defer m.Unlock()
+
+		return len(deleted) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, []string{"idle"}, deleted)
+	assert.False(t, lis.sessions.acquire("idle", "alice"))
+}
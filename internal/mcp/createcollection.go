@@ -0,0 +1,49 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// createCollectionArgs represents the arguments for the createCollection tool.
+type createCollectionArgs struct {
+	Collection string `json:"collection"`
+	Database   string `json:"database"`
+	DryRun     bool   `json:"dryRun,omitempty" jsonschema:"do not create the collection"`
+}
+
+// createCollection creates a new collection.
+func (s *server) createCollection(ctx context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[createCollectionArgs]) (*mcp.CallToolResult, error) { //nolint:lll // for readability
+	if s.l.Enabled(ctx, slog.LevelDebug) {
+		s.l.DebugContext(ctx, "createCollection", slog.Any("params", params))
+	}
+
+	// creating a collection does not affect any documents
+	if params.Arguments.DryRun {
+		return s.dryRunResult(ctx, int32(0))
+	}
+
+	req := wirebson.MustDocument(
+		"create", params.Arguments.Collection,
+		"$db", params.Arguments.Database,
+	)
+
+	return s.handle(ctx, req)
+}
@@ -0,0 +1,94 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// indexKey represents a single field of the index key.
+type indexKey struct {
+	Field string `json:"field"`
+	Type  any    `json:"type"  jsonschema:"1 for ascending, -1 for descending, or index type like \"text\""`
+}
+
+// createIndexArgs represents the arguments for the createIndex tool.
+type createIndexArgs struct {
+	Collection string     `json:"collection"`
+	Database   string     `json:"database"`
+	Keys       []indexKey `json:"keys"             jsonschema:"indexed fields in order"`
+	Name       string     `json:"name,omitempty"   jsonschema:"index name; generated from keys if not set"`
+	Unique     bool       `json:"unique,omitempty" jsonschema:"reject documents with duplicate values of indexed fields"`
+	DryRun     bool       `json:"dryRun,omitempty" jsonschema:"only return the number of documents to index"`
+}
+
+// createIndex creates an index on the collection.
+func (s *server) createIndex(ctx context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[createIndexArgs]) (*mcp.CallToolResult, error) { //nolint:lll // for readability
+	if s.l.Enabled(ctx, slog.LevelDebug) {
+		s.l.DebugContext(ctx, "createIndex", slog.Any("params", params))
+	}
+
+	if len(params.Arguments.Keys) == 0 {
+		return nil, errors.New("keys must not be empty")
+	}
+
+	key := wirebson.MakeDocument(len(params.Arguments.Keys))
+	names := make([]string, 0, len(params.Arguments.Keys))
+
+	for _, k := range params.Arguments.Keys {
+		v := k.Type
+
+		// JSON numbers are decoded as float64, but index key types are integers
+		if f, ok := v.(float64); ok && f == math.Trunc(f) {
+			v = int32(f)
+		}
+
+		if err := key.Add(k.Field, v); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		names = append(names, fmt.Sprintf("%s_%v", k.Field, v))
+	}
+
+	name := params.Arguments.Name
+	if name == "" {
+		name = strings.Join(names, "_")
+	}
+
+	if params.Arguments.DryRun {
+		return s.dryRun(ctx, params.Arguments.Database, params.Arguments.Collection, nil)
+	}
+
+	req := wirebson.MustDocument(
+		"createIndexes", params.Arguments.Collection,
+		"indexes", wirebson.MustArray(wirebson.MustDocument(
+			"key", key,
+			"name", name,
+			"unique", params.Arguments.Unique,
+		)),
+		"$db", params.Arguments.Database,
+	)
+
+	return s.handle(ctx, req)
+}
@@ -0,0 +1,59 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+)
+
+// distinctArgs represents the arguments for the distinct tool.
+type distinctArgs struct {
+	Collection string         `json:"collection"`
+	Database   string         `json:"database"`
+	Key        string         `json:"key"              jsonschema:"field to return distinct values for; dot notation is supported"`
+	Filter     map[string]any `json:"filter,omitempty" jsonschema:"query filter in MongoDB Extended JSON v2 format"`
+}
+
+// distinct returns distinct values of the field.
+func (s *server) distinct(ctx context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[distinctArgs]) (*mcp.CallToolResult, error) { //nolint:lll // for readability
+	if s.l.Enabled(ctx, slog.LevelDebug) {
+		s.l.DebugContext(ctx, "distinct", slog.Any("params", params))
+	}
+
+	filter, err := toDocument(params.Arguments.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("filter: %w", err)
+	}
+
+	req := wirebson.MustDocument(
+		"distinct", params.Arguments.Collection,
+		"key", params.Arguments.Key,
+		"maxTimeMS", maxTime.Milliseconds(),
+		"$db", params.Arguments.Database,
+	)
+
+	if filter != nil {
+		must.NoError(req.Add("query", filter))
+	}
+
+	return s.handle(ctx, req)
+}
@@ -0,0 +1,48 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// dropCollectionArgs represents the arguments for the dropCollection tool.
+type dropCollectionArgs struct {
+	Collection string `json:"collection"`
+	Database   string `json:"database"`
+	DryRun     bool   `json:"dryRun,omitempty" jsonschema:"only return the number of documents in the collection"`
+}
+
+// dropCollection drops the collection.
+func (s *server) dropCollection(ctx context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[dropCollectionArgs]) (*mcp.CallToolResult, error) { //nolint:lll // for readability
+	if s.l.Enabled(ctx, slog.LevelDebug) {
+		s.l.DebugContext(ctx, "dropCollection", slog.Any("params", params))
+	}
+
+	if params.Arguments.DryRun {
+		return s.dryRun(ctx, params.Arguments.Database, params.Arguments.Collection, nil)
+	}
+
+	req := wirebson.MustDocument(
+		"drop", params.Arguments.Collection,
+		"$db", params.Arguments.Database,
+	)
+
+	return s.handle(ctx, req)
+}
@@ -0,0 +1,85 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+)
+
+// explainArgs represents the arguments for the explain tool.
+type explainArgs struct {
+	Collection string           `json:"collection"`
+	Database   string           `json:"database"`
+	Filter     map[string]any   `json:"filter,omitempty"   jsonschema:"query filter of the find query in MongoDB Extended JSON v2 format"`
+	Sort       []sortKey        `json:"sort,omitempty"     jsonschema:"sort order of the find query"`
+	Pipeline   []map[string]any `json:"pipeline,omitempty" jsonschema:"aggregation pipeline to explain instead of the find query"`
+}
+
+// explain returns the query plan.
+func (s *server) explain(ctx context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[explainArgs]) (*mcp.CallToolResult, error) { //nolint:lll // for readability
+	if s.l.Enabled(ctx, slog.LevelDebug) {
+		s.l.DebugContext(ctx, "explain", slog.Any("params", params))
+	}
+
+	var cmd *wirebson.Document
+
+	if params.Arguments.Pipeline != nil {
+		pipeline, err := toPipeline(params.Arguments.Pipeline)
+		if err != nil {
+			return nil, fmt.Errorf("pipeline: %w", err)
+		}
+
+		cmd = wirebson.MustDocument(
+			"aggregate", params.Arguments.Collection,
+			"pipeline", pipeline,
+			"cursor", wirebson.MustDocument(),
+		)
+	} else {
+		filter, err := toDocument(params.Arguments.Filter)
+		if err != nil {
+			return nil, fmt.Errorf("filter: %w", err)
+		}
+
+		sort, err := toSort(params.Arguments.Sort)
+		if err != nil {
+			return nil, fmt.Errorf("sort: %w", err)
+		}
+
+		cmd = wirebson.MustDocument("find", params.Arguments.Collection)
+
+		if filter != nil {
+			must.NoError(cmd.Add("filter", filter))
+		}
+
+		if sort != nil {
+			must.NoError(cmd.Add("sort", sort))
+		}
+	}
+
+	req := wirebson.MustDocument(
+		"explain", cmd,
+		"verbosity", "queryPlanner",
+		"$db", params.Arguments.Database,
+	)
+
+	return s.handle(ctx, req)
+}
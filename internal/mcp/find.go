@@ -16,17 +16,24 @@
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 
 	"github.com/FerretDB/wire/wirebson"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
 )
 
 // findArgs represents the arguments for the find tool.
 type findArgs struct {
-	Collection string `json:"collection"`
-	Database   string `json:"database"`
-	Limit      int64  `json:"limit"`
+	Collection string         `json:"collection"`
+	Database   string         `json:"database"`
+	Filter     map[string]any `json:"filter,omitempty"     jsonschema:"query filter in MongoDB Extended JSON v2 format"`
+	Projection map[string]any `json:"projection,omitempty" jsonschema:"fields to include or exclude in MongoDB Extended JSON v2 format"`
+	Sort       []sortKey      `json:"sort,omitempty"       jsonschema:"sort order"`
+	Skip       int64          `json:"skip,omitempty"       jsonschema:"number of documents to skip"`
+	Limit      int64          `json:"limit,omitempty"      jsonschema:"maximum number of documents to return"`
 }
 
 // find returns documents from the collection.
@@ -35,11 +42,44 @@ func (s *server) find(ctx context.Context, _ *mcp.ServerSession, params *mcp.Cal
 		s.l.DebugContext(ctx, "find", slog.Any("params", params))
 	}
 
+	filter, err := toDocument(params.Arguments.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("filter: %w", err)
+	}
+
+	projection, err := toDocument(params.Arguments.Projection)
+	if err != nil {
+		return nil, fmt.Errorf("projection: %w", err)
+	}
+
+	sort, err := toSort(params.Arguments.Sort)
+	if err != nil {
+		return nil, fmt.Errorf("sort: %w", err)
+	}
+
 	req := wirebson.MustDocument(
 		"find", params.Arguments.Collection,
-		"limit", params.Arguments.Limit,
+		"limit", limit(params.Arguments.Limit),
+		"singleBatch", true,
+		"maxTimeMS", maxTime.Milliseconds(),
 		"$db", params.Arguments.Database,
 	)
 
-	return s.handle(ctx, req)
+	if filter != nil {
+		must.NoError(req.Add("filter", filter))
+	}
+
+	if projection != nil {
+		must.NoError(req.Add("projection", projection))
+	}
+
+	if sort != nil {
+		must.NoError(req.Add("sort", sort))
+	}
+
+	if params.Arguments.Skip > 0 {
+		must.NoError(req.Add("skip", params.Arguments.Skip))
+	}
+
+	return s.handleCursor(ctx, req)
 }
@@ -0,0 +1,55 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// insertManyArgs represents the arguments for the insertMany tool.
+type insertManyArgs struct {
+	Collection string           `json:"collection"`
+	Database   string           `json:"database"`
+	Documents  []map[string]any `json:"documents"        jsonschema:"documents to insert in MongoDB Extended JSON v2 format"`
+	DryRun     bool             `json:"dryRun,omitempty" jsonschema:"only return the number of documents to insert"`
+}
+
+// insertMany inserts documents into the collection.
+func (s *server) insertMany(ctx context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[insertManyArgs]) (*mcp.CallToolResult, error) { //nolint:lll // for readability
+	if s.l.Enabled(ctx, slog.LevelDebug) {
+		s.l.DebugContext(ctx, "insertMany", slog.Any("params", params))
+	}
+
+	docs, err := toArray(params.Arguments.Documents)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Arguments.DryRun {
+		return s.dryRunResult(ctx, int32(len(params.Arguments.Documents)))
+	}
+
+	req := wirebson.MustDocument(
+		"insert", params.Arguments.Collection,
+		"documents", docs,
+		"$db", params.Arguments.Database,
+	)
+
+	return s.handle(ctx, req)
+}
@@ -21,12 +21,13 @@
 	"log/slog"
 	"net"
 	"net/http"
+	"net/netip"
+	"sync"
 
 	"github.com/AlekSi/lazyerrors"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/prometheus/client_golang/prometheus"
 
-	"github.com/FerretDB/FerretDB/v2/build/version"
 	"github.com/FerretDB/FerretDB/v2/internal/clientconn/conninfo"
 	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
 	"github.com/FerretDB/FerretDB/v2/internal/util/ctxutil"
@@ -35,19 +36,22 @@
 
 // Listener represents MCP listener.
 type Listener struct {
-	opts *ListenOpts
-	lis  net.Listener
-	srv  *server
+	opts     *ListenOpts
+	lis      net.Listener
+	srv      *server
+	sessions sessions
+
+	drainOnce sync.Once
+	drain     chan struct{} // closed when draining starts
 }
 
 // ListenOpts represents [Listen] options.
 type ListenOpts struct { //nolint:vet // for readability
-	L       *slog.Logger
-	M       *middleware.Middleware
-	TCPAddr string
-
-	// TODO https://github.com/FerretDB/FerretDB/issues/5309
-	// Auth bool
+	L           *slog.Logger
+	M           *middleware.Middleware
+	TCPAddr     string
+	Auth        bool
+	AllowWrites bool // enables tools that modify data
 }
 
 // Listen creates a new MCP handler and starts listener on the given TCP address.
@@ -61,7 +65,11 @@ func Listen(opts *ListenOpts) (*Listener, error) {
 	return &Listener{
 		opts: opts,
 		lis:  lis,
-		srv:  newServer(opts.L, opts.M),
+		srv:  newServer(opts.L, opts.M, opts.AllowWrites),
+		sessions: sessions{
+			sessions: map[string]*session{},
+		},
+		drain: make(chan struct{}),
 	}, nil
 }
 
@@ -69,14 +77,16 @@ func Listen(opts *ListenOpts) (*Listener, error) {
 //
 // It exits when handler is stopped and listener closed.
 func (lis *Listener) Run(ctx context.Context) {
-	s := mcp.NewServer(&mcp.Implementation{Name: "FerretDB", Version: version.Get().Version}, nil)
-	lis.srv.addTools(s)
+	mcpHandler := mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server { return lis.srv.newMCPServer() }, nil)
+	var h http.Handler = mcpHandler
+	if lis.opts.Auth {
+		h = lis.authMiddleware(h)
 
-	mcpHandler := mcp.NewStreamableHTTPHandler(func(req *http.Request) *mcp.Server { return s }, nil)
-	srvHandler := http.NewServeMux()
+		go lis.expireSessions(ctx, mcpHandler, sessionIdleTimeout)
+	}
 
-	// TODO https://github.com/FerretDB/FerretDB/issues/5309
-	srvHandler.Handle("/mcp", connInfoMiddleware(mcpHandler))
+	srvHandler := http.NewServeMux()
+	srvHandler.Handle("/mcp", connInfoMiddleware(h))
 
 	srv := &http.Server{
 		Handler:  srvHandler,
@@ -89,7 +99,8 @@ func (lis *Listener) Run(ctx context.Context) {
 	lis.opts.L.InfoContext(ctx, fmt.Sprintf("Starting MCP server on http://%s/mcp", lis.lis.Addr()))
 
 	go func() {
-		if err := srv.Serve(lis.lis); !errors.Is(err, http.ErrServerClosed) {
+		// Drain closes listener
+		if err := srv.Serve(lis.lis); !errors.Is(err, http.ErrServerClosed) && !lis.Draining() {
 			lis.opts.L.LogAttrs(ctx, logging.LevelDPanic, "Serve exited with unexpected error", logging.Error(err))
 		}
 	}()
@@ -111,12 +122,39 @@ func (lis *Listener) Run(ctx context.Context) {
 	lis.opts.L.InfoContext(ctx, "MCP server stopped")
 }
 
+// Drain stops accepting new connections.
+// In-flight requests are handled; established connections are closed when [Listener.Run] returns.
+//
+// Draining can't be stopped.
+func (lis *Listener) Drain() {
+	lis.drainOnce.Do(func() {
+		lis.opts.L.Info("Draining: not accepting new MCP connections")
+
+		close(lis.drain)
+		_ = lis.lis.Close()
+	})
+}
+
+// Draining returns true if [Listener.Drain] was called.
+func (lis *Listener) Draining() bool {
+	select {
+	case <-lis.drain:
+		return true
+	default:
+		return false
+	}
+}
+
 // connInfoMiddleware returns a handler function that creates a new [*conninfo.ConnInfo],
 // calls the next handler, and closes the connection info after the request is done.
 func connInfoMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		connInfo := conninfo.New()
 		defer connInfo.Close()
+
+		// remote address is not an IP address and port for Unix domain sockets; Peer stays invalid then
+		connInfo.Peer, _ = netip.ParseAddrPort(r.RemoteAddr)
+
 		next.ServeHTTP(w, r.WithContext(conninfo.Ctx(r.Context(), connInfo)))
 	})
 }
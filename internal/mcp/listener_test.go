@@ -17,12 +17,15 @@
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
@@ -109,6 +112,76 @@ func TestAdmin(t *testing.T) {
 	})
 }
 
+func TestAuth(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in -short mode")
+	}
+
+	t.Parallel()
+
+	ctx := t.Context()
+	uri := "http://" + setupListener(t, true) + "/mcp"
+
+	t.Run("NoCredentials", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := http.Post(uri, "application/json", strings.NewReader(`{}`))
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			assert.NoError(t, resp.Body.Close())
+		})
+
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		assert.Equal(t, `Basic realm="FerretDB"`, resp.Header.Get("WWW-Authenticate"))
+	})
+
+	t.Run("WrongPassword", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := connectMCP(ctx, uri, "username", "wrong")
+		require.Error(t, err)
+	})
+
+	t.Run("Basic", func(t *testing.T) {
+		t.Parallel()
+
+		cs, err := connectMCP(ctx, uri, "username", "password")
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			assert.NoError(t, cs.Close())
+		})
+
+		res, err := cs.CallTool(ctx, &mcp.CallToolParams{Name: "listDatabases"})
+		require.NoError(t, err)
+		assert.False(t, res.IsError)
+	})
+}
+
+// basicAuthTransport is a [http.RoundTripper] that adds Basic authentication to all requests.
+type basicAuthTransport struct {
+	username string
+	password string
+}
+
+// RoundTrip implements [http.RoundTripper].
+func (t *basicAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.SetBasicAuth(t.username, t.password)
+
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// connectMCP connects to the MCP server at the given URI with Basic authentication.
+func connectMCP(ctx context.Context, uri, username, password string) (*mcp.ClientSession, error) {
+	transport := mcp.NewStreamableClientTransport(uri, &mcp.StreamableClientTransportOptions{
+		HTTPClient: &http.Client{
+			Transport: &basicAuthTransport{username: username, password: password},
+		},
+	})
+
+	return mcp.NewClient(&mcp.Implementation{Name: "test"}, nil).Connect(ctx, transport)
+}
+
 // askMCPHost sends query to MCP host in non-interactive mode with
 // the given config file and prompt.
 // Non-interactive mode without streaming is used for the ease of testing.
@@ -150,6 +223,28 @@ func askMCPHost(tb testing.TB, ctx context.Context, configF, prompt string) stri
 func setupMCP(tb testing.TB, ctx context.Context) string {
 	tb.Helper()
 
+	config := fmt.Sprintf(`{
+	"mcpServers": {
+	  "FerretDB": {
+	    "type": "remote",
+	    "url": "http://%s/mcp"
+	    }
+	  }
+	}`,
+		setupListener(tb, false),
+	)
+
+	configF := filepath.Join(tb.TempDir(), "mcphost.json")
+	err := os.WriteFile(configF, []byte(config), 0o666)
+	require.NoError(tb, err)
+
+	return configF
+}
+
+// setupListener sets up a new MCP listener and returns its address.
+func setupListener(tb testing.TB, auth bool) string {
+	tb.Helper()
+
 	sp, err := state.NewProvider("")
 	require.NoError(tb, err)
 
@@ -160,15 +255,43 @@ func setupMCP(tb testing.TB, ctx context.Context) string {
 		Metrics:       middleware.NewMetrics(),
 
 		PostgreSQLURL:          testutil.PostgreSQLURL(tb),
-		Auth:                   false,
+		Auth:                   auth,
 		ReplSetName:            "",
+		ReadOnly:               false,
 		SessionCleanupInterval: 0,
+		CustomCommands:         nil,
+		AuthMaxFailures:        0,
+		AuthLockout:            0,
+		AuthMaxLockout:         0,
 
 		ProxyAddr:        "",
 		ProxyTLSCertFile: "",
 		ProxyTLSKeyFile:  "",
 		ProxyTLSCAFile:   "",
 
+		DiffReportFile:    "",
+		DiffIgnoredFields: nil,
+
+		ShadowQueueSize:  0,
+		ShadowSampleRate: 0,
+		ShadowCommands:   nil,
+		ShadowNamespaces: nil,
+
+		RouteRulesFile: "",
+
+		DualWriteReconciliationFile: "",
+
+		Interceptors:   nil,
+		TracerProvider: nil,
+
+		AuditDestination: "",
+		AuditPath:        "",
+		AuditFormat:      "",
+		AuditMaxSize:     0,
+		AuditMaxBackups:  0,
+		AuditFilter:      "",
+		AuditNamespaces:  nil,
+
 		TCPAddr:        "127.0.0.1:0",
 		UnixAddr:       "",
 		TLSAddr:        "",
@@ -178,9 +301,18 @@ func setupMCP(tb testing.TB, ctx context.Context) string {
 		Mode:           middleware.NormalMode,
 		TestRecordsDir: "",
 
-		DataAPIAddr: "",
-
-		MCPAddr: "127.0.0.1:0",
+		DataAPIAddr:        "",
+		DataAPIUnixAddr:    "",
+		DataAPITLSAddr:     "",
+		DataAPIMaxBodySize: 0,
+		DataAPICORSOrigins: nil,
+
+		MCPAddr:        "127.0.0.1:0",
+		MCPAllowWrites: false,
+		MCPStdio:       false,
+		MCPUsername:    "",
+		MCPPassword:    "",
+		MCPAPIKey:      "",
 	})
 	require.NotNil(tb, res)
 
@@ -199,20 +331,5 @@ func setupMCP(tb testing.TB, ctx context.Context) string {
 		<-runDone
 	})
 
-	config := fmt.Sprintf(`{
-	"mcpServers": {
-	  "FerretDB": {
-	    "type": "remote",
-	    "url": "http://%s/mcp"
-	    }
-	  }
-	}`,
-		res.MCPListener.Addr(),
-	)
-
-	configF := filepath.Join(tb.TempDir(), "mcphost.json")
-	err = os.WriteFile(configF, []byte(config), 0o666)
-	require.NoError(tb, err)
-
-	return configF
+	return res.MCPListener.Addr().String()
 }
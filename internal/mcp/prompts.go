@@ -0,0 +1,90 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// addPrompts adds available MCP prompts for the given mcp server.
+func (s *server) addPrompts(srv *mcp.Server) {
+	srv.AddPrompt(
+		&mcp.Prompt{
+			Name:        "query",
+			Title:       "Write a query",
+			Description: "Helps to write a query against the collection using its inferred schema.",
+			Arguments: []*mcp.PromptArgument{{
+				Name:        "database",
+				Description: "Database name.",
+				Required:    true,
+			}, {
+				Name:        "collection",
+				Description: "Collection name.",
+				Required:    true,
+			}, {
+				Name:        "task",
+				Description: "What the query should do, in plain words.",
+			}},
+		},
+		s.queryPrompt,
+	)
+}
+
+// queryPrompt returns the prompt for writing a query against the collection.
+func (s *server) queryPrompt(ctx context.Context, _ *mcp.ServerSession, params *mcp.GetPromptParams) (*mcp.GetPromptResult, error) { //nolint:lll // for readability
+	database, collection := params.Arguments["database"], params.Arguments["collection"]
+	if database == "" || collection == "" {
+		return nil, errors.New("database and collection arguments are required")
+	}
+
+	schema, err := s.inferSchema(ctx, database, collection)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := json.MarshalIndent(schema.Fields, "", "  ")
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	var text strings.Builder
+
+	fmt.Fprintf(&text, "Write a query against the %q collection in the %q database.\n", collection, database)
+
+	if task := params.Arguments["task"]; task != "" {
+		fmt.Fprintf(&text, "The query should do the following: %s\n", task)
+	}
+
+	fmt.Fprintf(&text, "\nThe schema inferred from %d sampled documents is:\n\n%s\n\n", schema.SampleSize, b)
+	text.WriteString("Field frequency is a fraction of sampled documents that contain the field; " +
+		"fields of nested documents use dot notation.\n")
+	text.WriteString("Use the `find` tool for simple queries and the `aggregate` tool for grouping and joins. " +
+		"Pass filters, projections, and pipelines as MongoDB Extended JSON v2.")
+
+	return &mcp.GetPromptResult{
+		Description: fmt.Sprintf("Query against %s.%s", database, collection),
+		Messages: []*mcp.PromptMessage{{
+			Role:    "user",
+			Content: &mcp.TextContent{Text: text.String()},
+		}},
+	}, nil
+}
@@ -0,0 +1,267 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
+)
+
+// maxResources is the maximum number of collections registered as resources for a single session.
+const maxResources = 100
+
+// Resource URI templates.
+const (
+	databaseURITemplate   = "ferretdb:///{database}"
+	collectionURITemplate = "ferretdb:///{database}/{collection}"
+)
+
+// databaseURI returns the resource URI of the database.
+func databaseURI(database string) string {
+	return "ferretdb:///" + url.PathEscape(database)
+}
+
+// collectionURI returns the resource URI of the collection.
+func collectionURI(database, collection string) string {
+	return databaseURI(database) + "/" + url.PathEscape(collection)
+}
+
+// parseURI returns database and collection names from the resource URI.
+// Collection name is empty for the database URI.
+func parseURI(uri string) (database, collection string, err error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", "", lazyerrors.Error(err)
+	}
+
+	if u.Scheme != "ferretdb" || u.Host != "" {
+		return "", "", fmt.Errorf("invalid resource URI %q", uri)
+	}
+
+	p, _ := strings.CutPrefix(u.EscapedPath(), "/")
+	escapedDB, escapedColl, _ := strings.Cut(p, "/")
+
+	if database, err = url.PathUnescape(escapedDB); err != nil || database == "" {
+		return "", "", fmt.Errorf("invalid resource URI %q", uri)
+	}
+
+	if collection, err = url.PathUnescape(escapedColl); err != nil {
+		return "", "", fmt.Errorf("invalid resource URI %q", uri)
+	}
+
+	return database, collection, nil
+}
+
+// addResourceTemplates adds resource templates for databases and collections
+// that were not registered as resources, for example, created after the session started.
+func (s *server) addResourceTemplates(srv *mcp.Server) {
+	srv.AddResourceTemplate(
+		&mcp.ResourceTemplate{
+			Name:        "database",
+			URITemplate: databaseURITemplate,
+			Description: "Collections of the database.",
+			MIMEType:    "application/json",
+		},
+		s.readDatabase,
+	)
+	srv.AddResourceTemplate(
+		&mcp.ResourceTemplate{
+			Name:        "collection",
+			URITemplate: collectionURITemplate,
+			Description: "Schema of the collection inferred from sampled documents.",
+			MIMEType:    "application/json",
+		},
+		s.readCollection,
+	)
+}
+
+// addResources registers databases and collections accessible in the session as resources.
+//
+// Collection descriptions include the inferred schema,
+// so the number of collections is limited by [maxResources].
+func (s *server) addResources(ctx context.Context, srv *mcp.Server) {
+	databases, err := s.listDatabaseNames(ctx)
+	if err != nil {
+		s.l.WarnContext(ctx, "Failed to list databases for MCP resources", logging.Error(err))
+		return
+	}
+
+	var n int
+
+	for _, database := range databases {
+		collections, err := s.listCollectionNames(ctx, database)
+		if err != nil {
+			s.l.WarnContext(ctx, "Failed to list collections for MCP resources", logging.Error(err))
+			continue
+		}
+
+		srv.AddResource(
+			&mcp.Resource{
+				Name:        database,
+				URI:         databaseURI(database),
+				Description: fmt.Sprintf("Database %q with collections: %s.", database, strings.Join(collections, ", ")),
+				MIMEType:    "application/json",
+			},
+			s.readDatabase,
+		)
+
+		for _, collection := range collections {
+			if n >= maxResources {
+				s.l.DebugContext(ctx, "Too many collections for MCP resources", slog.Int("limit", maxResources))
+				return
+			}
+
+			n++
+
+			description := fmt.Sprintf("Collection %q in database %q.", collection, database)
+
+			if schema, err := s.inferSchema(ctx, database, collection); err == nil {
+				description += " " + schema.String()
+			} else {
+				s.l.DebugContext(ctx, "Failed to infer schema", logging.Error(err))
+			}
+
+			srv.AddResource(
+				&mcp.Resource{
+					Name:        database + "." + collection,
+					URI:         collectionURI(database, collection),
+					Description: description,
+					MIMEType:    "application/json",
+				},
+				s.readCollection,
+			)
+		}
+	}
+}
+
+// readDatabase returns database resource with the list of collections.
+func (s *server) readDatabase(ctx context.Context, _ *mcp.ServerSession, params *mcp.ReadResourceParams) (*mcp.ReadResourceResult, error) { //nolint:lll // for readability
+	database, collection, err := parseURI(params.URI)
+	if err != nil || collection != "" {
+		return nil, mcp.ResourceNotFoundError(params.URI)
+	}
+
+	collections, err := s.listCollectionNames(ctx, database)
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonResource(params.URI, map[string]any{
+		"database":    database,
+		"collections": collections,
+	})
+}
+
+// readCollection returns collection resource with the inferred schema.
+func (s *server) readCollection(ctx context.Context, _ *mcp.ServerSession, params *mcp.ReadResourceParams) (*mcp.ReadResourceResult, error) { //nolint:lll // for readability
+	database, collection, err := parseURI(params.URI)
+	if err != nil || collection == "" {
+		return nil, mcp.ResourceNotFoundError(params.URI)
+	}
+
+	schema, err := s.inferSchema(ctx, database, collection)
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonResource(params.URI, schema)
+}
+
+// jsonResource returns resource contents with the given value encoded as JSON.
+func jsonResource(uri string, v any) (*mcp.ReadResourceResult, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{{
+			URI:      uri,
+			MIMEType: "application/json",
+			Text:     string(b),
+		}},
+	}, nil
+}
+
+// listDatabaseNames returns names of databases accessible by the user.
+func (s *server) listDatabaseNames(ctx context.Context) ([]string, error) {
+	resp, err := s.run(ctx, wirebson.MustDocument(
+		"listDatabases", int32(1),
+		"nameOnly", true,
+		"$db", "admin",
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.OK() {
+		return nil, resp.MongoError()
+	}
+
+	doc, err := resp.DocumentRaw().DecodeDeep()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	databases, _ := doc.Get("databases").(*wirebson.Array)
+
+	return names(databases), nil
+}
+
+// listCollectionNames returns names of collections and views in the database.
+func (s *server) listCollectionNames(ctx context.Context, database string) ([]string, error) {
+	batch, err := s.firstBatch(ctx, wirebson.MustDocument(
+		"listCollections", int32(1),
+		"nameOnly", true,
+		"$db", database,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	return names(batch), nil
+}
+
+// names returns values of `name` fields of documents in the array.
+func names(arr *wirebson.Array) []string {
+	if arr == nil {
+		return nil
+	}
+
+	res := make([]string, 0, arr.Len())
+
+	for v := range arr.Values() {
+		doc, ok := v.(*wirebson.Document)
+		if !ok {
+			continue
+		}
+
+		if name, ok := doc.Get("name").(string); ok {
+			res = append(res, name)
+		}
+	}
+
+	return res
+}
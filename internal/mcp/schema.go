@@ -0,0 +1,242 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/FerretDB/wire/wirebson"
+)
+
+const (
+	// schemaSampleSize is the number of documents sampled for schema inference.
+	schemaSampleSize = 100
+
+	// schemaMaxDepth is the maximum depth of nested documents for schema inference.
+	schemaMaxDepth = 3
+
+	// schemaMaxExamples is the maximum number of example values per field.
+	schemaMaxExamples = 3
+
+	// schemaMaxExampleLen is the maximum length of a single example value.
+	schemaMaxExampleLen = 64
+)
+
+// collectionSchema represents the schema of the collection inferred from a sample of documents.
+type collectionSchema struct {
+	Database   string        `json:"database"`
+	Collection string        `json:"collection"`
+	SampleSize int           `json:"sampleSize"`
+	Fields     []fieldSchema `json:"fields"`
+}
+
+// fieldSchema represents the inferred schema of a single field.
+type fieldSchema struct {
+	Field     string   `json:"field"`     // dot notation for fields of nested documents
+	Types     []string `json:"types"`     // BSON type aliases, the most frequent first
+	Frequency float64  `json:"frequency"` // fraction of sampled documents with the field
+	Examples  []string `json:"examples,omitempty"`
+}
+
+// inferSchema returns the schema of the collection inferred from documents sampled with `$sample`.
+func (s *server) inferSchema(ctx context.Context, database, collection string) (*collectionSchema, error) {
+	req := wirebson.MustDocument(
+		"aggregate", collection,
+		"pipeline", wirebson.MustArray(
+			wirebson.MustDocument("$sample", wirebson.MustDocument("size", int64(schemaSampleSize))),
+		),
+		"cursor", wirebson.MustDocument("batchSize", int64(schemaSampleSize)),
+		"maxTimeMS", maxTime.Milliseconds(),
+		"$db", database,
+	)
+
+	arr, err := s.firstBatch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]*wirebson.Document, 0, arr.Len())
+
+	for v := range arr.Values() {
+		if doc, ok := v.(*wirebson.Document); ok {
+			docs = append(docs, doc)
+		}
+	}
+
+	return &collectionSchema{
+		Database:   database,
+		Collection: collection,
+		SampleSize: len(docs),
+		Fields:     inferFields(docs),
+	}, nil
+}
+
+// fieldStats accumulates statistics of a single field.
+type fieldStats struct {
+	count    int
+	types    map[string]int
+	examples []string
+}
+
+// inferFields returns schemas of fields found in the given documents
+// in the order of their first appearance.
+func inferFields(docs []*wirebson.Document) []fieldSchema {
+	var paths []string
+	stats := map[string]*fieldStats{}
+
+	var walk func(prefix string, doc *wirebson.Document, depth int)
+	walk = func(prefix string, doc *wirebson.Document, depth int) {
+		for name, v := range doc.All() {
+			path := prefix + name
+
+			st := stats[path]
+			if st == nil {
+				st = &fieldStats{types: map[string]int{}}
+				stats[path] = st
+				paths = append(paths, path)
+			}
+
+			st.count++
+			st.types[typeAlias(v)]++
+
+			if e, ok := example(v); ok && len(st.examples) < schemaMaxExamples && !slices.Contains(st.examples, e) {
+				st.examples = append(st.examples, e)
+			}
+
+			if nested, ok := v.(*wirebson.Document); ok && depth < schemaMaxDepth {
+				walk(path+".", nested, depth+1)
+			}
+		}
+	}
+
+	for _, doc := range docs {
+		walk("", doc, 1)
+	}
+
+	res := make([]fieldSchema, 0, len(paths))
+
+	for _, path := range paths {
+		st := stats[path]
+
+		types := make([]string, 0, len(st.types))
+		for t := range st.types {
+			types = append(types, t)
+		}
+
+		slices.SortFunc(types, func(a, b string) int {
+			if c := cmp.Compare(st.types[b], st.types[a]); c != 0 {
+				return c
+			}
+
+			return cmp.Compare(a, b)
+		})
+
+		res = append(res, fieldSchema{
+			Field:     path,
+			Types:     types,
+			Frequency: math.Round(float64(st.count)/float64(len(docs))*100) / 100,
+			Examples:  st.examples,
+		})
+	}
+
+	return res
+}
+
+// typeAlias returns the BSON type alias of the given value, as used by the `$type` query operator.
+func typeAlias(v any) string {
+	switch v.(type) {
+	case *wirebson.Document, wirebson.RawDocument:
+		return "object"
+	case *wirebson.Array, wirebson.RawArray:
+		return "array"
+	case float64:
+		return "double"
+	case string:
+		return "string"
+	case wirebson.Binary:
+		return "binData"
+	case wirebson.UndefinedType:
+		return "undefined"
+	case wirebson.ObjectID:
+		return "objectId"
+	case bool:
+		return "bool"
+	case time.Time:
+		return "date"
+	case wirebson.NullType:
+		return "null"
+	case wirebson.Regex:
+		return "regex"
+	case int32:
+		return "int"
+	case wirebson.Timestamp:
+		return "timestamp"
+	case int64:
+		return "long"
+	case wirebson.Decimal128:
+		return "decimal"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+// example returns the example value in Extended JSON v2 format.
+// It returns false for documents, arrays, binary data, and too long values.
+func example(v any) (string, bool) {
+	switch v.(type) {
+	case *wirebson.Document, wirebson.RawDocument, *wirebson.Array, wirebson.RawArray, wirebson.Binary:
+		return "", false
+	}
+
+	b, err := wirebson.MustDocument("v", v).MarshalJSON()
+	if err != nil {
+		return "", false
+	}
+
+	// remove `{"v":` and `}`
+	res := strings.TrimSuffix(strings.TrimPrefix(string(b), `{"v":`), "}")
+	if len(res) > schemaMaxExampleLen {
+		return "", false
+	}
+
+	return res, true
+}
+
+// String returns a short description of the schema suitable for resource descriptions.
+func (cs *collectionSchema) String() string {
+	if len(cs.Fields) == 0 {
+		return "No documents sampled."
+	}
+
+	fields := make([]string, len(cs.Fields))
+
+	for i, f := range cs.Fields {
+		fields[i] = fmt.Sprintf("%s (%s, %.0f%%", f.Field, strings.Join(f.Types, "|"), f.Frequency*100)
+
+		if len(f.Examples) > 0 {
+			fields[i] += ", e.g. " + f.Examples[0]
+		}
+
+		fields[i] += ")"
+	}
+
+	return fmt.Sprintf("Fields inferred from %d sampled documents: %s.", cs.SampleSize, strings.Join(fields, "; "))
+}
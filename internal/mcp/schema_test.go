@@ -0,0 +1,139 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInferFields(t *testing.T) {
+	t.Parallel()
+
+	docs := []*wirebson.Document{
+		wirebson.MustDocument(
+			"_id", int32(1),
+			"name", "Jane Austen",
+			"born", time.Date(1775, 12, 16, 0, 0, 0, 0, time.UTC),
+			"address", wirebson.MustDocument("country", "UK"),
+		),
+		wirebson.MustDocument(
+			"_id", int32(2),
+			"name", "Herman Melville",
+			"tags", wirebson.MustArray("novelist"),
+		),
+		wirebson.MustDocument(
+			"_id", int64(3),
+			"name", "Jane Austen",
+		),
+		wirebson.MustDocument(
+			"_id", int32(4),
+			"name", wirebson.Null,
+		),
+	}
+
+	expected := []fieldSchema{{
+		Field:     "_id",
+		Types:     []string{"int", "long"},
+		Frequency: 1,
+		Examples:  []string{`{"$numberInt":"1"}`, `{"$numberInt":"2"}`, `{"$numberLong":"3"}`},
+	}, {
+		Field:     "name",
+		Types:     []string{"string", "null"},
+		Frequency: 1,
+		Examples:  []string{`"Jane Austen"`, `"Herman Melville"`, `null`},
+	}, {
+		Field:     "born",
+		Types:     []string{"date"},
+		Frequency: 0.25,
+		Examples:  []string{`{"$date":{"$numberLong":"-6123427200000"}}`},
+	}, {
+		Field:     "address",
+		Types:     []string{"object"},
+		Frequency: 0.25,
+	}, {
+		Field:     "address.country",
+		Types:     []string{"string"},
+		Frequency: 0.25,
+		Examples:  []string{`"UK"`},
+	}, {
+		Field:     "tags",
+		Types:     []string{"array"},
+		Frequency: 0.25,
+	}}
+
+	assert.Equal(t, expected, inferFields(docs))
+
+	assert.Empty(t, inferFields(nil))
+}
+
+func TestCollectionSchemaString(t *testing.T) {
+	t.Parallel()
+
+	cs := &collectionSchema{
+		SampleSize: 2,
+		Fields: []fieldSchema{{
+			Field:     "_id",
+			Types:     []string{"objectId"},
+			Frequency: 1,
+		}, {
+			Field:     "name",
+			Types:     []string{"string", "null"},
+			Frequency: 0.5,
+			Examples:  []string{`"Jane"`},
+		}},
+	}
+
+	expected := `Fields inferred from 2 sampled documents: _id (objectId, 100%); name (string|null, 50%, e.g. "Jane").`
+	assert.Equal(t, expected, cs.String())
+
+	assert.Equal(t, "No documents sampled.", new(collectionSchema).String())
+}
+
+func TestParseURI(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		database   string
+		collection string
+	}{
+		{database: "db"},
+		{database: "db", collection: "coll"},
+		{database: "my db", collection: "a/b?c#d"},
+	} {
+		uri := databaseURI(tc.database)
+		if tc.collection != "" {
+			uri = collectionURI(tc.database, tc.collection)
+		}
+
+		database, collection, err := parseURI(uri)
+		require.NoError(t, err, uri)
+		assert.Equal(t, tc.database, database, uri)
+		assert.Equal(t, tc.collection, collection, uri)
+	}
+
+	for _, uri := range []string{
+		"file:///db",
+		"ferretdb://host/db",
+		"ferretdb:///",
+	} {
+		_, _, err := parseURI(uri)
+		assert.Error(t, err, uri)
+	}
+}
@@ -18,37 +18,129 @@
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
+	"time"
 
+	"github.com/AlekSi/lazyerrors"
+	"github.com/AlekSi/pointer"
 	"github.com/FerretDB/wire/wirebson"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"go.mongodb.org/mongo-driver/v2/bson"
 
+	"github.com/FerretDB/FerretDB/v2/build/version"
 	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+)
+
+// Limits applied to tool calls, so agent queries can't overload the database.
+const (
+	// maxDocuments is the maximum number of documents returned by a single tool call.
+	maxDocuments = 100
+
+	// maxTime is the maximum execution time of a single tool call.
+	maxTime = 30 * time.Second
+
+	// maxResultSize is the maximum size of a tool result in bytes.
+	maxResultSize = 1024 * 1024
 )
 
 // server handles MCP request.
 type server struct {
-	l *slog.Logger
-	m *middleware.Middleware
+	l           *slog.Logger
+	m           *middleware.Middleware
+	allowWrites bool
 }
 
-// newServer creates a new server with the given parameter.
-func newServer(l *slog.Logger, m *middleware.Middleware) *server {
+// newServer creates a new server with the given parameters.
+func newServer(l *slog.Logger, m *middleware.Middleware, allowWrites bool) *server {
 	return &server{
-		l: l,
-		m: m,
+		l:           l,
+		m:           m,
+		allowWrites: allowWrites,
 	}
 }
 
+// newMCPServer creates a new MCP server with tools, resources, and prompts.
+//
+// A new MCP server is created for each session,
+// so registered resources are specific to databases and collections accessible by the session's user.
+func (s *server) newMCPServer() *mcp.Server {
+	var srv *mcp.Server
+
+	srv = mcp.NewServer(
+		&mcp.Implementation{Name: "FerretDB", Version: version.Get().Version},
+		&mcp.ServerOptions{
+			// called with the session's context, so resources are listed as the authenticated user
+			InitializedHandler: func(ctx context.Context, _ *mcp.ServerSession, _ *mcp.InitializedParams) {
+				s.addResources(ctx, srv)
+			},
+		},
+	)
+
+	s.addTools(srv)
+	s.addResourceTemplates(srv)
+	s.addPrompts(srv)
+
+	return srv
+}
+
 // addTools adds available MCP tools for the given mcp server.
+//
+// Tools that modify data are added only if writes are allowed.
 func (s *server) addTools(srv *mcp.Server) {
 	// sorted alphabetically
+	mcp.AddTool(
+		srv,
+		&mcp.Tool{
+			Name:        "aggregate",
+			Description: fmt.Sprintf("Runs the aggregation pipeline and returns up to %d documents.", maxDocuments),
+			Annotations: readOnlyAnnotations("Aggregate documents"),
+		},
+		s.aggregate,
+	)
+	mcp.AddTool(
+		srv,
+		&mcp.Tool{
+			Name:        "collStats",
+			Description: "Returns storage statistics of the collection.",
+			Annotations: readOnlyAnnotations("Collection statistics"),
+		},
+		s.collStats,
+	)
+	mcp.AddTool(
+		srv,
+		&mcp.Tool{
+			Name:        "count",
+			Description: "Returns the number of documents matched by the filter.",
+			Annotations: readOnlyAnnotations("Count documents"),
+		},
+		s.count,
+	)
+	mcp.AddTool(
+		srv,
+		&mcp.Tool{
+			Name:        "distinct",
+			Description: "Returns distinct values of the field in documents matched by the filter.",
+			Annotations: readOnlyAnnotations("Distinct values"),
+		},
+		s.distinct,
+	)
+	mcp.AddTool(
+		srv,
+		&mcp.Tool{
+			Name:        "explain",
+			Description: "Returns the query plan of the find query or aggregation pipeline.",
+			Annotations: readOnlyAnnotations("Explain query"),
+		},
+		s.explain,
+	)
 	mcp.AddTool(
 		srv,
 		&mcp.Tool{
 			Name:        "find",
-			Description: "Returns documents matched by the query.",
+			Description: fmt.Sprintf("Returns up to %d documents matched by the query.", maxDocuments),
+			Annotations: readOnlyAnnotations("Find documents"),
 		},
 		s.find,
 	)
@@ -57,6 +149,7 @@ func (s *server) addTools(srv *mcp.Server) {
 		&mcp.Tool{
 			Name:        "listCollections",
 			Description: "Returns the information of the collections and views in the database.",
+			Annotations: readOnlyAnnotations("List collections"),
 		},
 		s.listCollections,
 	)
@@ -65,13 +158,202 @@ func (s *server) addTools(srv *mcp.Server) {
 		&mcp.Tool{
 			Name:        "listDatabases",
 			Description: "Returns a summary of all databases.",
+			Annotations: readOnlyAnnotations("List databases"),
 		},
 		s.listDatabases,
 	)
+	mcp.AddTool(
+		srv,
+		&mcp.Tool{
+			Name:        "listIndexes",
+			Description: "Returns indexes of the collection.",
+			Annotations: readOnlyAnnotations("List indexes"),
+		},
+		s.listIndexes,
+	)
+
+	if !s.allowWrites {
+		return
+	}
+
+	// sorted alphabetically
+	mcp.AddTool(
+		srv,
+		&mcp.Tool{
+			Name:        "createCollection",
+			Description: "Creates a new collection. With dryRun, only checks that the collection does not exist.",
+			Annotations: writeAnnotations("Create collection", false, true),
+		},
+		s.createCollection,
+	)
+	mcp.AddTool(
+		srv,
+		&mcp.Tool{
+			Name:        "createIndex",
+			Description: "Creates an index on the collection. With dryRun, returns the number of documents to index.",
+			Annotations: writeAnnotations("Create index", false, true),
+		},
+		s.createIndex,
+	)
+	mcp.AddTool(
+		srv,
+		&mcp.Tool{
+			Name:        "deleteMany",
+			Description: "Deletes all documents matched by the filter. With dryRun, returns the number of matched documents.",
+			Annotations: writeAnnotations("Delete documents", true, true),
+		},
+		s.deleteMany,
+	)
+	mcp.AddTool(
+		srv,
+		&mcp.Tool{
+			Name:        "dropCollection",
+			Description: "Drops the collection with all its documents and indexes. With dryRun, returns the number of documents.",
+			Annotations: writeAnnotations("Drop collection", true, true),
+		},
+		s.dropCollection,
+	)
+	mcp.AddTool(
+		srv,
+		&mcp.Tool{
+			Name:        "insertMany",
+			Description: "Inserts documents into the collection. With dryRun, returns the number of documents to insert.",
+			Annotations: writeAnnotations("Insert documents", false, false),
+		},
+		s.insertMany,
+	)
+	mcp.AddTool(
+		srv,
+		&mcp.Tool{
+			Name:        "updateMany",
+			Description: "Updates all documents matched by the filter. With dryRun, returns the number of matched documents.",
+			Annotations: writeAnnotations("Update documents", true, false),
+		},
+		s.updateMany,
+	)
+}
+
+// readOnlyAnnotations returns annotations for tools that do not modify data.
+func readOnlyAnnotations(title string) *mcp.ToolAnnotations {
+	return &mcp.ToolAnnotations{
+		Title:         title,
+		ReadOnlyHint:  true,
+		OpenWorldHint: pointer.To(false),
+	}
+}
+
+// writeAnnotations returns annotations for tools that modify data.
+// Destructive tools may delete or overwrite existing data;
+// idempotent tools have no additional effect when called repeatedly with the same arguments.
+func writeAnnotations(title string, destructive, idempotent bool) *mcp.ToolAnnotations {
+	return &mcp.ToolAnnotations{
+		Title:           title,
+		DestructiveHint: pointer.To(destructive),
+		IdempotentHint:  idempotent,
+		OpenWorldHint:   pointer.To(false),
+	}
 }
 
 // handle sends the request document to the middleware and returns result used by MCP tool.
 func (s *server) handle(ctx context.Context, reqDoc *wirebson.Document) (*mcp.CallToolResult, error) {
+	resp, err := s.run(ctx, reqDoc)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.result(ctx, resp.DocumentRaw(), !resp.OK())
+}
+
+// handleCursor is like [server.handle], but for commands that return a cursor.
+// It closes the cursor after the first batch, so results are limited by the batch size.
+func (s *server) handleCursor(ctx context.Context, reqDoc *wirebson.Document) (*mcp.CallToolResult, error) {
+	resp, err := s.run(ctx, reqDoc)
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.OK() {
+		return s.result(ctx, resp.DocumentRaw(), true)
+	}
+
+	raw, ok := resp.Document().Get("cursor").(wirebson.AnyDocument)
+	if !ok {
+		return nil, lazyerrors.New("no cursor in response")
+	}
+
+	cursor, err := raw.Decode()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	s.killCursor(ctx, reqDoc, cursor)
+
+	return s.result(ctx, resp.DocumentRaw(), false)
+}
+
+// firstBatch sends the request document for the command that returns a cursor,
+// and returns the deeply decoded first batch.
+// The cursor is closed after that.
+func (s *server) firstBatch(ctx context.Context, reqDoc *wirebson.Document) (*wirebson.Array, error) {
+	resp, err := s.run(ctx, reqDoc)
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.OK() {
+		return nil, resp.MongoError()
+	}
+
+	raw, ok := resp.Document().Get("cursor").(wirebson.AnyDocument)
+	if !ok {
+		return nil, lazyerrors.New("no cursor in response")
+	}
+
+	cursor, err := raw.Decode()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	s.killCursor(ctx, reqDoc, cursor)
+
+	batch, ok := cursor.Get("firstBatch").(wirebson.RawArray)
+	if !ok {
+		return nil, lazyerrors.New("no firstBatch in cursor")
+	}
+
+	arr, err := batch.DecodeDeep()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return arr, nil
+}
+
+// killCursor kills the cursor returned for the given request document, if it is not exhausted.
+func (s *server) killCursor(ctx context.Context, reqDoc, cursor *wirebson.Document) {
+	id, _ := cursor.Get("id").(int64)
+	if id == 0 {
+		return
+	}
+
+	killReq := wirebson.MustDocument(
+		"killCursors", reqDoc.Get(reqDoc.Command()),
+		"cursors", wirebson.MustArray(id),
+		"$db", reqDoc.Get("$db"),
+	)
+
+	if _, err := s.run(context.WithoutCancel(ctx), killReq); err != nil {
+		s.l.WarnContext(ctx, "Failed to kill cursor", slog.Int64("id", id), slog.String("error", err.Error()))
+	}
+}
+
+// run sends the request document to the middleware and returns the response.
+//
+// The execution time is limited by [maxTime].
+func (s *server) run(ctx context.Context, reqDoc *wirebson.Document) (*middleware.Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, maxTime)
+	defer cancel()
+
 	req, err := middleware.RequestDoc(reqDoc)
 	if err != nil {
 		return nil, err
@@ -82,8 +364,13 @@ func (s *server) handle(ctx context.Context, reqDoc *wirebson.Document) (*mcp.Ca
 		return nil, errors.New("internal error")
 	}
 
-	doc, err := resp.DocumentRaw().DecodeDeep()
-	if doc == nil {
+	return resp, nil
+}
+
+// result returns MCP tool result with the given document.
+func (s *server) result(ctx context.Context, raw wirebson.RawDocument, isError bool) (*mcp.CallToolResult, error) {
+	doc, err := raw.DecodeDeep()
+	if err != nil {
 		return nil, err
 	}
 
@@ -92,9 +379,16 @@ func (s *server) handle(ctx context.Context, reqDoc *wirebson.Document) (*mcp.Ca
 		return nil, err
 	}
 
+	if len(b) > maxResultSize {
+		return nil, fmt.Errorf(
+			"result is too large (%d bytes, the limit is %d bytes); use a filter, projection, or smaller limit",
+			len(b), maxResultSize,
+		)
+	}
+
 	res := &mcp.CallToolResultFor[any]{
 		Content: []mcp.Content{&mcp.TextContent{Text: string(b)}},
-		IsError: !resp.OK(),
+		IsError: isError,
 	}
 
 	if s.l.Enabled(ctx, slog.LevelDebug) {
@@ -104,9 +398,134 @@ func (s *server) handle(ctx context.Context, reqDoc *wirebson.Document) (*mcp.Ca
 	return res, nil
 }
 
+// dryRun returns the result of a dry run with the number of documents
+// in the collection matched by the filter, without modifying data.
+func (s *server) dryRun(ctx context.Context, database, collection string, filter wirebson.RawDocument) (*mcp.CallToolResult, error) { //nolint:lll // for readability
+	if filter == nil {
+		filter = must.NotFail(wirebson.MustDocument().Encode())
+	}
+
+	resp, err := s.run(ctx, wirebson.MustDocument(
+		"count", collection,
+		"query", filter,
+		"$db", database,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.OK() {
+		return s.result(ctx, resp.DocumentRaw(), true)
+	}
+
+	return s.dryRunResult(ctx, resp.Document().Get("n"))
+}
+
+// dryRunResult returns the result of a dry run with the given number of affected documents.
+func (s *server) dryRunResult(ctx context.Context, n any) (*mcp.CallToolResult, error) {
+	raw, err := wirebson.MustDocument("dryRun", true, "n", n).Encode()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return s.result(ctx, raw, false)
+}
+
+// toDocument converts the tool argument with extended JSON v2 object to a wirebson.RawDocument.
+// It returns nil for nil argument.
+func toDocument(v map[string]any) (wirebson.RawDocument, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	res, err := fromExtendedJSON(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(wirebson.RawDocument), nil
+}
+
+// toArray converts the tool argument with extended JSON v2 objects to a wirebson.RawArray.
+func toArray(v []map[string]any) (wirebson.RawArray, error) {
+	arr := wirebson.MakeArray(len(v))
+
+	for _, e := range v {
+		doc, err := toDocument(e)
+		if err != nil {
+			return nil, err
+		}
+
+		if doc == nil {
+			return nil, errors.New("null is not a document")
+		}
+
+		if err = arr.Add(doc); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+	}
+
+	return arr.Encode()
+}
+
+// sortKey represents a single field of the sort order.
+type sortKey struct {
+	Field string `json:"field"`
+	Order int32  `json:"order" jsonschema:"1 for ascending, -1 for descending"`
+}
+
+// toSort converts the tool argument with sort order to a wirebson.Document.
+// It returns nil for empty argument.
+func toSort(keys []sortKey) (*wirebson.Document, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	res := wirebson.MakeDocument(len(keys))
+
+	for _, k := range keys {
+		if k.Order != 1 && k.Order != -1 {
+			return nil, fmt.Errorf("invalid sort order %d for field %q", k.Order, k.Field)
+		}
+
+		if err := res.Add(k.Field, k.Order); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+	}
+
+	return res, nil
+}
+
+// toPipeline converts the tool argument with aggregation pipeline to a wirebson.RawArray.
+// Stages that write data are rejected, so only write tools can modify data.
+func toPipeline(stages []map[string]any) (wirebson.RawArray, error) {
+	for _, stage := range stages {
+		for _, name := range []string{"$out", "$merge"} {
+			if _, ok := stage[name]; ok {
+				return nil, fmt.Errorf("%s stage is not allowed", name)
+			}
+		}
+	}
+
+	return toArray(stages)
+}
+
+// limit returns the number of documents to return for the given requested limit,
+// capped by [maxDocuments].
+func limit(l int64) int64 {
+	if l <= 0 || l > maxDocuments {
+		return maxDocuments
+	}
+
+	return l
+}
+
 // fromExtendedJSON converts raw encoded extended JSON v2 to a wirebson.RawDocument or wirebson.RawArray.
-//
-//nolint:unused // for now
 func fromExtendedJSON(b json.RawMessage) (any, error) {
 	var raw any
 
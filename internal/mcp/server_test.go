@@ -0,0 +1,165 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FerretDB/FerretDB/v2/internal/util/testutil"
+)
+
+func TestAddTools(t *testing.T) {
+	t.Parallel()
+
+	readTools := []string{
+		"aggregate", "collStats", "count", "distinct", "explain", "find", "listCollections", "listDatabases", "listIndexes",
+	}
+	writeTools := []string{"createCollection", "createIndex", "deleteMany", "dropCollection", "insertMany", "updateMany"}
+
+	for name, tc := range map[string]struct {
+		allowWrites bool
+		expected    []string
+	}{
+		"ReadOnly": {
+			expected: readTools,
+		},
+		"AllowWrites": {
+			allowWrites: true,
+			expected:    append(append([]string{}, readTools...), writeTools...),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+
+			srv := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+			newServer(testutil.Logger(t), nil, tc.allowWrites).addTools(srv)
+
+			st, ct := mcp.NewInMemoryTransports()
+
+			ss, err := srv.Connect(ctx, st)
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				assert.NoError(t, ss.Close())
+			})
+
+			cs, err := mcp.NewClient(&mcp.Implementation{Name: "test"}, nil).Connect(ctx, ct)
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				assert.NoError(t, cs.Close())
+			})
+
+			res, err := cs.ListTools(ctx, nil)
+			require.NoError(t, err)
+
+			var actual []string
+
+			for _, tool := range res.Tools {
+				actual = append(actual, tool.Name)
+
+				require.NotNil(t, tool.Annotations, tool.Name)
+				require.NotNil(t, tool.InputSchema, tool.Name)
+
+				if tool.Annotations.ReadOnlyHint {
+					assert.Contains(t, readTools, tool.Name)
+					continue
+				}
+
+				assert.Contains(t, writeTools, tool.Name)
+				assert.NotNil(t, tool.Annotations.DestructiveHint, tool.Name)
+				assert.Contains(t, tool.InputSchema.Properties, "dryRun", tool.Name)
+			}
+
+			assert.ElementsMatch(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestToArray(t *testing.T) {
+	t.Parallel()
+
+	arr, err := toArray([]map[string]any{
+		{"v": float64(42)},
+		{"v": map[string]any{"$numberLong": "42"}},
+	})
+	require.NoError(t, err)
+
+	actual, err := arr.DecodeDeep()
+	require.NoError(t, err)
+
+	expected := wirebson.MustArray(
+		wirebson.MustDocument("v", int32(42)),
+		wirebson.MustDocument("v", int64(42)),
+	)
+	testutil.AssertEqual(t, expected, actual)
+
+	_, err = toArray([]map[string]any{nil})
+	require.Error(t, err)
+}
+
+func TestToSort(t *testing.T) {
+	t.Parallel()
+
+	actual, err := toSort([]sortKey{{Field: "b", Order: -1}, {Field: "a", Order: 1}})
+	require.NoError(t, err)
+	testutil.AssertEqual(t, wirebson.MustDocument("b", int32(-1), "a", int32(1)), actual)
+
+	actual, err = toSort(nil)
+	require.NoError(t, err)
+	assert.Nil(t, actual)
+
+	_, err = toSort([]sortKey{{Field: "a", Order: 2}})
+	require.Error(t, err)
+}
+
+func TestToPipeline(t *testing.T) {
+	t.Parallel()
+
+	_, err := toPipeline([]map[string]any{{"$match": map[string]any{"v": 42}}})
+	require.NoError(t, err)
+
+	for _, stage := range []string{"$out", "$merge"} {
+		_, err = toPipeline([]map[string]any{{"$match": map[string]any{}}, {stage: "coll"}})
+		require.ErrorContains(t, err, stage)
+	}
+}
+
+func TestLimit(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, int64(maxDocuments), limit(0))
+	assert.Equal(t, int64(maxDocuments), limit(-1))
+	assert.Equal(t, int64(maxDocuments), limit(maxDocuments+1))
+	assert.Equal(t, int64(10), limit(10))
+}
+
+func TestResultSize(t *testing.T) {
+	t.Parallel()
+
+	s := newServer(testutil.Logger(t), nil, false)
+
+	raw, err := wirebson.MustDocument("v", strings.Repeat("x", maxResultSize)).Encode()
+	require.NoError(t, err)
+
+	_, err = s.result(t.Context(), raw, false)
+	require.ErrorContains(t, err, "result is too large")
+}
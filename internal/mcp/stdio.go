@@ -0,0 +1,96 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/FerretDB/FerretDB/v2/internal/clientconn/conninfo"
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
+)
+
+// Stdio represents MCP server that uses stdio transport.
+type Stdio struct {
+	opts *StdioOpts
+	srv  *server
+}
+
+// StdioOpts represents [NewStdio] options.
+type StdioOpts struct { //nolint:vet // for readability
+	L           *slog.Logger
+	M           *middleware.Middleware
+	Auth        bool
+	AllowWrites bool // enables tools that modify data
+
+	// Credentials used for all tool calls if authentication is enabled;
+	// API key takes precedence over username and password.
+	Username string
+	Password string
+	APIKey   string
+
+	Transport mcp.Transport // nil value means stdin and stdout
+}
+
+// NewStdio creates a new MCP server for stdio transport.
+// [Stdio.Run] must be called on the returned value.
+func NewStdio(opts *StdioOpts) *Stdio {
+	return &Stdio{
+		opts: opts,
+		srv:  newServer(opts.L, opts.M, opts.AllowWrites),
+	}
+}
+
+// Run runs MCP server until ctx is canceled or the client closes the connection (stdin).
+//
+// All tool calls are executed as the user authenticated with configured credentials.
+func (s *Stdio) Run(ctx context.Context) {
+	connInfo := conninfo.New()
+	defer connInfo.Close()
+
+	ctx = conninfo.Ctx(ctx, connInfo)
+
+	if s.opts.Auth {
+		var err error
+		if s.opts.APIKey != "" {
+			err = s.srv.authenticateAPIKey(ctx, s.opts.APIKey)
+		} else {
+			err = s.srv.authenticateSCRAM(ctx, s.opts.Username, s.opts.Password)
+		}
+
+		if err != nil {
+			s.opts.L.LogAttrs(ctx, slog.LevelError, "MCP stdio server failed to authenticate", logging.Error(err))
+			return
+		}
+	}
+
+	t := s.opts.Transport
+	if t == nil {
+		t = mcp.NewStdioTransport()
+	}
+
+	s.opts.L.InfoContext(ctx, "Starting MCP server on stdio")
+
+	err := s.srv.newMCPServer().Run(ctx, t)
+	if err != nil && !errors.Is(err, context.Canceled) {
+		s.opts.L.LogAttrs(ctx, slog.LevelError, "MCP stdio server exited with error", logging.Error(err))
+	}
+
+	s.opts.L.InfoContext(ctx, "MCP server stopped")
+}
@@ -0,0 +1,39 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/FerretDB/FerretDB/v2/internal/util/testutil"
+)
+
+func TestStdioNoCredentials(t *testing.T) {
+	t.Parallel()
+
+	st, _ := mcp.NewInMemoryTransports()
+
+	s := NewStdio(&StdioOpts{
+		L:         testutil.Logger(t),
+		Auth:      true,
+		Transport: st,
+	})
+
+	// Run should exit without serving (and without using the nil middleware)
+	// if credentials are not configured
+	s.Run(t.Context())
+}
@@ -0,0 +1,67 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// updateManyArgs represents the arguments for the updateMany tool.
+type updateManyArgs struct {
+	Collection string         `json:"collection"`
+	Database   string         `json:"database"`
+	Filter     map[string]any `json:"filter"           jsonschema:"query filter in MongoDB Extended JSON v2 format; empty object matches all documents"`
+	Update     map[string]any `json:"update"           jsonschema:"update operators like $set in MongoDB Extended JSON v2 format"`
+	Upsert     bool           `json:"upsert,omitempty" jsonschema:"insert a new document if no documents match the filter"`
+	DryRun     bool           `json:"dryRun,omitempty" jsonschema:"only return the number of documents matched by the filter"`
+}
+
+// updateMany updates documents matched by the filter.
+func (s *server) updateMany(ctx context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[updateManyArgs]) (*mcp.CallToolResult, error) { //nolint:lll // for readability
+	if s.l.Enabled(ctx, slog.LevelDebug) {
+		s.l.DebugContext(ctx, "updateMany", slog.Any("params", params))
+	}
+
+	filter, err := toDocument(params.Arguments.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	update, err := toDocument(params.Arguments.Update)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Arguments.DryRun {
+		return s.dryRun(ctx, params.Arguments.Database, params.Arguments.Collection, filter)
+	}
+
+	req := wirebson.MustDocument(
+		"update", params.Arguments.Collection,
+		"updates", wirebson.MustArray(wirebson.MustDocument(
+			"q", filter,
+			"u", update,
+			"multi", true,
+			"upsert", params.Arguments.Upsert,
+		)),
+		"$db", params.Arguments.Database,
+	)
+
+	return s.handle(ctx, req)
+}
@@ -26,13 +26,13 @@
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5/pgconn"
 	"go.mongodb.org/mongo-driver/v2/mongo"
-	"go.opentelemetry.io/otel"
 	otelcodes "go.opentelemetry.io/otel/codes"
 	oteltrace "go.opentelemetry.io/otel/trace"
 
 	"github.com/FerretDB/FerretDB/v2/internal/util/devbuild"
 	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
 	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+	"github.com/FerretDB/FerretDB/v2/internal/util/observability"
 )
 
 //go:generate go run ./generate.go
@@ -211,7 +211,7 @@ func MapWrappedCode(code int32) Code {
 // The whole function is temporary workaround for:
 // TODO https://github.com/FerretDB/FerretDB-DocumentDB/issues/895
 func MapWriteErrors(ctx context.Context, res wirebson.AnyDocument) wirebson.AnyDocument {
-	_, span := otel.Tracer("").Start(ctx, "mongoerrors.MapWriteErrors")
+	_, span := observability.Tracer(ctx).Start(ctx, "mongoerrors.MapWriteErrors")
 	defer span.End()
 
 	resDoc := must.NotFail(res.Decode())
@@ -0,0 +1,538 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replay
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire"
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/FerretDB/wire/wireclient"
+
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/util/bsondiff"
+	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+)
+
+// skippedCommands contains commands that are not replayed.
+// Recorded authentication conversations can't be replayed;
+// the connection is authenticated with credentials from the target URI instead.
+var skippedCommands = []string{
+	"authenticate",
+	"authenticateAPIKey",
+	"logout",
+	"saslContinue",
+	"saslStart",
+}
+
+// Opts represents [Replay] options.
+//
+//nolint:vet // for readability
+type Opts struct {
+	// URI is a MongoDB URI of the target; it may contain credentials.
+	URI string
+
+	// PreserveTiming, if true, starts sessions concurrently at the recorded offsets
+	// relative to the first session.
+	// Otherwise, sessions are replayed one by one.
+	PreserveTiming bool
+
+	// Rules are used to normalize responses before comparison.
+	// Nil value means [bsondiff.DefaultRules], the same as in diff modes.
+	Rules *bsondiff.Rules
+
+	// Report, if not nil, receives a single line of Canonical Extended JSON v2
+	// for every request with differing or failed response.
+	Report io.Writer
+
+	L *slog.Logger
+}
+
+// Summary represents replay results.
+type Summary struct {
+	Sessions int // replayed sessions
+	Requests int // all recorded requests
+	Skipped  int // not replayed requests
+	Matched  int // requests with matching responses
+	Differed int // requests with differing responses
+	Failed   int // requests that failed due to connection or protocol errors
+	Unknown  int // requests without responses (for example, with moreToCome flag)
+
+	// Sessions that were not replayed because they have no recorded responses
+	// (for example, recorded by older versions without .resp files).
+	// Their requests are not counted in other fields.
+	NoResponses int
+}
+
+// Regressed returns true if some requests differed or failed.
+func (s *Summary) Regressed() bool {
+	return s.Differed > 0 || s.Failed > 0
+}
+
+// String implements [fmt.Stringer].
+func (s *Summary) String() string {
+	return fmt.Sprintf(
+		"sessions: %d, requests: %d, skipped: %d, matched: %d, differed: %d, failed: %d, unknown: %d, "+
+			"sessions without recorded responses: %d",
+		s.Sessions, s.Requests, s.Skipped, s.Matched, s.Differed, s.Failed, s.Unknown, s.NoResponses,
+	)
+}
+
+// replayer holds the state shared by all sessions.
+type replayer struct {
+	opts  *Opts
+	rules *bsondiff.Rules
+
+	m       sync.Mutex
+	summary Summary
+}
+
+// Replay replays the given sessions against the target and compares responses with recorded ones.
+//
+// The returned error is only for problems that prevent replaying;
+// problems with individual sessions and requests are counted in the returned summary.
+func Replay(ctx context.Context, sessions []*Session, opts *Opts) (*Summary, error) {
+	must.NotBeZero(opts)
+
+	if _, _, _, _, err := wireclient.Credentials(opts.URI); err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	r := &replayer{
+		opts:  opts,
+		rules: opts.Rules,
+	}
+
+	if r.rules == nil {
+		r.rules = bsondiff.DefaultRules()
+	}
+
+	if !opts.PreserveTiming || len(sessions) == 0 {
+		for _, s := range sessions {
+			if ctx.Err() != nil {
+				break
+			}
+
+			r.replaySession(ctx, s)
+		}
+
+		return &r.summary, nil
+	}
+
+	start := time.Now()
+	first := sessions[0].Start
+
+	var wg sync.WaitGroup
+
+	for _, s := range sessions {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Until(start.Add(s.Start.Sub(first)))):
+			}
+
+			r.replaySession(ctx, s)
+		}()
+	}
+
+	wg.Wait()
+
+	return &r.summary, nil
+}
+
+// replaySession replays a single session using a new connection.
+func (r *replayer) replaySession(ctx context.Context, s *Session) {
+	l := r.opts.L.With(slog.String("file", s.File))
+
+	if s.Responses == nil {
+		l.WarnContext(ctx, "No recorded responses, session is not replayed")
+		r.count(func(summary *Summary) { summary.NoResponses++ })
+
+		return
+	}
+
+	r.count(func(summary *Summary) { summary.Sessions++; summary.Requests += len(s.Requests) })
+
+	conn, err := connect(ctx, r.opts.URI, l)
+	if err != nil {
+		l.ErrorContext(ctx, "Failed to connect", logging.Error(err))
+		r.count(func(summary *Summary) { summary.Failed += len(s.Requests) })
+
+		return
+	}
+
+	defer func() {
+		if err = conn.Close(); err != nil {
+			l.WarnContext(ctx, "Failed to close connection", logging.Error(err))
+		}
+	}()
+
+	// recorded cursor ID -> actual cursor ID
+	cursors := map[int64]int64{}
+
+	for i, req := range s.Requests {
+		if slices.Contains(skippedCommands, req.Document().Command()) {
+			r.count(func(summary *Summary) { summary.Skipped++ })
+			continue
+		}
+
+		body, err := rewriteRequest(req, cursors)
+		if err != nil {
+			l.WarnContext(ctx, "Failed to rewrite request, sending as is", slog.Int("index", i), logging.Error(err))
+			body = req.WireBody()
+		}
+
+		if msg, ok := body.(*wire.OpMsg); ok && msg.Flags.FlagSet(wire.OpMsgMoreToCome) {
+			// there is no response
+			header := &wire.MsgHeader{
+				MessageLength: int32(wire.MsgHeaderLen + msg.Size()),
+				RequestID:     req.WireHeader().RequestID,
+				OpCode:        wire.OpCodeMsg,
+			}
+
+			if err = conn.Write(ctx, header, body); err != nil {
+				r.fail(ctx, s, i, req, err)
+				r.count(func(summary *Summary) { summary.Failed += len(s.Requests) - i - 1 })
+
+				return
+			}
+
+			r.count(func(summary *Summary) { summary.Unknown++ })
+
+			continue
+		}
+
+		resHeader, resBody, err := conn.Request(ctx, body)
+		if err == nil {
+			var actual *middleware.Response
+			if actual, err = middleware.ResponseWire(resHeader, resBody); err == nil {
+				expected := s.Responses[req.WireHeader().RequestID]
+				mapCursor(cursors, expected, actual)
+				r.compare(ctx, s, i, req, expected, actual)
+
+				continue
+			}
+		}
+
+		// the connection state is unknown; abort the session
+		r.fail(ctx, s, i, req, err)
+		r.count(func(summary *Summary) { summary.Failed += len(s.Requests) - i - 1 })
+
+		return
+	}
+}
+
+// compare compares recorded and actual responses, updates summary, and writes report.
+func (r *replayer) compare(ctx context.Context, s *Session, i int, req *middleware.Request, expected, actual *middleware.Response) {
+	if expected == nil {
+		r.count(func(summary *Summary) { summary.Unknown++ })
+		return
+	}
+
+	diffs, err := r.diff(expected, actual)
+	if err != nil {
+		r.fail(ctx, s, i, req, err)
+		return
+	}
+
+	if len(diffs) == 0 {
+		r.count(func(summary *Summary) { summary.Matched++ })
+		return
+	}
+
+	r.count(func(summary *Summary) { summary.Differed++ })
+
+	r.opts.L.DebugContext(
+		ctx, "Response differs",
+		slog.String("file", s.File), slog.Int("index", i), slog.Int("differences", len(diffs)),
+	)
+
+	r.report(ctx, s, i, req, expected, actual, diffs, nil)
+}
+
+// diff returns differences between normalized recorded and actual responses.
+func (r *replayer) diff(expected, actual *middleware.Response) ([]bsondiff.Difference, error) {
+	e, err := r.rules.Normalize(expected.DocumentRaw())
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	a, err := r.rules.Normalize(actual.DocumentRaw())
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return bsondiff.Compare(e, a)
+}
+
+// fail counts the failed request and writes report.
+func (r *replayer) fail(ctx context.Context, s *Session, i int, req *middleware.Request, err error) {
+	r.count(func(summary *Summary) { summary.Failed++ })
+
+	r.opts.L.WarnContext(ctx, "Request failed", slog.String("file", s.File), slog.Int("index", i), logging.Error(err))
+
+	r.report(ctx, s, i, req, s.Responses[req.WireHeader().RequestID], nil, nil, err)
+}
+
+// count updates summary under the lock.
+func (r *replayer) count(f func(*Summary)) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	f(&r.summary)
+}
+
+// report writes a single report line if the report writer is set.
+func (r *replayer) report(ctx context.Context, s *Session, i int, req *middleware.Request, expected, actual *middleware.Response, diffs []bsondiff.Difference, reqErr error) {
+	if r.opts.Report == nil {
+		return
+	}
+
+	doc, err := reportDoc(s, i, req, expected, actual, diffs, reqErr)
+	if err == nil {
+		var b []byte
+		if b, err = doc.MarshalJSON(); err == nil {
+			r.m.Lock()
+			_, err = r.opts.Report.Write(append(b, '\n'))
+			r.m.Unlock()
+		}
+	}
+
+	if err != nil {
+		r.opts.L.WarnContext(ctx, "Failed to write report", logging.Error(err))
+	}
+}
+
+// reportDoc returns a report document.
+func reportDoc(s *Session, i int, req *middleware.Request, expected, actual *middleware.Response, diffs []bsondiff.Difference, reqErr error) (*wirebson.Document, error) {
+	command := req.Document().Command()
+
+	namespace, _ := req.Document().Get("$db").(string)
+	if collection, ok := req.Document().Get(command).(string); ok && namespace != "" {
+		namespace += "." + collection
+	}
+
+	reqDoc, err := req.DocumentDeep()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	res := wirebson.MustDocument(
+		"file", s.File,
+		"index", int32(i),
+		"command", command,
+		"namespace", namespace,
+		"request", reqDoc,
+	)
+
+	for _, r := range []struct {
+		name string
+		resp *middleware.Response
+	}{
+		{"expected", expected},
+		{"actual", actual},
+	} {
+		if r.resp == nil {
+			continue
+		}
+
+		var doc *wirebson.Document
+		if doc, err = r.resp.DocumentDeep(); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		if err = res.Add(r.name, doc); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+	}
+
+	if reqErr != nil {
+		if err = res.Add("error", reqErr.Error()); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+	}
+
+	if diffs == nil {
+		return res, nil
+	}
+
+	differences := wirebson.MakeArray(len(diffs))
+
+	for _, d := range diffs {
+		doc := wirebson.MustDocument("path", d.Path)
+
+		if d.A != nil {
+			if err = doc.Add("expected", d.A); err != nil {
+				return nil, lazyerrors.Error(err)
+			}
+		}
+
+		if d.B != nil {
+			if err = doc.Add("actual", d.B); err != nil {
+				return nil, lazyerrors.Error(err)
+			}
+		}
+
+		if err = differences.Add(doc); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+	}
+
+	if err = res.Add("differences", differences); err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return res, nil
+}
+
+// connect establishes a new connection and authenticates it if the URI contains credentials.
+func connect(ctx context.Context, uri string, l *slog.Logger) (*wireclient.Conn, error) {
+	cleanURI, userinfo, authSource, authMechanism, err := wireclient.Credentials(uri)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	conn, err := wireclient.Connect(ctx, cleanURI, l)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	if userinfo == nil {
+		return conn, nil
+	}
+
+	if err = conn.Login(ctx, userinfo, authSource, authMechanism); err != nil {
+		_ = conn.Close()
+		return nil, lazyerrors.Error(err)
+	}
+
+	return conn, nil
+}
+
+// rewriteRequest returns the request body suitable for replaying:
+// cluster time and speculative authentication are removed,
+// and recorded cursor IDs are replaced with actual ones.
+//
+// Requests that are not OP_MSG with a single section are returned as is.
+func rewriteRequest(req *middleware.Request, cursors map[int64]int64) (wire.MsgBody, error) {
+	msg, ok := req.WireBody().(*wire.OpMsg)
+	if !ok {
+		return req.WireBody(), nil
+	}
+
+	if _, err := msg.DocumentRaw(); err != nil {
+		// document sequences
+		return msg, nil
+	}
+
+	doc, err := msg.Document()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	doc.Remove("$clusterTime")
+	doc.Remove("speculativeAuthenticate")
+
+	switch doc.Command() {
+	case "getMore":
+		if id, ok := doc.Get("getMore").(int64); ok {
+			if actual, ok := cursors[id]; ok {
+				if err = doc.Replace("getMore", actual); err != nil {
+					return nil, lazyerrors.Error(err)
+				}
+			}
+		}
+
+	case "killCursors":
+		if ids, ok := doc.Get("cursors").(wirebson.AnyArray); ok {
+			var arr *wirebson.Array
+			if arr, err = ids.Decode(); err != nil {
+				return nil, lazyerrors.Error(err)
+			}
+
+			res := wirebson.MakeArray(arr.Len())
+
+			for v := range arr.Values() {
+				if id, ok := v.(int64); ok {
+					if actual, ok := cursors[id]; ok {
+						v = actual
+					}
+				}
+
+				if err = res.Add(v); err != nil {
+					return nil, lazyerrors.Error(err)
+				}
+			}
+
+			if err = doc.Replace("cursors", res); err != nil {
+				return nil, lazyerrors.Error(err)
+			}
+		}
+	}
+
+	res, err := wire.NewOpMsg(doc)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	// checksum is not computed, and exhaust responses are not supported
+	res.Flags = msg.Flags & wire.OpMsgFlags(wire.OpMsgMoreToCome)
+
+	return res, nil
+}
+
+// mapCursor remembers the mapping between recorded and actual cursor IDs.
+func mapCursor(cursors map[int64]int64, expected, actual *middleware.Response) {
+	if expected == nil {
+		return
+	}
+
+	e := cursorID(expected)
+	a := cursorID(actual)
+
+	if e != 0 && a != 0 {
+		cursors[e] = a
+	}
+}
+
+// cursorID returns the cursor ID from the response, or 0.
+func cursorID(resp *middleware.Response) int64 {
+	c, ok := resp.Document().Get("cursor").(wirebson.AnyDocument)
+	if !ok {
+		return 0
+	}
+
+	doc, err := c.Decode()
+	if err != nil {
+		return 0
+	}
+
+	id, _ := doc.Get("id").(int64)
+
+	return id
+}
@@ -0,0 +1,246 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replay
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/FerretDB/wire"
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/FerretDB/wire/wireclient"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FerretDB/FerretDB/v2/internal/clientconn"
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+	"github.com/FerretDB/FerretDB/v2/internal/util/testutil"
+)
+
+// testHandler is a [middleware.Handler] that responds with the command name
+// and the optional extra field.
+type testHandler struct {
+	prometheus.Collector
+	extra bool
+}
+
+// Run implements [middleware.Handler].
+func (h *testHandler) Run(ctx context.Context) {
+	<-ctx.Done()
+}
+
+// Handle implements [middleware.Handler].
+func (h *testHandler) Handle(ctx context.Context, req *middleware.Request) (*middleware.Response, error) {
+	doc := wirebson.MustDocument(
+		"command", req.Document().Command(),
+		"localTime", time.Now(),
+		"ok", float64(1),
+	)
+
+	if h.extra {
+		must.NoError(doc.Add("extra", true))
+	}
+
+	return middleware.ResponseDoc(req, doc)
+}
+
+// setupListener starts a listener with [testHandler] and returns its URI.
+func setupListener(t *testing.T, extra bool, recordsDir string) string {
+	t.Helper()
+
+	m := middleware.New(&middleware.NewOpts{
+		Mode:    middleware.NormalMode,
+		DocDB:   &testHandler{Collector: prometheus.NewRegistry(), extra: extra},
+		Metrics: middleware.NewMetrics(),
+		L:       testutil.Logger(t),
+	})
+
+	l, err := clientconn.Listen(&clientconn.ListenerOpts{
+		M:              m,
+		Logger:         testutil.Logger(t),
+		TCP:            "127.0.0.1:0",
+		TestRecordsDir: recordsDir,
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(testutil.Ctx(t))
+	mDone := make(chan struct{})
+	lDone := make(chan struct{})
+
+	go func() {
+		defer close(mDone)
+		m.Run(ctx)
+	}()
+
+	go func() {
+		defer close(lDone)
+		l.Run(ctx)
+	}()
+
+	// prevent panic on logging after test ends
+	t.Cleanup(func() {
+		cancel()
+		<-lDone
+		<-mDone
+	})
+
+	return "mongodb://" + l.TCPAddr().String() + "/"
+}
+
+func TestReplay(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.Ctx(t)
+	dir := t.TempDir()
+
+	uri := setupListener(t, false, dir)
+
+	conn, err := wireclient.Connect(ctx, uri, testutil.Logger(t))
+	require.NoError(t, err)
+
+	for _, cmd := range []string{"ping", "find", "saslStart"} {
+		_, _, err = conn.Request(ctx, wire.MustOpMsg(cmd, int32(1), "$db", "test"))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, conn.Close())
+
+	var sessions []*Session
+
+	// files are renamed after the connection is closed on the server side
+	require.Eventually(t, func() bool {
+		sessions, err = Load(dir)
+		require.NoError(t, err)
+
+		return len(sessions) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.Len(t, sessions[0].Requests, 3)
+	require.Len(t, sessions[0].Responses, 3)
+
+	t.Run("Match", func(t *testing.T) {
+		t.Parallel()
+
+		var report bytes.Buffer
+
+		summary, err := Replay(ctx, sessions, &Opts{
+			URI:    setupListener(t, false, ""),
+			Report: &report,
+			L:      testutil.Logger(t),
+		})
+		require.NoError(t, err)
+
+		expected := &Summary{Sessions: 1, Requests: 3, Skipped: 1, Matched: 2}
+		assert.Equal(t, expected, summary)
+		assert.False(t, summary.Regressed())
+		assert.Empty(t, report.String())
+	})
+
+	t.Run("Differ", func(t *testing.T) {
+		t.Parallel()
+
+		var report bytes.Buffer
+
+		summary, err := Replay(ctx, sessions, &Opts{
+			URI:            setupListener(t, true, ""),
+			PreserveTiming: true,
+			Report:         &report,
+			L:              testutil.Logger(t),
+		})
+		require.NoError(t, err)
+
+		expected := &Summary{Sessions: 1, Requests: 3, Skipped: 1, Differed: 2}
+		assert.Equal(t, expected, summary)
+		assert.True(t, summary.Regressed())
+
+		lines := strings.Split(strings.TrimSpace(report.String()), "\n")
+		require.Len(t, lines, 2)
+		assert.Contains(t, lines[0], `"command":"ping"`)
+		assert.Contains(t, lines[0], `"differences":[{"path":"extra","actual":true}]`)
+	})
+
+	t.Run("NoResponses", func(t *testing.T) {
+		t.Parallel()
+
+		// copy requests without responses, like records made by older versions
+		oldDir := t.TempDir()
+		b, err := os.ReadFile(sessions[0].File)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(oldDir, filepath.Base(sessions[0].File)), b, 0o666))
+
+		oldSessions, err := Load(oldDir)
+		require.NoError(t, err)
+		require.Len(t, oldSessions, 1)
+		require.Nil(t, oldSessions[0].Responses)
+
+		var report bytes.Buffer
+
+		summary, err := Replay(ctx, oldSessions, &Opts{
+			URI:    setupListener(t, true, ""),
+			Report: &report,
+			L:      testutil.Logger(t),
+		})
+		require.NoError(t, err)
+
+		expected := &Summary{NoResponses: 1}
+		assert.Equal(t, expected, summary)
+		assert.False(t, summary.Regressed())
+		assert.Empty(t, report.String())
+	})
+}
+
+func TestRewriteRequest(t *testing.T) {
+	t.Parallel()
+
+	cursors := map[int64]int64{1: 42}
+
+	for name, tc := range map[string]struct {
+		req      *wirebson.Document
+		expected *wirebson.Document
+	}{
+		"GetMore": {
+			req:      wirebson.MustDocument("getMore", int64(1), "collection", "c", "$db", "test", "$clusterTime", int64(0)),
+			expected: wirebson.MustDocument("getMore", int64(42), "collection", "c", "$db", "test"),
+		},
+		"KillCursors": {
+			req:      wirebson.MustDocument("killCursors", "c", "cursors", wirebson.MustArray(int64(1), int64(2)), "$db", "test"),
+			expected: wirebson.MustDocument("killCursors", "c", "cursors", wirebson.MustArray(int64(42), int64(2)), "$db", "test"),
+		},
+		"Hello": {
+			req:      wirebson.MustDocument("hello", int32(1), "speculativeAuthenticate", wirebson.MustDocument(), "$db", "admin"),
+			expected: wirebson.MustDocument("hello", int32(1), "$db", "admin"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := must.NotFail(middleware.RequestDoc(tc.req))
+
+			body, err := rewriteRequest(req, cursors)
+			require.NoError(t, err)
+
+			actual, err := body.(*wire.OpMsg).DocumentRaw()
+			require.NoError(t, err)
+			assert.Equal(t, must.NotFail(tc.expected.Encode()), actual)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package replay provides replaying of recorded wire protocol traffic.
+//
+// Traffic is recorded by the wire protocol listener with the `--dev-records-dir` flag:
+// each client connection is stored as a .bin file with requests
+// and a .resp file with responses; file modification time is set to the connection start time.
+package replay
+
+import (
+	"bufio"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire"
+
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+)
+
+// Session represents a single recorded client connection.
+type Session struct {
+	// File is the path of the file with requests.
+	File string
+
+	// Start is the connection start time.
+	Start time.Time
+
+	// Requests contains recorded requests in order.
+	Requests []*middleware.Request
+
+	// Responses contains recorded responses by request ID.
+	// It is nil if responses were not recorded (there is no .resp file).
+	Responses map[int32]*middleware.Response
+}
+
+// Load finds all .bin files in the given directory recursively and parses them with
+// corresponding .resp files (if present).
+//
+// Sessions are sorted by start time.
+func Load(dir string) ([]*Session, error) {
+	var res []*Session
+
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		if entry.IsDir() || filepath.Ext(path) != ".bin" {
+			return nil
+		}
+
+		s, err := loadSession(path)
+		if err != nil {
+			return lazyerrors.Errorf("%s: %w", path, err)
+		}
+
+		res = append(res, s)
+
+		return nil
+	})
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	slices.SortStableFunc(res, func(a, b *Session) int {
+		if c := a.Start.Compare(b.Start); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.File, b.File)
+	})
+
+	return res, nil
+}
+
+// loadSession parses a single .bin file and the corresponding .resp file.
+func loadSession(path string) (*Session, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	res := &Session{
+		File:      path,
+		Start:     fi.ModTime(),
+		Responses: map[int32]*middleware.Response{},
+	}
+
+	err = readMessages(path, func(header *wire.MsgHeader, body wire.MsgBody) error {
+		req, e := middleware.RequestWire(header, body)
+		if e != nil {
+			return lazyerrors.Error(e)
+		}
+
+		res.Requests = append(res.Requests, req)
+
+		return nil
+	})
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	respPath := strings.TrimSuffix(path, ".bin") + ".resp"
+
+	err = readMessages(respPath, func(header *wire.MsgHeader, body wire.MsgBody) error {
+		resp, e := middleware.ResponseWire(header, body)
+		if e != nil {
+			return lazyerrors.Error(e)
+		}
+
+		res.Responses[header.ResponseTo] = resp
+
+		return nil
+	})
+
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		// responses were not recorded
+		res.Responses = nil
+	case err != nil:
+		return nil, lazyerrors.Error(err)
+	}
+
+	return res, nil
+}
+
+// readMessages reads all wire protocol messages from the given file and calls f for each of them.
+func readMessages(path string, f func(*wire.MsgHeader, wire.MsgBody) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	defer file.Close() //nolint:errcheck // we are only reading it
+
+	r := bufio.NewReader(file)
+
+	for {
+		header, body, err := wire.ReadMessage(r)
+		if errors.Is(err, wire.ErrZeroRead) {
+			return nil
+		}
+
+		if err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		if err = f(header, body); err != nil {
+			return lazyerrors.Error(err)
+		}
+	}
+}
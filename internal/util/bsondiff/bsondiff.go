@@ -0,0 +1,291 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bsondiff provides normalization and field-level comparison of BSON documents.
+//
+// It is used to compare responses of different handlers (for example, DocumentDB and proxy)
+// while ignoring volatile fields like timestamps and connection IDs.
+package bsondiff
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+)
+
+// DefaultIgnoredFields contains names of volatile response fields that are ignored by default.
+//
+// They are used by both diff modes and replay, so they agree on the same traffic.
+// Cursor IDs and speculative authentication results are always different
+// between servers and between runs.
+var DefaultIgnoredFields = []string{
+	"localTime",
+	"connectionId",
+	"operationTime",
+	"$clusterTime",
+	"cursor.id",
+	"speculativeAuthenticate",
+}
+
+// Rules represents normalization rules.
+type Rules struct {
+	// IgnoredFields contains fields that are removed from documents before comparison.
+	//
+	// A name without dots (e.g. "localTime") matches the field with that name at any depth.
+	// A dotted path (e.g. "cursor.id") matches the field at that exact path from the document root;
+	// array indexes are not a part of the path.
+	IgnoredFields []string
+}
+
+// DefaultRules returns rules with [DefaultIgnoredFields].
+func DefaultRules() *Rules {
+	return &Rules{
+		IgnoredFields: slices.Clone(DefaultIgnoredFields),
+	}
+}
+
+// Normalize returns a deeply decoded copy of the given document with ignored fields removed.
+func (r *Rules) Normalize(doc wirebson.AnyDocument) (*wirebson.Document, error) {
+	d, err := doc.Decode()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return r.normalizeDoc(d, "")
+}
+
+// ignored returns true if the field with the given name at the given path should be removed.
+func (r *Rules) ignored(name, path string) bool {
+	for _, f := range r.IgnoredFields {
+		if strings.Contains(f, ".") {
+			if f == path {
+				return true
+			}
+
+			continue
+		}
+
+		if f == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalizeDoc returns a copy of the document with ignored fields removed.
+func (r *Rules) normalizeDoc(doc *wirebson.Document, prefix string) (*wirebson.Document, error) {
+	res := wirebson.MakeDocument(doc.Len())
+
+	for name, v := range doc.All() {
+		path := join(prefix, name)
+		if r.ignored(name, path) {
+			continue
+		}
+
+		v, err := r.normalizeValue(v, path)
+		if err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		if err = res.Add(name, v); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+	}
+
+	return res, nil
+}
+
+// normalizeValue returns a normalized copy of composite values, and scalar values as is.
+func (r *Rules) normalizeValue(v any, path string) (any, error) {
+	switch v := v.(type) {
+	case wirebson.AnyDocument:
+		d, err := v.Decode()
+		if err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		return r.normalizeDoc(d, path)
+
+	case wirebson.AnyArray:
+		a, err := v.Decode()
+		if err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		res := wirebson.MakeArray(a.Len())
+
+		for e := range a.Values() {
+			// array indexes are not a part of the path
+			if e, err = r.normalizeValue(e, path); err != nil {
+				return nil, lazyerrors.Error(err)
+			}
+
+			if err = res.Add(e); err != nil {
+				return nil, lazyerrors.Error(err)
+			}
+		}
+
+		return res, nil
+
+	default:
+		return v, nil
+	}
+}
+
+// Difference represents a single field-level difference.
+type Difference struct {
+	// Path is a dotted path to the field; array elements are represented by indexes.
+	Path string
+
+	// A and B are values of the compared documents.
+	// nil means that the field is missing.
+	A, B any
+}
+
+// String implements [fmt.Stringer].
+func (d Difference) String() string {
+	return fmt.Sprintf("%s: %s != %s", d.Path, logValue(d.A), logValue(d.B))
+}
+
+// logValue returns a short representation of the value for String.
+func logValue(v any) string {
+	if v == nil {
+		return "<missing>"
+	}
+
+	return wirebson.LogMessage(v)
+}
+
+// Compare returns field-level differences between two documents.
+//
+// Documents are compared field by field regardless of the field order;
+// arrays are compared element by element.
+// Documents should be normalized with [Rules.Normalize] first, if needed.
+func Compare(a, b wirebson.AnyDocument) ([]Difference, error) {
+	var res []Difference
+	if err := compare(&res, "", a, b); err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return res, nil
+}
+
+// compare appends differences between two values to res.
+func compare(res *[]Difference, path string, a, b any) error {
+	switch a := a.(type) {
+	case wirebson.AnyDocument:
+		bDoc, ok := b.(wirebson.AnyDocument)
+		if !ok {
+			*res = append(*res, Difference{Path: path, A: a, B: b})
+			return nil
+		}
+
+		return compareDocs(res, path, a, bDoc)
+
+	case wirebson.AnyArray:
+		bArr, ok := b.(wirebson.AnyArray)
+		if !ok {
+			*res = append(*res, Difference{Path: path, A: a, B: b})
+			return nil
+		}
+
+		return compareArrays(res, path, a, bArr)
+
+	default:
+		if !wirebson.Equal(a, b) {
+			*res = append(*res, Difference{Path: path, A: a, B: b})
+		}
+
+		return nil
+	}
+}
+
+// compareDocs appends differences between two documents to res.
+func compareDocs(res *[]Difference, path string, a, b wirebson.AnyDocument) error {
+	aDoc, err := a.Decode()
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	bDoc, err := b.Decode()
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	for name, aV := range aDoc.All() {
+		p := join(path, name)
+
+		bV := bDoc.Get(name)
+		if bV == nil {
+			*res = append(*res, Difference{Path: p, A: aV})
+			continue
+		}
+
+		if err = compare(res, p, aV, bV); err != nil {
+			return lazyerrors.Error(err)
+		}
+	}
+
+	for name, bV := range bDoc.All() {
+		if aDoc.Get(name) == nil {
+			*res = append(*res, Difference{Path: join(path, name), B: bV})
+		}
+	}
+
+	return nil
+}
+
+// compareArrays appends differences between two arrays to res.
+func compareArrays(res *[]Difference, path string, a, b wirebson.AnyArray) error {
+	aArr, err := a.Decode()
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	bArr, err := b.Decode()
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	for i := range max(aArr.Len(), bArr.Len()) {
+		p := join(path, strconv.Itoa(i))
+
+		switch {
+		case i >= aArr.Len():
+			*res = append(*res, Difference{Path: p, B: bArr.Get(i)})
+		case i >= bArr.Len():
+			*res = append(*res, Difference{Path: p, A: aArr.Get(i)})
+		default:
+			if err = compare(res, p, aArr.Get(i), bArr.Get(i)); err != nil {
+				return lazyerrors.Error(err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// join returns a dotted path.
+func join(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+
+	return prefix + "." + name
+}
@@ -0,0 +1,102 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bsondiff
+
+import (
+	"testing"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+)
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+
+	doc := wirebson.MustDocument(
+		"ok", float64(1),
+		"localTime", int64(42),
+		"cursor", wirebson.MustDocument(
+			"id", int64(123),
+			"firstBatch", wirebson.MustArray(
+				wirebson.MustDocument("_id", int32(1), "connectionId", int32(2)),
+			),
+		),
+		"$clusterTime", wirebson.MustDocument("clusterTime", int64(1)),
+	)
+
+	actual, err := DefaultRules().Normalize(doc)
+	require.NoError(t, err)
+
+	expected := wirebson.MustDocument(
+		"ok", float64(1),
+		"cursor", wirebson.MustDocument(
+			"firstBatch", wirebson.MustArray(
+				wirebson.MustDocument("_id", int32(1)),
+			),
+		),
+	)
+	assert.Equal(t, must.NotFail(expected.Encode()), must.NotFail(actual.Encode()))
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		a, b     *wirebson.Document
+		expected []Difference
+	}{
+		"Equal": {
+			a: wirebson.MustDocument("a", int32(1), "b", "foo"),
+			b: wirebson.MustDocument("b", "foo", "a", int32(1)),
+		},
+		"Value": {
+			a:        wirebson.MustDocument("a", int32(1)),
+			b:        wirebson.MustDocument("a", int32(2)),
+			expected: []Difference{{Path: "a", A: int32(1), B: int32(2)}},
+		},
+		"Type": {
+			a:        wirebson.MustDocument("a", int32(1)),
+			b:        wirebson.MustDocument("a", int64(1)),
+			expected: []Difference{{Path: "a", A: int32(1), B: int64(1)}},
+		},
+		"Missing": {
+			a: wirebson.MustDocument("a", int32(1)),
+			b: wirebson.MustDocument("b", int32(1)),
+			expected: []Difference{
+				{Path: "a", A: int32(1)},
+				{Path: "b", B: int32(1)},
+			},
+		},
+		"Nested": {
+			a: wirebson.MustDocument("a", wirebson.MustDocument("b", wirebson.MustArray(int32(1), int32(2)))),
+			b: wirebson.MustDocument("a", wirebson.MustDocument("b", wirebson.MustArray(int32(1), int32(3), int32(4)))),
+			expected: []Difference{
+				{Path: "a.b.1", A: int32(2), B: int32(3)},
+				{Path: "a.b.2", B: int32(4)},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := Compare(tc.a, tc.b)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
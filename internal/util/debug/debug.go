@@ -28,6 +28,7 @@
 	"net/http"
 	_ "net/http/pprof" // for profiling
 	"slices"
+	"strconv"
 	"sync/atomic"
 	"text/template"
 	"time"
@@ -59,6 +60,14 @@
 // It must be thread-safe.
 type Probe func(ctx context.Context) bool
 
+// Switch represents a runtime on/off switch exposed by the debug handler.
+//
+// Both functions must be thread-safe.
+type Switch struct {
+	Get func() bool
+	Set func(bool)
+}
+
 // Listener represents TCP listener with debug HTTP handler.
 //
 //nolint:vet // for readability
@@ -78,6 +87,11 @@ type ListenOpts struct {
 	R       prometheus.Registerer
 	Livez   Probe
 	Readyz  Probe
+
+	ReadOnly *Switch // if nil, /debug/readonly is not available
+	Drain    func()  // if nil, /debug/drain is not available
+
+	ReloadRoutes func() error // if nil, /debug/routes/reload is not available
 }
 
 // Listen creates a new debug handler and starts listener on the given TCP address.
@@ -183,6 +197,21 @@ func Listen(opts *ListenOpts) (*Listener, error) {
 		"/debug/events":   "/x/net/trace events",
 	}
 
+	if opts.ReadOnly != nil {
+		http.HandleFunc("/debug/readonly", readOnlyHandler(l, opts.ReadOnly))
+		handlers["/debug/readonly"] = "Read-only mode (GET to check, POST with enabled=true/false and " + StateHeader + " header to change)"
+	}
+
+	if opts.Drain != nil {
+		http.HandleFunc("/debug/drain", drainHandler(l, opts.Drain))
+		handlers["/debug/drain"] = "Drain mode (POST with " + StateHeader + " header to stop accepting new connections)"
+	}
+
+	if opts.ReloadRoutes != nil {
+		http.HandleFunc("/debug/routes/reload", reloadRoutesHandler(l, opts.ReloadRoutes))
+		handlers["/debug/routes/reload"] = "Routing rules (POST with " + StateHeader + " header to reload from file)"
+	}
+
 	var page bytes.Buffer
 	must.NoError(template.Must(template.New("debug").Parse(`
 	<html>
@@ -217,6 +246,103 @@ func Listen(opts *ListenOpts) (*Listener, error) {
 	}, nil
 }
 
+// StateHeader is the header required by debug handlers that change server state.
+//
+// Browsers can't add it to cross-site requests without a CORS preflight, which is never allowed,
+// so it protects those handlers from cross-site request forgery.
+const StateHeader = "X-FerretDB-Debug"
+
+// checkStateHeader returns false and writes an error response if the request lacks [StateHeader].
+func checkStateHeader(rw http.ResponseWriter, r *http.Request) bool {
+	if r.Header.Get(StateHeader) != "" {
+		return true
+	}
+
+	http.Error(rw, StateHeader+" header is required", http.StatusForbidden)
+
+	return false
+}
+
+// readOnlyHandler returns a handler for getting and changing read-only mode.
+func readOnlyHandler(l *slog.Logger, s *Switch) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			// nothing
+
+		case http.MethodPost:
+			if !checkStateHeader(rw, r) {
+				return
+			}
+
+			enabled, err := strconv.ParseBool(r.FormValue("enabled"))
+			if err != nil {
+				http.Error(rw, "enabled should be true or false", http.StatusBadRequest)
+				return
+			}
+
+			s.Set(enabled)
+			l.InfoContext(r.Context(), "Read-only mode changed via debug handler", slog.Bool("enabled", enabled))
+
+		default:
+			rw.Header().Set("Allow", "GET, POST")
+			http.Error(rw, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		_, _ = fmt.Fprintln(rw, s.Get())
+	}
+}
+
+// drainHandler returns a handler for starting drain mode.
+func drainHandler(l *slog.Logger, drain func()) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			rw.Header().Set("Allow", "POST")
+			http.Error(rw, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		if !checkStateHeader(rw, r) {
+			return
+		}
+
+		l.InfoContext(r.Context(), "Drain requested via debug handler")
+		drain()
+
+		rw.WriteHeader(http.StatusAccepted)
+	}
+}
+
+// reloadRoutesHandler returns a handler for reloading routing rules.
+func reloadRoutesHandler(l *slog.Logger, reload func() error) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			rw.Header().Set("Allow", "POST")
+			http.Error(rw, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		if !checkStateHeader(rw, r) {
+			return
+		}
+
+		l.InfoContext(r.Context(), "Routing rules reload requested via debug handler")
+
+		if err := reload(); err != nil {
+			l.WarnContext(r.Context(), "Failed to reload routing rules", logging.Error(err))
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		_, _ = fmt.Fprintln(rw, "OK")
+	}
+}
+
 // Run runs debug handler until ctx is canceled.
 //
 // It exits when handler is stopped and listener closed.
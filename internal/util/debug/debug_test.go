@@ -19,9 +19,12 @@
 	"archive/zip"
 	"bytes"
 	"context"
+	"errors"
 	"io"
 	"net/http"
+	"net/url"
 	"regexp"
+	"strings"
 	"sync/atomic"
 	"testing"
 
@@ -41,10 +44,30 @@ func assertProbe(t *testing.T, u string, expected int) {
 	assert.Equal(t, expected, res.StatusCode)
 }
 
+// post sends POST request with form values and, if stateHeader is true, [StateHeader].
+func post(t *testing.T, u string, values url.Values, stateHeader bool) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, u, strings.NewReader(values.Encode()))
+	require.NoError(t, err)
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	if stateHeader {
+		req.Header.Set(StateHeader, "1")
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	return res
+}
+
 func TestDebug(t *testing.T) {
 	t.Parallel()
 
-	var livez, readyz atomic.Bool
+	var livez, readyz, readOnly, drained atomic.Bool
+	var reloadErr atomic.Pointer[error]
 
 	h := must.NotFail(Listen(&ListenOpts{
 		TCPAddr: "127.0.0.1:0",
@@ -52,6 +75,18 @@ func TestDebug(t *testing.T) {
 		R:       prometheus.NewRegistry(),
 		Livez:   func(context.Context) bool { return livez.Load() },
 		Readyz:  func(context.Context) bool { return readyz.Load() },
+		ReadOnly: &Switch{
+			Get: readOnly.Load,
+			Set: readOnly.Store,
+		},
+		Drain: func() { drained.Store(true) },
+		ReloadRoutes: func() error {
+			if err := reloadErr.Load(); err != nil {
+				return *err
+			}
+
+			return nil
+		},
 	}))
 
 	ctx, cancel := context.WithCancel(testutil.Ctx(t))
@@ -80,6 +115,80 @@ func TestDebug(t *testing.T) {
 		assertProbe(t, ready, http.StatusOK)
 	})
 
+	t.Run("ReadOnly", func(t *testing.T) {
+		u := "http://" + h.lis.Addr().String() + "/debug/readonly"
+
+		res, err := http.Get(u)
+		require.NoError(t, err)
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "false\n", string(body))
+
+		res = post(t, u, url.Values{"enabled": {"true"}}, false)
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusForbidden, res.StatusCode, "cross-site form post should be rejected")
+		assert.False(t, readOnly.Load())
+
+		res = post(t, u, url.Values{"enabled": {"true"}}, true)
+		body, err = io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "true\n", string(body))
+		assert.True(t, readOnly.Load())
+
+		res = post(t, u, url.Values{"enabled": {"maybe"}}, true)
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		assert.True(t, readOnly.Load())
+	})
+
+	t.Run("Drain", func(t *testing.T) {
+		u := "http://" + h.lis.Addr().String() + "/debug/drain"
+
+		res, err := http.Get(u)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+		assert.False(t, drained.Load())
+
+		res = post(t, u, nil, false)
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+		assert.False(t, drained.Load())
+
+		res = post(t, u, nil, true)
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusAccepted, res.StatusCode)
+		assert.True(t, drained.Load())
+	})
+
+	t.Run("ReloadRoutes", func(t *testing.T) {
+		u := "http://" + h.lis.Addr().String() + "/debug/routes/reload"
+
+		res, err := http.Get(u)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+
+		res = post(t, u, nil, false)
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+		res = post(t, u, nil, true)
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+
+		err = errors.New("invalid rules")
+		reloadErr.Store(&err)
+
+		res = post(t, u, nil, true)
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	})
+
 	t.Run("Archive", func(t *testing.T) {
 		u := "http://" + h.lis.Addr().String() + "/debug/archive"
 
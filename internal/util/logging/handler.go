@@ -156,6 +156,20 @@ func NewHandler(out io.Writer, opts *NewHandlerOpts) *Handler {
 	}
 }
 
+// WrapHandler creates a new handler that wraps the given base handler.
+//
+// Level, format, and attributes of log records are controlled by the base handler.
+// Messages are not checked, as if [NewHandlerOpts].SkipChecks was set.
+func WrapHandler(base slog.Handler) *Handler {
+	must.NotBeZero(base)
+
+	return &Handler{
+		base:          base,
+		skipChecks:    true,
+		recentEntries: newCircularBuffer(1024),
+	}
+}
+
 // Enabled implements [slog.Handler].
 func (h *Handler) Enabled(ctx context.Context, l slog.Level) bool {
 	return h.base.Enabled(ctx, l)
@@ -85,6 +85,24 @@ func TestHandler(t *testing.T) {
 	}
 }
 
+func TestWrapHandler(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	h := WrapHandler(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	l := slog.New(h)
+	l.Debug("debug message")
+	l.Info("info message.", slog.Int("k", 42))
+
+	assert.NotContains(t, buf.String(), "debug message")
+	assert.Contains(t, buf.String(), `"msg":"info message.","k":42`)
+
+	entries, err := h.RecentEntries()
+	require.NoError(t, err)
+	assert.Equal(t, 1, entries.Len())
+}
+
 func TestShortPath(t *testing.T) {
 	t.Parallel()
 
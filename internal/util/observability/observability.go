@@ -29,6 +29,7 @@
 	otelsdkresource "go.opentelemetry.io/otel/sdk/resource"
 	otelsdktrace "go.opentelemetry.io/otel/sdk/trace"
 	otelsemconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
 
 	"github.com/FerretDB/FerretDB/v2/build/version"
 	"github.com/FerretDB/FerretDB/v2/internal/util/ctxutil"
@@ -112,6 +113,19 @@ func NewOTelTraceExporter(opts *OTelTraceExporterOpts) (*OTelTraceExporter, erro
 	}, nil
 }
 
+// Tracer returns a tracer of the provider that created the span in ctx,
+// or of the global provider if ctx does not contain a valid span.
+//
+// It allows each embedded FerretDB instance to use its own tracer provider
+// that is used for the request span.
+func Tracer(ctx context.Context) oteltrace.Tracer {
+	if span := oteltrace.SpanFromContext(ctx); span.SpanContext().IsValid() {
+		return span.TracerProvider().Tracer("")
+	}
+
+	return otel.Tracer("")
+}
+
 // Run runs OTLP trace exporter until ctx is canceled.
 func (ot *OTelTraceExporter) Run(ctx context.Context) {
 	<-ctx.Done()
@@ -0,0 +1,77 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package readyz provides readiness checks that ping FerretDB over the wire protocol.
+package readyz
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire"
+	"github.com/FerretDB/wire/wireclient"
+)
+
+// Ping sends `ping` command over the given connection.
+//
+// It returns an error if the request failed or the command returned an error,
+// for example, because PostgreSQL is not available.
+func Ping(ctx context.Context, conn *wireclient.Conn) error {
+	_, resBody, err := conn.Request(ctx, wire.MustOpMsg(
+		"ping", int32(1),
+		"$db", "admin",
+	))
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	res, err := resBody.(*wire.OpMsg).Document()
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	if ok, _ := res.Get("ok").(float64); ok == 1 {
+		return nil
+	}
+
+	code, _ := res.Get("code").(int32)
+	codeName, _ := res.Get("codeName").(string)
+	errmsg, _ := res.Get("errmsg").(string)
+
+	return fmt.Errorf("%s (%d): %s", codeName, code, errmsg)
+}
+
+// PingURI connects to FerretDB with the given MongoDB URI and sends `ping` command.
+// Credentials in the URI are ignored.
+func PingURI(ctx context.Context, uri string, l *slog.Logger) (err error) {
+	cleanURI, _, _, _, err := wireclient.Credentials(uri)
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	conn, err := wireclient.Connect(ctx, cleanURI, l)
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	defer func() {
+		if e := conn.Close(); e != nil && err == nil {
+			err = lazyerrors.Error(e)
+		}
+	}()
+
+	return Ping(ctx, conn)
+}
@@ -21,14 +21,18 @@
 	"sync"
 	"time"
 
+	"github.com/AlekSi/lazyerrors"
 	"github.com/prometheus/client_golang/prometheus"
+	oteltrace "go.opentelemetry.io/otel/trace"
 
+	"github.com/FerretDB/FerretDB/v2/internal/audit"
 	"github.com/FerretDB/FerretDB/v2/internal/clientconn"
 	"github.com/FerretDB/FerretDB/v2/internal/dataapi"
 	"github.com/FerretDB/FerretDB/v2/internal/handler"
 	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
 	"github.com/FerretDB/FerretDB/v2/internal/handlers/proxy"
 	"github.com/FerretDB/FerretDB/v2/internal/mcp"
+	"github.com/FerretDB/FerretDB/v2/internal/util/bsondiff"
 	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
 	"github.com/FerretDB/FerretDB/v2/internal/util/must"
 	"github.com/FerretDB/FerretDB/v2/internal/util/state"
@@ -46,7 +50,12 @@ type SetupOpts struct {
 	PostgreSQLURL          string
 	Auth                   bool
 	ReplSetName            string
+	ReadOnly               bool
 	SessionCleanupInterval time.Duration
+	CustomCommands         []*handler.CustomCommand
+	AuthMaxFailures        int // zero value disables authentication lockouts
+	AuthLockout            time.Duration
+	AuthMaxLockout         time.Duration
 
 	// Proxy handler
 	ProxyAddr        string
@@ -54,6 +63,35 @@ type SetupOpts struct {
 	ProxyTLSKeyFile  string
 	ProxyTLSCAFile   string
 
+	// Diff modes
+	DiffReportFile    string   // empty value disables reports
+	DiffIgnoredFields []string // nil value means bsondiff.DefaultIgnoredFields
+
+	// Shadow modes
+	ShadowQueueSize  int     // zero value means middleware.DefaultShadowQueueSize
+	ShadowSampleRate float64 // zero value means middleware.DefaultShadowSampleRate
+	ShadowCommands   []string
+	ShadowNamespaces []string
+
+	// Route mode
+	RouteRulesFile string
+
+	// Dual-write modes
+	DualWriteReconciliationFile string // empty value disables the log
+
+	// Middleware
+	Interceptors   []*middleware.Interceptor
+	TracerProvider oteltrace.TracerProvider // nil means the global provider
+
+	// Audit log
+	AuditDestination string // empty value disables audit log
+	AuditPath        string
+	AuditFormat      string
+	AuditMaxSize     int64
+	AuditMaxBackups  int
+	AuditFilter      string
+	AuditNamespaces  []string
+
 	// Wire protocol listener
 	TCPAddr        string // empty value disables TCP listener
 	UnixAddr       string // empty value disables Unix listener
@@ -65,20 +103,35 @@ type SetupOpts struct {
 	TestRecordsDir string // empty value disables recording
 
 	// DataAPI listener
-	DataAPIAddr string // empty value disables Data API listener
-
-	// MCPAddr listener
-	MCPAddr string // empty value disables MCP listener
+	DataAPIAddr        string   // empty value disables Data API TCP listener
+	DataAPIUnixAddr    string   // empty value disables Data API Unix listener
+	DataAPITLSAddr     string   // empty value disables Data API TLS listener; TLS files are shared with wire protocol listener
+	DataAPIMaxBodySize int64    // zero value means dataapi.DefaultMaxBodySize
+	DataAPICORSOrigins []string // empty value disables CORS
+
+	// MCP listener and stdio server
+	MCPAddr        string // empty value disables MCP listener
+	MCPAllowWrites bool   // enables MCP tools that modify data
+	MCPStdio       bool   // enables MCP server on stdin and stdout
+	MCPUsername    string // used by MCP stdio server if authentication is enabled
+	MCPPassword    string // used by MCP stdio server if authentication is enabled
+	MCPAPIKey      string // used by MCP stdio server if authentication is enabled
 }
 
 // SetupResult represents [Setup] result.
 type SetupResult struct {
-	docdbH          middleware.Handler
-	proxyH          middleware.Handler
-	m               *middleware.Middleware
-	WireListener    *clientconn.Listener
-	DataAPIListener *dataapi.Listener
-	MCPListener     *mcp.Listener
+	docdbH            *handler.Handler
+	proxyH            middleware.Handler
+	diffReporter      *middleware.DiffReporter
+	router            *middleware.Router
+	reconciliationLog *middleware.DiffReporter
+	audit             *audit.Logger
+	m                 *middleware.Middleware
+	metrics           *middleware.Metrics
+	WireListener      *clientconn.Listener
+	DataAPIListener   *dataapi.Listener
+	MCPListener       *mcp.Listener
+	MCPStdio          *mcp.Stdio
 }
 
 // Setup creates and sets up:
@@ -88,6 +141,7 @@ type SetupResult struct {
 //   - wire protocol listener ([*clientconn.Listener]);
 //   - Data API listener ([*dataapi.Listener]);
 //   - MCP listener ([*mcp.Listener]);
+//   - MCP stdio server ([*mcp.Stdio]);
 //   - unregistered Prometheus collector for the above components.
 //
 // It does not change the global state or creates components that are different in tests.
@@ -103,7 +157,10 @@ type SetupResult struct {
 func Setup(ctx context.Context, opts *SetupOpts) *SetupResult {
 	must.NotBeZero(opts)
 
-	var res SetupResult
+	res := SetupResult{
+		metrics: opts.Metrics,
+	}
+
 	var err error
 
 	// If we exit early, we must Run what we already created to avoid leaks:
@@ -121,12 +178,19 @@ func Setup(ctx context.Context, opts *SetupOpts) *SetupResult {
 		TCPHost: opts.TCPAddr,
 
 		ReplSetName: opts.ReplSetName,
+		ReadOnly:    opts.ReadOnly,
 
 		L:             logging.WithName(opts.Logger, "documentdb"),
 		Metrics:       opts.Metrics,
 		StateProvider: opts.StateProvider,
 
 		SessionCleanupInterval: opts.SessionCleanupInterval,
+
+		AuthMaxFailures: opts.AuthMaxFailures,
+		AuthLockout:     opts.AuthLockout,
+		AuthMaxLockout:  opts.AuthMaxLockout,
+
+		CustomCommands: opts.CustomCommands,
 	})
 	if err != nil {
 		opts.Logger.LogAttrs(ctx, logging.LevelDPanic, "Failed to construct DocumentDB handler", logging.Error(err))
@@ -152,13 +216,109 @@ func Setup(ctx context.Context, opts *SetupOpts) *SetupResult {
 		}
 	}
 
+	if opts.DiffReportFile != "" {
+		res.diffReporter, err = middleware.NewDiffReporter(opts.DiffReportFile)
+		if err != nil {
+			opts.Logger.LogAttrs(ctx, logging.LevelDPanic, "Failed to open diff report file", logging.Error(err))
+			res.Run(exitCtx)
+
+			return nil
+		}
+	}
+
+	if opts.DualWriteReconciliationFile != "" {
+		res.reconciliationLog, err = middleware.NewDiffReporter(opts.DualWriteReconciliationFile)
+		if err != nil {
+			opts.Logger.LogAttrs(ctx, logging.LevelDPanic, "Failed to open reconciliation log file", logging.Error(err))
+			res.Run(exitCtx)
+
+			return nil
+		}
+	}
+
+	interceptors := opts.Interceptors
+
+	if opts.AuditDestination != "" {
+		//exhaustruct:enforce
+		res.audit, err = audit.New(&audit.Opts{
+			Destination: opts.AuditDestination,
+			Path:        opts.AuditPath,
+			Format:      opts.AuditFormat,
+			MaxSize:     opts.AuditMaxSize,
+			MaxBackups:  opts.AuditMaxBackups,
+			Filter:      opts.AuditFilter,
+			Namespaces:  opts.AuditNamespaces,
+			L:           logging.WithName(opts.Logger, "audit"),
+		})
+		if err != nil {
+			opts.Logger.LogAttrs(ctx, logging.LevelDPanic, "Failed to set up audit log", logging.Error(err))
+			res.Run(exitCtx)
+
+			return nil
+		}
+
+		// audit interceptor goes first to see responses produced by other interceptors
+		interceptors = append([]*middleware.Interceptor{res.audit.Interceptor()}, interceptors...)
+	}
+
+	if opts.Mode == middleware.RouteMode {
+		if opts.RouteRulesFile == "" {
+			opts.Logger.LogAttrs(ctx, logging.LevelDPanic, "Routing rules file is required for route mode")
+			res.Run(exitCtx)
+
+			return nil
+		}
+
+		res.router, err = middleware.NewRouter(opts.RouteRulesFile, logging.WithName(opts.Logger, "router"))
+		if err != nil {
+			opts.Logger.LogAttrs(ctx, logging.LevelDPanic, "Failed to load routing rules", logging.Error(err))
+			res.Run(exitCtx)
+
+			return nil
+		}
+	}
+
+	var diffRules *bsondiff.Rules
+	if opts.DiffIgnoredFields != nil {
+		diffRules = &bsondiff.Rules{
+			IgnoredFields: opts.DiffIgnoredFields,
+		}
+	}
+
+	// in proxy mode, all requests are handled by the proxy handler
+	var docdb middleware.Handler
+	if opts.Mode != middleware.ProxyMode {
+		docdb = res.docdbH
+	}
+
 	//exhaustruct:enforce
 	res.m = middleware.New(&middleware.NewOpts{
 		Mode:    opts.Mode,
-		DocDB:   res.docdbH,
+		DocDB:   docdb,
 		Proxy:   res.proxyH,
 		Metrics: opts.Metrics,
 		L:       logging.WithName(opts.Logger, "middleware"),
+
+		DiffRules:    diffRules,
+		DiffReporter: res.diffReporter,
+
+		//exhaustruct:enforce
+		Shadow: &middleware.ShadowOpts{
+			QueueSize:  opts.ShadowQueueSize,
+			SampleRate: opts.ShadowSampleRate,
+			Commands:   opts.ShadowCommands,
+			Namespaces: opts.ShadowNamespaces,
+		},
+
+		Router: res.router,
+
+		ReconciliationLog: res.reconciliationLog,
+
+		Interceptors: interceptors,
+
+		ReadOnly: res.docdbH,
+
+		TracerProvider: opts.TracerProvider,
 	})
 
 	//exhaustruct:enforce
@@ -189,13 +349,20 @@ func Setup(ctx context.Context, opts *SetupOpts) *SetupResult {
 		return nil
 	}
 
-	if opts.DataAPIAddr != "" {
+	if opts.DataAPIAddr != "" || opts.DataAPIUnixAddr != "" || opts.DataAPITLSAddr != "" {
 		//exhaustruct:enforce
 		res.DataAPIListener, err = dataapi.Listen(&dataapi.ListenOpts{
-			L:       logging.WithName(opts.Logger, "dataapi"),
-			M:       res.m,
-			TCPAddr: opts.DataAPIAddr,
-			Auth:    opts.Auth,
+			L:           logging.WithName(opts.Logger, "dataapi"),
+			M:           res.m,
+			TCPAddr:     opts.DataAPIAddr,
+			UnixAddr:    opts.DataAPIUnixAddr,
+			TLSAddr:     opts.DataAPITLSAddr,
+			TLSCertFile: opts.TLSCertFile,
+			TLSKeyFile:  opts.TLSKeyFile,
+			TLSCAFile:   opts.TLSCAFile,
+			Auth:        opts.Auth,
+			MaxBodySize: opts.DataAPIMaxBodySize,
+			CORSOrigins: opts.DataAPICORSOrigins,
 		})
 		if err != nil {
 			opts.Logger.LogAttrs(ctx, logging.LevelDPanic, "Failed to construct DataAPI listener", logging.Error(err))
@@ -208,9 +375,11 @@ func Setup(ctx context.Context, opts *SetupOpts) *SetupResult {
 	if opts.MCPAddr != "" {
 		//exhaustruct:enforce
 		res.MCPListener, err = mcp.Listen(&mcp.ListenOpts{
-			L:       logging.WithName(opts.Logger, "mcp"),
-			M:       res.m,
-			TCPAddr: opts.MCPAddr,
+			L:           logging.WithName(opts.Logger, "mcp"),
+			M:           res.m,
+			TCPAddr:     opts.MCPAddr,
+			Auth:        opts.Auth,
+			AllowWrites: opts.MCPAllowWrites,
 		})
 		if err != nil {
 			opts.Logger.LogAttrs(ctx, logging.LevelDPanic, "Failed to construct MCP listener", logging.Error(err))
@@ -220,6 +389,20 @@ func Setup(ctx context.Context, opts *SetupOpts) *SetupResult {
 		}
 	}
 
+	if opts.MCPStdio {
+		//exhaustruct:enforce
+		res.MCPStdio = mcp.NewStdio(&mcp.StdioOpts{
+			L:           logging.WithName(opts.Logger, "mcp"),
+			M:           res.m,
+			Auth:        opts.Auth,
+			AllowWrites: opts.MCPAllowWrites,
+			Username:    opts.MCPUsername,
+			Password:    opts.MCPPassword,
+			APIKey:      opts.MCPAPIKey,
+			Transport:   nil,
+		})
+	}
+
 	return &res
 }
 
@@ -257,10 +440,96 @@ func (sr *SetupResult) Run(ctx context.Context) {
 	<-lDone
 	hCancel()
 	<-hDone
+
+	if sr.diffReporter != nil {
+		_ = sr.diffReporter.Close()
+	}
+
+	if sr.reconciliationLog != nil {
+		_ = sr.reconciliationLog.Close()
+	}
+
+	if sr.audit != nil {
+		_ = sr.audit.Close()
+	}
+}
+
+// Stats represents [SetupResult.Stats] result.
+type Stats struct {
+	Connections int64 // open wire protocol connections
+	Requests    int64 // handled requests
+	Cursors     int   // open cursors
+	Sessions    int   // active sessions
+}
+
+// Stats returns statistics of running components.
+func (sr *SetupResult) Stats() *Stats {
+	var res Stats
+
+	if sr.WireListener != nil {
+		res.Connections = sr.WireListener.Connections()
+	}
+
+	for _, commands := range sr.metrics.GetResponses() {
+		for _, arguments := range commands {
+			for _, m := range arguments {
+				res.Requests += int64(m.Total)
+			}
+		}
+	}
+
+	if sr.docdbH != nil {
+		res.Sessions, res.Cursors = sr.docdbH.Stats()
+	}
+
+	return &res
+}
+
+// ReadOnly returns true if read-only mode is enabled.
+func (sr *SetupResult) ReadOnly() bool {
+	return sr.docdbH.ReadOnly()
+}
+
+// SetReadOnly enables or disables read-only mode.
+// It is stored by DocumentDB handler, but enforced by the middleware in all operation modes.
+func (sr *SetupResult) SetReadOnly(readOnly bool) {
+	sr.docdbH.SetReadOnly(readOnly)
+}
+
+// Drain stops accepting new connections on all network listeners:
+// MongoDB protocol, Data API, and MCP.
+// See [clientconn.Listener.Drain] for details.
+func (sr *SetupResult) Drain() {
+	if sr.WireListener != nil {
+		sr.WireListener.Drain()
+	}
+
+	if sr.DataAPIListener != nil {
+		sr.DataAPIListener.Drain()
+	}
+
+	if sr.MCPListener != nil {
+		sr.MCPListener.Drain()
+	}
+}
+
+// ReloadRoutes reloads routing rules from the file.
+// It returns an error if rules are invalid or FerretDB is not in route mode.
+func (sr *SetupResult) ReloadRoutes() error {
+	if sr.router == nil {
+		return lazyerrors.New("FerretDB is not in route mode")
+	}
+
+	return sr.router.Reload()
 }
 
 // runListeners runs all listeners until ctx is canceled.
+//
+// If MCP stdio server is set up, all listeners are also stopped when it exits.
 func (sr *SetupResult) runListeners(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	var wg sync.WaitGroup
 
 	if sr.WireListener != nil {
@@ -290,6 +559,17 @@ func (sr *SetupResult) runListeners(ctx context.Context) {
 		}()
 	}
 
+	if sr.MCPStdio != nil {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			defer cancel()
+
+			sr.MCPStdio.Run(ctx)
+		}()
+	}
+
 	wg.Wait()
 }
 